@@ -0,0 +1,216 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+)
+
+var (
+	ErrInvalidKey       = errors.New("invalid blob key")
+	ErrInvalidSignature = errors.New("invalid or expired signature")
+)
+
+// LocalStore keeps blobs on the local filesystem and serves them through
+// Handler, which only answers URLs signed by SignedURL.
+type LocalStore struct {
+	root       string
+	publicURL  string
+	signingKey []byte
+	now        func() time.Time
+}
+
+// NewLocalStore stores blobs below root. publicURL is the address at which
+// Handler is mounted, for example https://example.com/blobs.
+func NewLocalStore(root, publicURL string, signingKey []byte) *LocalStore {
+	return &LocalStore{
+		root:       root,
+		publicURL:  strings.TrimSuffix(publicURL, "/"),
+		signingKey: signingKey,
+		now:        time.Now,
+	}
+}
+
+func (l *LocalStore) Put(_ context.Context, key string, data []byte, _ string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(name), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a partial blob.
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+func (l *LocalStore) Get(_ context.Context, key string) (io.ReadCloser, *model.BlobInfo, error) {
+	file, info, err := l.open(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return file, info, nil
+}
+
+func (l *LocalStore) Delete(_ context.Context, key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (l *LocalStore) SignedURL(_ context.Context, key string, ttl time.Duration) (string, error) {
+	_, err := l.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(l.now().Add(ttl).Unix(), 10)
+	query := url.Values{
+		"expires":   {expires},
+		"signature": {l.sign(key, expires)},
+	}
+
+	return l.publicURL + "/" + key + "?" + query.Encode(), nil
+}
+
+// Verify checks a signature produced by SignedURL.
+func (l *LocalStore) Verify(key, expires, signature string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || l.now().Unix() > unix {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(l.sign(key, expires)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// Handler serves signed blobs. Keys are content addressed, so responses
+// may be cached until the signature expires.
+func (l *LocalStore) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.Trim(r.URL.Path, "/")
+
+		expires := r.URL.Query().Get("expires")
+		err := l.Verify(key, expires, r.URL.Query().Get("signature"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		file, info, err := l.open(key)
+		if err != nil {
+			if errors.Is(err, model.ErrBlobNotFound) || errors.Is(err, ErrInvalidKey) {
+				http.NotFound(w, r)
+				return
+			}
+
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		defer file.Close()
+
+		unix, _ := strconv.ParseInt(expires, 10, 64)
+		maxAge := unix - l.now().Unix()
+
+		w.Header().Set("Content-Type", info.ContentType)
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d, immutable", maxAge))
+		etag := sha256.Sum256([]byte(key))
+		w.Header().Set("ETag", `"`+hex.EncodeToString(etag[:8])+`"`)
+		http.ServeContent(w, r, path.Base(key), info.ModTime, file)
+	})
+}
+
+func (l *LocalStore) open(key string) (*os.File, *model.BlobInfo, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, model.ErrBlobNotFound
+		}
+
+		return nil, nil, err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return file, &model.BlobInfo{
+		ContentType: contentType,
+		Size:        stat.Size(),
+		ModTime:     stat.ModTime(),
+	}, nil
+}
+
+// path maps a key to a file below root, refusing keys that would escape it.
+func (l *LocalStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
+
+func (l *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, l.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/blob"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.TODO()
+	store := blob.NewLocalStore(t.TempDir(), "http://localhost/blobs", []byte("secret"))
+
+	t.Run("ok: put, get and delete", func(t *testing.T) {
+		err := store.Put(ctx, "covers/1/abc/small.png", []byte("png"), "image/png")
+		assert.Nil(t, err)
+
+		body, info, err := store.Get(ctx, "covers/1/abc/small.png")
+		assert.Nil(t, err)
+		data, _ := io.ReadAll(body)
+		body.Close()
+		assert.Equal(t, "png", string(data))
+		assert.Equal(t, "image/png", info.ContentType)
+
+		err = store.Delete(ctx, "covers/1/abc/small.png")
+		assert.Nil(t, err)
+
+		_, _, err = store.Get(ctx, "covers/1/abc/small.png")
+		assert.ErrorIs(t, err, model.ErrBlobNotFound)
+	})
+
+	t.Run("error: key escapes root", func(t *testing.T) {
+		for _, key := range []string{"../secret", "/etc/passwd", "covers/../../x", ""} {
+			err := store.Put(ctx, key, []byte("x"), "text/plain")
+			assert.ErrorIs(t, err, blob.ErrInvalidKey, key)
+		}
+	})
+
+	t.Run("ok: serve signed url", func(t *testing.T) {
+		err := store.Put(ctx, "covers/2/def/medium.jpg", []byte("jpeg"), "image/jpeg")
+		assert.Nil(t, err)
+
+		signed, err := store.SignedURL(ctx, "covers/2/def/medium.jpg", time.Hour)
+		assert.Nil(t, err)
+
+		u, err := url.Parse(signed)
+		assert.Nil(t, err)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, u.RequestURI(), nil)
+		http.StripPrefix("/blobs", store.Handler()).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "jpeg", rec.Body.String())
+		assert.Equal(t, "image/jpeg", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Cache-Control"), "max-age=")
+	})
+
+	t.Run("error: tampered signature", func(t *testing.T) {
+		signed, err := store.SignedURL(ctx, "covers/2/def/medium.jpg", time.Hour)
+		assert.Nil(t, err)
+
+		u, _ := url.Parse(signed)
+		query := u.Query()
+		query.Set("expires", "9999999999")
+		u.RawQuery = query.Encode()
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, u.RequestURI(), nil)
+		http.StripPrefix("/blobs", store.Handler()).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("error: expired signature", func(t *testing.T) {
+		signed, err := store.SignedURL(ctx, "covers/2/def/medium.jpg", -time.Minute)
+		assert.Nil(t, err)
+
+		u, _ := url.Parse(signed)
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, u.RequestURI(), nil)
+		http.StripPrefix("/blobs", store.Handler()).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
@@ -0,0 +1,98 @@
+package citation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rhtyx/bayarind-service.git/names"
+)
+
+var (
+	bibtexEscaper = strings.NewReplacer(
+		`\`, `\textbackslash{}`,
+		`{`, `\{`,
+		`}`, `\}`,
+		`&`, `\&`,
+		`%`, `\%`,
+		`$`, `\$`,
+		`#`, `\#`,
+		`_`, `\_`,
+		`~`, `\textasciitilde{}`,
+		`^`, `\textasciicircum{}`,
+	)
+
+	// bibtexAnd is the word BibTeX separates names by, which a name part
+	// can only contain inside braces.
+	bibtexAnd = regexp.MustCompile(`(?i)(^|\s)and(\s|$)`)
+)
+
+// WriteBibTeX renders items as @book entries.
+func WriteBibTeX(w io.Writer, items []Item) error {
+	buf := bufio.NewWriter(w)
+	for i, key := range keys(items) {
+		item := items[i]
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		fmt.Fprintf(buf, "@book{%s,\n", key)
+		writeBibTeXField(buf, "author", bibtexAuthors(item.Authors))
+		writeBibTeXField(buf, "title", bibtexEscaper.Replace(item.FullTitle()))
+		writeBibTeXField(buf, "publisher", bibtexEscaper.Replace(item.Publisher))
+		if item.Year != 0 {
+			writeBibTeXField(buf, "year", strconv.Itoa(item.Year))
+		}
+		writeBibTeXField(buf, "isbn", bibtexEscaper.Replace(item.ISBN))
+		writeBibTeXField(buf, "language", bibtexEscaper.Replace(item.Language))
+		buf.WriteString("}\n")
+	}
+
+	return buf.Flush()
+}
+
+func writeBibTeXField(buf *bufio.Writer, name, value string) {
+	if value == "" {
+		return
+	}
+
+	fmt.Fprintf(buf, "  %s = {%s},\n", name, value)
+}
+
+// bibtexAuthors joins names in BibTeX's "von Last, Jr, First" form.
+func bibtexAuthors(authors []names.Name) string {
+	formatted := []string{}
+	for _, author := range authors {
+		if author.Literal != "" {
+			formatted = append(formatted, "{"+bibtexEscaper.Replace(author.Literal)+"}")
+			continue
+		}
+
+		name := bibtexNamePart(author.Family)
+		if author.Particle != "" {
+			name = bibtexNamePart(author.Particle) + " " + name
+		}
+		if author.Suffix != "" {
+			name += ", " + bibtexNamePart(author.Suffix)
+		}
+		if author.Given != "" {
+			name += ", " + bibtexNamePart(author.Given)
+		}
+
+		formatted = append(formatted, name)
+	}
+
+	return strings.Join(formatted, " and ")
+}
+
+func bibtexNamePart(part string) string {
+	part = bibtexEscaper.Replace(part)
+	if bibtexAnd.MatchString(part) {
+		return "{" + part + "}"
+	}
+
+	return part
+}
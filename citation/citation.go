@@ -0,0 +1,162 @@
+// Package citation renders books as BibTeX, RIS and CSL-JSON references.
+package citation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rhtyx/bayarind-service.git/names"
+)
+
+const (
+	FormatBibTeX  = "bibtex"
+	FormatRIS     = "ris"
+	FormatCSLJSON = "csl-json"
+
+	MIMEBibTeX  = "application/x-bibtex"
+	MIMERIS     = "application/x-research-info-systems"
+	MIMECSLJSON = "application/vnd.citationstyles.csl+json"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported citation format")
+
+// Formats lists the formats in the order they are offered, CSL-JSON, the
+// default, first.
+var Formats = []string{FormatCSLJSON, FormatBibTeX, FormatRIS}
+
+// titleStopWords are skipped when a citation key takes the first word of a
+// title.
+var titleStopWords = map[string]bool{"a": true, "an": true, "the": true}
+
+// Item is a book as cited. Key is generated from the first author, year and
+// title when empty.
+type Item struct {
+	Key       string
+	Title     string
+	Subtitle  string
+	Authors   []names.Name
+	Publisher string
+	Year      int
+	ISBN      string
+	Language  string
+}
+
+// FullTitle joins the title and subtitle the way styles without a
+// subtitle field expect.
+func (i Item) FullTitle() string {
+	if i.Subtitle == "" {
+		return i.Title
+	}
+
+	return i.Title + ": " + i.Subtitle
+}
+
+// Write renders items in format.
+func Write(w io.Writer, format string, items []Item) error {
+	switch format {
+	case FormatBibTeX:
+		return WriteBibTeX(w, items)
+	case FormatRIS:
+		return WriteRIS(w, items)
+	case FormatCSLJSON:
+		return WriteCSLJSON(w, items)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatBibTeX:
+		return MIMEBibTeX + "; charset=utf-8"
+	case FormatRIS:
+		return MIMERIS + "; charset=utf-8"
+	default:
+		return MIMECSLJSON
+	}
+}
+
+// MediaType is the media type of format without parameters, "" for
+// unknown formats.
+func MediaType(format string) string {
+	switch format {
+	case FormatBibTeX:
+		return MIMEBibTeX
+	case FormatRIS:
+		return MIMERIS
+	case FormatCSLJSON:
+		return MIMECSLJSON
+	default:
+		return ""
+	}
+}
+
+// keys returns a citation key per item, such as "toer1980bumi", adding
+// "a", "b" and so on to keys that would repeat.
+func keys(items []Item) []string {
+	result := make([]string, len(items))
+	counts := map[string]int{}
+	for i, item := range items {
+		key := item.Key
+		if key == "" {
+			key = generateKey(item)
+		}
+
+		result[i] = key
+		counts[key]++
+	}
+
+	seen := map[string]int{}
+	for i, key := range result {
+		if counts[key] > 1 {
+			result[i] = key + string(rune('a'+seen[key]%26))
+			if seen[key] >= 26 {
+				result[i] += strconv.Itoa(seen[key] / 26)
+			}
+			seen[key]++
+		}
+	}
+
+	return result
+}
+
+func generateKey(item Item) string {
+	key := "anon"
+	if len(item.Authors) > 0 {
+		author := item.Authors[0]
+		if author.Literal != "" {
+			key = keyWord(author.Literal)
+		} else {
+			key = keyWord(author.Family)
+		}
+	}
+
+	if item.Year != 0 {
+		key += strconv.Itoa(item.Year)
+	}
+
+	for _, word := range strings.Fields(names.Normalize(item.Title)) {
+		if !titleStopWords[word] {
+			key += keyWord(word)
+			break
+		}
+	}
+
+	return key
+}
+
+// keyWord keeps the ASCII letters and digits of a word, since BibTeX keys
+// cannot hold much else.
+func keyWord(word string) string {
+	var b strings.Builder
+	for _, r := range names.Normalize(word) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package citation
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/rhtyx/bayarind-service.git/names"
+)
+
+type cslItem struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Author    []cslName `json:"author,omitempty"`
+	Publisher string    `json:"publisher,omitempty"`
+	Issued    *cslDate  `json:"issued,omitempty"`
+	ISBN      string    `json:"ISBN,omitempty"`
+	Language  string    `json:"language,omitempty"`
+}
+
+type cslName struct {
+	Family              string `json:"family,omitempty"`
+	Given               string `json:"given,omitempty"`
+	NonDroppingParticle string `json:"non-dropping-particle,omitempty"`
+	Suffix              string `json:"suffix,omitempty"`
+	Literal             string `json:"literal,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// WriteCSLJSON renders items as a CSL-JSON array, which citeproc
+// processors and reference managers read.
+func WriteCSLJSON(w io.Writer, items []Item) error {
+	docs := []cslItem{}
+	for i, key := range keys(items) {
+		item := items[i]
+		doc := cslItem{
+			ID:        key,
+			Type:      "book",
+			Title:     item.FullTitle(),
+			Publisher: item.Publisher,
+			ISBN:      item.ISBN,
+			Language:  item.Language,
+		}
+
+		for _, author := range item.Authors {
+			doc.Author = append(doc.Author, cslNameOf(author))
+		}
+
+		if item.Year != 0 {
+			doc.Issued = &cslDate{DateParts: [][]int{{item.Year}}}
+		}
+
+		docs = append(docs, doc)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(docs)
+}
+
+func cslNameOf(name names.Name) cslName {
+	if name.Literal != "" {
+		return cslName{Literal: name.Literal, Suffix: name.Suffix}
+	}
+
+	return cslName{
+		Family:              name.Family,
+		Given:               name.Given,
+		NonDroppingParticle: name.Particle,
+		Suffix:              name.Suffix,
+	}
+}
@@ -0,0 +1,43 @@
+package citation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteRIS renders items as BOOK references. RIS has no escaping, so line
+// breaks inside values are folded into spaces.
+func WriteRIS(w io.Writer, items []Item) error {
+	buf := bufio.NewWriter(w)
+	for i, key := range keys(items) {
+		item := items[i]
+
+		writeRISField(buf, "TY", "BOOK")
+		writeRISField(buf, "ID", key)
+		for _, author := range item.Authors {
+			writeRISField(buf, "AU", author.Inverted())
+		}
+		writeRISField(buf, "TI", item.FullTitle())
+		writeRISField(buf, "PB", item.Publisher)
+		if item.Year != 0 {
+			writeRISField(buf, "PY", strconv.Itoa(item.Year))
+		}
+		writeRISField(buf, "SN", item.ISBN)
+		writeRISField(buf, "LA", item.Language)
+		buf.WriteString("ER  - \r\n")
+	}
+
+	return buf.Flush()
+}
+
+func writeRISField(buf *bufio.Writer, tag, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return
+	}
+
+	fmt.Fprintf(buf, "%s  - %s\r\n", tag, value)
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rhtyx/bayarind-service.git/citation"
+	"github.com/rhtyx/bayarind-service.git/names"
+	"github.com/stretchr/testify/assert"
+)
+
+func newItems() []citation.Item {
+	return []citation.Item{
+		{
+			Title:     "Bumi Manusia",
+			Subtitle:  "Sebuah Roman",
+			Authors:   []names.Name{names.Parse("Pramoedya Ananta Toer")},
+			Publisher: "Hasta Mitra",
+			Year:      1980,
+			ISBN:      "9789799731234",
+			Language:  "id",
+		},
+		{
+			Title:     "Anak Semua Bangsa",
+			Authors:   []names.Name{names.Parse("Pramoedya Ananta Toer")},
+			Publisher: "Hasta Mitra",
+			Year:      1980,
+		},
+		{
+			Title: "Anak Semua Bangsa",
+			Authors: []names.Name{
+				names.Parse("Ludwig van Beethoven"),
+				names.Parse("King, Martin Luther, Jr."),
+				names.Parse("Sukarno"),
+			},
+			Publisher: "Smith & Sons_100% {Press}",
+			Year:      1980,
+		},
+	}
+}
+
+func TestWriteBibTeX(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := citation.WriteBibTeX(buf, newItems())
+		assert.Nil(t, err)
+
+		bibtex := buf.String()
+		assert.Contains(t, bibtex, "@book{toer1980bumi,\n")
+		assert.Contains(t, bibtex, "  author = {Toer, Pramoedya Ananta},\n")
+		assert.Contains(t, bibtex, "  title = {Bumi Manusia: Sebuah Roman},\n")
+		assert.Contains(t, bibtex, "  year = {1980},\n")
+		assert.Contains(t, bibtex, "@book{toer1980anak,\n")
+		assert.Contains(t, bibtex, "@book{beethoven1980anak,\n")
+		assert.Contains(t, bibtex, "  author = {van Beethoven, Ludwig and King, Jr., Martin Luther and {Sukarno}},\n")
+		assert.Contains(t, bibtex, `  publisher = {Smith \& Sons\_100\% \{Press\}},`)
+	})
+
+	t.Run("ok: repeated keys", func(t *testing.T) {
+		items := newItems()
+		items[1].Title = items[0].Title
+
+		buf := &bytes.Buffer{}
+		err := citation.WriteBibTeX(buf, items)
+		assert.Nil(t, err)
+		assert.Contains(t, buf.String(), "@book{toer1980bumia,\n")
+		assert.Contains(t, buf.String(), "@book{toer1980bumib,\n")
+	})
+
+	t.Run("ok: and inside a name", func(t *testing.T) {
+		items := []citation.Item{{Title: "Atlas", Authors: []names.Name{{Family: "Rand and Co"}}}}
+
+		buf := &bytes.Buffer{}
+		err := citation.WriteBibTeX(buf, items)
+		assert.Nil(t, err)
+		assert.Contains(t, buf.String(), "  author = {{Rand and Co}},\n")
+	})
+}
+
+func TestWriteRIS(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		items := newItems()
+		items[0].Title = "Bumi\nManusia"
+
+		buf := &bytes.Buffer{}
+		err := citation.WriteRIS(buf, items)
+		assert.Nil(t, err)
+
+		ris := buf.String()
+		assert.Equal(t, 3, strings.Count(ris, "TY  - BOOK\r\n"))
+		assert.Equal(t, 3, strings.Count(ris, "ER  - \r\n"))
+		assert.Contains(t, ris, "AU  - Toer, Pramoedya Ananta\r\n")
+		assert.Contains(t, ris, "AU  - van Beethoven, Ludwig\r\n")
+		assert.Contains(t, ris, "AU  - King, Martin Luther, Jr.\r\n")
+		assert.Contains(t, ris, "AU  - Sukarno\r\n")
+		assert.Contains(t, ris, "TI  - Bumi Manusia: Sebuah Roman\r\n")
+		assert.Contains(t, ris, "SN  - 9789799731234\r\n")
+	})
+}
+
+func TestWriteCSLJSON(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := citation.WriteCSLJSON(buf, newItems())
+		assert.Nil(t, err)
+
+		var docs []map[string]interface{}
+		assert.Nil(t, json.Unmarshal(buf.Bytes(), &docs))
+		assert.Len(t, docs, 3)
+		assert.Equal(t, "toer1980bumi", docs[0]["id"])
+		assert.Equal(t, "book", docs[0]["type"])
+		assert.Equal(t, "9789799731234", docs[0]["ISBN"])
+		assert.Equal(t, []interface{}{[]interface{}{float64(1980)}}, docs[0]["issued"].(map[string]interface{})["date-parts"])
+
+		authors := docs[2]["author"].([]interface{})
+		assert.Equal(t, map[string]interface{}{"family": "Beethoven", "given": "Ludwig", "non-dropping-particle": "van"}, authors[0])
+		assert.Equal(t, map[string]interface{}{"family": "King", "given": "Martin Luther", "suffix": "Jr."}, authors[1])
+		assert.Equal(t, map[string]interface{}{"literal": "Sukarno"}, authors[2])
+	})
+
+	t.Run("ok: empty", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := citation.WriteCSLJSON(buf, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "[]\n", buf.String())
+	})
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("error: unsupported format", func(t *testing.T) {
+		err := citation.Write(&bytes.Buffer{}, "endnote", newItems())
+		assert.True(t, errors.Is(err, citation.ErrUnsupportedFormat))
+	})
+}
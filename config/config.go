@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -11,6 +12,30 @@ import (
 const (
 	DefaultApplicationRefreshTokenDuration = 24 * time.Hour
 	DefaultApplicationAccessTokenDuration  = 5 * time.Minute
+	DefaultApplicationTrashRetentionDays   = 30
+	DefaultApplicationTrashPurgeInterval   = 24 * time.Hour
+	DefaultApplicationImportMaxBytes       = 32 << 20
+	DefaultApplicationDefaultLocale        = "id"
+	DefaultMetadataBaseURL                 = "https://openlibrary.org"
+	DefaultMetadataTimeout                 = 5 * time.Second
+	DefaultMetadataCacheTTL                = 24 * time.Hour
+	DefaultBlobDriver                      = "local"
+	DefaultBlobLocalDir                    = "./data/blobs"
+	DefaultBlobSignedURLTTL                = 1 * time.Hour
+	DefaultCoverMaxBytes                   = 5 << 20
+	DefaultLoanPeriodDays                  = 21
+	DefaultLoanMaxRenewals                 = 2
+	DefaultHoldPickupDays                  = 7
+	DefaultHoldExpiryInterval              = 15 * time.Minute
+	DefaultFineCurrency                    = "USD"
+	DefaultFinePerDay                      = 25
+	DefaultFineMaxOverdue                  = 1000
+	DefaultFineLostItem                    = 2500
+	DefaultFineBlockThreshold              = 1000
+	DefaultRecommendationRefreshInterval   = 6 * time.Hour
+	DefaultRecommendationPerBook           = 50
+	DefaultOPDSMaxFailedLogins             = 5
+	DefaultOPDSLockout                     = 15 * time.Minute
 	DefaultPostgresMaxIdleConns            = 3
 	DefaultPostgresMaxOpenConns            = 5
 	DefaultPostgresMaxConnLifetime         = 1 * time.Hour
@@ -38,6 +63,13 @@ func Port() string {
 	return viper.GetString("application.port")
 }
 
+// PublicURL is the externally reachable address of the service, used in
+// the stable URIs of linked data. Requests fall back to their own host
+// when it is not set.
+func PublicURL() string {
+	return strings.TrimSuffix(viper.GetString("application.public-url"), "/")
+}
+
 func LogLevel() string {
 	return viper.GetString("log-level")
 }
@@ -62,6 +94,241 @@ func AccessTokenDuration() time.Duration {
 	return res
 }
 
+func TrashRetention() time.Duration {
+	days := viper.GetInt("application.trash-retention-days")
+	if days <= 0 {
+		days = DefaultApplicationTrashRetentionDays
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func TrashPurgeInterval() time.Duration {
+	cfg := viper.GetString("application.trash-purge-interval")
+	res, err := time.ParseDuration(cfg)
+	if err != nil || res <= 0 {
+		return DefaultApplicationTrashPurgeInterval
+	}
+
+	return res
+}
+
+func ImportMaxBytes() int64 {
+	if viper.GetInt64("application.import-max-bytes") <= 0 {
+		return DefaultApplicationImportMaxBytes
+	}
+	return viper.GetInt64("application.import-max-bytes")
+}
+
+// DefaultLocale is the locale of the untranslated catalog fields, served
+// when no requested locale has a translation.
+func DefaultLocale() string {
+	if viper.GetString("application.default-locale") == "" {
+		return DefaultApplicationDefaultLocale
+	}
+	return viper.GetString("application.default-locale")
+}
+
+func MetadataBaseURL() string {
+	if viper.GetString("metadata.base-url") == "" {
+		return DefaultMetadataBaseURL
+	}
+	return viper.GetString("metadata.base-url")
+}
+
+func MetadataTimeout() time.Duration {
+	cfg := viper.GetString("metadata.timeout")
+	res, err := time.ParseDuration(cfg)
+	if err != nil || res <= 0 {
+		return DefaultMetadataTimeout
+	}
+
+	return res
+}
+
+func MetadataCacheTTL() time.Duration {
+	cfg := viper.GetString("metadata.cache-ttl")
+	res, err := time.ParseDuration(cfg)
+	if err != nil || res <= 0 {
+		return DefaultMetadataCacheTTL
+	}
+
+	return res
+}
+
+func BlobDriver() string {
+	if viper.GetString("blob.driver") == "" {
+		return DefaultBlobDriver
+	}
+	return viper.GetString("blob.driver")
+}
+
+func BlobLocalDir() string {
+	if viper.GetString("blob.local-dir") == "" {
+		return DefaultBlobLocalDir
+	}
+	return viper.GetString("blob.local-dir")
+}
+
+// BlobPublicURL is the externally reachable address of the /blobs route
+// used in URLs signed by the local blob store.
+func BlobPublicURL() string {
+	return viper.GetString("blob.public-url")
+}
+
+func BlobSigningKey() string {
+	return viper.GetString("blob.signing-key")
+}
+
+func BlobSignedURLTTL() time.Duration {
+	cfg := viper.GetString("blob.signed-url-ttl")
+	res, err := time.ParseDuration(cfg)
+	if err != nil || res <= 0 {
+		return DefaultBlobSignedURLTTL
+	}
+
+	return res
+}
+
+func S3Endpoint() string {
+	return viper.GetString("blob.s3.endpoint")
+}
+
+func S3Region() string {
+	return viper.GetString("blob.s3.region")
+}
+
+func S3Bucket() string {
+	return viper.GetString("blob.s3.bucket")
+}
+
+func S3AccessKey() string {
+	return viper.GetString("blob.s3.access-key")
+}
+
+func S3SecretKey() string {
+	return viper.GetString("blob.s3.secret-key")
+}
+
+func S3PathStyle() bool {
+	return viper.GetBool("blob.s3.path-style")
+}
+
+func CoverMaxBytes() int64 {
+	if viper.GetInt64("cover.max-bytes") <= 0 {
+		return DefaultCoverMaxBytes
+	}
+	return viper.GetInt64("cover.max-bytes")
+}
+
+func LoanPeriod() time.Duration {
+	days := viper.GetInt("loan.period-days")
+	if days <= 0 {
+		days = DefaultLoanPeriodDays
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// LoanMaxRenewals may be set to 0 to forbid renewals.
+func LoanMaxRenewals() int {
+	if !viper.IsSet("loan.max-renewals") || viper.GetInt("loan.max-renewals") < 0 {
+		return DefaultLoanMaxRenewals
+	}
+	return viper.GetInt("loan.max-renewals")
+}
+
+func HoldPickupWindow() time.Duration {
+	days := viper.GetInt("hold.pickup-days")
+	if days <= 0 {
+		days = DefaultHoldPickupDays
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func HoldExpiryInterval() time.Duration {
+	cfg := viper.GetString("hold.expiry-interval")
+	res, err := time.ParseDuration(cfg)
+	if err != nil || res <= 0 {
+		return DefaultHoldExpiryInterval
+	}
+
+	return res
+}
+
+func FineCurrency() string {
+	if viper.GetString("fine.currency") == "" {
+		return DefaultFineCurrency
+	}
+	return viper.GetString("fine.currency")
+}
+
+// Fine amounts are in minor units of FineCurrency. Each may be set to 0 to
+// charge nothing.
+
+func FinePerDay() int64 {
+	return fineAmount("fine.per-day", DefaultFinePerDay)
+}
+
+func FineMaxOverdue() int64 {
+	return fineAmount("fine.max-overdue", DefaultFineMaxOverdue)
+}
+
+func FineLostItem() int64 {
+	return fineAmount("fine.lost-item", DefaultFineLostItem)
+}
+
+// FineBlockThreshold is the balance above which a reader may no longer
+// borrow, renew or place holds.
+func FineBlockThreshold() int64 {
+	return fineAmount("fine.block-threshold", DefaultFineBlockThreshold)
+}
+
+func fineAmount(key string, fallback int64) int64 {
+	if !viper.IsSet(key) || viper.GetInt64(key) < 0 {
+		return fallback
+	}
+	return viper.GetInt64(key)
+}
+
+func RecommendationRefreshInterval() time.Duration {
+	cfg := viper.GetString("recommendation.refresh-interval")
+	res, err := time.ParseDuration(cfg)
+	if err != nil || res <= 0 {
+		return DefaultRecommendationRefreshInterval
+	}
+
+	return res
+}
+
+// RecommendationPerBook is how many similar books are kept for each book.
+func RecommendationPerBook() int {
+	if viper.GetInt("recommendation.per-book") <= 0 {
+		return DefaultRecommendationPerBook
+	}
+	return viper.GetInt("recommendation.per-book")
+}
+
+// OPDSMaxFailedLogins is how many wrong passwords a username or client IP
+// may send to /opds/ before it is locked out.
+func OPDSMaxFailedLogins() int {
+	if viper.GetInt("opds.max-failed-logins") <= 0 {
+		return DefaultOPDSMaxFailedLogins
+	}
+	return viper.GetInt("opds.max-failed-logins")
+}
+
+func OPDSLockout() time.Duration {
+	cfg := viper.GetString("opds.lockout")
+	res, err := time.ParseDuration(cfg)
+	if err != nil || res <= 0 {
+		return DefaultOPDSLockout
+	}
+
+	return res
+}
+
 func PostgresHost() string {
 	return viper.GetString("postgres.host")
 }
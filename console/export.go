@@ -0,0 +1,149 @@
+package console
+
+import (
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/db"
+	"github.com/rhtyx/bayarind-service.git/export"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/repository"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use: "export",
+	Run: doExport,
+}
+
+func init() {
+	exportCmd.PersistentFlags().String("entity", "books", "entity to export (books, authors)")
+	exportCmd.PersistentFlags().String("format", export.FormatCSV, "output format (csv, ndjson, and marc or marcxml for books)")
+	exportCmd.PersistentFlags().String("output", "", "file to write to, defaults to stdout")
+	exportCmd.PersistentFlags().String("author-id", "", "only export books of this author")
+	exportCmd.PersistentFlags().String("isbn", "", "only export the book with this isbn")
+	exportCmd.PersistentFlags().String("title", "", "only export books whose title contains this text")
+	exportCmd.PersistentFlags().String("name", "", "only export authors whose name contains this text")
+	exportCmd.PersistentFlags().String("updated-since", "", "only export rows changed since this RFC 3339 time")
+	RootCmd.AddCommand(exportCmd)
+}
+
+func doExport(cmd *cobra.Command, _ []string) {
+	entity := cmd.Flag("entity").Value.String()
+	format := cmd.Flag("format").Value.String()
+	output := cmd.Flag("output").Value.String()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"entity": entity,
+		"format": format,
+		"output": output,
+	})
+
+	var updatedSince *time.Time
+	if cmd.Flag("updated-since").Value.String() != "" {
+		t, err := time.Parse(time.RFC3339, cmd.Flag("updated-since").Value.String())
+		if err != nil {
+			logger.Fatal("Invalid updated-since: ", err)
+		}
+
+		updatedSince = &t
+	}
+
+	// Keep stdout clean for the export itself when no file is given.
+	var out io.Writer = os.Stdout
+	if output == "" {
+		logrus.SetOutput(os.Stderr)
+	} else {
+		file, err := os.Create(output)
+		if err != nil {
+			logger.Fatal("Failed to create output file: ", err)
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	db.InitPostgresDB()
+	ctx := context.Background()
+
+	rows := 0
+	switch entity {
+	case "books":
+		filter := model.BookFilter{
+			ISBN:         cmd.Flag("isbn").Value.String(),
+			Title:        cmd.Flag("title").Value.String(),
+			UpdatedSince: updatedSince,
+		}
+
+		if cmd.Flag("author-id").Value.String() != "" {
+			authorID, err := strconv.ParseInt(cmd.Flag("author-id").Value.String(), 10, 64)
+			if err != nil {
+				logger.Fatal("Invalid author-id: ", err)
+			}
+
+			filter.AuthorID = authorID
+		}
+
+		writer, err := export.NewWriter(out, format, export.BookHeader)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		bookRepository := repository.NewBookRepository(db.PostgresDB)
+		if export.IsMARC(format) {
+			err = bookRepository.StreamCitations(ctx, filter, func(citation *model.Citation) error {
+				rows++
+				return writer.Write(export.BookRecord(citation), nil)
+			})
+		} else {
+			err = bookRepository.Stream(ctx, filter, func(book *model.Book) error {
+				rows++
+				return writer.Write(book, export.BookRow(book))
+			})
+		}
+		if err != nil {
+			logger.Fatal("Failed to export books: ", err)
+		}
+
+		err = writer.Close()
+		if err != nil {
+			logger.Fatal("Failed to write export: ", err)
+		}
+	case "authors":
+		if export.IsMARC(format) {
+			logger.Fatal("MARC exports only cover books")
+		}
+
+		filter := model.AuthorFilter{
+			Name:         cmd.Flag("name").Value.String(),
+			UpdatedSince: updatedSince,
+		}
+
+		writer, err := export.NewWriter(out, format, export.AuthorHeader)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		err = repository.NewAuthorRepository(db.PostgresDB).Stream(ctx, filter, func(author *model.Author) error {
+			rows++
+			return writer.Write(author, export.AuthorRow(author))
+		})
+		if err != nil {
+			logger.Fatal("Failed to export authors: ", err)
+		}
+
+		err = writer.Close()
+		if err != nil {
+			logger.Fatal("Failed to write export: ", err)
+		}
+	default:
+		logger.Fatal("Unknown entity")
+	}
+
+	logger.Infof("Exported %d %s", rows, entity)
+}
@@ -0,0 +1,33 @@
+package console
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/config"
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runHoldExpirer expires uncollected holds and hands their copies to the
+// next readers once at startup and then on every expiry interval.
+func runHoldExpirer(ctx context.Context, holdService model.HoldService) {
+	ticker := time.NewTicker(config.HoldExpiryInterval())
+	defer ticker.Stop()
+
+	for {
+		expired, err := holdService.ExpirePickups(ctx)
+		if err != nil {
+			logrus.WithContext(ctx).Error("Failed to expire holds: ", err)
+		} else if expired > 0 {
+			logrus.WithContext(ctx).Infof("Expired %d uncollected holds", expired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package console
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/config"
+	"github.com/rhtyx/bayarind-service.git/db"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/repository"
+	"github.com/rhtyx/bayarind-service.git/service"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var purgeCmd = &cobra.Command{
+	Use: "purge",
+	Run: doPurge,
+}
+
+func init() {
+	RootCmd.AddCommand(purgeCmd)
+}
+
+func doPurge(_ *cobra.Command, _ []string) {
+	db.InitPostgresDB()
+
+	authorRepository := repository.NewAuthorRepository(db.PostgresDB)
+	bookRepository := repository.NewBookRepository(db.PostgresDB)
+	userRepository := repository.NewUserRepository(db.PostgresDB)
+	editionRepository := repository.NewEditionRepository(db.PostgresDB)
+
+	purgeTrash(
+		context.Background(),
+		service.NewBookService(bookRepository, authorRepository, editionRepository),
+		service.NewAuthorService(authorRepository, bookRepository),
+		service.NewUserService(userRepository),
+	)
+}
+
+// runTrashPurger hard-deletes trashed rows older than the configured
+// retention once at startup and then on every purge interval.
+func runTrashPurger(ctx context.Context, bookService model.BookService, authorService model.AuthorService, userService model.UserService) {
+	ticker := time.NewTicker(config.TrashPurgeInterval())
+	defer ticker.Stop()
+
+	for {
+		purgeTrash(ctx, bookService, authorService, userService)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeTrash removes books before authors so that authors whose trashed
+// books expire in the same run can be purged as well.
+func purgeTrash(ctx context.Context, bookService model.BookService, authorService model.AuthorService, userService model.UserService) {
+	deletedBefore := time.Now().Add(-config.TrashRetention())
+	logger := logrus.
+		WithContext(ctx).
+		WithField("deletedBefore", deletedBefore)
+
+	books, err := bookService.Purge(ctx, deletedBefore)
+	if err != nil {
+		logger.Error("Failed to purge books: ", err)
+	}
+
+	authors, err := authorService.Purge(ctx, deletedBefore)
+	if err != nil {
+		logger.Error("Failed to purge authors: ", err)
+	}
+
+	users, err := userService.Purge(ctx, deletedBefore)
+	if err != nil {
+		logger.Error("Failed to purge users: ", err)
+	}
+
+	logger.Infof("Purged %d books, %d authors and %d users from trash", books, authors, users)
+}
@@ -0,0 +1,33 @@
+package console
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/config"
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runRecommendationRefresher recomputes book similarities once at startup
+// and then on every refresh interval.
+func runRecommendationRefresher(ctx context.Context, recommendationService model.RecommendationService) {
+	ticker := time.NewTicker(config.RecommendationRefreshInterval())
+	defer ticker.Stop()
+
+	for {
+		stored, err := recommendationService.Refresh(ctx)
+		if err != nil {
+			logrus.WithContext(ctx).Error("Failed to refresh recommendations: ", err)
+		} else {
+			logrus.WithContext(ctx).Infof("Stored %d book similarities", stored)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package console
+
+import (
+	"context"
+	"slices"
+
+	"github.com/rhtyx/bayarind-service.git/db"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/repository"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var roleCmd = &cobra.Command{
+	Use: "role",
+	Run: doRole,
+}
+
+func init() {
+	roleCmd.PersistentFlags().String("username", "", "username to grant the role to")
+	roleCmd.PersistentFlags().String("role", model.RoleReader, "role to grant (reader, librarian, admin)")
+	RootCmd.AddCommand(roleCmd)
+}
+
+func doRole(cmd *cobra.Command, _ []string) {
+	username := cmd.Flag("username").Value.String()
+	role := cmd.Flag("role").Value.String()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"username": username,
+		"role":     role,
+	})
+
+	if !slices.Contains([]string{model.RoleReader, model.RoleLibrarian, model.RoleAdmin}, role) {
+		logger.Fatal("Unknown role")
+	}
+
+	db.InitPostgresDB()
+	ctx := context.Background()
+	userRepository := repository.NewUserRepository(db.PostgresDB)
+
+	user, err := userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		logger.Fatal("Failed to find user: ", err)
+	}
+
+	user.Role = role
+	_, err = userRepository.UpdateColumns(ctx, user, []string{"role"})
+	if err != nil {
+		logger.Fatal("Failed to update user role: ", err)
+	}
+
+	logger.Info("Role granted")
+}
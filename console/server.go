@@ -1,13 +1,19 @@
 package console
 
 import (
+	"context"
 	"errors"
+	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
+	"github.com/rhtyx/bayarind-service.git/blob"
 	"github.com/rhtyx/bayarind-service.git/config"
 	"github.com/rhtyx/bayarind-service.git/controller"
 	"github.com/rhtyx/bayarind-service.git/db"
+	"github.com/rhtyx/bayarind-service.git/metadata"
+	"github.com/rhtyx/bayarind-service.git/model"
 	"github.com/rhtyx/bayarind-service.git/repository"
 	"github.com/rhtyx/bayarind-service.git/service"
 	"github.com/rhtyx/bayarind-service.git/token"
@@ -15,6 +21,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/gommon/log"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -37,17 +44,90 @@ func run(cmd *cobra.Command, _ []string) {
 	bookRepository := repository.NewBookRepository(db.PostgresDB)
 	userRepository := repository.NewUserRepository(db.PostgresDB)
 	sessionRepository := repository.NewSessionRepository(db.PostgresDB)
+	importJobRepository := repository.NewImportJobRepository(db.PostgresDB)
+	publisherRepository := repository.NewPublisherRepository(db.PostgresDB)
+	workRepository := repository.NewWorkRepository(db.PostgresDB)
+	editionRepository := repository.NewEditionRepository(db.PostgresDB)
+	subjectRepository := repository.NewSubjectRepository(db.PostgresDB)
+	tagRepository := repository.NewTagRepository(db.PostgresDB)
+	copyRepository := repository.NewCopyRepository(db.PostgresDB)
+	loanRepository := repository.NewLoanRepository(db.PostgresDB)
+	holdRepository := repository.NewHoldRepository(db.PostgresDB)
+	ledgerRepository := repository.NewLedgerRepository(db.PostgresDB)
+	reviewRepository := repository.NewReviewRepository(db.PostgresDB)
+	shelfRepository := repository.NewShelfRepository(db.PostgresDB)
+	recommendationRepository := repository.NewRecommendationRepository(db.PostgresDB)
 
-	authorService := service.NewAuthorService(authorRepository)
-	bookService := service.NewBookService(bookRepository, authorRepository)
+	authorService := service.NewAuthorService(authorRepository, bookRepository)
+	bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
 	userService := service.NewUserService(userRepository)
 	sessionService := service.NewSessionService(sessionRepository, userRepository, token.Jwt)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	importService := service.NewImportService(ctx, importJobRepository, authorRepository, publisherRepository, bookService)
+	failed, err := importService.FailUnfinished(ctx)
+	if err != nil {
+		logrus.Fatal("Failed to recover unfinished imports: ", err)
+	}
+	if failed > 0 {
+		logrus.Warnf("Marked %d unfinished imports as failed", failed)
+	}
+
+	publisherService := service.NewPublisherService(publisherRepository, editionRepository)
+	workService := service.NewWorkService(workRepository, editionRepository, authorRepository)
+	editionService := service.NewEditionService(editionRepository, workRepository, publisherRepository)
+	subjectService := service.NewSubjectService(subjectRepository, bookRepository)
+	tagService := service.NewTagService(tagRepository, bookRepository)
+	copyService := service.NewCopyService(copyRepository, bookRepository, loanRepository)
+	finePolicy := model.FinePolicy{
+		PerDay:     config.FinePerDay(),
+		MaxOverdue: config.FineMaxOverdue(),
+		LostItem:   config.FineLostItem(),
+	}
+	accountService := service.NewAccountService(ledgerRepository, userRepository, loanRepository, finePolicy, config.FineCurrency(), config.FineBlockThreshold())
+	holdService := service.NewHoldService(holdRepository, bookRepository, accountService, config.HoldPickupWindow())
+	reviewService := service.NewReviewService(reviewRepository, bookRepository)
+	shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+	recommendationService := service.NewRecommendationService(recommendationRepository, bookRepository, config.RecommendationPerBook())
+	catalogService := service.NewCatalogService(bookRepository, authorRepository, subjectRepository)
+	citationService := service.NewCitationService(bookRepository, authorRepository, editionRepository, publisherRepository)
+	loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, config.LoanPeriod(), config.LoanMaxRenewals())
+	metadataProvider := metadata.NewCachedProvider(
+		metadata.NewOpenLibraryProvider(config.MetadataBaseURL(), &http.Client{Timeout: config.MetadataTimeout()}),
+		config.MetadataCacheTTL(),
+	)
+	metadataService := service.NewMetadataService(metadataProvider, authorRepository)
+	blobStore, blobHandler := newBlobStore()
+	coverService := service.NewCoverService(bookRepository, blobStore, config.BlobSignedURLTTL())
 
 	ctrl := controller.NewController()
 	ctrl.RegisterAuthorService(authorService)
 	ctrl.RegisterBookService(bookService)
 	ctrl.RegisterUserService(userService)
 	ctrl.RegisterSessionService(sessionService)
+	ctrl.RegisterImportService(importService)
+	ctrl.RegisterMetadataService(metadataService)
+	ctrl.RegisterCoverService(coverService)
+	ctrl.RegisterPublisherService(publisherService)
+	ctrl.RegisterWorkService(workService)
+	ctrl.RegisterEditionService(editionService)
+	ctrl.RegisterSubjectService(subjectService)
+	ctrl.RegisterTagService(tagService)
+	ctrl.RegisterCopyService(copyService)
+	ctrl.RegisterLoanService(loanService)
+	ctrl.RegisterHoldService(holdService)
+	ctrl.RegisterAccountService(accountService)
+	ctrl.RegisterReviewService(reviewService)
+	ctrl.RegisterShelfService(shelfService)
+	ctrl.RegisterRecommendationService(recommendationService)
+	ctrl.RegisterCatalogService(catalogService)
+	ctrl.RegisterCitationService(citationService)
+	ctrl.RegisterBlobHandler(blobHandler)
+
+	go runTrashPurger(ctx, bookService, authorService, userService)
+	go runHoldExpirer(ctx, holdService)
+	go runRecommendationRefresher(ctx, recommendationService)
 
 	sigCh := make(chan os.Signal, 1)
 	errCh := make(chan error, 1)
@@ -60,14 +140,50 @@ func run(cmd *cobra.Command, _ []string) {
 
 	go runHTTPServer(ctrl, errCh)
 	log.Error(<-errCh)
+
+	cancel()
+	importService.Wait()
 }
 
 func runHTTPServer(ctrl *controller.Controller, errCh chan<- error) {
 	e := echo.New()
 	e.Pre(middleware.AddTrailingSlash())
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		ExposeHeaders: []string{controller.HeaderETag},
+	}))
 
 	ctrl.InitRoutes(e)
 	errCh <- e.Start(":" + config.Port())
 }
+
+// newBlobStore builds the configured blob store. The local store also
+// returns the handler serving its signed URLs; S3 serves its own.
+func newBlobStore() (model.BlobStore, http.Handler) {
+	switch config.BlobDriver() {
+	case "s3":
+		store, err := blob.NewS3Store(blob.S3Config{
+			Endpoint:  config.S3Endpoint(),
+			Region:    config.S3Region(),
+			Bucket:    config.S3Bucket(),
+			AccessKey: config.S3AccessKey(),
+			SecretKey: config.S3SecretKey(),
+			PathStyle: config.S3PathStyle(),
+		}, &http.Client{Timeout: 30 * time.Second})
+		if err != nil {
+			logrus.Fatal("Failed to configure s3 blob store: ", err)
+		}
+
+		return store, nil
+	case "local":
+		if config.BlobSigningKey() == "" {
+			logrus.Fatal("Missing blob.signing-key")
+		}
+
+		store := blob.NewLocalStore(config.BlobLocalDir(), config.BlobPublicURL(), []byte(config.BlobSigningKey()))
+		return store, store.Handler()
+	default:
+		logrus.Fatal("Unknown blob driver ", config.BlobDriver())
+		return nil, nil
+	}
+}
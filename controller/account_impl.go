@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// FindAccount returns the balance and ledger of the caller. Librarians and
+// admins may pass user_id to see the account of another user.
+func (c Controller) FindAccount(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	if e.QueryParam("user_id") != "" {
+		holderID, err := strconv.ParseInt(e.QueryParam("user_id"), 10, 64)
+		if err != nil {
+			logger.WithField("userID", e.QueryParam("user_id")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query user_id", ErrBadRequest.Error()))
+		}
+
+		err = c.checkOwnerAccess(ctx, e, holderID)
+		if err != nil {
+			logger.WithField("userID", holderID).Error(err)
+			return parseError(e, err)
+		}
+
+		userID = holderID
+	}
+
+	account, err := c.accountService.FindAccount(ctx, userID)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, account)
+}
+
+func (c Controller) CreateCharge(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	body := &dto.ChargeRequest{}
+	err := json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	staffID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	entry, err := c.accountService.Charge(ctx, &model.LedgerEntry{
+		UserID:    body.UserID,
+		Reason:    body.Reason,
+		Amount:    body.Amount,
+		LoanID:    body.LoanID,
+		Note:      body.Note,
+		CreatedBy: &staffID,
+	})
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusCreated, entry)
+}
+
+func (c Controller) CreatePayment(e echo.Context) error {
+	return c.createCredit(e, model.EntryKindPayment)
+}
+
+func (c Controller) CreateWaiver(e echo.Context) error {
+	return c.createCredit(e, model.EntryKindWaiver)
+}
+
+func (c Controller) createCredit(e echo.Context, kind string) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	body := &dto.CreditRequest{}
+	err := json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	staffID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	entry, err := c.accountService.Credit(ctx, &model.LedgerEntry{
+		UserID:    body.UserID,
+		Kind:      kind,
+		Amount:    body.Amount,
+		Note:      body.Note,
+		CreatedBy: &staffID,
+	})
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusCreated, entry)
+}
@@ -45,6 +45,14 @@ func (c Controller) Login(e echo.Context) error {
 		HMACSecretKey: hmacSecretKey,
 	}
 
+	// A failed lookup must not lock readers out, so it only goes unflagged.
+	account, err := c.accountService.FindStanding(ctx, session.UserID)
+	if err != nil {
+		logger.WithField("userID", session.UserID).Error(err)
+	} else {
+		response.AccountBlocked = account.Blocked
+	}
+
 	return e.JSON(http.StatusOK, response)
 }
 
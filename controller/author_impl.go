@@ -2,12 +2,18 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rhtyx/bayarind-service.git/dto"
 	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/patch"
+	"github.com/rhtyx/bayarind-service.git/schemaorg"
 	"github.com/rhtyx/bayarind-service.git/utils"
 
 	"github.com/go-playground/validator/v10"
@@ -27,29 +33,26 @@ func (c Controller) CreateAuthor(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
 	}
 
-	validate := validator.New()
+	validate := dto.NewValidator()
 	err = validate.Struct(body)
 	if err != nil {
 		logger.WithField("body", utils.Dump(body)).Error(err)
 		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
 	}
 
-	birthDate, err := utils.ParseDate(body.BirthDate)
+	author, err := newAuthor(body)
 	if err != nil {
 		logger.WithField("body", utils.Dump(body)).Error(err)
 		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
 	}
 
-	author := &model.Author{
-		Name:      body.Name,
-		BirthDate: *birthDate,
-	}
 	author, err = c.authorService.Create(ctx, author)
 	if err != nil {
 		logger.WithField("author", utils.Dump(author)).Error(err)
 		return parseError(e, err)
 	}
 
+	setETag(e, author.Version)
 	return e.JSON(http.StatusCreated, author)
 }
 
@@ -63,12 +66,38 @@ func (c Controller) FindAuthorByID(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
 	}
 
+	chosen, err := negotiateRepresentation(e, representationJSON, representationJSONLD)
+	if err != nil {
+		logger.WithField("format", e.QueryParam("format")).Error(err)
+		return parseError(e, err)
+	}
+
 	author, err := c.authorService.FindByID(ctx, authorID)
+	if errors.Is(err, ErrNotFound) {
+		targetAuthorID, redirectErr := c.authorService.FindRedirect(ctx, authorID)
+		if redirectErr == nil {
+			return e.Redirect(http.StatusMovedPermanently, mergedAuthorURL(e, targetAuthorID))
+		}
+	}
+
 	if err != nil {
 		logger.WithField("authorID", authorID).Error(err)
 		return parseError(e, err)
 	}
 
+	chain := locales(e)
+	addContentLanguage(e, author.Localize(chain)...)
+	setETag(e, author.Version, chosen.format, localeVariant(chain))
+	if notModified(e, author.Version, chosen.format, localeVariant(chain)) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	if chosen.format == formatJSONLD {
+		return writeBlob(e, http.StatusOK, schemaorg.MIMEJSONLD, func(w io.Writer) error {
+			return schemaorg.Write(w, schemaorg.NewPerson(authorURI(e, author.ID), author))
+		})
+	}
+
 	return e.JSON(http.StatusOK, author)
 }
 
@@ -76,12 +105,23 @@ func (c *Controller) FindAllAuthors(e echo.Context) error {
 	ctx := e.Request().Context()
 	logger := logrus.WithContext(ctx)
 
-	authors, err := c.authorService.FindAll(ctx)
+	var authors []*model.Author
+	var err error
+	if name := e.QueryParam("name"); name != "" {
+		authors, err = c.authorService.FindAllByName(ctx, name)
+	} else {
+		authors, err = c.authorService.FindAll(ctx)
+	}
 	if err != nil {
 		logger.Error(err)
 		return parseError(e, err)
 	}
 
+	chain := locales(e)
+	for _, author := range authors {
+		addContentLanguage(e, author.Localize(chain)...)
+	}
+
 	return e.JSON(http.StatusOK, authors)
 }
 
@@ -95,6 +135,22 @@ func (c Controller) UpdateAuthor(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
 	}
 
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		currAuthor, err := c.authorService.FindByID(ctx, authorID)
+		if err != nil {
+			logger.WithField("authorID", authorID).Error(err)
+			return parseError(e, err)
+		}
+
+		version = currAuthor.Version
+	}
+
 	body := &dto.AuthorRequest{}
 	err = json.NewDecoder(e.Request().Body).Decode(body)
 	if err != nil {
@@ -102,30 +158,97 @@ func (c Controller) UpdateAuthor(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
 	}
 
-	validate := validator.New()
+	validate := dto.NewValidator()
 	err = validate.Struct(body)
 	if err != nil {
 		logger.WithField("body", utils.Dump(body)).Error(err)
 		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
 	}
 
-	birthDate, err := utils.ParseDate(body.BirthDate)
+	author, err := newAuthor(body)
 	if err != nil {
 		logger.WithField("body", utils.Dump(body)).Error(err)
 		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
 	}
 
-	author := &model.Author{
-		ID:        authorID,
-		Name:      body.Name,
-		BirthDate: *birthDate,
-	}
+	author.ID = authorID
+	author.Version = version
 	author, err = c.authorService.Update(ctx, author)
 	if err != nil {
 		logger.WithField("author", utils.Dump(author)).Error(err)
 		return parseError(e, err)
 	}
 
+	setETag(e, author.Version)
+	return e.JSON(http.StatusOK, author)
+}
+
+func (c Controller) PatchAuthor(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	authorID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("authorID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	currAuthor, err := c.authorService.FindByID(ctx, authorID)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		version = currAuthor.Version
+	}
+
+	if version != currAuthor.Version {
+		return e.JSON(http.StatusPreconditionFailed, ErrPreconditionFailed.Error())
+	}
+
+	current := newAuthorRequest(currAuthor)
+	body := &dto.AuthorRequest{}
+	err = decodePatch(e, current, body)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	fields, columns := patch.Changed(current, body)
+	if len(fields) == 0 {
+		setETag(e, currAuthor.Version)
+		return e.JSON(http.StatusOK, currAuthor)
+	}
+
+	validate := dto.NewValidator()
+	err = validate.StructPartial(body, fields...)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	author, err := newAuthor(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid birth_date", ErrBadRequest.Error()))
+	}
+
+	author.ID = authorID
+	author.Version = version
+	author, err = c.authorService.Patch(ctx, author, columns)
+	if err != nil {
+		logger.WithField("columns", columns).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, author.Version)
 	return e.JSON(http.StatusOK, author)
 }
 
@@ -139,7 +262,49 @@ func (c Controller) DeleteAuthor(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
 	}
 
-	err = c.authorService.Delete(ctx, authorID)
+	cascade := false
+	if e.QueryParam("cascade") != "" {
+		cascade, err = strconv.ParseBool(e.QueryParam("cascade"))
+		if err != nil {
+			logger.WithField("cascade", e.QueryParam("cascade")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query cascade", ErrBadRequest.Error()))
+		}
+	}
+
+	var targetAuthorID int64
+	if e.QueryParam("reassign_to") != "" {
+		targetAuthorID, err = strconv.ParseInt(e.QueryParam("reassign_to"), 10, 64)
+		if err != nil {
+			logger.WithField("reassignTo", e.QueryParam("reassign_to")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query reassign_to", ErrBadRequest.Error()))
+		}
+	}
+
+	switch {
+	case cascade && targetAuthorID != 0:
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: cascade and reassign_to are exclusive", ErrBadRequest.Error()))
+	case cascade:
+		userID, ok := e.Get("userID").(int64)
+		if !ok {
+			return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+		}
+
+		isAdmin, err := c.hasRole(ctx, userID, model.RoleAdmin)
+		if err != nil {
+			logger.WithField("userID", userID).Error(err)
+			return parseError(e, err)
+		}
+
+		if !isAdmin {
+			return e.JSON(http.StatusForbidden, ErrForbidden.Error())
+		}
+
+		err = c.authorService.DeleteCascade(ctx, authorID)
+	case targetAuthorID != 0:
+		err = c.authorService.DeleteAndReassign(ctx, authorID, targetAuthorID)
+	default:
+		err = c.authorService.Delete(ctx, authorID)
+	}
 	if err != nil {
 		logger.WithField("authorID", authorID).Error(err)
 		return parseError(e, err)
@@ -147,3 +312,129 @@ func (c Controller) DeleteAuthor(e echo.Context) error {
 
 	return e.JSON(http.StatusOK, "Author deleted")
 }
+
+// FindDuplicateAuthors lists pairs of authors born on the same day under
+// near-identical names.
+func (c Controller) FindDuplicateAuthors(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	duplicates, err := c.authorService.FindDuplicates(ctx)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, duplicates)
+}
+
+// MergeAuthors folds the authors in the body into the author in the path.
+func (c Controller) MergeAuthors(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	authorID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("authorID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	body := &dto.MergeAuthorsRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	author, err := c.authorService.Merge(ctx, authorID, body.AuthorIDs)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, author.Version)
+	return e.JSON(http.StatusOK, author)
+}
+
+// mergedAuthorURL is the request URL with the merged author id in its path
+// replaced by the id of the author it was merged into.
+func mergedAuthorURL(e echo.Context, targetAuthorID int64) string {
+	url := *e.Request().URL
+	url.Path = strings.Replace(url.Path, "/"+e.Param("id")+"/", fmt.Sprintf("/%d/", targetAuthorID), 1)
+	return url.String()
+}
+
+// newAuthor builds an author from a validated request.
+func newAuthor(body *dto.AuthorRequest) (*model.Author, error) {
+	birthDate, err := utils.ParseDate(body.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+
+	author := &model.Author{
+		Name:        body.Name,
+		BirthDate:   *birthDate,
+		Nationality: body.Nationality,
+		Biography:   body.Biography,
+		Website:     body.Website,
+		VIAF:        body.VIAF,
+		ISNI:        body.ISNI,
+		WikidataID:  body.WikidataID,
+		Aliases:     []*model.AuthorAlias{},
+
+		Translations: authorTranslations(body.Translations),
+	}
+
+	if body.DeathDate != "" {
+		author.DeathDate, err = utils.ParseDate(body.DeathDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, alias := range body.Aliases {
+		author.Aliases = append(author.Aliases, &model.AuthorAlias{
+			Name: alias.Name,
+			Kind: alias.Kind,
+		})
+	}
+
+	return author, nil
+}
+
+// newAuthorRequest is the request that would write author as it is.
+func newAuthorRequest(author *model.Author) *dto.AuthorRequest {
+	body := &dto.AuthorRequest{
+		Name:        author.Name,
+		BirthDate:   author.BirthDate.Format(time.DateOnly),
+		Nationality: author.Nationality,
+		Biography:   author.Biography,
+		Website:     author.Website,
+		VIAF:        author.VIAF,
+		ISNI:        author.ISNI,
+		WikidataID:  author.WikidataID,
+		Aliases:     []dto.AuthorAliasRequest{},
+
+		Translations: authorTranslationRequests(author.Translations),
+	}
+
+	if author.DeathDate != nil {
+		body.DeathDate = author.DeathDate.Format(time.DateOnly)
+	}
+
+	for _, alias := range author.Aliases {
+		body.Aliases = append(body.Aliases, dto.AuthorAliasRequest{
+			Name: alias.Name,
+			Kind: alias.Kind,
+		})
+	}
+
+	return body
+}
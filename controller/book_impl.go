@@ -3,11 +3,16 @@ package controller
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/dublincore"
+	"github.com/rhtyx/bayarind-service.git/export"
 	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/patch"
+	"github.com/rhtyx/bayarind-service.git/schemaorg"
 	"github.com/rhtyx/bayarind-service.git/utils"
 
 	"github.com/go-playground/validator/v10"
@@ -34,9 +39,11 @@ func (c Controller) CreateBook(e echo.Context) error {
 	}
 
 	book := &model.Book{
-		ISBN:     body.ISBN,
-		Title:    body.Title,
-		AuthorID: body.AuthorID,
+		ISBN:         body.ISBN,
+		Title:        body.Title,
+		Subtitle:     body.Subtitle,
+		AuthorID:     body.AuthorID,
+		Translations: bookTranslations(body.Translations),
 	}
 	book, err = c.bookService.Create(ctx, book)
 	if err != nil {
@@ -44,9 +51,37 @@ func (c Controller) CreateBook(e echo.Context) error {
 		return parseError(e, err)
 	}
 
+	setETag(e, book.Version, bookRating(book))
 	return e.JSON(http.StatusCreated, book)
 }
 
+func (c Controller) LookupBook(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	body := &dto.BookLookupRequest{}
+	err := json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	metadata, err := c.metadataService.Lookup(ctx, body.ISBN)
+	if err != nil {
+		logger.WithField("isbn", body.ISBN).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, metadata)
+}
+
 func (c Controller) FindBookByID(e echo.Context) error {
 	ctx := e.Request().Context()
 	logger := logrus.WithContext(ctx)
@@ -57,25 +92,146 @@ func (c Controller) FindBookByID(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
 	}
 
+	chosen, err := negotiateRepresentation(e, representationJSON, representationJSONLD, representationOAIDC, representationMARC, representationMARCXML)
+	if err != nil {
+		logger.WithField("format", e.QueryParam("format")).Error(err)
+		return parseError(e, err)
+	}
+
 	book, err := c.bookService.FindByID(ctx, bookID)
 	if err != nil {
 		logger.WithField("bookID", bookID).Error(err)
 		return parseError(e, err)
 	}
 
-	return e.JSON(http.StatusOK, book)
+	// A failed view only weakens recommendations, so the book is served
+	// anyway.
+	if userID, ok := e.Get("userID").(int64); ok {
+		err = c.recommendationService.RecordView(ctx, userID, bookID)
+		if err != nil {
+			logger.WithField("bookID", bookID).Error(err)
+		}
+	}
+
+	// MARC records are not translated, so they do not vary by locale.
+	var chain []string
+	variant := []string{bookRating(book), chosen.format}
+	if !export.IsMARC(chosen.format) {
+		chain = locales(e)
+		addContentLanguage(e, book.Localize(chain)...)
+		variant = append(variant, localeVariant(chain))
+	}
+
+	setETag(e, book.Version, variant...)
+	if notModified(e, book.Version, variant...) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	switch chosen.format {
+	case export.FormatMARC, export.FormatMARCXML:
+		return c.writeBookMARC(e, book, chosen.format)
+	case formatJSONLD, formatOAIDC:
+		return c.writeBookLinkedData(e, book, chosen.format, chain)
+	default:
+		return e.JSON(http.StatusOK, book)
+	}
+}
+
+// writeBookMARC answers with the MARC record of book, catalogued in the
+// default locale rather than translated, with its edition and publisher.
+func (c Controller) writeBookMARC(e echo.Context, book *model.Book, format string) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	citations, err := c.citationService.FindByBookIDs(ctx, []int64{book.ID})
+	if err != nil {
+		logger.WithField("bookID", book.ID).Error(err)
+		return parseError(e, err)
+	}
+
+	citation := citations[0]
+	citation.Book = book
+
+	return writeBlob(e, http.StatusOK, export.ContentType(format), func(w io.Writer) error {
+		writer, err := export.NewWriter(w, format, nil)
+		if err != nil {
+			return err
+		}
+
+		err = writer.Write(export.BookRecord(citation), nil)
+		if err != nil {
+			return err
+		}
+
+		return writer.Close()
+	})
+}
+
+// writeBookLinkedData answers with book as a schema.org JSON-LD node or an
+// OAI-DC record, filled in from its author, edition and publisher.
+func (c Controller) writeBookLinkedData(e echo.Context, book *model.Book, format string, chain []string) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	citations, err := c.citationService.FindByBookIDs(ctx, []int64{book.ID})
+	if err != nil {
+		logger.WithField("bookID", book.ID).Error(err)
+		return parseError(e, err)
+	}
+
+	citation := citations[0]
+	citation.Book = book
+	if citation.Author != nil {
+		addContentLanguage(e, citation.Author.Localize(chain)...)
+	}
+
+	if format == formatOAIDC {
+		return writeBlob(e, http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, func(w io.Writer) error {
+			return dublincore.Write(w, dublincore.FromCitation(bookURI(e, book.ID), citation))
+		})
+	}
+
+	return writeBlob(e, http.StatusOK, schemaorg.MIMEJSONLD, func(w io.Writer) error {
+		return schemaorg.Write(w, schemaorg.NewBook(bookURI(e, book.ID), authorURI(e, book.AuthorID), citation))
+	})
 }
 
 func (c Controller) FindAllBooks(e echo.Context) error {
 	ctx := e.Request().Context()
 	logger := logrus.WithContext(ctx)
 
-	books, err := c.bookService.FindAll(ctx)
+	var books []*model.Book
+	var err error
+	switch {
+	case e.QueryParam("subject") != "" && e.QueryParam("tag") != "":
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: subject and tag are exclusive", ErrBadRequest.Error()))
+	case e.QueryParam("subject") != "":
+		subjectID, err := strconv.ParseInt(e.QueryParam("subject"), 10, 64)
+		if err != nil {
+			logger.WithField("subject", e.QueryParam("subject")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query subject", ErrBadRequest.Error()))
+		}
+
+		books, err = c.subjectService.FindBooks(ctx, subjectID)
+		if err != nil {
+			logger.WithField("subjectID", subjectID).Error(err)
+			return parseError(e, err)
+		}
+	case e.QueryParam("tag") != "":
+		books, err = c.tagService.FindBooks(ctx, e.QueryParam("tag"))
+	default:
+		books, err = c.bookService.FindAll(ctx)
+	}
 	if err != nil {
 		logger.Error(err)
 		return parseError(e, err)
 	}
 
+	chain := locales(e)
+	for _, book := range books {
+		addContentLanguage(e, book.Localize(chain)...)
+	}
+
 	return e.JSON(http.StatusOK, books)
 }
 
@@ -89,6 +245,22 @@ func (c Controller) UpdateBook(e echo.Context) error {
 		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
 	}
 
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		currBook, err := c.bookService.FindByID(ctx, bookID)
+		if err != nil {
+			logger.WithField("bookID", bookID).Error(err)
+			return parseError(e, err)
+		}
+
+		version = currBook.Version
+	}
+
 	body := &dto.BookRequest{}
 	err = json.NewDecoder(e.Request().Body).Decode(body)
 	if err != nil {
@@ -104,10 +276,13 @@ func (c Controller) UpdateBook(e echo.Context) error {
 	}
 
 	book := &model.Book{
-		ID:       bookID,
-		ISBN:     body.ISBN,
-		Title:    body.Title,
-		AuthorID: body.AuthorID,
+		ID:           bookID,
+		ISBN:         body.ISBN,
+		Title:        body.Title,
+		Subtitle:     body.Subtitle,
+		AuthorID:     body.AuthorID,
+		Translations: bookTranslations(body.Translations),
+		Version:      version,
 	}
 	book, err = c.bookService.Update(ctx, book)
 	if err != nil {
@@ -115,6 +290,83 @@ func (c Controller) UpdateBook(e echo.Context) error {
 		return parseError(e, err)
 	}
 
+	setETag(e, book.Version, bookRating(book))
+	return e.JSON(http.StatusOK, book)
+}
+
+func (c Controller) PatchBook(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	currBook, err := c.bookService.FindByID(ctx, bookID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		version = currBook.Version
+	}
+
+	if version != currBook.Version {
+		return e.JSON(http.StatusPreconditionFailed, ErrPreconditionFailed.Error())
+	}
+
+	current := &dto.BookRequest{
+		ISBN:         currBook.ISBN,
+		Title:        currBook.Title,
+		Subtitle:     currBook.Subtitle,
+		AuthorID:     currBook.AuthorID,
+		Translations: bookTranslationRequests(currBook.Translations),
+	}
+	body := &dto.BookRequest{}
+	err = decodePatch(e, current, body)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	fields, columns := patch.Changed(current, body)
+	if len(fields) == 0 {
+		setETag(e, currBook.Version, bookRating(currBook))
+		return e.JSON(http.StatusOK, currBook)
+	}
+
+	validate := validator.New()
+	err = validate.StructPartial(body, fields...)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	book := &model.Book{
+		ID:           bookID,
+		ISBN:         body.ISBN,
+		Title:        body.Title,
+		Subtitle:     body.Subtitle,
+		AuthorID:     body.AuthorID,
+		Translations: bookTranslations(body.Translations),
+		Version:      version,
+	}
+	book, err = c.bookService.Patch(ctx, book, columns)
+	if err != nil {
+		logger.WithField("columns", columns).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, book.Version, bookRating(book))
 	return e.JSON(http.StatusOK, book)
 }
 
@@ -136,3 +388,13 @@ func (c Controller) DeleteBook(e echo.Context) error {
 
 	return e.JSON(http.StatusOK, "Book deleted")
 }
+
+// bookRating tags the ETag of a book with its rating, which reviews change
+// without bumping the version.
+func bookRating(book *model.Book) string {
+	if book.RatingAverage == nil {
+		return fmt.Sprintf("r%d", book.RatingCount)
+	}
+
+	return fmt.Sprintf("r%d_%s", book.RatingCount, strconv.FormatFloat(*book.RatingAverage, 'f', -1, 64))
+}
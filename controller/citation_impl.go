@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/citation"
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/names"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) FindBookCitation(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	chosen, err := negotiateRepresentation(e, citationRepresentations...)
+	if err != nil {
+		logger.WithField("format", e.QueryParam("format")).Error(err)
+		return parseError(e, err)
+	}
+
+	citations, err := c.citationService.FindByBookIDs(ctx, []int64{bookID})
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return writeCitations(e, chosen.format, citations)
+}
+
+// CiteBooks renders the references of several books at once, in the order
+// their ids are given.
+func (c Controller) CiteBooks(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	chosen, err := negotiateRepresentation(e, citationRepresentations...)
+	if err != nil {
+		logger.WithField("format", e.QueryParam("format")).Error(err)
+		return parseError(e, err)
+	}
+
+	body := &dto.CitationRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	citations, err := c.citationService.FindByBookIDs(ctx, body.BookIDs)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return parseError(e, err)
+	}
+
+	return writeCitations(e, chosen.format, citations)
+}
+
+// citationRepresentations are offered in the order of citation.Formats,
+// CSL-JSON being the default.
+var citationRepresentations = []representation{representationCSLJSON, representationBibTeX, representationRIS}
+
+func writeCitations(e echo.Context, format string, citations []*model.Citation) error {
+	items := []citation.Item{}
+	for _, c := range citations {
+		items = append(items, citationItem(c))
+	}
+
+	return writeBlob(e, http.StatusOK, citation.ContentType(format), func(w io.Writer) error {
+		return citation.Write(w, format, items)
+	})
+}
+
+func citationItem(c *model.Citation) citation.Item {
+	item := citation.Item{
+		Title:    c.Book.Title,
+		Subtitle: c.Book.Subtitle,
+		ISBN:     c.Book.ISBN,
+	}
+
+	if c.Author != nil {
+		item.Authors = []names.Name{names.Parse(c.Author.Name)}
+	}
+
+	if c.Edition != nil {
+		item.Language = c.Edition.Language
+		if c.Edition.PublicationDate != nil {
+			item.Year = c.Edition.PublicationDate.Year()
+		}
+	}
+
+	if c.Publisher != nil {
+		item.Publisher = c.Publisher.Name
+	}
+
+	return item
+}
@@ -1,20 +1,45 @@
 package controller
 
 import (
+	"net/http"
+
+	"github.com/rhtyx/bayarind-service.git/config"
 	"github.com/rhtyx/bayarind-service.git/model"
 
 	"github.com/labstack/echo/v4"
 )
 
 type Controller struct {
-	authorService  model.AuthorService
-	bookService    model.BookService
-	userService    model.UserService
-	sessionService model.SessionService
+	authorService         model.AuthorService
+	bookService           model.BookService
+	userService           model.UserService
+	sessionService        model.SessionService
+	importService         model.ImportService
+	metadataService       model.MetadataService
+	coverService          model.CoverService
+	publisherService      model.PublisherService
+	workService           model.WorkService
+	editionService        model.EditionService
+	subjectService        model.SubjectService
+	tagService            model.TagService
+	copyService           model.CopyService
+	loanService           model.LoanService
+	holdService           model.HoldService
+	accountService        model.AccountService
+	reviewService         model.ReviewService
+	shelfService          model.ShelfService
+	recommendationService model.RecommendationService
+	catalogService        model.CatalogService
+	citationService       model.CitationService
+
+	blobHandler http.Handler
+	opdsLogins  *loginLimiter
 }
 
 func NewController() *Controller {
-	return new(Controller)
+	return &Controller{
+		opdsLogins: newLoginLimiter(config.OPDSMaxFailedLogins(), config.OPDSLockout()),
+	}
 }
 
 func (c *Controller) RegisterAuthorService(authorService model.AuthorService) {
@@ -33,28 +58,249 @@ func (c *Controller) RegisterSessionService(sessionService model.SessionService)
 	c.sessionService = sessionService
 }
 
+func (c *Controller) RegisterImportService(importService model.ImportService) {
+	c.importService = importService
+}
+
+func (c *Controller) RegisterMetadataService(metadataService model.MetadataService) {
+	c.metadataService = metadataService
+}
+
+func (c *Controller) RegisterCoverService(coverService model.CoverService) {
+	c.coverService = coverService
+}
+
+func (c *Controller) RegisterPublisherService(publisherService model.PublisherService) {
+	c.publisherService = publisherService
+}
+
+func (c *Controller) RegisterWorkService(workService model.WorkService) {
+	c.workService = workService
+}
+
+func (c *Controller) RegisterEditionService(editionService model.EditionService) {
+	c.editionService = editionService
+}
+
+func (c *Controller) RegisterSubjectService(subjectService model.SubjectService) {
+	c.subjectService = subjectService
+}
+
+func (c *Controller) RegisterTagService(tagService model.TagService) {
+	c.tagService = tagService
+}
+
+func (c *Controller) RegisterCopyService(copyService model.CopyService) {
+	c.copyService = copyService
+}
+
+func (c *Controller) RegisterLoanService(loanService model.LoanService) {
+	c.loanService = loanService
+}
+
+func (c *Controller) RegisterHoldService(holdService model.HoldService) {
+	c.holdService = holdService
+}
+
+func (c *Controller) RegisterAccountService(accountService model.AccountService) {
+	c.accountService = accountService
+}
+
+func (c *Controller) RegisterReviewService(reviewService model.ReviewService) {
+	c.reviewService = reviewService
+}
+
+func (c *Controller) RegisterShelfService(shelfService model.ShelfService) {
+	c.shelfService = shelfService
+}
+
+func (c *Controller) RegisterRecommendationService(recommendationService model.RecommendationService) {
+	c.recommendationService = recommendationService
+}
+
+func (c *Controller) RegisterCatalogService(catalogService model.CatalogService) {
+	c.catalogService = catalogService
+}
+
+func (c *Controller) RegisterCitationService(citationService model.CitationService) {
+	c.citationService = citationService
+}
+
+// RegisterBlobHandler mounts a handler for signed blob URLs under /blobs.
+// Only blob stores that do not serve their own URLs need one.
+func (c *Controller) RegisterBlobHandler(blobHandler http.Handler) {
+	c.blobHandler = blobHandler
+}
+
 func (c Controller) InitRoutes(route *echo.Echo) {
+	if c.blobHandler != nil {
+		route.GET("/blobs/*", echo.WrapHandler(http.StripPrefix("/blobs", c.blobHandler)))
+	}
+
+	route.GET("/shared/shelves/:token/", c.FindSharedShelf)
+
+	opds := route.Group("/opds", c.OPDSAuthMiddleware)
+	opds.GET("/", c.OPDSRoot)
+	opds.GET("/opensearch/", c.OPDSOpenSearch)
+	opds.GET("/search/", c.OPDSSearch)
+	opds.GET("/books/", c.OPDSBooks)
+	opds.GET("/books/:id/", c.OPDSBook)
+	opds.GET("/books/:id/cover/:size/", c.RedirectCover)
+	opds.POST("/books/:id/borrow/", c.OPDSBorrowBook)
+	opds.GET("/authors/", c.OPDSAuthors)
+	opds.GET("/authors/:id/", c.OPDSAuthorBooks)
+	opds.GET("/subjects/", c.OPDSSubjects)
+	opds.GET("/subjects/:id/", c.OPDSSubjectBooks)
+
 	r := route.Group("/api/v1")
 	r.Use(HmacMiddleware)
 
 	user := r.Group("/users", JwtMiddleware)
 	user.GET("/", c.FindUserByID)
 	user.PUT("/", c.UpdateUser)
+	user.PATCH("/", c.PatchUser)
 	user.DELETE("/", c.DeleteUser)
+	user.GET("/recommendations/", c.FindRecommendations)
 
 	book := r.Group("/books", JwtMiddleware)
 	book.POST("/", c.CreateBook)
+	book.POST("/lookup/", c.LookupBook)
+	book.POST("/citations/", c.CiteBooks)
 	book.GET("/:id/", c.FindBookByID)
 	book.GET("/", c.FindAllBooks)
 	book.PUT("/:id/", c.UpdateBook)
+	book.PATCH("/:id/", c.PatchBook)
 	book.DELETE("/:id/", c.DeleteBook)
+	book.PUT("/:id/cover/", c.UploadCover)
+	book.GET("/:id/cover/", c.FindCover)
+	book.GET("/:id/cover/:size/", c.RedirectCover)
+	book.GET("/:id/subjects/", c.FindBookSubjects)
+	book.PUT("/:id/subjects/", c.SetBookSubjects, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	book.GET("/:id/tags/", c.FindBookTags)
+	book.PUT("/:id/tags/", c.SetBookTags, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	book.GET("/:id/copies/", c.FindBookCopies)
+	book.POST("/:id/copies/", c.CreateCopy, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	book.GET("/:id/availability/", c.FindBookAvailability)
+	book.POST("/:id/holds/", c.PlaceHold)
+	book.GET("/:id/holds/", c.FindBookHolds, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	book.GET("/:id/reviews/", c.FindBookReviews)
+	book.POST("/:id/reviews/", c.CreateReview)
+	book.GET("/:id/similar/", c.FindSimilarBooks)
+	book.GET("/:id/citation/", c.FindBookCitation)
+	book.GET("/:id/versions/", c.FindBookVersions)
+	book.GET("/:id/versions/diff/", c.DiffBookVersions)
+	book.GET("/:id/versions/:version/", c.FindBookVersion)
+	book.POST("/:id/versions/:version/restore/", c.RestoreBookVersion, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
 
 	author := r.Group("/authors", JwtMiddleware)
 	author.POST("/", c.CreateAuthor)
+	author.GET("/duplicates/", c.FindDuplicateAuthors, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
 	author.GET("/:id/", c.FindAuthorByID)
 	author.GET("/", c.FindAllAuthors)
 	author.PUT("/:id/", c.UpdateAuthor)
+	author.PATCH("/:id/", c.PatchAuthor)
 	author.DELETE("/:id/", c.DeleteAuthor)
+	author.POST("/:id/merge/", c.MergeAuthors, c.RoleMiddleware(model.RoleAdmin))
+	author.GET("/:id/versions/", c.FindAuthorVersions)
+	author.GET("/:id/versions/diff/", c.DiffAuthorVersions)
+	author.GET("/:id/versions/:version/", c.FindAuthorVersion)
+	author.POST("/:id/versions/:version/restore/", c.RestoreAuthorVersion, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+
+	publisher := r.Group("/publishers", JwtMiddleware)
+	publisher.POST("/", c.CreatePublisher, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	publisher.GET("/:id/", c.FindPublisherByID)
+	publisher.GET("/", c.FindAllPublishers)
+	publisher.PUT("/:id/", c.UpdatePublisher, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	publisher.DELETE("/:id/", c.DeletePublisher, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+
+	work := r.Group("/works", JwtMiddleware)
+	work.POST("/", c.CreateWork, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	work.GET("/:id/", c.FindWorkByID)
+	work.GET("/:id/editions/", c.FindWorkEditions)
+	work.GET("/", c.FindAllWorks)
+	work.PUT("/:id/", c.UpdateWork, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	work.DELETE("/:id/", c.DeleteWork, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+
+	edition := r.Group("/editions", JwtMiddleware)
+	edition.POST("/", c.CreateEdition, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	edition.GET("/:id/", c.FindEditionByID)
+	edition.GET("/", c.FindAllEditions)
+	edition.PUT("/:id/", c.UpdateEdition, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	edition.DELETE("/:id/", c.DeleteEdition, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+
+	subject := r.Group("/subjects", JwtMiddleware)
+	subject.POST("/", c.CreateSubject, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	subject.GET("/", c.FindSubjectTree)
+	subject.GET("/:id/", c.FindSubjectByID)
+	subject.GET("/:id/tree/", c.FindSubjectSubtree)
+	subject.PUT("/:id/", c.UpdateSubject, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	subject.DELETE("/:id/", c.DeleteSubject, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+
+	tag := r.Group("/tags", JwtMiddleware)
+	tag.GET("/", c.FindAllTags)
+	tag.DELETE("/:id/", c.DeleteTag, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+
+	copies := r.Group("/copies", JwtMiddleware, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	copies.GET("/:id/", c.FindCopyByID)
+	copies.PUT("/:id/", c.UpdateCopy)
+	copies.DELETE("/:id/", c.DeleteCopy)
+
+	circulation := r.Group("/circulation", JwtMiddleware, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	circulation.POST("/checkout/", c.Checkout)
+	circulation.POST("/checkin/", c.Checkin)
+
+	loan := r.Group("/loans", JwtMiddleware)
+	loan.GET("/", c.FindAllLoans)
+	loan.GET("/:id/", c.FindLoanByID)
+	loan.POST("/:id/renew/", c.RenewLoan)
+
+	hold := r.Group("/holds", JwtMiddleware)
+	hold.GET("/", c.FindAllHolds)
+	hold.GET("/:id/", c.FindHoldByID)
+	hold.DELETE("/:id/", c.CancelHold)
+
+	review := r.Group("/reviews", JwtMiddleware)
+	review.GET("/:id/", c.FindReviewByID)
+	review.PUT("/:id/", c.UpdateReview)
+	review.DELETE("/:id/", c.DeleteReview)
+	review.POST("/:id/votes/", c.VoteReview)
+	review.DELETE("/:id/votes/", c.UnvoteReview)
+	review.POST("/:id/hide/", c.HideReview, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	review.POST("/:id/unhide/", c.UnhideReview, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+
+	shelf := r.Group("/shelves", JwtMiddleware)
+	shelf.GET("/", c.FindAllShelves)
+	shelf.POST("/", c.CreateShelf)
+	shelf.GET("/:id/", c.FindShelfByID)
+	shelf.PUT("/:id/", c.UpdateShelf)
+	shelf.DELETE("/:id/", c.DeleteShelf)
+	shelf.GET("/:id/books/", c.FindShelfBooks)
+	shelf.POST("/:id/books/", c.AddShelfBook)
+	shelf.PUT("/:id/books/:book_id/", c.MoveShelfBook)
+	shelf.DELETE("/:id/books/:book_id/", c.RemoveShelfBook)
+
+	account := r.Group("/account", JwtMiddleware)
+	account.GET("/", c.FindAccount)
+	account.POST("/charges/", c.CreateCharge, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	account.POST("/payments/", c.CreatePayment, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	account.POST("/waivers/", c.CreateWaiver, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+
+	imports := r.Group("/imports", JwtMiddleware, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	imports.POST("/", c.CreateImport)
+	imports.GET("/", c.FindAllImports)
+	imports.GET("/:id/", c.FindImportByID)
+
+	exports := r.Group("/exports", JwtMiddleware, c.RoleMiddleware(model.RoleLibrarian, model.RoleAdmin))
+	exports.GET("/books/", c.ExportBooks)
+	exports.GET("/authors/", c.ExportAuthors)
+
+	trash := r.Group("/trash", JwtMiddleware, c.RoleMiddleware(model.RoleAdmin))
+	trash.GET("/books/", c.FindAllDeletedBooks)
+	trash.POST("/books/:id/restore/", c.RestoreBook)
+	trash.GET("/authors/", c.FindAllDeletedAuthors)
+	trash.POST("/authors/:id/restore/", c.RestoreAuthor)
+	trash.GET("/users/", c.FindAllDeletedUsers)
+	trash.POST("/users/:id/restore/", c.RestoreUser)
 
 	auth := r.Group("/auth")
 	auth.POST("/login/", c.Login)
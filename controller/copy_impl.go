@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) CreateCopy(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	body := &dto.CopyRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	bookCopy := &model.Copy{
+		BookID:    bookID,
+		Barcode:   body.Barcode,
+		Branch:    body.Branch,
+		Condition: body.Condition,
+	}
+	bookCopy, err = c.copyService.Create(ctx, bookCopy)
+	if err != nil {
+		logger.WithField("copy", utils.Dump(bookCopy)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, bookCopy.Version)
+	return e.JSON(http.StatusCreated, bookCopy)
+}
+
+func (c Controller) FindCopyByID(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	copyID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("copyID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	bookCopy, err := c.copyService.FindByID(ctx, copyID)
+	if err != nil {
+		logger.WithField("copyID", copyID).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, bookCopy.Version)
+	if notModified(e, bookCopy.Version) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.JSON(http.StatusOK, bookCopy)
+}
+
+func (c Controller) FindBookCopies(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	copies, err := c.copyService.FindAllByBookID(ctx, bookID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, copies)
+}
+
+func (c Controller) UpdateCopy(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	copyID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("copyID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("copyID", copyID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		currCopy, err := c.copyService.FindByID(ctx, copyID)
+		if err != nil {
+			logger.WithField("copyID", copyID).Error(err)
+			return parseError(e, err)
+		}
+
+		version = currCopy.Version
+	}
+
+	body := &dto.CopyRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	bookCopy := &model.Copy{
+		ID:        copyID,
+		Barcode:   body.Barcode,
+		Branch:    body.Branch,
+		Condition: body.Condition,
+		Version:   version,
+	}
+	bookCopy, err = c.copyService.Update(ctx, bookCopy)
+	if err != nil {
+		logger.WithField("copy", utils.Dump(bookCopy)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, bookCopy.Version)
+	return e.JSON(http.StatusOK, bookCopy)
+}
+
+func (c Controller) DeleteCopy(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	copyID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("copyID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	err = c.copyService.Delete(ctx, copyID)
+	if err != nil {
+		logger.WithField("copyID", copyID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, "Copy deleted")
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+var coverMediaTypes = []string{"image/jpeg", "image/png"}
+
+func (c Controller) UploadCover(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	data, err := readCoverUpload(e)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	cover, err := c.coverService.Upload(ctx, bookID, data)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, cover.Version)
+	return e.JSON(http.StatusOK, cover)
+}
+
+func (c Controller) FindCover(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	cover, err := c.coverService.FindByBookID(ctx, bookID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	e.Response().Header().Set(echo.HeaderCacheControl, coverCacheControl(cover.ExpiresAt))
+	return e.JSON(http.StatusOK, cover)
+}
+
+// RedirectCover sends the client to a signed URL of one cover size, so
+// that it can be used directly as an image source.
+func (c Controller) RedirectCover(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	url, expiresAt, err := c.coverService.URL(ctx, bookID, e.Param("size"))
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	e.Response().Header().Set(echo.HeaderCacheControl, coverCacheControl(expiresAt))
+	return e.Redirect(http.StatusFound, url)
+}
+
+// coverCacheControl lets clients reuse signed URLs for half of their
+// remaining lifetime, so a cached URL is never used after it expires.
+func coverCacheControl(expiresAt time.Time) string {
+	return fmt.Sprintf("private, max-age=%d", int(time.Until(expiresAt).Seconds()/2))
+}
+
+// readCoverUpload accepts either a multipart form with a "file" field or a
+// raw image body. The declared type must match the sniffed content.
+func readCoverUpload(e echo.Context) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(e.Request().Header.Get(echo.HeaderContentType))
+
+	var reader io.Reader = e.Request().Body
+	if mediaType == echo.MIMEMultipartForm {
+		header, err := e.FormFile("file")
+		if err != nil {
+			return nil, errors.Join(ErrBadRequest, errors.New(": missing file"))
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			return nil, errors.Join(ErrBadRequest, err)
+		}
+		defer file.Close()
+
+		reader = file
+		mediaType, _, _ = mime.ParseMediaType(header.Header.Get(echo.HeaderContentType))
+	}
+
+	if !slices.Contains(coverMediaTypes, mediaType) {
+		return nil, errors.Join(ErrMediaType, errors.New(": cover must be image/jpeg or image/png"))
+	}
+
+	maxBytes := config.CoverMaxBytes()
+	data, err := io.ReadAll(io.LimitReader(reader, maxBytes+1))
+	if err != nil {
+		return nil, errors.Join(ErrBadRequest, err)
+	}
+
+	if int64(len(data)) > maxBytes {
+		return nil, errors.Join(ErrTooLarge, fmt.Errorf(": limit is %d bytes", maxBytes))
+	}
+
+	if http.DetectContentType(data) != mediaType {
+		return nil, errors.Join(ErrMediaType, fmt.Errorf(": content is not %s", mediaType))
+	}
+
+	return data, nil
+}
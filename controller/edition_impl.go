@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) CreateEdition(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	body := &dto.EditionRequest{}
+	err := json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	edition, err := newEdition(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid publication_date", ErrBadRequest.Error()))
+	}
+
+	edition, err = c.editionService.Create(ctx, edition)
+	if err != nil {
+		logger.WithField("edition", utils.Dump(edition)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, edition.Version)
+	return e.JSON(http.StatusCreated, edition)
+}
+
+func (c Controller) FindEditionByID(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	editionID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("editionID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	edition, err := c.editionService.FindByID(ctx, editionID)
+	if err != nil {
+		logger.WithField("editionID", editionID).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, edition.Version)
+	if notModified(e, edition.Version) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.JSON(http.StatusOK, edition)
+}
+
+func (c Controller) FindAllEditions(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	editions, err := c.editionService.FindAll(ctx)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, editions)
+}
+
+func (c Controller) UpdateEdition(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	editionID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("editionID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("editionID", editionID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		currEdition, err := c.editionService.FindByID(ctx, editionID)
+		if err != nil {
+			logger.WithField("editionID", editionID).Error(err)
+			return parseError(e, err)
+		}
+
+		version = currEdition.Version
+	}
+
+	body := &dto.EditionRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	edition, err := newEdition(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid publication_date", ErrBadRequest.Error()))
+	}
+
+	edition.ID = editionID
+	edition.Version = version
+	edition, err = c.editionService.Update(ctx, edition)
+	if err != nil {
+		logger.WithField("edition", utils.Dump(edition)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, edition.Version)
+	return e.JSON(http.StatusOK, edition)
+}
+
+func (c Controller) DeleteEdition(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	editionID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("editionID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	err = c.editionService.Delete(ctx, editionID)
+	if err != nil {
+		logger.WithField("editionID", editionID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, "Edition deleted")
+}
+
+func newEdition(body *dto.EditionRequest) (*model.Edition, error) {
+	edition := &model.Edition{
+		WorkID:      body.WorkID,
+		PublisherID: body.PublisherID,
+		ISBN:        body.ISBN,
+		Format:      body.Format,
+		Language:    body.Language,
+		PageCount:   body.PageCount,
+	}
+
+	if body.PublicationDate != "" {
+		publicationDate, err := utils.ParseDate(body.PublicationDate)
+		if err != nil {
+			return nil, err
+		}
+
+		edition.PublicationDate = publicationDate
+	}
+
+	return edition, nil
+}
@@ -14,6 +14,17 @@ var (
 	ErrDuplicate      = errors.New("duplicate entry")
 	ErrUnauthorized   = errors.New("unauthorized")
 	ErrCredentials    = errors.New("wrong username or password")
+	ErrForbidden      = errors.New("forbidden")
+	ErrDependency     = errors.New("dependent entries exist")
+	ErrConflict       = errors.New("conflict")
+	ErrMediaType      = errors.New("unsupported media type")
+	ErrNotAcceptable  = errors.New("not acceptable")
+	ErrTooLarge       = errors.New("payload too large")
+	ErrBadGateway     = errors.New("upstream service failed")
+	ErrTooManyLogins  = errors.New("too many failed logins")
+
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrPreconditionRequired = errors.New("precondition required")
 )
 
 func parseError(e echo.Context, err error) error {
@@ -28,6 +39,26 @@ func parseError(e echo.Context, err error) error {
 		return e.JSON(http.StatusConflict, err.Error())
 	case errors.Is(err, ErrCredentials):
 		return e.JSON(http.StatusUnauthorized, err.Error())
+	case errors.Is(err, ErrForbidden):
+		return e.JSON(http.StatusForbidden, err.Error())
+	case errors.Is(err, ErrDependency):
+		return e.JSON(http.StatusConflict, err.Error())
+	case errors.Is(err, ErrConflict):
+		return e.JSON(http.StatusConflict, err.Error())
+	case errors.Is(err, ErrMediaType):
+		return e.JSON(http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, ErrNotAcceptable):
+		return e.JSON(http.StatusNotAcceptable, err.Error())
+	case errors.Is(err, ErrTooLarge):
+		return e.JSON(http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, ErrBadGateway):
+		return e.JSON(http.StatusBadGateway, err.Error())
+	case errors.Is(err, ErrTooManyLogins):
+		return e.JSON(http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, ErrPreconditionFailed):
+		return e.JSON(http.StatusPreconditionFailed, err.Error())
+	case errors.Is(err, ErrPreconditionRequired):
+		return e.JSON(http.StatusPreconditionRequired, err.Error())
 	default:
 		return e.JSON(http.StatusInternalServerError, ErrInternalServer)
 	}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// versionETag leads with the version, which is all If-Match compares.
+// The variant tells apart responses of the same version that differ, such
+// as derived fields that change without an edit.
+func versionETag(version int64, variant ...string) string {
+	return fmt.Sprintf(`"%s"`, strings.Join(append([]string{strconv.FormatInt(version, 10)}, variant...), "-"))
+}
+
+// localeVariant names the locale chain a response was localized for, so
+// that each language a resource is served in has its own ETag.
+func localeVariant(chain []string) string {
+	return strings.Join(chain, "+")
+}
+
+func setETag(e echo.Context, version int64, variant ...string) {
+	e.Response().Header().Set(HeaderETag, versionETag(version, variant...))
+}
+
+// notModified reports whether the If-None-Match header matches the
+// current version and variant, in which case the handler should answer
+// 304.
+func notModified(e echo.Context, version int64, variant ...string) bool {
+	header := e.Request().Header.Get(HeaderIfNoneMatch)
+	if header == "" {
+		return false
+	}
+
+	etag := versionETag(version, variant...)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ifMatchVersion returns the version carried by the If-Match header. A
+// wildcard yields matchAny so the caller can use the stored version.
+func ifMatchVersion(e echo.Context) (version int64, matchAny bool, err error) {
+	header := strings.TrimSpace(e.Request().Header.Get(HeaderIfMatch))
+	if header == "" {
+		return 0, false, errors.Join(ErrPreconditionRequired, errors.New(": If-Match header is required"))
+	}
+
+	if header == "*" {
+		return 0, true, nil
+	}
+
+	etag := strings.TrimSpace(strings.Split(header, ",")[0])
+	if strings.HasPrefix(etag, "W/") {
+		return 0, false, errors.Join(ErrPreconditionFailed, errors.New(": weak etag"))
+	}
+
+	version, err = strconv.ParseInt(strings.SplitN(strings.Trim(etag, `"`), "-", 2)[0], 10, 64)
+	if err != nil {
+		return 0, false, errors.Join(ErrPreconditionFailed, errors.New(": malformed etag"))
+	}
+
+	return version, false, nil
+}
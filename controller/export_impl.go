@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/export"
+	"github.com/rhtyx/bayarind-service.git/isbn"
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// exportFlushEvery bounds how many rows are buffered before they are
+// pushed to the client.
+const exportFlushEvery = 500
+
+func (c Controller) ExportBooks(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	filter := model.BookFilter{
+		Title: e.QueryParam("title"),
+	}
+
+	if e.QueryParam("isbn") != "" {
+		canonical, err := isbn.Normalize(e.QueryParam("isbn"))
+		if err != nil {
+			logger.WithField("isbn", e.QueryParam("isbn")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query isbn", ErrBadRequest.Error()))
+		}
+
+		filter.ISBN = canonical
+	}
+
+	if e.QueryParam("author_id") != "" {
+		authorID, err := strconv.ParseInt(e.QueryParam("author_id"), 10, 64)
+		if err != nil {
+			logger.WithField("authorID", e.QueryParam("author_id")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query author_id", ErrBadRequest.Error()))
+		}
+
+		filter.AuthorID = authorID
+	}
+
+	updatedSince, err := parseUpdatedSince(e)
+	if err != nil {
+		logger.WithField("updatedSince", e.QueryParam("updated_since")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query updated_since", ErrBadRequest.Error()))
+	}
+	filter.UpdatedSince = updatedSince
+
+	format := exportFormat(e)
+	writer, err := startExport(e, format, "books", export.BookHeader)
+	if err != nil {
+		logger.WithField("format", format).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query format", ErrBadRequest.Error()))
+	}
+
+	rows := 0
+	if export.IsMARC(format) {
+		err = c.bookService.StreamCitations(ctx, filter, func(citation *model.Citation) error {
+			rows++
+			return writeExportRow(e, writer, rows, export.BookRecord(citation), nil)
+		})
+	} else {
+		err = c.bookService.Stream(ctx, filter, func(book *model.Book) error {
+			rows++
+			return writeExportRow(e, writer, rows, book, export.BookRow(book))
+		})
+	}
+	if err != nil {
+		// The status line has already been sent, so the client can only
+		// notice the failure through the truncated body.
+		logger.WithField("rows", rows).Error(err)
+		return nil
+	}
+
+	return finishExport(e, writer)
+}
+
+func (c Controller) ExportAuthors(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	filter := model.AuthorFilter{
+		Name: e.QueryParam("name"),
+	}
+
+	updatedSince, err := parseUpdatedSince(e)
+	if err != nil {
+		logger.WithField("updatedSince", e.QueryParam("updated_since")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query updated_since", ErrBadRequest.Error()))
+	}
+	filter.UpdatedSince = updatedSince
+
+	// MARC records describe books; authors have no bibliographic record.
+	format := exportFormat(e)
+	if export.IsMARC(format) {
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query format", ErrBadRequest.Error()))
+	}
+
+	writer, err := startExport(e, format, "authors", export.AuthorHeader)
+	if err != nil {
+		logger.WithField("format", format).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query format", ErrBadRequest.Error()))
+	}
+
+	rows := 0
+	err = c.authorService.Stream(ctx, filter, func(author *model.Author) error {
+		rows++
+		return writeExportRow(e, writer, rows, author, export.AuthorRow(author))
+	})
+	if err != nil {
+		logger.WithField("rows", rows).Error(err)
+		return nil
+	}
+
+	return finishExport(e, writer)
+}
+
+func exportFormat(e echo.Context) string {
+	format := strings.ToLower(e.QueryParam("format"))
+	if format == "" {
+		return export.FormatCSV
+	}
+
+	return format
+}
+
+func parseUpdatedSince(e echo.Context) (*time.Time, error) {
+	if e.QueryParam("updated_since") == "" {
+		return nil, nil
+	}
+
+	updatedSince, err := time.Parse(time.RFC3339, e.QueryParam("updated_since"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedSince, nil
+}
+
+// startExport validates the format before anything is written so that an
+// unsupported format can still be answered with a regular 400.
+func startExport(e echo.Context, format, name string, header []string) (*export.Writer, error) {
+	writer, err := export.NewWriter(e.Response(), format, header)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Response().Header().Set(echo.HeaderContentType, export.ContentType(format))
+	e.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name+"."+export.Extension(format)))
+	e.Response().WriteHeader(http.StatusOK)
+
+	return writer, nil
+}
+
+func writeExportRow(e echo.Context, writer *export.Writer, rows int, record interface{}, row []string) error {
+	err := writer.Write(record, row)
+	if err != nil {
+		return err
+	}
+
+	if rows%exportFlushEvery == 0 {
+		err = writer.Flush()
+		if err != nil {
+			return err
+		}
+
+		e.Response().Flush()
+	}
+
+	return nil
+}
+
+func finishExport(e echo.Context, writer *export.Writer) error {
+	err := writer.Close()
+	if err != nil {
+		logrus.WithContext(e.Request().Context()).Error(err)
+		return nil
+	}
+
+	e.Response().Flush()
+	return nil
+}
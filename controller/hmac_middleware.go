@@ -1,14 +1,22 @@
 package controller
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/rhtyx/bayarind-service.git/config"
 	"github.com/rhtyx/bayarind-service.git/token"
 )
 
+// multipartOverheadBytes is the room left above the largest upload for
+// the boundaries and headers of a multipart form.
+const multipartOverheadBytes = 64 << 10
+
 func HmacMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		hmacString := c.Request().Header.Get("X-HMAC")
@@ -16,10 +24,19 @@ func HmacMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return c.JSON(http.StatusBadRequest, ErrBadRequest)
 		}
 
-		body, err := io.ReadAll(c.Request().Body)
+		// The whole body is signed, so it is read here before any handler
+		// can bound it; cap it at the largest upload handlers accept.
+		maxBytes := requestMaxBytes()
+		body, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, maxBytes))
 		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return c.JSON(http.StatusRequestEntityTooLarge, fmt.Sprintf("%s: limit is %d bytes", ErrTooLarge.Error(), maxBytes))
+			}
+
 			return c.JSON(http.StatusInternalServerError, ErrInternalServer)
 		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
 
 		digest, err := hex.DecodeString(hmacString)
 		if err != nil {
@@ -33,3 +50,9 @@ func HmacMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+
+// requestMaxBytes is the largest request body the API reads: the larger
+// of the import and cover limits, plus multipart framing.
+func requestMaxBytes() int64 {
+	return max(config.ImportMaxBytes(), config.CoverMaxBytes()) + multipartOverheadBytes
+}
@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) PlaceHold(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	hold, err := c.holdService.Place(ctx, bookID, userID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusCreated, hold)
+}
+
+func (c Controller) FindBookHolds(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	holds, err := c.holdService.FindAllByBookID(ctx, bookID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, holds)
+}
+
+func (c Controller) FindBookAvailability(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	availability, err := c.holdService.FindAvailability(ctx, bookID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, availability)
+}
+
+// FindAllHolds lists the holds of the caller, only the waiting and ready
+// ones when active=true. Librarians and admins may pass user_id to list the
+// holds of another user.
+func (c Controller) FindAllHolds(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	activeOnly := false
+	if e.QueryParam("active") != "" {
+		var err error
+		activeOnly, err = strconv.ParseBool(e.QueryParam("active"))
+		if err != nil {
+			logger.WithField("active", e.QueryParam("active")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query active", ErrBadRequest.Error()))
+		}
+	}
+
+	if e.QueryParam("user_id") != "" {
+		holderID, err := strconv.ParseInt(e.QueryParam("user_id"), 10, 64)
+		if err != nil {
+			logger.WithField("userID", e.QueryParam("user_id")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query user_id", ErrBadRequest.Error()))
+		}
+
+		if holderID != userID {
+			isStaff, err := c.hasRole(ctx, userID, model.RoleLibrarian, model.RoleAdmin)
+			if err != nil {
+				logger.WithField("userID", userID).Error(err)
+				return parseError(e, err)
+			}
+
+			if !isStaff {
+				return e.JSON(http.StatusForbidden, ErrForbidden.Error())
+			}
+		}
+
+		userID = holderID
+	}
+
+	holds, err := c.holdService.FindAllByUserID(ctx, userID, activeOnly)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, holds)
+}
+
+func (c Controller) FindHoldByID(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	holdID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("holdID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	hold, err := c.holdService.FindByID(ctx, holdID)
+	if err != nil {
+		logger.WithField("holdID", holdID).Error(err)
+		return parseError(e, err)
+	}
+
+	err = c.checkOwnerAccess(ctx, e, hold.UserID)
+	if err != nil {
+		logger.WithField("holdID", holdID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, hold)
+}
+
+func (c Controller) CancelHold(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	holdID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("holdID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	hold, err := c.holdService.FindByID(ctx, holdID)
+	if err != nil {
+		logger.WithField("holdID", holdID).Error(err)
+		return parseError(e, err)
+	}
+
+	err = c.checkOwnerAccess(ctx, e, hold.UserID)
+	if err != nil {
+		logger.WithField("holdID", holdID).Error(err)
+		return parseError(e, err)
+	}
+
+	hold, err = c.holdService.Cancel(ctx, holdID)
+	if err != nil {
+		logger.WithField("holdID", holdID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, hold)
+}
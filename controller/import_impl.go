@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rhtyx/bayarind-service.git/config"
+	"github.com/rhtyx/bayarind-service.git/marc"
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) CreateImport(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	dryRun := false
+	if e.QueryParam("dry_run") != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(e.QueryParam("dry_run"))
+		if err != nil {
+			logger.WithField("dryRun", e.QueryParam("dry_run")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query dry_run", ErrBadRequest.Error()))
+		}
+	}
+
+	data, format, err := readImportUpload(e)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	if e.QueryParam("format") != "" {
+		format = strings.ToLower(e.QueryParam("format"))
+	}
+
+	job := &model.ImportJob{
+		UserID: userID,
+		Format: format,
+		DryRun: dryRun,
+	}
+	job, err = c.importService.Create(ctx, job, data)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	e.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/api/v1/imports/%d/", job.ID))
+	return e.JSON(http.StatusAccepted, job)
+}
+
+func (c Controller) FindImportByID(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	jobID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("jobID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	job, err := c.importService.FindByID(ctx, jobID, userID)
+	if err != nil {
+		logger.WithField("jobID", jobID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, job)
+}
+
+func (c Controller) FindAllImports(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	jobs, err := c.importService.FindAllByUserID(ctx, userID)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, jobs)
+}
+
+// readImportUpload accepts either a multipart form with a "file" field or
+// a raw CSV, NDJSON or MARC body, and guesses the format from the file
+// extension or the Content-Type.
+func readImportUpload(e echo.Context) ([]byte, string, error) {
+	mediaType, _, _ := mime.ParseMediaType(e.Request().Header.Get(echo.HeaderContentType))
+
+	var (
+		reader io.Reader = e.Request().Body
+		format           = importFormat(mediaType)
+	)
+	if mediaType == echo.MIMEMultipartForm {
+		header, err := e.FormFile("file")
+		if err != nil {
+			return nil, "", errors.Join(ErrBadRequest, errors.New(": missing file"))
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			return nil, "", errors.Join(ErrBadRequest, err)
+		}
+		defer file.Close()
+
+		reader = file
+		format = importFormat(header.Header.Get(echo.HeaderContentType))
+		switch strings.ToLower(filepath.Ext(header.Filename)) {
+		case ".csv":
+			format = model.ImportFormatCSV
+		case ".ndjson", ".jsonl":
+			format = model.ImportFormatNDJSON
+		case ".mrc", ".marc":
+			format = model.ImportFormatMARC
+		case ".xml", ".marcxml":
+			format = model.ImportFormatMARCXML
+		}
+	}
+
+	maxBytes := config.ImportMaxBytes()
+	data, err := io.ReadAll(io.LimitReader(reader, maxBytes+1))
+	if err != nil {
+		return nil, "", errors.Join(ErrBadRequest, err)
+	}
+
+	if int64(len(data)) > maxBytes {
+		return nil, "", errors.Join(ErrTooLarge, fmt.Errorf(": limit is %d bytes", maxBytes))
+	}
+
+	return data, format, nil
+}
+
+func importFormat(mediaType string) string {
+	switch mediaType {
+	case "text/csv":
+		return model.ImportFormatCSV
+	case "application/x-ndjson", "application/jsonl", "application/jsonlines":
+		return model.ImportFormatNDJSON
+	case marc.MIMEMARC:
+		return model.ImportFormatMARC
+	case marc.MIMEMARCXML:
+		return model.ImportFormatMARCXML
+	default:
+		return ""
+	}
+}
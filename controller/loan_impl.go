@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) Checkout(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	body := &dto.CheckoutRequest{}
+	err := json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	loan, err := c.loanService.Checkout(ctx, body.Barcode, body.UserID)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusCreated, loan)
+}
+
+func (c Controller) Checkin(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	body := &dto.CheckinRequest{}
+	err := json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	loan, err := c.loanService.Checkin(ctx, body.Barcode)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, loan)
+}
+
+// FindAllLoans lists the loans of the caller. Librarians and admins may
+// pass user_id to list the loans of another user.
+func (c Controller) FindAllLoans(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	openOnly := false
+	if e.QueryParam("open") != "" {
+		var err error
+		openOnly, err = strconv.ParseBool(e.QueryParam("open"))
+		if err != nil {
+			logger.WithField("open", e.QueryParam("open")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query open", ErrBadRequest.Error()))
+		}
+	}
+
+	if e.QueryParam("user_id") != "" {
+		borrowerID, err := strconv.ParseInt(e.QueryParam("user_id"), 10, 64)
+		if err != nil {
+			logger.WithField("userID", e.QueryParam("user_id")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query user_id", ErrBadRequest.Error()))
+		}
+
+		if borrowerID != userID {
+			isStaff, err := c.hasRole(ctx, userID, model.RoleLibrarian, model.RoleAdmin)
+			if err != nil {
+				logger.WithField("userID", userID).Error(err)
+				return parseError(e, err)
+			}
+
+			if !isStaff {
+				return e.JSON(http.StatusForbidden, ErrForbidden.Error())
+			}
+		}
+
+		userID = borrowerID
+	}
+
+	loans, err := c.loanService.FindAllByUserID(ctx, userID, openOnly)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, loans)
+}
+
+func (c Controller) FindLoanByID(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	loanID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("loanID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	loan, err := c.loanService.FindByID(ctx, loanID)
+	if err != nil {
+		logger.WithField("loanID", loanID).Error(err)
+		return parseError(e, err)
+	}
+
+	err = c.checkOwnerAccess(ctx, e, loan.UserID)
+	if err != nil {
+		logger.WithField("loanID", loanID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, loan)
+}
+
+func (c Controller) RenewLoan(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	loanID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("loanID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	loan, err := c.loanService.FindByID(ctx, loanID)
+	if err != nil {
+		logger.WithField("loanID", loanID).Error(err)
+		return parseError(e, err)
+	}
+
+	err = c.checkOwnerAccess(ctx, e, loan.UserID)
+	if err != nil {
+		logger.WithField("loanID", loanID).Error(err)
+		return parseError(e, err)
+	}
+
+	loan, err = c.loanService.Renew(ctx, loanID)
+	if err != nil {
+		logger.WithField("loanID", loanID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, loan)
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/rhtyx/bayarind-service.git/config"
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/i18n"
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/labstack/echo/v4"
+)
+
+// locales is the chain of locales the response to e is translated along:
+// ?lang=, then Accept-Language, then the default locale. Since the
+// response now depends on them, it also sets Vary.
+func locales(e echo.Context) []string {
+	preferred := []string{}
+	if lang := e.QueryParam("lang"); lang != "" {
+		preferred = append(preferred, lang)
+	}
+
+	preferred = append(preferred, i18n.ParseAcceptLanguage(e.Request().Header.Get("Accept-Language"))...)
+	chain := i18n.Chain(config.DefaultLocale(), preferred...)
+
+	e.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+	return chain
+}
+
+// addContentLanguage adds the locales a response is served in, as
+// returned by Localize, to its Content-Language. Untranslated fields are
+// in the default locale.
+func addContentLanguage(e echo.Context, served ...string) {
+	header := e.Response().Header()
+	languages := []string{}
+	if current := header.Get("Content-Language"); current != "" {
+		languages = strings.Split(current, ", ")
+	}
+
+	for _, locale := range served {
+		if locale == "" {
+			locale = i18n.Canonical(config.DefaultLocale())
+		}
+
+		if !slices.Contains(languages, locale) {
+			languages = append(languages, locale)
+		}
+	}
+
+	if len(languages) > 0 {
+		header.Set("Content-Language", strings.Join(languages, ", "))
+	}
+}
+
+func bookTranslations(requests map[string]dto.BookTranslationRequest) model.Translations[model.BookTranslation] {
+	translations := model.Translations[model.BookTranslation]{}
+	for locale, request := range requests {
+		translations[locale] = model.BookTranslation(request)
+	}
+
+	return translations
+}
+
+func bookTranslationRequests(translations model.Translations[model.BookTranslation]) map[string]dto.BookTranslationRequest {
+	requests := map[string]dto.BookTranslationRequest{}
+	for locale, translation := range translations {
+		requests[locale] = dto.BookTranslationRequest(translation)
+	}
+
+	return requests
+}
+
+func authorTranslations(requests map[string]dto.AuthorTranslationRequest) model.Translations[model.AuthorTranslation] {
+	translations := model.Translations[model.AuthorTranslation]{}
+	for locale, request := range requests {
+		translations[locale] = model.AuthorTranslation(request)
+	}
+
+	return translations
+}
+
+func authorTranslationRequests(translations model.Translations[model.AuthorTranslation]) map[string]dto.AuthorTranslationRequest {
+	requests := map[string]dto.AuthorTranslationRequest{}
+	for locale, translation := range translations {
+		requests[locale] = dto.AuthorTranslationRequest(translation)
+	}
+
+	return requests
+}
+
+func subjectTranslations(requests map[string]dto.SubjectTranslationRequest) model.Translations[model.SubjectTranslation] {
+	translations := model.Translations[model.SubjectTranslation]{}
+	for locale, request := range requests {
+		translations[locale] = model.SubjectTranslation(request)
+	}
+
+	return translations
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"sync"
+	"time"
+)
+
+type loginFailures struct {
+	count     int
+	expiresAt time.Time
+}
+
+// loginLimiter counts failed sign-ins per key, such as a username or a
+// client IP, and locks a key out once it reaches max failures within
+// lockout of its first one.
+type loginLimiter struct {
+	max     int
+	lockout time.Duration
+	now     func() time.Time
+
+	mu       sync.Mutex
+	failures map[string]loginFailures
+}
+
+func newLoginLimiter(max int, lockout time.Duration) *loginLimiter {
+	return &loginLimiter{
+		max:      max,
+		lockout:  lockout,
+		now:      time.Now,
+		failures: map[string]loginFailures{},
+	}
+}
+
+// RetryAfter reports how long until any of keys may sign in again, or zero
+// when none of them is locked out.
+func (l *loginLimiter) RetryAfter(keys ...string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var wait time.Duration
+	for _, key := range keys {
+		entry, ok := l.failures[key]
+		if !ok || entry.count < l.max || !now.Before(entry.expiresAt) {
+			continue
+		}
+
+		wait = max(wait, entry.expiresAt.Sub(now))
+	}
+
+	return wait
+}
+
+func (l *loginLimiter) Fail(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.evictExpired()
+	for _, key := range keys {
+		entry, ok := l.failures[key]
+		if !ok {
+			entry.expiresAt = l.now().Add(l.lockout)
+		}
+
+		entry.count++
+		l.failures[key] = entry
+	}
+}
+
+func (l *loginLimiter) Reset(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		delete(l.failures, key)
+	}
+}
+
+func (l *loginLimiter) evictExpired() {
+	now := l.now()
+	for key, entry := range l.failures {
+		if !now.Before(entry.expiresAt) {
+			delete(l.failures, key)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rhtyx/bayarind-service.git/citation"
+	"github.com/rhtyx/bayarind-service.git/dublincore"
+	"github.com/rhtyx/bayarind-service.git/export"
+	"github.com/rhtyx/bayarind-service.git/marc"
+	"github.com/rhtyx/bayarind-service.git/schemaorg"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// negotiate picks the offered media type the Accept header prefers, the
+// first offer breaking ties. It returns the first offer when accept is
+// empty and "" when no offer is acceptable.
+func negotiate(accept string, offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	best, bestQ, bestSpecificity := "", 0.0, -1
+	for _, offer := range offers {
+		q, specificity := acceptQuality(accept, offer)
+		if q > bestQ || (q == bestQ && q > 0 && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+
+	return best
+}
+
+// acceptQuality returns the q-value the most specific range in accept
+// gives offer, and how specific that range was: 2 for an exact type, 1 for
+// type/* and 0 for */*.
+func acceptQuality(accept, offer string) (float64, int) {
+	q, specificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		rangeSpecificity := -1
+		switch {
+		case mediaRange == offer:
+			rangeSpecificity = 2
+		case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(mediaRange, "*")):
+			rangeSpecificity = 1
+		case mediaRange == "*/*":
+			rangeSpecificity = 0
+		}
+
+		if rangeSpecificity <= specificity {
+			continue
+		}
+
+		rangeQ := 1.0
+		if value, ok := params["q"]; ok {
+			rangeQ, err = strconv.ParseFloat(value, 64)
+			if err != nil || rangeQ < 0 || rangeQ > 1 {
+				continue
+			}
+		}
+
+		q, specificity = rangeQ, rangeSpecificity
+	}
+
+	return q, specificity
+}
+
+const (
+	formatJSON   = "json"
+	formatJSONLD = "jsonld"
+	formatOAIDC  = "oai_dc"
+)
+
+// representation is one form a handler can answer in: the name ?format=
+// asks for it by and the media types Accept does, preferred first.
+type representation struct {
+	format     string
+	mediaTypes []string
+}
+
+var (
+	representationJSON    = representation{formatJSON, []string{echo.MIMEApplicationJSON}}
+	representationJSONLD  = representation{formatJSONLD, []string{schemaorg.MIMEJSONLD}}
+	representationOAIDC   = representation{formatOAIDC, []string{dublincore.MIMEOAIDC}}
+	representationMARC    = representation{export.FormatMARC, []string{marc.MIMEMARC}}
+	representationMARCXML = representation{export.FormatMARCXML, []string{marc.MIMEMARCXML}}
+
+	// Citations take plain JSON to mean CSL-JSON.
+	representationCSLJSON = representation{citation.FormatCSLJSON, []string{citation.MIMECSLJSON, echo.MIMEApplicationJSON}}
+	representationBibTeX  = representation{citation.FormatBibTeX, []string{citation.MIMEBibTeX}}
+	representationRIS     = representation{citation.FormatRIS, []string{citation.MIMERIS}}
+)
+
+// negotiateRepresentation picks the representation ?format= names, or
+// else the one the Accept header prefers, the first offered breaking ties
+// and answering requests without Accept. The response varies by Accept
+// either way.
+func negotiateRepresentation(e echo.Context, offered ...representation) (representation, error) {
+	e.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+	if format := strings.ToLower(e.QueryParam("format")); format != "" {
+		for _, r := range offered {
+			if r.format == format {
+				return r, nil
+			}
+		}
+
+		return representation{}, errors.Join(ErrBadRequest, errors.New(": invalid query format"))
+	}
+
+	offers := []string{}
+	for _, r := range offered {
+		offers = append(offers, r.mediaTypes...)
+	}
+
+	chosen := negotiate(e.Request().Header.Get(echo.HeaderAccept), offers...)
+	for _, r := range offered {
+		if slices.Contains(r.mediaTypes, chosen) {
+			return r, nil
+		}
+	}
+
+	return representation{}, errors.Join(ErrNotAcceptable, fmt.Errorf(": offered are %s", strings.Join(offers, ", ")))
+}
+
+// writeBlob answers with the body write renders. The body is buffered so
+// that a failure halfway still ends in a clean error response.
+func writeBlob(e echo.Context, status int, contentType string, write func(w io.Writer) error) error {
+	buf := &bytes.Buffer{}
+	err := write(buf)
+	if err != nil {
+		logrus.WithContext(e.Request().Context()).Error(err)
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	return e.Blob(status, contentType, buf.Bytes())
+}
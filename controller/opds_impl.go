@@ -0,0 +1,390 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/config"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/opds"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// OPDSRoot is the start of the catalog, leading to the newest books, the
+// authors and the subjects.
+func (c Controller) OPDSRoot(e echo.Context) error {
+	now := time.Now()
+	feed := opdsFeed(e, opds.KindNavigation, config.ApplicationName())
+	feed.Navigation = []opds.Navigation{
+		{
+			ID:      absoluteURL(e, "/opds/books/"),
+			Title:   "New books",
+			Summary: "The most recently added and changed books.",
+			Href:    "/opds/books/",
+			Kind:    opds.KindAcquisition,
+			Updated: now,
+		},
+		{
+			ID:      absoluteURL(e, "/opds/authors/"),
+			Title:   "Authors",
+			Summary: "Books by author.",
+			Href:    "/opds/authors/",
+			Kind:    opds.KindNavigation,
+			Updated: now,
+		},
+		{
+			ID:      absoluteURL(e, "/opds/subjects/"),
+			Title:   "Subjects",
+			Summary: "Books by subject.",
+			Href:    "/opds/subjects/",
+			Kind:    opds.KindNavigation,
+			Updated: now,
+		},
+	}
+
+	return writeOPDSFeed(e, feed)
+}
+
+func (c Controller) OPDSBooks(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	pagination, err := parsePagination(e)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	page, err := c.catalogService.FindBooks(ctx, model.BookFilter{}, pagination)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	return writeOPDSFeed(e, opdsAcquisitionFeed(e, "New books", page))
+}
+
+// OPDSSearch lists the books whose title, subtitle, author or author alias
+// contains ?query=.
+func (c Controller) OPDSSearch(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	query := strings.TrimSpace(e.QueryParam("query"))
+	if query == "" {
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query query", ErrBadRequest.Error()))
+	}
+
+	pagination, err := parsePagination(e)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	page, err := c.catalogService.FindBooks(ctx, model.BookFilter{Query: query}, pagination)
+	if err != nil {
+		logger.WithField("query", query).Error(err)
+		return parseError(e, err)
+	}
+
+	return writeOPDSFeed(e, opdsAcquisitionFeed(e, fmt.Sprintf("Search: %s", query), page))
+}
+
+// OPDSOpenSearch describes the search of the catalog to OPDS 1.2 apps.
+func (c Controller) OPDSOpenSearch(e echo.Context) error {
+	return writeBlob(e, http.StatusOK, opds.MIMEOpenSearch+"; charset=utf-8", func(w io.Writer) error {
+		return opds.WriteOpenSearch(w, config.ApplicationName(), "Search the catalog by title or author.", "/opds/search/?query={searchTerms}")
+	})
+}
+
+func (c Controller) OPDSBook(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	entry, err := c.catalogService.FindBook(ctx, bookID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return writeOPDSPublication(e, http.StatusOK, opdsPublication(e, entry, locales(e)))
+}
+
+// OPDSBorrowBook places a hold on a book for the caller. It only answers
+// POST, so that crawlers and prefetching readers following the link do not
+// place holds.
+func (c Controller) OPDSBorrowBook(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	_, err = c.holdService.Place(ctx, bookID, userID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	entry, err := c.catalogService.FindBook(ctx, bookID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return writeOPDSPublication(e, http.StatusCreated, opdsPublication(e, entry, locales(e)))
+}
+
+func (c Controller) OPDSAuthors(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	pagination, err := parsePagination(e)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	page, err := c.catalogService.FindAuthors(ctx, pagination)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	feed := opdsFeed(e, opds.KindNavigation, "Authors")
+	for _, author := range page.Items {
+		href := fmt.Sprintf("/opds/authors/%d/", author.ID)
+		feed.Navigation = append(feed.Navigation, opds.Navigation{
+			ID:      absoluteURL(e, href),
+			Title:   author.Name,
+			Href:    href,
+			Kind:    opds.KindAcquisition,
+			Updated: updatedAt(author.CreatedAt, author.UpdatedAt),
+		})
+	}
+	feed.Paginate(e.Request().URL, page.Page, page.PerPage, page.Total)
+
+	return writeOPDSFeed(e, feed)
+}
+
+func (c Controller) OPDSAuthorBooks(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	authorID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("authorID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	pagination, err := parsePagination(e)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	author, page, err := c.catalogService.FindBooksByAuthor(ctx, authorID, pagination)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	feed := opdsAcquisitionFeed(e, author.Name, page)
+	feed.Links = append(feed.Links, opds.Link{Rel: opds.RelUp, Href: "/opds/authors/", Kind: opds.KindNavigation})
+	return writeOPDSFeed(e, feed)
+}
+
+func (c Controller) OPDSSubjects(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	subjects, err := c.catalogService.FindSubjects(ctx)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	chain := locales(e)
+	feed := opdsFeed(e, opds.KindNavigation, "Subjects")
+	for _, subject := range subjects {
+		addContentLanguage(e, subject.Localize(chain)...)
+		href := fmt.Sprintf("/opds/subjects/%d/", subject.ID)
+		feed.Navigation = append(feed.Navigation, opds.Navigation{
+			ID:      absoluteURL(e, href),
+			Title:   subject.Name,
+			Href:    href,
+			Kind:    opds.KindAcquisition,
+			Updated: updatedAt(subject.CreatedAt, subject.UpdatedAt),
+		})
+	}
+
+	return writeOPDSFeed(e, feed)
+}
+
+func (c Controller) OPDSSubjectBooks(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	subjectID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("subjectID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	pagination, err := parsePagination(e)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	subject, page, err := c.catalogService.FindBooksBySubject(ctx, subjectID, pagination)
+	if err != nil {
+		logger.WithField("subjectID", subjectID).Error(err)
+		return parseError(e, err)
+	}
+
+	addContentLanguage(e, subject.Localize(locales(e))...)
+	feed := opdsAcquisitionFeed(e, subject.Name, page)
+	feed.Links = append(feed.Links, opds.Link{Rel: opds.RelUp, Href: "/opds/subjects/", Kind: opds.KindNavigation})
+	return writeOPDSFeed(e, feed)
+}
+
+// opdsFeed starts a feed of kind at the request URL, linking to itself,
+// the start of the catalog and its search.
+func opdsFeed(e echo.Context, kind, title string) *opds.Feed {
+	return &opds.Feed{
+		ID:      absoluteURL(e, e.Request().URL.Path),
+		Title:   title,
+		Kind:    kind,
+		Updated: time.Now(),
+		Links: []opds.Link{
+			{Rel: opds.RelSelf, Href: e.Request().URL.RequestURI(), Kind: kind},
+			{Rel: opds.RelStart, Href: "/opds/", Kind: opds.KindNavigation},
+		},
+		SearchHref:     "/opds/opensearch/",
+		SearchTemplate: "/opds/search/{?query}",
+	}
+}
+
+// opdsAcquisitionFeed is a paginated feed of the books of page, updated
+// when the most recently changed of them was.
+func opdsAcquisitionFeed(e echo.Context, title string, page *model.CatalogPage) *opds.Feed {
+	feed := opdsFeed(e, opds.KindAcquisition, title)
+	feed.Publications = []opds.Publication{}
+
+	chain := locales(e)
+	for i, entry := range page.Items {
+		publication := opdsPublication(e, entry, chain)
+		if i == 0 || publication.Updated.After(feed.Updated) {
+			feed.Updated = publication.Updated
+		}
+
+		feed.Publications = append(feed.Publications, publication)
+	}
+	feed.Paginate(e.Request().URL, page.Page, page.PerPage, page.Total)
+
+	return feed
+}
+
+// opdsPublication describes a book, translated along chain, with a borrow
+// link placing a hold on it.
+func opdsPublication(e echo.Context, entry *model.CatalogEntry, chain []string) opds.Publication {
+	book := entry.Book
+	addContentLanguage(e, book.Localize(chain)...)
+
+	publication := opds.Publication{
+		ID:         opds.ISBNURN(book.ISBN),
+		Title:      book.Title,
+		Subtitle:   book.Subtitle,
+		Identifier: opds.ISBNURN(book.ISBN),
+		Updated:    updatedAt(book.CreatedAt, book.UpdatedAt),
+		Href:       fmt.Sprintf("/opds/books/%d/", book.ID),
+		Acquisitions: []opds.Link{{
+			Rel:  opds.RelBorrow,
+			Href: fmt.Sprintf("/opds/books/%d/borrow/", book.ID),
+		}},
+	}
+
+	if entry.Author != nil {
+		publication.Authors = []opds.Contributor{{
+			Name: entry.Author.Name,
+			Href: fmt.Sprintf("/opds/authors/%d/", entry.Author.ID),
+		}}
+	}
+
+	for _, subject := range entry.Subjects {
+		addContentLanguage(e, subject.Localize(chain)...)
+		publication.Subjects = append(publication.Subjects, subject.Name)
+	}
+
+	if book.CoverKey != "" {
+		publication.Image = fmt.Sprintf("/opds/books/%d/cover/%s/", book.ID, model.CoverLarge)
+		publication.Thumbnail = fmt.Sprintf("/opds/books/%d/cover/%s/", book.ID, model.CoverSmall)
+	}
+
+	return publication
+}
+
+// opdsJSON tells whether e prefers OPDS 2.0 to OPDS 1.2, which apps get
+// unless they accept JSON.
+func opdsJSON(e echo.Context) bool {
+	e.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	switch negotiate(e.Request().Header.Get(echo.HeaderAccept), opds.MIMEAtom, opds.MIMEJSON, echo.MIMEApplicationJSON) {
+	case opds.MIMEJSON, echo.MIMEApplicationJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+func writeOPDSFeed(e echo.Context, feed *opds.Feed) error {
+	if opdsJSON(e) {
+		return writeBlob(e, http.StatusOK, opds.MIMEJSON, func(w io.Writer) error {
+			return opds.WriteJSON(w, feed)
+		})
+	}
+
+	return writeBlob(e, http.StatusOK, opds.FeedType(feed.Kind)+";charset=utf-8", func(w io.Writer) error {
+		return opds.WriteAtom(w, feed)
+	})
+}
+
+func writeOPDSPublication(e echo.Context, status int, publication opds.Publication) error {
+	if opdsJSON(e) {
+		return writeBlob(e, status, opds.MIMEPublication, func(w io.Writer) error {
+			return opds.WriteJSONPublication(w, publication)
+		})
+	}
+
+	return writeBlob(e, status, opds.MIMEAtomEntry+";charset=utf-8", func(w io.Writer) error {
+		return opds.WriteAtomEntry(w, publication)
+	})
+}
+
+func updatedAt(createdAt time.Time, updatedAt *time.Time) time.Time {
+	if updatedAt != nil {
+		return *updatedAt
+	}
+
+	return createdAt
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rhtyx/bayarind-service.git/config"
+	"github.com/rhtyx/bayarind-service.git/opds"
+	"github.com/rhtyx/bayarind-service.git/token"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// OPDSAuthMiddleware authenticates e-reader apps, which cannot sign their
+// requests with HMAC. It takes the bearer access tokens of /api/v1, or
+// HTTP Basic credentials from apps that cannot refresh a token. Wrong
+// passwords lock out the username and the client IP for a while, since
+// Basic credentials can be guessed without a token to slow them down.
+func (c Controller) OPDSAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(e echo.Context) error {
+		ctx := e.Request().Context()
+
+		if bearer, ok := strings.CutPrefix(e.Request().Header.Get(echo.HeaderAuthorization), "Bearer "); ok {
+			claims, err := token.Jwt.ValidateToken(bearer)
+			if err != nil {
+				return opdsUnauthorized(e)
+			}
+
+			// Tokens outlive their users, so a deleted user's token is refused.
+			_, err = c.userService.FindByID(ctx, claims.UserID)
+			if errors.Is(err, ErrNotFound) {
+				return opdsUnauthorized(e)
+			}
+
+			if err != nil {
+				logrus.WithContext(ctx).WithField("userID", claims.UserID).Error(err)
+				return parseError(e, err)
+			}
+
+			e.Set("userID", claims.UserID)
+			return next(e)
+		}
+
+		username, password, ok := e.Request().BasicAuth()
+		if !ok {
+			return opdsUnauthorized(e)
+		}
+
+		keys := []string{"username:" + username, "ip:" + e.RealIP()}
+		if wait := c.opdsLogins.RetryAfter(keys...); wait > 0 {
+			e.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			return parseError(e, ErrTooManyLogins)
+		}
+
+		user, err := c.sessionService.Authenticate(ctx, username, password)
+		if errors.Is(err, ErrCredentials) {
+			c.opdsLogins.Fail(keys...)
+			return opdsUnauthorized(e)
+		}
+
+		if err != nil {
+			logrus.WithContext(ctx).WithField("username", username).Error(err)
+			return parseError(e, err)
+		}
+
+		c.opdsLogins.Reset(keys[0])
+		e.Set("userID", user.ID)
+		return next(e)
+	}
+}
+
+// opdsUnauthorized challenges for Basic credentials, describing them in an
+// OPDS authentication document for the apps that read one.
+func opdsUnauthorized(e echo.Context) error {
+	e.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="`+config.ApplicationName()+`"`)
+	e.Response().Header().Set(echo.HeaderContentType, opds.MIMEAuthentication)
+	e.Response().WriteHeader(http.StatusUnauthorized)
+	return opds.WriteAuthentication(e.Response(), absoluteURL(e, "/opds/"), config.ApplicationName())
+}
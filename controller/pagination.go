@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+// parsePagination reads the page and per_page query parameters, defaulting
+// to the first page of DefaultPerPage items.
+func parsePagination(e echo.Context) (model.Pagination, error) {
+	pagination := model.Pagination{Page: 1, PerPage: DefaultPerPage}
+
+	if e.QueryParam("page") != "" {
+		page, err := strconv.Atoi(e.QueryParam("page"))
+		if err != nil || page < 1 {
+			return pagination, errors.Join(ErrBadRequest, errors.New(": invalid query page"))
+		}
+
+		pagination.Page = page
+	}
+
+	if e.QueryParam("per_page") != "" {
+		perPage, err := strconv.Atoi(e.QueryParam("per_page"))
+		if err != nil || perPage < 1 || perPage > MaxPerPage {
+			return pagination, errors.Join(ErrBadRequest, errors.New(": invalid query per_page"))
+		}
+
+		pagination.PerPage = perPage
+	}
+
+	return pagination, nil
+}
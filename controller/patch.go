@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+
+	"github.com/rhtyx/bayarind-service.git/patch"
+
+	"github.com/labstack/echo/v4"
+)
+
+// decodePatch applies the request body to current as a merge patch or a
+// JSON Patch, depending on the Content-Type, and decodes the result into
+// target. Fields unknown to the DTO are rejected.
+func decodePatch(e echo.Context, current, target interface{}) error {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return errors.Join(ErrInternalServer, err)
+	}
+
+	body, err := io.ReadAll(e.Request().Body)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(e.Request().Header.Get(echo.HeaderContentType))
+
+	var patched []byte
+	switch mediaType {
+	case patch.MIMEMergePatch:
+		patched, err = patch.MergePatch(doc, body)
+	case patch.MIMEJSONPatch:
+		patched, err = patch.JSONPatch(doc, body)
+	default:
+		return errors.Join(ErrMediaType, errors.New(": use "+patch.MIMEMergePatch+" or "+patch.MIMEJSONPatch))
+	}
+	if errors.Is(err, patch.ErrTestFailed) {
+		return errors.Join(ErrConflict, err)
+	}
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(target)
+	if err != nil {
+		return errors.Join(ErrBadRequest, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) CreatePublisher(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	body := &dto.PublisherRequest{}
+	err := json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	publisher := &model.Publisher{
+		Name:    body.Name,
+		Website: body.Website,
+	}
+	publisher, err = c.publisherService.Create(ctx, publisher)
+	if err != nil {
+		logger.WithField("publisher", utils.Dump(publisher)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, publisher.Version)
+	return e.JSON(http.StatusCreated, publisher)
+}
+
+func (c Controller) FindPublisherByID(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	publisherID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("publisherID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	publisher, err := c.publisherService.FindByID(ctx, publisherID)
+	if err != nil {
+		logger.WithField("publisherID", publisherID).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, publisher.Version)
+	if notModified(e, publisher.Version) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.JSON(http.StatusOK, publisher)
+}
+
+func (c Controller) FindAllPublishers(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	publishers, err := c.publisherService.FindAll(ctx)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, publishers)
+}
+
+func (c Controller) UpdatePublisher(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	publisherID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("publisherID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("publisherID", publisherID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		currPublisher, err := c.publisherService.FindByID(ctx, publisherID)
+		if err != nil {
+			logger.WithField("publisherID", publisherID).Error(err)
+			return parseError(e, err)
+		}
+
+		version = currPublisher.Version
+	}
+
+	body := &dto.PublisherRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	publisher := &model.Publisher{
+		ID:      publisherID,
+		Name:    body.Name,
+		Website: body.Website,
+		Version: version,
+	}
+	publisher, err = c.publisherService.Update(ctx, publisher)
+	if err != nil {
+		logger.WithField("publisher", utils.Dump(publisher)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, publisher.Version)
+	return e.JSON(http.StatusOK, publisher)
+}
+
+func (c Controller) DeletePublisher(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	publisherID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("publisherID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	err = c.publisherService.Delete(ctx, publisherID)
+	if err != nil {
+		logger.WithField("publisherID", publisherID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, "Publisher deleted")
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// FindSimilarBooks lists the books most like a book, leaving out the books
+// the caller has already read.
+func (c Controller) FindSimilarBooks(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	pagination, err := parsePagination(e)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	recommendations, err := c.recommendationService.FindSimilar(ctx, bookID, userID, pagination)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	chain := locales(e)
+	for _, recommendation := range recommendations {
+		addContentLanguage(e, recommendation.Localize(chain)...)
+	}
+
+	return e.JSON(http.StatusOK, recommendations)
+}
+
+// FindRecommendations lists unread books like the ones the caller has
+// viewed, borrowed, reviewed or shelved.
+func (c Controller) FindRecommendations(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	pagination, err := parsePagination(e)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	recommendations, err := c.recommendationService.FindForUser(ctx, userID, pagination)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	chain := locales(e)
+	for _, recommendation := range recommendations {
+		addContentLanguage(e, recommendation.Localize(chain)...)
+	}
+
+	return e.JSON(http.StatusOK, recommendations)
+}
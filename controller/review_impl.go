@@ -0,0 +1,351 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) CreateReview(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	body := &dto.ReviewRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	review := &model.Review{
+		BookID: bookID,
+		UserID: userID,
+		Rating: body.Rating,
+		Body:   strings.TrimSpace(body.Body),
+	}
+	review, err = c.reviewService.Create(ctx, review)
+	if err != nil {
+		logger.WithField("review", utils.Dump(review)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, review.Version)
+	return e.JSON(http.StatusCreated, review)
+}
+
+// FindBookReviews lists the visible reviews of a book, a page at a time.
+// sort is one of helpful (the default), newest, highest or lowest.
+// Librarians and admins may pass include_hidden=true to moderate.
+func (c Controller) FindBookReviews(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	pagination, err := parsePagination(e)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	includeHidden := false
+	if e.QueryParam("include_hidden") != "" {
+		includeHidden, err = strconv.ParseBool(e.QueryParam("include_hidden"))
+		if err != nil {
+			logger.WithField("includeHidden", e.QueryParam("include_hidden")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query include_hidden", ErrBadRequest.Error()))
+		}
+	}
+
+	if includeHidden {
+		userID, ok := e.Get("userID").(int64)
+		if !ok {
+			return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+		}
+
+		isStaff, err := c.hasRole(ctx, userID, model.RoleLibrarian, model.RoleAdmin)
+		if err != nil {
+			logger.WithField("userID", userID).Error(err)
+			return parseError(e, err)
+		}
+
+		if !isStaff {
+			return e.JSON(http.StatusForbidden, ErrForbidden.Error())
+		}
+	}
+
+	page, err := c.reviewService.FindAllByBookID(ctx, bookID, e.QueryParam("sort"), includeHidden, pagination)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, page)
+}
+
+// FindReviewByID returns a review. Hidden reviews are only shown to their
+// author and to librarians and admins.
+func (c Controller) FindReviewByID(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	reviewID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("reviewID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	review, err := c.reviewService.FindByID(ctx, reviewID)
+	if err != nil {
+		logger.WithField("reviewID", reviewID).Error(err)
+		return parseError(e, err)
+	}
+
+	if review.Hidden {
+		err = c.checkOwnerAccess(ctx, e, review.UserID)
+		if err != nil {
+			logger.WithField("reviewID", reviewID).Error(err)
+			return e.JSON(http.StatusNotFound, fmt.Sprintf("%s: review", ErrNotFound.Error()))
+		}
+	}
+
+	setETag(e, review.Version)
+	if notModified(e, review.Version) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.JSON(http.StatusOK, review)
+}
+
+// UpdateReview lets users edit their own reviews.
+func (c Controller) UpdateReview(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	reviewID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("reviewID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("reviewID", reviewID).Error(err)
+		return parseError(e, err)
+	}
+
+	currReview, err := c.reviewService.FindByID(ctx, reviewID)
+	if err != nil {
+		logger.WithField("reviewID", reviewID).Error(err)
+		return parseError(e, err)
+	}
+
+	if currReview.UserID != userID {
+		return e.JSON(http.StatusForbidden, ErrForbidden.Error())
+	}
+
+	if matchAny {
+		version = currReview.Version
+	}
+
+	body := &dto.ReviewRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	review := &model.Review{
+		ID:      reviewID,
+		Rating:  body.Rating,
+		Body:    strings.TrimSpace(body.Body),
+		Version: version,
+	}
+	review, err = c.reviewService.Update(ctx, review)
+	if err != nil {
+		logger.WithField("review", utils.Dump(review)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, review.Version)
+	return e.JSON(http.StatusOK, review)
+}
+
+// DeleteReview lets users delete their own reviews, and librarians and
+// admins delete any.
+func (c Controller) DeleteReview(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	reviewID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("reviewID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	review, err := c.reviewService.FindByID(ctx, reviewID)
+	if err != nil {
+		logger.WithField("reviewID", reviewID).Error(err)
+		return parseError(e, err)
+	}
+
+	err = c.checkOwnerAccess(ctx, e, review.UserID)
+	if err != nil {
+		logger.WithField("reviewID", reviewID).Error(err)
+		return parseError(e, err)
+	}
+
+	err = c.reviewService.Delete(ctx, reviewID)
+	if err != nil {
+		logger.WithField("reviewID", reviewID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, "Review deleted")
+}
+
+func (c Controller) HideReview(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	reviewID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("reviewID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	body := &dto.HideReviewRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	review, err := c.reviewService.Hide(ctx, reviewID, body.Reason)
+	if err != nil {
+		logger.WithField("reviewID", reviewID).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, review.Version)
+	return e.JSON(http.StatusOK, review)
+}
+
+func (c Controller) UnhideReview(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	reviewID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("reviewID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	review, err := c.reviewService.Unhide(ctx, reviewID)
+	if err != nil {
+		logger.WithField("reviewID", reviewID).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, review.Version)
+	return e.JSON(http.StatusOK, review)
+}
+
+func (c Controller) VoteReview(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	reviewID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("reviewID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	review, err := c.reviewService.Vote(ctx, reviewID, userID)
+	if err != nil {
+		logger.WithField("reviewID", reviewID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, review)
+}
+
+func (c Controller) UnvoteReview(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	reviewID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("reviewID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	review, err := c.reviewService.Unvote(ctx, reviewID, userID)
+	if err != nil {
+		logger.WithField("reviewID", reviewID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, review)
+}
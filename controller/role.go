@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) RoleMiddleware(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(e echo.Context) error {
+			ctx := e.Request().Context()
+
+			userID, ok := e.Get("userID").(int64)
+			if !ok {
+				return e.JSON(http.StatusUnauthorized, ErrUnauthorized.Error())
+			}
+
+			allowed, err := c.hasRole(ctx, userID, roles...)
+			if err != nil {
+				logrus.WithContext(ctx).WithField("userID", userID).Error(err)
+				return parseError(e, err)
+			}
+
+			if !allowed {
+				return e.JSON(http.StatusForbidden, ErrForbidden.Error())
+			}
+
+			return next(e)
+		}
+	}
+}
+
+func (c Controller) hasRole(ctx context.Context, userID int64, roles ...string) (bool, error) {
+	user, err := c.userService.FindByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(roles, user.Role), nil
+}
+
+// checkOwnerAccess lets users reach what they own, such as their loans,
+// holds and account, and librarians and admins reach everyone's.
+func (c Controller) checkOwnerAccess(ctx context.Context, e echo.Context, ownerID int64) error {
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return ErrInternalServer
+	}
+
+	if ownerID == userID {
+		return nil
+	}
+
+	isStaff, err := c.hasRole(ctx, userID, model.RoleLibrarian, model.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if !isStaff {
+		return ErrForbidden
+	}
+
+	return nil
+}
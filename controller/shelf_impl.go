@@ -0,0 +1,413 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) CreateShelf(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	body := &dto.ShelfRequest{}
+	err := json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	shelf := &model.Shelf{
+		UserID:  userID,
+		Name:    body.Name,
+		Privacy: body.Privacy,
+	}
+	shelf, err = c.shelfService.Create(ctx, shelf)
+	if err != nil {
+		logger.WithField("shelf", utils.Dump(shelf)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, shelf.Version)
+	return e.JSON(http.StatusCreated, shelf)
+}
+
+// FindAllShelves lists the shelves of the caller, or the public shelves of
+// the user given by user_id.
+func (c Controller) FindAllShelves(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	if e.QueryParam("user_id") != "" {
+		ownerID, err := strconv.ParseInt(e.QueryParam("user_id"), 10, 64)
+		if err != nil {
+			logger.WithField("userID", e.QueryParam("user_id")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query user_id", ErrBadRequest.Error()))
+		}
+
+		if ownerID != userID {
+			shelves, err := c.shelfService.FindPublicByUserID(ctx, ownerID)
+			if err != nil {
+				logger.WithField("userID", ownerID).Error(err)
+				return parseError(e, err)
+			}
+
+			return e.JSON(http.StatusOK, shelves)
+		}
+	}
+
+	shelves, err := c.shelfService.FindAllByUserID(ctx, userID)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, shelves)
+}
+
+func (c Controller) FindShelfByID(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	shelfID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("shelfID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	shelf, err := c.findVisibleShelf(ctx, e, shelfID)
+	if err != nil {
+		logger.WithField("shelfID", shelfID).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, shelf.Version)
+	if notModified(e, shelf.Version) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.JSON(http.StatusOK, shelf)
+}
+
+func (c Controller) UpdateShelf(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	shelfID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("shelfID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("shelfID", shelfID).Error(err)
+		return parseError(e, err)
+	}
+
+	currShelf, err := c.findOwnShelf(ctx, e, shelfID)
+	if err != nil {
+		logger.WithField("shelfID", shelfID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		version = currShelf.Version
+	}
+
+	body := &dto.ShelfRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	shelf := &model.Shelf{
+		ID:      shelfID,
+		Name:    body.Name,
+		Privacy: body.Privacy,
+		Version: version,
+	}
+	shelf, err = c.shelfService.Update(ctx, shelf)
+	if err != nil {
+		logger.WithField("shelf", utils.Dump(shelf)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, shelf.Version)
+	return e.JSON(http.StatusOK, shelf)
+}
+
+func (c Controller) DeleteShelf(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	shelfID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("shelfID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	_, err = c.findOwnShelf(ctx, e, shelfID)
+	if err != nil {
+		logger.WithField("shelfID", shelfID).Error(err)
+		return parseError(e, err)
+	}
+
+	err = c.shelfService.Delete(ctx, shelfID)
+	if err != nil {
+		logger.WithField("shelfID", shelfID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, "Shelf deleted")
+}
+
+func (c Controller) FindShelfBooks(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	shelfID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("shelfID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	_, err = c.findVisibleShelf(ctx, e, shelfID)
+	if err != nil {
+		logger.WithField("shelfID", shelfID).Error(err)
+		return parseError(e, err)
+	}
+
+	entries, err := c.shelfService.FindEntries(ctx, shelfID)
+	if err != nil {
+		logger.WithField("shelfID", shelfID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, entries)
+}
+
+func (c Controller) AddShelfBook(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	shelfID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("shelfID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	_, err = c.findOwnShelf(ctx, e, shelfID)
+	if err != nil {
+		logger.WithField("shelfID", shelfID).Error(err)
+		return parseError(e, err)
+	}
+
+	body := &dto.ShelfEntryRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	entry := &model.ShelfEntry{
+		ShelfID:  shelfID,
+		BookID:   body.BookID,
+		Position: body.Position,
+		Note:     body.Note,
+	}
+	entry, err = c.shelfService.AddEntry(ctx, entry)
+	if err != nil {
+		logger.WithField("entry", utils.Dump(entry)).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusCreated, entry)
+}
+
+func (c Controller) MoveShelfBook(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	shelfID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("shelfID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	bookID, err := strconv.ParseInt(e.Param("book_id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("book_id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param book_id", ErrBadRequest.Error()))
+	}
+
+	_, err = c.findOwnShelf(ctx, e, shelfID)
+	if err != nil {
+		logger.WithField("shelfID", shelfID).Error(err)
+		return parseError(e, err)
+	}
+
+	body := &dto.MoveShelfEntryRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	entry := &model.ShelfEntry{
+		ShelfID:  shelfID,
+		BookID:   bookID,
+		Position: body.Position,
+		Note:     body.Note,
+	}
+	entry, err = c.shelfService.MoveEntry(ctx, entry)
+	if err != nil {
+		logger.WithField("entry", utils.Dump(entry)).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, entry)
+}
+
+func (c Controller) RemoveShelfBook(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	shelfID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("shelfID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	bookID, err := strconv.ParseInt(e.Param("book_id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("book_id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param book_id", ErrBadRequest.Error()))
+	}
+
+	_, err = c.findOwnShelf(ctx, e, shelfID)
+	if err != nil {
+		logger.WithField("shelfID", shelfID).Error(err)
+		return parseError(e, err)
+	}
+
+	err = c.shelfService.RemoveEntry(ctx, shelfID, bookID)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"shelfID": shelfID,
+			"bookID":  bookID,
+		}).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, "Book removed from shelf")
+}
+
+// FindSharedShelf serves the read-only view of a shelf shared by link. It
+// is mounted outside the API, so visitors need no account.
+func (c Controller) FindSharedShelf(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	shelf, err := c.shelfService.FindShared(ctx, e.Param("token"))
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, shelf)
+}
+
+// findOwnShelf returns a shelf of the caller. Shelves are personal, so
+// librarians and admins get no say over them either.
+func (c Controller) findOwnShelf(ctx context.Context, e echo.Context, shelfID int64) (*model.Shelf, error) {
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return nil, ErrInternalServer
+	}
+
+	shelf, err := c.shelfService.FindByID(ctx, shelfID)
+	if err != nil {
+		return nil, err
+	}
+
+	if shelf.UserID != userID {
+		return nil, ErrForbidden
+	}
+
+	return shelf, nil
+}
+
+// findVisibleShelf returns a shelf of the caller or a public shelf of
+// someone else, without its share token.
+func (c Controller) findVisibleShelf(ctx context.Context, e echo.Context, shelfID int64) (*model.Shelf, error) {
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return nil, ErrInternalServer
+	}
+
+	shelf, err := c.shelfService.FindByID(ctx, shelfID)
+	if err != nil {
+		return nil, err
+	}
+
+	if shelf.UserID == userID {
+		return shelf, nil
+	}
+
+	if shelf.Privacy != model.ShelfPrivacyPublic {
+		return nil, ErrForbidden
+	}
+
+	shelf.ShareToken = nil
+	return shelf, nil
+}
@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) CreateSubject(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	body := &dto.SubjectRequest{}
+	err := json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	subject := &model.Subject{
+		Name:         body.Name,
+		ParentID:     body.ParentID,
+		Translations: subjectTranslations(body.Translations),
+	}
+	subject, err = c.subjectService.Create(ctx, subject)
+	if err != nil {
+		logger.WithField("subject", utils.Dump(subject)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, subject.Version)
+	return e.JSON(http.StatusCreated, subject)
+}
+
+func (c Controller) FindSubjectByID(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	subjectID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("subjectID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	subject, err := c.subjectService.FindByID(ctx, subjectID)
+	if err != nil {
+		logger.WithField("subjectID", subjectID).Error(err)
+		return parseError(e, err)
+	}
+
+	chain := locales(e)
+	addContentLanguage(e, subject.Localize(chain)...)
+	setETag(e, subject.Version, localeVariant(chain))
+	if notModified(e, subject.Version, localeVariant(chain)) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.JSON(http.StatusOK, subject)
+}
+
+func (c Controller) FindSubjectTree(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	subjects, err := c.subjectService.FindTree(ctx)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	chain := locales(e)
+	for _, subject := range subjects {
+		addContentLanguage(e, subject.Localize(chain)...)
+	}
+
+	return e.JSON(http.StatusOK, subjects)
+}
+
+func (c Controller) FindSubjectSubtree(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	subjectID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("subjectID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	subject, err := c.subjectService.FindSubtree(ctx, subjectID)
+	if err != nil {
+		logger.WithField("subjectID", subjectID).Error(err)
+		return parseError(e, err)
+	}
+
+	addContentLanguage(e, subject.Localize(locales(e))...)
+
+	return e.JSON(http.StatusOK, subject)
+}
+
+func (c Controller) UpdateSubject(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	subjectID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("subjectID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("subjectID", subjectID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		currSubject, err := c.subjectService.FindByID(ctx, subjectID)
+		if err != nil {
+			logger.WithField("subjectID", subjectID).Error(err)
+			return parseError(e, err)
+		}
+
+		version = currSubject.Version
+	}
+
+	body := &dto.SubjectRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	subject := &model.Subject{
+		ID:           subjectID,
+		Name:         body.Name,
+		ParentID:     body.ParentID,
+		Translations: subjectTranslations(body.Translations),
+		Version:      version,
+	}
+	subject, err = c.subjectService.Update(ctx, subject)
+	if err != nil {
+		logger.WithField("subject", utils.Dump(subject)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, subject.Version)
+	return e.JSON(http.StatusOK, subject)
+}
+
+func (c Controller) DeleteSubject(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	subjectID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("subjectID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	err = c.subjectService.Delete(ctx, subjectID)
+	if err != nil {
+		logger.WithField("subjectID", subjectID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, "Subject deleted")
+}
+
+func (c Controller) FindBookSubjects(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	subjects, err := c.subjectService.FindByBookID(ctx, bookID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	chain := locales(e)
+	for _, subject := range subjects {
+		addContentLanguage(e, subject.Localize(chain)...)
+	}
+
+	return e.JSON(http.StatusOK, subjects)
+}
+
+func (c Controller) SetBookSubjects(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	body := &dto.BookSubjectsRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	subjects, err := c.subjectService.SetBookSubjects(ctx, bookID, body.SubjectIDs)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, subjects)
+}
@@ -1 +1,169 @@
 package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFindAllBooks(t *testing.T) {
+	serve := func(t *testing.T, target string, books []*model.Book) *httptest.ResponseRecorder {
+		ctrl := gomock.NewController(t)
+		bookService := mock.NewMockBookService(ctrl)
+		bookService.EXPECT().
+			FindAll(gomock.Any()).
+			Times(1).
+			DoAndReturn(func(context.Context) ([]*model.Book, error) {
+				return books, nil
+			})
+
+		c := controller.NewController()
+		c.RegisterBookService(bookService)
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		err := c.FindAllBooks(echo.New().NewContext(req, rec))
+		assert.Nil(t, err)
+		return rec
+	}
+
+	t.Run("ok: content language is the translation served", func(t *testing.T) {
+		rec := serve(t, "/api/v1/books/?lang=en-GB", []*model.Book{{
+			Title: "Bumi Manusia",
+			Translations: model.Translations[model.BookTranslation]{
+				"en": {Title: "This Earth of Mankind"},
+			},
+		}})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "en", rec.Header().Get("Content-Language"))
+		assert.Contains(t, rec.Body.String(), "This Earth of Mankind")
+	})
+
+	t.Run("ok: content language falls back to the default locale", func(t *testing.T) {
+		rec := serve(t, "/api/v1/books/?lang=en", []*model.Book{{Title: "Bumi Manusia"}})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "id", rec.Header().Get("Content-Language"))
+	})
+
+	t.Run("ok: content language lists every locale served", func(t *testing.T) {
+		rec := serve(t, "/api/v1/books/?lang=en", []*model.Book{
+			{
+				Title: "Bumi Manusia",
+				Translations: model.Translations[model.BookTranslation]{
+					"en": {Title: "This Earth of Mankind"},
+				},
+			},
+			{Title: "Cantik Itu Luka"},
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "en, id", rec.Header().Get("Content-Language"))
+	})
+
+	t.Run("ok: no content language for an empty list", func(t *testing.T) {
+		rec := serve(t, "/api/v1/books/?lang=en", []*model.Book{})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Language"))
+	})
+}
+
+func TestFindBookByID(t *testing.T) {
+	book := &model.Book{ID: 1729327188000000001, Title: "Bumi Manusia", ISBN: "9789799731234", Version: 1}
+
+	serve := func(t *testing.T, target, accept, ifNoneMatch string) *httptest.ResponseRecorder {
+		ctrl := gomock.NewController(t)
+		bookService := mock.NewMockBookService(ctrl)
+		bookService.EXPECT().
+			FindByID(gomock.Any(), book.ID).
+			AnyTimes().
+			DoAndReturn(func(context.Context, int64) (*model.Book, error) {
+				copied := *book
+				return &copied, nil
+			})
+
+		citationService := mock.NewMockCitationService(ctrl)
+		citationService.EXPECT().
+			FindByBookIDs(gomock.Any(), []int64{book.ID}).
+			AnyTimes().
+			Return([]*model.Citation{{Book: book}}, nil)
+
+		c := controller.NewController()
+		c.RegisterBookService(bookService)
+		c.RegisterCitationService(citationService)
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set(echo.HeaderAccept, accept)
+		req.Header.Set(controller.HeaderIfNoneMatch, ifNoneMatch)
+		rec := httptest.NewRecorder()
+		e := echo.New().NewContext(req, rec)
+		e.SetParamNames("id")
+		e.SetParamValues(strconv.FormatInt(book.ID, 10))
+		err := c.FindBookByID(e)
+		assert.Nil(t, err)
+		return rec
+	}
+
+	t.Run("ok: browsers get json", func(t *testing.T) {
+		rec := serve(t, "/api/v1/books/1729327188000000001/", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	})
+
+	t.Run("error: plain xml is not acceptable", func(t *testing.T) {
+		rec := serve(t, "/api/v1/books/1729327188000000001/", "application/xml", "")
+		assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+	})
+
+	t.Run("ok: oai_dc by media type", func(t *testing.T) {
+		rec := serve(t, "/api/v1/books/1729327188000000001/", "application/oai_dc+xml", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationXML)
+		assert.Contains(t, rec.Body.String(), "<oai_dc:dc")
+	})
+
+	t.Run("ok: oai_dc by format", func(t *testing.T) {
+		rec := serve(t, "/api/v1/books/1729327188000000001/?format=oai_dc", "", "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "<dc:title>Bumi Manusia</dc:title>")
+	})
+
+	t.Run("ok: a new review changes the etag", func(t *testing.T) {
+		rec := serve(t, "/api/v1/books/1729327188000000001/", "", "")
+		etag := rec.Header().Get(controller.HeaderETag)
+
+		rec = serve(t, "/api/v1/books/1729327188000000001/", "", etag)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+
+		average := 4.0
+		book.RatingCount, book.RatingAverage = 1, &average
+		defer func() { book.RatingCount, book.RatingAverage = 0, nil }()
+
+		rec = serve(t, "/api/v1/books/1729327188000000001/", "", etag)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEqual(t, etag, rec.Header().Get(controller.HeaderETag))
+	})
+
+	t.Run("ok: each format and locale has its own etag", func(t *testing.T) {
+		rec := serve(t, "/api/v1/books/1729327188000000001/", "", "")
+		etag := rec.Header().Get(controller.HeaderETag)
+
+		for _, target := range []string{
+			"/api/v1/books/1729327188000000001/?format=jsonld",
+			"/api/v1/books/1729327188000000001/?format=oai_dc",
+			"/api/v1/books/1729327188000000001/?format=marcxml",
+			"/api/v1/books/1729327188000000001/?lang=en",
+		} {
+			rec = serve(t, target, "", etag)
+			assert.Equal(t, http.StatusOK, rec.Code, target)
+			assert.NotEqual(t, etag, rec.Header().Get(controller.HeaderETag), target)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportBooks(t *testing.T) {
+	t.Run("error: invalid isbn", func(t *testing.T) {
+		c := controller.NewController()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/exports/books?isbn=978-0-306-40615-8", nil)
+		rec := httptest.NewRecorder()
+		err := c.ExportBooks(echo.New().NewContext(req, rec))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid query isbn")
+	})
+
+	t.Run("ok: marc records come with their citations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		book := &model.Book{ID: 1729327188000000001, ISBN: "9780306406157", Title: "Bumi Manusia", AuthorID: 1729327188000000002}
+
+		// Authors, editions and publishers come from the stream, not from
+		// lookups of their own that would need more connections.
+		bookService := mock.NewMockBookService(ctrl)
+		bookService.EXPECT().
+			StreamCitations(gomock.Any(), model.BookFilter{}, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, _ model.BookFilter, fn func(*model.Citation) error) error {
+				return fn(&model.Citation{Book: book, Author: &model.Author{ID: book.AuthorID, Name: "Pramoedya Ananta Toer"}})
+			})
+
+		c := controller.NewController()
+		c.RegisterBookService(bookService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/exports/books?format=marcxml", nil)
+		rec := httptest.NewRecorder()
+		err := c.ExportBooks(echo.New().NewContext(req, rec))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Bumi Manusia")
+		assert.Contains(t, rec.Body.String(), "Toer, Pramoedya Ananta")
+	})
+}
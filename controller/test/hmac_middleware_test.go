@@ -0,0 +1,61 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/token"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHmacMiddleware(t *testing.T) {
+	token.Hmac = &token.HMAC{SecretKey: []byte("secret")}
+	viper.Set("application.import-max-bytes", 1024)
+	viper.Set("cover.max-bytes", 512)
+	t.Cleanup(viper.Reset)
+
+	serve := func(body []byte) (*httptest.ResponseRecorder, []byte) {
+		var received []byte
+		handler := controller.HmacMiddleware(func(c echo.Context) error {
+			received, _ = io.ReadAll(c.Request().Body)
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/books/", bytes.NewReader(body))
+		req.Header.Set("X-HMAC", token.Hmac.GenerateHMAC(body))
+		rec := httptest.NewRecorder()
+		err := handler(echo.New().NewContext(req, rec))
+		assert.Nil(t, err)
+		return rec, received
+	}
+
+	t.Run("ok: body restored for the handler", func(t *testing.T) {
+		body := []byte(`{"title":"Bumi Manusia"}`)
+		rec, received := serve(body)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, body, received)
+	})
+
+	t.Run("error: body over the largest upload", func(t *testing.T) {
+		rec, received := serve(bytes.Repeat([]byte("a"), 1024+(64<<10)+1))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Nil(t, received)
+	})
+
+	t.Run("error: bad signature", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/books/", bytes.NewReader([]byte("{}")))
+		req.Header.Set("X-HMAC", token.Hmac.GenerateHMAC([]byte("other")))
+		rec := httptest.NewRecorder()
+		err := controller.HmacMiddleware(func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})(echo.New().NewContext(req, rec))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
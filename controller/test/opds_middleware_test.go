@@ -0,0 +1,104 @@
+package test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rhtyx/bayarind-service.git/config"
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/token"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOPDSAuthMiddleware(t *testing.T) {
+	const userID = int64(1729327188000000001)
+	next := func(e echo.Context) error {
+		return e.NoContent(http.StatusOK)
+	}
+
+	serve := func(c *controller.Controller, header func(req *http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/opds/", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		header(req)
+		rec := httptest.NewRecorder()
+		err := c.OPDSAuthMiddleware(next)(echo.New().NewContext(req, rec))
+		assert.Nil(t, err)
+		return rec
+	}
+
+	t.Run("ok: basic credentials", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionService := mock.NewMockSessionService(ctrl)
+		sessionService.EXPECT().
+			Authenticate(gomock.Any(), "reader", "secret").
+			Times(1).
+			Return(&model.User{ID: userID}, nil)
+
+		c := controller.NewController()
+		c.RegisterSessionService(sessionService)
+
+		rec := serve(c, func(req *http.Request) { req.SetBasicAuth("reader", "secret") })
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("error: wrong passwords lock out the username and IP", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sessionService := mock.NewMockSessionService(ctrl)
+		sessionService.EXPECT().
+			Authenticate(gomock.Any(), "reader", "guess").
+			Times(config.DefaultOPDSMaxFailedLogins).
+			Return(nil, controller.ErrCredentials)
+
+		c := controller.NewController()
+		c.RegisterSessionService(sessionService)
+
+		for range config.DefaultOPDSMaxFailedLogins {
+			rec := serve(c, func(req *http.Request) { req.SetBasicAuth("reader", "guess") })
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		}
+
+		rec := serve(c, func(req *http.Request) { req.SetBasicAuth("reader", "secret") })
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+		rec = serve(c, func(req *http.Request) { req.SetBasicAuth("other", "secret") })
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	})
+
+	t.Run("error: bearer token of a deleted user", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.Nil(t, err)
+		publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		assert.Nil(t, err)
+		token.Jwt = &token.JWT{
+			PrivateKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+			PublicKey:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}),
+		}
+		accessToken, err := token.Jwt.CreateToken(userID, time.Now(), time.Minute)
+		assert.Nil(t, err)
+
+		ctrl := gomock.NewController(t)
+		userService := mock.NewMockUserService(ctrl)
+		userService.EXPECT().
+			FindByID(gomock.Any(), userID).
+			Times(1).
+			Return(nil, errors.Join(controller.ErrNotFound, errors.New(": user not found")))
+
+		c := controller.NewController()
+		c.RegisterUserService(userService)
+
+		rec := serve(c, func(req *http.Request) { req.Header.Set(echo.HeaderAuthorization, "Bearer "+accessToken) })
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFindShelfByID(t *testing.T) {
+	const ownerID, visitorID = int64(1729327188000000001), int64(1729327188000000002)
+	shareToken := "c2hhcmVkLXNoZWxmLXRva2Vu"
+
+	serve := func(t *testing.T, shelf model.Shelf, userID int64) *httptest.ResponseRecorder {
+		ctrl := gomock.NewController(t)
+		shelfService := mock.NewMockShelfService(ctrl)
+		shelfService.EXPECT().
+			FindByID(gomock.Any(), shelf.ID).
+			Times(1).
+			Return(&shelf, nil)
+
+		c := controller.NewController()
+		c.RegisterShelfService(shelfService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/shelves/"+strconv.FormatInt(shelf.ID, 10)+"/", nil)
+		rec := httptest.NewRecorder()
+		e := echo.New().NewContext(req, rec)
+		e.SetParamNames("id")
+		e.SetParamValues(strconv.FormatInt(shelf.ID, 10))
+		e.Set("userID", userID)
+		err := c.FindShelfByID(e)
+		assert.Nil(t, err)
+		return rec
+	}
+
+	t.Run("ok: own private shelf", func(t *testing.T) {
+		rec := serve(t, model.Shelf{ID: 1, UserID: ownerID, Privacy: model.ShelfPrivacyPrivate}, ownerID)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("error: private shelf of someone else", func(t *testing.T) {
+		rec := serve(t, model.Shelf{ID: 1, UserID: ownerID, Privacy: model.ShelfPrivacyPrivate}, visitorID)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("error: link shelf of someone else", func(t *testing.T) {
+		rec := serve(t, model.Shelf{ID: 1, UserID: ownerID, Privacy: model.ShelfPrivacyLink, ShareToken: &shareToken}, visitorID)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("ok: public shelf of someone else hides its token", func(t *testing.T) {
+		rec := serve(t, model.Shelf{ID: 1, UserID: ownerID, Privacy: model.ShelfPrivacyPublic, ShareToken: &shareToken}, visitorID)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), shareToken)
+	})
+}
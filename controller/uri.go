@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/rhtyx/bayarind-service.git/config"
+
+	"github.com/labstack/echo/v4"
+)
+
+// absoluteURL is the absolute URL of path on the configured public URL,
+// or on the host e was sent to when none is set. OPDS feeds and linked
+// data use them as ids.
+func absoluteURL(e echo.Context, path string) string {
+	if base := config.PublicURL(); base != "" {
+		return base + path
+	}
+
+	return e.Scheme() + "://" + e.Request().Host + path
+}
+
+// bookURI is the stable URI identifying a book in linked data.
+func bookURI(e echo.Context, bookID int64) string {
+	return absoluteURL(e, fmt.Sprintf("/api/v1/books/%d/", bookID))
+}
+
+// authorURI is the stable URI identifying an author in linked data.
+func authorURI(e echo.Context, authorID int64) string {
+	return absoluteURL(e, fmt.Sprintf("/api/v1/authors/%d/", authorID))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/rhtyx/bayarind-service.git/dto"
 	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/patch"
 	"github.com/rhtyx/bayarind-service.git/utils"
 
 	"github.com/go-playground/validator/v10"
@@ -99,6 +100,57 @@ func (c Controller) UpdateUser(e echo.Context) error {
 	return e.JSON(http.StatusOK, user)
 }
 
+func (c Controller) PatchUser(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	userID, ok := e.Get("userID").(int64)
+	if !ok {
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	currUser, err := c.userService.FindByID(ctx, userID)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	current := &dto.UserRequest{
+		Username: currUser.Username,
+	}
+	body := &dto.UserRequest{}
+	err = decodePatch(e, current, body)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return parseError(e, err)
+	}
+
+	fields, columns := patch.Changed(current, body)
+	if len(fields) == 0 {
+		return e.JSON(http.StatusOK, currUser)
+	}
+
+	validate := validator.New()
+	err = validate.StructPartial(body, fields...)
+	if err != nil {
+		logger.WithField("userID", userID).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	user := &model.User{
+		ID:       userID,
+		Username: body.Username,
+		Password: body.Password,
+	}
+	user, err = c.userService.Patch(ctx, user, columns)
+	if err != nil {
+		logger.WithField("columns", columns).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, user)
+}
+
 func (c Controller) DeleteUser(e echo.Context) error {
 	ctx := e.Request().Context()
 	logger := logrus.WithContext(ctx)
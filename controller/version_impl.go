@@ -0,0 +1,344 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) FindBookVersions(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	versions, err := c.bookService.FindVersions(ctx, bookID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, versions)
+}
+
+func (c Controller) FindBookVersion(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	version, err := strconv.ParseInt(e.Param("version"), 10, 64)
+	if err != nil {
+		logger.WithField("version", e.Param("version")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param version", ErrBadRequest.Error()))
+	}
+
+	bookVersion, err := c.bookService.FindVersion(ctx, bookID, version)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, bookVersion)
+}
+
+// DiffBookVersions compares the versions given by from and to. to defaults
+// to the current version.
+func (c Controller) DiffBookVersions(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	from, err := strconv.ParseInt(e.QueryParam("from"), 10, 64)
+	if err != nil {
+		logger.WithField("from", e.QueryParam("from")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query from", ErrBadRequest.Error()))
+	}
+
+	var to int64
+	if e.QueryParam("to") != "" {
+		to, err = strconv.ParseInt(e.QueryParam("to"), 10, 64)
+		if err != nil {
+			logger.WithField("to", e.QueryParam("to")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query to", ErrBadRequest.Error()))
+		}
+	} else {
+		book, err := c.bookService.FindByID(ctx, bookID)
+		if err != nil {
+			logger.WithField("bookID", bookID).Error(err)
+			return parseError(e, err)
+		}
+
+		to = book.Version
+	}
+
+	diff, err := c.bookService.DiffVersions(ctx, bookID, from, to)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, diff)
+}
+
+// RestoreBookVersion writes a past version of a book back as a new version,
+// through the same validation as PUT.
+func (c Controller) RestoreBookVersion(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	bookID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("bookID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	restoreVersion, err := strconv.ParseInt(e.Param("version"), 10, 64)
+	if err != nil {
+		logger.WithField("version", e.Param("version")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param version", ErrBadRequest.Error()))
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	currBook, err := c.bookService.FindByID(ctx, bookID)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		version = currBook.Version
+	}
+
+	bookVersion, err := c.bookService.FindVersion(ctx, bookID, restoreVersion)
+	if err != nil {
+		logger.WithField("bookID", bookID).Error(err)
+		return parseError(e, err)
+	}
+
+	body := &dto.BookRequest{
+		ISBN:         bookVersion.ISBN,
+		Title:        bookVersion.Title,
+		Subtitle:     bookVersion.Subtitle,
+		AuthorID:     bookVersion.AuthorID,
+		Translations: bookTranslationRequests(bookVersion.Translations),
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	book := &model.Book{
+		ID:           bookID,
+		ISBN:         body.ISBN,
+		Title:        body.Title,
+		Subtitle:     body.Subtitle,
+		AuthorID:     body.AuthorID,
+		Translations: bookTranslations(body.Translations),
+		Version:      version,
+	}
+	book, err = c.bookService.Update(ctx, book)
+	if err != nil {
+		logger.WithField("book", utils.Dump(book)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, book.Version, bookRating(book))
+	return e.JSON(http.StatusOK, book)
+}
+
+func (c Controller) FindAuthorVersions(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	authorID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("authorID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	versions, err := c.authorService.FindVersions(ctx, authorID)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, versions)
+}
+
+func (c Controller) FindAuthorVersion(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	authorID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("authorID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	version, err := strconv.ParseInt(e.Param("version"), 10, 64)
+	if err != nil {
+		logger.WithField("version", e.Param("version")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param version", ErrBadRequest.Error()))
+	}
+
+	authorVersion, err := c.authorService.FindVersion(ctx, authorID, version)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, authorVersion)
+}
+
+// DiffAuthorVersions compares the versions given by from and to. to
+// defaults to the current version.
+func (c Controller) DiffAuthorVersions(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	authorID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("authorID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	from, err := strconv.ParseInt(e.QueryParam("from"), 10, 64)
+	if err != nil {
+		logger.WithField("from", e.QueryParam("from")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query from", ErrBadRequest.Error()))
+	}
+
+	var to int64
+	if e.QueryParam("to") != "" {
+		to, err = strconv.ParseInt(e.QueryParam("to"), 10, 64)
+		if err != nil {
+			logger.WithField("to", e.QueryParam("to")).Error(err)
+			return e.JSON(http.StatusBadRequest, fmt.Sprintf("%s: invalid query to", ErrBadRequest.Error()))
+		}
+	} else {
+		author, err := c.authorService.FindByID(ctx, authorID)
+		if err != nil {
+			logger.WithField("authorID", authorID).Error(err)
+			return parseError(e, err)
+		}
+
+		to = author.Version
+	}
+
+	diff, err := c.authorService.DiffVersions(ctx, authorID, from, to)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, diff)
+}
+
+// RestoreAuthorVersion writes a past version of an author back as a new
+// version, through the same validation as PUT.
+func (c Controller) RestoreAuthorVersion(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	authorID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("authorID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	restoreVersion, err := strconv.ParseInt(e.Param("version"), 10, 64)
+	if err != nil {
+		logger.WithField("version", e.Param("version")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param version", ErrBadRequest.Error()))
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	currAuthor, err := c.authorService.FindByID(ctx, authorID)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		version = currAuthor.Version
+	}
+
+	authorVersion, err := c.authorService.FindVersion(ctx, authorID, restoreVersion)
+	if err != nil {
+		logger.WithField("authorID", authorID).Error(err)
+		return parseError(e, err)
+	}
+
+	// Aliases are not versioned, so the current ones are kept.
+	restored := *currAuthor
+	restored.Name = authorVersion.Name
+	restored.BirthDate = authorVersion.BirthDate
+	restored.DeathDate = authorVersion.DeathDate
+	restored.Nationality = authorVersion.Nationality
+	restored.Biography = authorVersion.Biography
+	restored.Website = authorVersion.Website
+	restored.VIAF = authorVersion.VIAF
+	restored.ISNI = authorVersion.ISNI
+	restored.WikidataID = authorVersion.WikidataID
+	restored.Translations = authorVersion.Translations
+	body := newAuthorRequest(&restored)
+
+	validate := dto.NewValidator()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	author, err := newAuthor(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusInternalServerError, ErrInternalServer.Error())
+	}
+
+	author.ID = authorID
+	author.Version = version
+	author, err = c.authorService.Update(ctx, author)
+	if err != nil {
+		logger.WithField("author", utils.Dump(author)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, author.Version)
+	return e.JSON(http.StatusOK, author)
+}
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (c Controller) CreateWork(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	body := &dto.WorkRequest{}
+	err := json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	work := &model.Work{
+		Title:    body.Title,
+		AuthorID: body.AuthorID,
+	}
+	work, err = c.workService.Create(ctx, work)
+	if err != nil {
+		logger.WithField("work", utils.Dump(work)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, work.Version)
+	return e.JSON(http.StatusCreated, work)
+}
+
+func (c Controller) FindWorkByID(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	workID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("workID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	work, err := c.workService.FindByID(ctx, workID)
+	if err != nil {
+		logger.WithField("workID", workID).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, work.Version)
+	if notModified(e, work.Version) {
+		return e.NoContent(http.StatusNotModified)
+	}
+
+	return e.JSON(http.StatusOK, work)
+}
+
+func (c Controller) FindAllWorks(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	works, err := c.workService.FindAll(ctx)
+	if err != nil {
+		logger.Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, works)
+}
+
+func (c Controller) FindWorkEditions(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	workID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("workID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	editions, err := c.workService.FindEditions(ctx, workID)
+	if err != nil {
+		logger.WithField("workID", workID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, editions)
+}
+
+func (c Controller) UpdateWork(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	workID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("workID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	version, matchAny, err := ifMatchVersion(e)
+	if err != nil {
+		logger.WithField("workID", workID).Error(err)
+		return parseError(e, err)
+	}
+
+	if matchAny {
+		currWork, err := c.workService.FindByID(ctx, workID)
+		if err != nil {
+			logger.WithField("workID", workID).Error(err)
+			return parseError(e, err)
+		}
+
+		version = currWork.Version
+	}
+
+	body := &dto.WorkRequest{}
+	err = json.NewDecoder(e.Request().Body).Decode(body)
+	if err != nil {
+		logger.Error(err)
+		return e.JSON(http.StatusBadRequest, ErrBadRequest.Error())
+	}
+
+	validate := validator.New()
+	err = validate.Struct(body)
+	if err != nil {
+		logger.WithField("body", utils.Dump(body)).Error(err)
+		return e.JSON(http.StatusBadRequest, utils.ParseValidationError(err))
+	}
+
+	work := &model.Work{
+		ID:       workID,
+		Title:    body.Title,
+		AuthorID: body.AuthorID,
+		Version:  version,
+	}
+	work, err = c.workService.Update(ctx, work)
+	if err != nil {
+		logger.WithField("work", utils.Dump(work)).Error(err)
+		return parseError(e, err)
+	}
+
+	setETag(e, work.Version)
+	return e.JSON(http.StatusOK, work)
+}
+
+func (c Controller) DeleteWork(e echo.Context) error {
+	ctx := e.Request().Context()
+	logger := logrus.WithContext(ctx)
+
+	workID, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil {
+		logger.WithField("workID", e.Param("id")).Error(err)
+		return e.JSON(http.StatusBadRequest, fmt.Errorf("%s: invalid param id", ErrBadRequest.Error()))
+	}
+
+	err = c.workService.Delete(ctx, workID)
+	if err != nil {
+		logger.WithField("workID", workID).Error(err)
+		return parseError(e, err)
+	}
+
+	return e.JSON(http.StatusOK, "Work deleted")
+}
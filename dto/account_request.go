@@ -0,0 +1,15 @@
+package dto
+
+type ChargeRequest struct {
+	UserID int64  `json:"user_id" validate:"required"`
+	Reason string `json:"reason" validate:"required,oneof=lost damage other"`
+	Amount int64  `json:"amount" validate:"omitempty,gt=0"`
+	LoanID *int64 `json:"loan_id"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+type CreditRequest struct {
+	UserID int64  `json:"user_id" validate:"required"`
+	Amount int64  `json:"amount" validate:"required,gt=0"`
+	Note   string `json:"note" validate:"max=500"`
+}
@@ -1,6 +1,30 @@
 package dto
 
 type AuthorRequest struct {
-	Name      string `json:"name" validate:"required,min=1"`
-	BirthDate string `json:"birth_date" validate:"required"`
+	Name        string               `json:"name" validate:"required,min=1"`
+	BirthDate   string               `json:"birth_date" validate:"required,datetime=2006-01-02"`
+	DeathDate   string               `json:"death_date" validate:"omitempty,datetime=2006-01-02"`
+	Nationality string               `json:"nationality" validate:"omitempty,iso3166_1_alpha2"`
+	Biography   string               `json:"biography" validate:"max=20000"`
+	Website     string               `json:"website" validate:"omitempty,http_url"`
+	VIAF        string               `json:"viaf" validate:"omitempty,numeric,max=22"`
+	ISNI        string               `json:"isni" validate:"omitempty,isni"`
+	WikidataID  string               `json:"wikidata_id" validate:"omitempty,wikidata_qid"`
+	Aliases     []AuthorAliasRequest `json:"aliases" validate:"max=50,dive"`
+
+	Translations map[string]AuthorTranslationRequest `json:"translations" validate:"dive,keys,bcp47_language_tag,endkeys"`
+}
+
+// AuthorTranslationRequest holds the fields of an author in one locale.
+type AuthorTranslationRequest struct {
+	Biography string `json:"biography" validate:"max=20000"`
+}
+
+type AuthorAliasRequest struct {
+	Name string `json:"name" validate:"required,max=200"`
+	Kind string `json:"kind" validate:"omitempty,oneof=alternate pseudonym"`
+}
+
+type MergeAuthorsRequest struct {
+	AuthorIDs []int64 `json:"author_ids" validate:"required,min=1,dive,required"`
 }
@@ -0,0 +1,5 @@
+package dto
+
+type BookLookupRequest struct {
+	ISBN string `json:"isbn" validate:"required,isbn"`
+}
@@ -1,7 +1,16 @@
 package dto
 
 type BookRequest struct {
-	ISBN     string `json:"isbn" validate:"required,isbn"`
-	Title    string `json:"title" validate:"required,min=1"`
-	AuthorID int64  `json:"author_id" validate:"required,numeric"`
+	ISBN         string                            `json:"isbn" validate:"required,isbn"`
+	Title        string                            `json:"title" validate:"required,min=1"`
+	Subtitle     string                            `json:"subtitle"`
+	AuthorID     int64                             `json:"author_id" validate:"required,numeric"`
+	Translations map[string]BookTranslationRequest `json:"translations" validate:"dive,keys,bcp47_language_tag,endkeys"`
+}
+
+// BookTranslationRequest holds the fields of a book in one locale. Empty
+// fields fall back to the next locale.
+type BookTranslationRequest struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
 }
@@ -0,0 +1,5 @@
+package dto
+
+type CitationRequest struct {
+	BookIDs []int64 `json:"book_ids" validate:"required,min=1,max=100,dive,required"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type CopyRequest struct {
+	Barcode   string `json:"barcode" validate:"required,min=1"`
+	Branch    string `json:"branch" validate:"required,min=1"`
+	Condition string `json:"condition" validate:"omitempty,oneof=new good fair poor damaged"`
+}
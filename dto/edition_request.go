@@ -0,0 +1,11 @@
+package dto
+
+type EditionRequest struct {
+	WorkID          int64  `json:"work_id" validate:"required"`
+	PublisherID     *int64 `json:"publisher_id"`
+	ISBN            string `json:"isbn" validate:"omitempty,isbn"`
+	Format          string `json:"format" validate:"omitempty,oneof=hardcover paperback ebook audiobook other"`
+	Language        string `json:"language" validate:"omitempty,bcp47_language_tag"`
+	PublicationDate string `json:"publication_date" validate:"omitempty,datetime=2006-01-02"`
+	PageCount       int    `json:"page_count" validate:"min=0"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type ImportRow struct {
+	ISBN            string `json:"isbn" validate:"required,isbn"`
+	Title           string `json:"title" validate:"required,min=1"`
+	Subtitle        string `json:"subtitle"`
+	AuthorID        int64  `json:"author_id" validate:"required_without=AuthorName"`
+	AuthorName      string `json:"author_name" validate:"required_without=AuthorID"`
+	AuthorBirthDate string `json:"author_birth_date" validate:"omitempty,datetime=2006-01-02"`
+	PublisherName   string `json:"publisher_name"`
+	PublicationDate string `json:"publication_date" validate:"omitempty,datetime=2006-01-02"`
+	Language        string `json:"language" validate:"omitempty,bcp47_language_tag"`
+}
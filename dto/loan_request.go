@@ -0,0 +1,10 @@
+package dto
+
+type CheckoutRequest struct {
+	Barcode string `json:"barcode" validate:"required,min=1"`
+	UserID  int64  `json:"user_id" validate:"required"`
+}
+
+type CheckinRequest struct {
+	Barcode string `json:"barcode" validate:"required,min=1"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type PublisherRequest struct {
+	Name    string `json:"name" validate:"required,min=1"`
+	Website string `json:"website" validate:"omitempty,url"`
+}
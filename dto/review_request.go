@@ -0,0 +1,10 @@
+package dto
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Body   string `json:"body" validate:"max=10000"`
+}
+
+type HideReviewRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
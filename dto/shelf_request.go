@@ -0,0 +1,17 @@
+package dto
+
+type ShelfRequest struct {
+	Name    string `json:"name" validate:"required,min=1,max=100"`
+	Privacy string `json:"privacy" validate:"omitempty,oneof=private public link"`
+}
+
+type ShelfEntryRequest struct {
+	BookID   int64  `json:"book_id" validate:"required"`
+	Position int    `json:"position" validate:"min=0"`
+	Note     string `json:"note" validate:"max=1000"`
+}
+
+type MoveShelfEntryRequest struct {
+	Position int    `json:"position" validate:"required,min=1"`
+	Note     string `json:"note" validate:"max=1000"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+type SubjectRequest struct {
+	Name         string                               `json:"name" validate:"required,min=1"`
+	ParentID     *int64                               `json:"parent_id"`
+	Translations map[string]SubjectTranslationRequest `json:"translations" validate:"dive,keys,bcp47_language_tag,endkeys"`
+}
+
+// SubjectTranslationRequest holds the name of a subject in one locale.
+type SubjectTranslationRequest struct {
+	Name string `json:"name"`
+}
+
+type BookSubjectsRequest struct {
+	SubjectIDs []int64 `json:"subject_ids" validate:"required"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type BookTagsRequest struct {
+	Tags []string `json:"tags" validate:"required,dive,required"`
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func validAuthorRequest() *dto.AuthorRequest {
+	return &dto.AuthorRequest{
+		Name:        "Pramoedya Ananta Toer",
+		BirthDate:   "1925-02-06",
+		DeathDate:   "2006-04-30",
+		Nationality: "ID",
+		Website:     "https://example.org/pram",
+		VIAF:        "54147484",
+		ISNI:        "0000000121032683",
+		WikidataID:  "Q312680",
+		Aliases: []dto.AuthorAliasRequest{
+			{Name: "Pram", Kind: "pseudonym"},
+		},
+	}
+}
+
+func failedFields(err error) []string {
+	fields := []string{}
+	for _, err := range err.(validator.ValidationErrors) {
+		fields = append(fields, err.Field())
+	}
+
+	return fields
+}
+
+func TestAuthorRequest(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		err := dto.NewValidator().Struct(validAuthorRequest())
+		assert.Nil(t, err)
+	})
+
+	t.Run("ok: isni with X check digit", func(t *testing.T) {
+		body := validAuthorRequest()
+		body.ISNI = "000000012146438X"
+
+		err := dto.NewValidator().Struct(body)
+		assert.Nil(t, err)
+	})
+
+	t.Run("ok: living author", func(t *testing.T) {
+		body := validAuthorRequest()
+		body.DeathDate = ""
+
+		err := dto.NewValidator().Struct(body)
+		assert.Nil(t, err)
+	})
+
+	t.Run("error: death date before birth date", func(t *testing.T) {
+		body := validAuthorRequest()
+		body.DeathDate = "1920-01-01"
+
+		err := dto.NewValidator().Struct(body)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"DeathDate"}, failedFields(err))
+	})
+
+	t.Run("error: death date in the future", func(t *testing.T) {
+		body := validAuthorRequest()
+		body.DeathDate = time.Now().AddDate(1, 0, 0).Format(time.DateOnly)
+
+		err := dto.NewValidator().Struct(body)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"DeathDate"}, failedFields(err))
+	})
+
+	t.Run("error: identifiers", func(t *testing.T) {
+		body := validAuthorRequest()
+		body.ISNI = "0000000121032684"
+		body.WikidataID = "Q0123"
+		body.VIAF = "v54147484"
+		body.Nationality = "IDN"
+
+		err := dto.NewValidator().Struct(body)
+		assert.Error(t, err)
+		assert.ElementsMatch(t, []string{"ISNI", "WikidataID", "VIAF", "Nationality"}, failedFields(err))
+	})
+
+	t.Run("error: alias kind", func(t *testing.T) {
+		body := validAuthorRequest()
+		body.Aliases = []dto.AuthorAliasRequest{{Name: "Pram", Kind: "nickname"}}
+
+		err := dto.NewValidator().Struct(body)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"Kind"}, failedFields(err))
+	})
+}
@@ -4,4 +4,8 @@ type TokenResponse struct {
 	RefreshToken  string `json:"refresh_token"`
 	AccessToken   string `json:"access_token"`
 	HMACSecretKey string `json:"hmac_secret_key"`
+
+	// AccountBlocked flags a login whose account owes more than the block
+	// threshold, so clients can warn before the reader tries to borrow.
+	AccountBlocked bool `json:"account_blocked,omitempty"`
 }
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var wikidataQIDPattern = regexp.MustCompile(`^Q[1-9][0-9]*$`)
+
+// NewValidator returns a validator that also knows the tags and struct
+// rules the request DTOs declare beyond the built-in ones.
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterValidation("isni", isISNI)
+	validate.RegisterValidation("wikidata_qid", isWikidataQID)
+	validate.RegisterStructValidation(validateAuthorRequest, AuthorRequest{})
+
+	return validate
+}
+
+// isISNI checks a 16 character ISNI whose last character is its ISO 7064
+// MOD 11-2 check digit, X standing for 10.
+func isISNI(fl validator.FieldLevel) bool {
+	isni := fl.Field().String()
+	if len(isni) != 16 {
+		return false
+	}
+
+	sum := 0
+	for _, r := range isni[:15] {
+		if r < '0' || r > '9' {
+			return false
+		}
+
+		sum = (sum + int(r-'0')) * 2
+	}
+
+	check := (12 - sum%11) % 11
+	if check == 10 {
+		return isni[15] == 'X'
+	}
+
+	return int(isni[15]-'0') == check
+}
+
+func isWikidataQID(fl validator.FieldLevel) bool {
+	return wikidataQIDPattern.MatchString(fl.Field().String())
+}
+
+// validateAuthorRequest checks that an author died after being born and
+// not in the future. Malformed dates are left to their field tags.
+func validateAuthorRequest(sl validator.StructLevel) {
+	body := sl.Current().Interface().(AuthorRequest)
+	if body.DeathDate == "" {
+		return
+	}
+
+	deathDate, err := time.Parse(time.DateOnly, body.DeathDate)
+	if err != nil {
+		return
+	}
+
+	if deathDate.After(time.Now()) {
+		sl.ReportError(body.DeathDate, "DeathDate", "DeathDate", "notfuture", "")
+		return
+	}
+
+	birthDate, err := time.Parse(time.DateOnly, body.BirthDate)
+	if err == nil && !deathDate.After(birthDate) {
+		sl.ReportError(body.DeathDate, "DeathDate", "DeathDate", "gtfield", "BirthDate")
+	}
+}
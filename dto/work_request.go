@@ -0,0 +1,6 @@
+package dto
+
+type WorkRequest struct {
+	Title    string `json:"title" validate:"required,min=1"`
+	AuthorID int64  `json:"author_id" validate:"required"`
+}
@@ -0,0 +1,158 @@
+// Package dublincore describes books as simple Dublin Core records in the
+// oai_dc XML format of the OAI-PMH.
+package dublincore
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/names"
+)
+
+const (
+	nsOAIDC     = "http://www.openarchives.org/OAI/2.0/oai_dc/"
+	nsDC        = "http://purl.org/dc/elements/1.1/"
+	nsXSI       = "http://www.w3.org/2001/XMLSchema-instance"
+	oaiDCSchema = nsOAIDC + " http://www.openarchives.org/OAI/2.0/oai_dc.xsd"
+)
+
+// MIMEOAIDC asks for an oai_dc record rather than any XML. It is not a
+// registered type; records are served as application/xml.
+const MIMEOAIDC = "application/oai_dc+xml"
+
+// dateLayout is the W3CDTF date OAI-DC recommends for dc:date.
+const dateLayout = "2006-01-02"
+
+// Record holds the fifteen Dublin Core elements, each of which may repeat
+// or be left out. Elements are written in the order the oai_dc schema
+// lists them.
+type Record struct {
+	Title       []string
+	Creator     []string
+	Subject     []string
+	Description []string
+	Publisher   []string
+	Contributor []string
+	Date        []string
+	Type        []string
+	Format      []string
+	Identifier  []string
+	Source      []string
+	Language    []string
+	Relation    []string
+	Coverage    []string
+	Rights      []string
+}
+
+// FromCitation describes the book of citation, identified by the URI id
+// and by its ISBN as a URN. The title and subtitle share one title, and
+// the author is written "Last, First" as catalogues sort them.
+func FromCitation(id string, citation *model.Citation) Record {
+	book := citation.Book
+	record := Record{
+		Title:      []string{book.Title},
+		Type:       []string{"Text"},
+		Identifier: []string{id},
+	}
+
+	if book.Subtitle != "" {
+		record.Title[0] += ": " + book.Subtitle
+	}
+
+	if book.ISBN != "" {
+		record.Identifier = append(record.Identifier, "urn:isbn:"+book.ISBN)
+	}
+
+	if citation.Author != nil {
+		record.Creator = []string{names.Parse(citation.Author.Name).Inverted()}
+	}
+
+	if citation.Publisher != nil {
+		record.Publisher = []string{citation.Publisher.Name}
+	}
+
+	if edition := citation.Edition; edition != nil {
+		if edition.PublicationDate != nil {
+			record.Date = []string{edition.PublicationDate.Format(dateLayout)}
+		}
+
+		if edition.Language != "" {
+			record.Language = []string{edition.Language}
+		}
+	}
+
+	return record
+}
+
+// Write writes record as an oai_dc:dc document.
+func Write(w io.Writer, record Record) error {
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	root := xml.StartElement{
+		Name: xml.Name{Local: "oai_dc:dc"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns:oai_dc"}, Value: nsOAIDC},
+			{Name: xml.Name{Local: "xmlns:dc"}, Value: nsDC},
+			{Name: xml.Name{Local: "xmlns:xsi"}, Value: nsXSI},
+			{Name: xml.Name{Local: "xsi:schemaLocation"}, Value: oaiDCSchema},
+		},
+	}
+	err = encoder.EncodeToken(root)
+	if err != nil {
+		return err
+	}
+
+	for _, element := range record.elements() {
+		for _, value := range element.values {
+			err = encoder.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: "dc:" + element.name}})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = encoder.EncodeToken(root.End())
+	if err != nil {
+		return err
+	}
+
+	err = encoder.Flush()
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+type element struct {
+	name   string
+	values []string
+}
+
+func (r Record) elements() []element {
+	return []element{
+		{"title", r.Title},
+		{"creator", r.Creator},
+		{"subject", r.Subject},
+		{"description", r.Description},
+		{"publisher", r.Publisher},
+		{"contributor", r.Contributor},
+		{"date", r.Date},
+		{"type", r.Type},
+		{"format", r.Format},
+		{"identifier", r.Identifier},
+		{"source", r.Source},
+		{"language", r.Language},
+		{"relation", r.Relation},
+		{"coverage", r.Coverage},
+		{"rights", r.Rights},
+	}
+}
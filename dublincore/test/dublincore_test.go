@@ -0,0 +1,73 @@
+package test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/dublincore"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCitation() *model.Citation {
+	published := time.Date(1980, time.August, 25, 0, 0, 0, 0, time.UTC)
+	return &model.Citation{
+		Book:      &model.Book{ID: 1, Title: "Bumi Manusia", Subtitle: "Sebuah Roman", ISBN: "9789799731234"},
+		Author:    &model.Author{ID: 2, Name: "Pramoedya Ananta Toer"},
+		Edition:   &model.Edition{Language: "id", PublicationDate: &published},
+		Publisher: &model.Publisher{Name: "Hasta Mitra"},
+	}
+}
+
+func TestFromCitation(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		record := dublincore.FromCitation("https://library.example/api/v1/books/1/", newCitation())
+		assert.Equal(t, []string{"Bumi Manusia: Sebuah Roman"}, record.Title)
+		assert.Equal(t, []string{"Toer, Pramoedya Ananta"}, record.Creator)
+		assert.Equal(t, []string{"Hasta Mitra"}, record.Publisher)
+		assert.Equal(t, []string{"1980-08-25"}, record.Date)
+		assert.Equal(t, []string{"Text"}, record.Type)
+		assert.Equal(t, []string{"https://library.example/api/v1/books/1/", "urn:isbn:9789799731234"}, record.Identifier)
+		assert.Equal(t, []string{"id"}, record.Language)
+	})
+
+	t.Run("ok: without edition or author", func(t *testing.T) {
+		record := dublincore.FromCitation("urn:test", &model.Citation{Book: &model.Book{Title: "Untitled"}})
+		assert.Equal(t, []string{"Untitled"}, record.Title)
+		assert.Equal(t, []string{"urn:test"}, record.Identifier)
+		assert.Empty(t, record.Creator)
+		assert.Empty(t, record.Date)
+		assert.Empty(t, record.Language)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	buf := &bytes.Buffer{}
+	record := dublincore.FromCitation("https://library.example/api/v1/books/1/", newCitation())
+	record.Subject = []string{"Fiction & History"}
+	err := dublincore.Write(buf, record)
+	assert.Nil(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, `<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/"`)
+	assert.Contains(t, out, "<dc:title>Bumi Manusia: Sebuah Roman</dc:title>")
+	assert.Contains(t, out, "<dc:subject>Fiction &amp; History</dc:subject>")
+	assert.Contains(t, out, "<dc:identifier>urn:isbn:9789799731234</dc:identifier>")
+	assert.Less(t, bytes.Index(buf.Bytes(), []byte("dc:title")), bytes.Index(buf.Bytes(), []byte("dc:creator")))
+	assert.Less(t, bytes.Index(buf.Bytes(), []byte("dc:date")), bytes.Index(buf.Bytes(), []byte("dc:type")))
+
+	// The document is namespace-aware XML with every element under dc.
+	doc := struct {
+		XMLName xml.Name
+		Titles  []string `xml:"http://purl.org/dc/elements/1.1/ title"`
+		Creator string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	}{}
+	err = xml.Unmarshal(buf.Bytes(), &doc)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://www.openarchives.org/OAI/2.0/oai_dc/", doc.XMLName.Space)
+	assert.Equal(t, "dc", doc.XMLName.Local)
+	assert.Equal(t, []string{"Bumi Manusia: Sebuah Roman"}, doc.Titles)
+	assert.Equal(t, "Toer, Pramoedya Ananta", doc.Creator)
+}
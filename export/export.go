@@ -0,0 +1,186 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/marc"
+	"github.com/rhtyx/bayarind-service.git/model"
+)
+
+const (
+	FormatCSV     = "csv"
+	FormatNDJSON  = "ndjson"
+	FormatMARC    = "marc"
+	FormatMARCXML = "marcxml"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+var (
+	BookHeader   = []string{"id", "isbn", "title", "author_id", "version", "created_at", "updated_at"}
+	AuthorHeader = []string{"id", "name", "birth_date", "version", "created_at", "updated_at"}
+)
+
+// Writer encodes records one at a time so that exports never hold more
+// than a single row in memory. CSV output uses the flat row, NDJSON output
+// uses the record's own json encoding and MARC output takes a
+// *marc.Record.
+type Writer struct {
+	format  string
+	buf     *bufio.Writer
+	csv     *csv.Writer
+	encoder *json.Encoder
+	marc    *marc.Writer
+	marcXML *marc.XMLWriter
+}
+
+func NewWriter(w io.Writer, format string, header []string) (*Writer, error) {
+	buf := bufio.NewWriter(w)
+	writer := &Writer{
+		format: format,
+		buf:    buf,
+	}
+
+	switch format {
+	case FormatCSV:
+		writer.csv = csv.NewWriter(buf)
+		err := writer.csv.Write(header)
+		if err != nil {
+			return nil, err
+		}
+	case FormatNDJSON:
+		writer.encoder = json.NewEncoder(buf)
+	case FormatMARC:
+		writer.marc = marc.NewWriter(buf)
+	case FormatMARCXML:
+		writer.marcXML = marc.NewXMLWriter(buf)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	return writer, nil
+}
+
+func (w *Writer) Write(record interface{}, row []string) error {
+	if w.csv != nil {
+		return w.csv.Write(row)
+	}
+
+	if w.marc != nil || w.marcXML != nil {
+		marcRecord, ok := record.(*marc.Record)
+		if !ok {
+			return fmt.Errorf("%w: %T is not a marc record", ErrUnsupportedFormat, record)
+		}
+
+		if w.marc != nil {
+			return w.marc.Write(marcRecord)
+		}
+
+		return w.marcXML.Write(marcRecord)
+	}
+
+	return w.encoder.Encode(record)
+}
+
+func (w *Writer) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		err := w.csv.Error()
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.buf.Flush()
+}
+
+// Close ends the document, which only MARCXML needs, and flushes it.
+func (w *Writer) Close() error {
+	if w.marcXML != nil {
+		err := w.marcXML.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// IsMARC tells whether format writes MARC records rather than rows.
+func IsMARC(format string) bool {
+	return format == FormatMARC || format == FormatMARCXML
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatMARC:
+		return marc.MIMEMARC
+	case FormatMARCXML:
+		return marc.MIMEMARCXML + "; charset=utf-8"
+	default:
+		return "application/x-ndjson"
+	}
+}
+
+// Extension is the file name extension of an export in format.
+func Extension(format string) string {
+	switch format {
+	case FormatMARC:
+		return "mrc"
+	case FormatMARCXML:
+		return "xml"
+	default:
+		return format
+	}
+}
+
+// BookRecord is the MARC record of the book of citation, with its
+// publication statement and language taken from the edition.
+func BookRecord(citation *model.Citation) *marc.Record {
+	return marc.FromEntry(&marc.Entry{
+		Book:      citation.Book,
+		Author:    citation.Author,
+		Edition:   citation.Edition,
+		Publisher: citation.Publisher,
+	})
+}
+
+func BookRow(book *model.Book) []string {
+	return []string{
+		strconv.FormatInt(book.ID, 10),
+		book.ISBN,
+		book.Title,
+		strconv.FormatInt(book.AuthorID, 10),
+		strconv.FormatInt(book.Version, 10),
+		book.CreatedAt.Format(time.RFC3339),
+		formatTime(book.UpdatedAt),
+	}
+}
+
+func AuthorRow(author *model.Author) []string {
+	return []string{
+		strconv.FormatInt(author.ID, 10),
+		author.Name,
+		author.BirthDate.Format(time.DateOnly),
+		strconv.FormatInt(author.Version, 10),
+		author.CreatedAt.Format(time.RFC3339),
+		formatTime(author.UpdatedAt),
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
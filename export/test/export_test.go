@@ -0,0 +1,99 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/export"
+	"github.com/rhtyx/bayarind-service.git/marc"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	createdAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	book := &model.Book{
+		ID:        1729327188000000001,
+		ISBN:      "9789295055025",
+		Title:     "Title, with comma",
+		AuthorID:  1729327188000000002,
+		Version:   1,
+		CreatedAt: createdAt,
+	}
+
+	t.Run("ok: csv", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		writer, err := export.NewWriter(buf, export.FormatCSV, export.BookHeader)
+		assert.Nil(t, err)
+
+		err = writer.Write(book, export.BookRow(book))
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Flush())
+		assert.Equal(t,
+			"id,isbn,title,author_id,version,created_at,updated_at\n"+
+				"1729327188000000001,9789295055025,\"Title, with comma\",1729327188000000002,1,2026-10-19T09:00:00Z,\n",
+			buf.String())
+	})
+
+	t.Run("ok: ndjson", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		writer, err := export.NewWriter(buf, export.FormatNDJSON, export.BookHeader)
+		assert.Nil(t, err)
+
+		err = writer.Write(book, export.BookRow(book))
+		assert.Nil(t, err)
+		err = writer.Write(book, export.BookRow(book))
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Flush())
+
+		lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+		assert.Len(t, lines, 2)
+		assert.Contains(t, string(lines[0]), `"id":1729327188000000001`)
+	})
+
+	t.Run("ok: marcxml", func(t *testing.T) {
+		published := time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+		citation := &model.Citation{
+			Book:      book,
+			Author:    &model.Author{ID: book.AuthorID, Name: "Pramoedya Ananta Toer"},
+			Edition:   &model.Edition{Language: "id", PublicationDate: &published},
+			Publisher: &model.Publisher{Name: "Hasta Mitra"},
+		}
+
+		buf := &bytes.Buffer{}
+		writer, err := export.NewWriter(buf, export.FormatMARCXML, export.BookHeader)
+		assert.Nil(t, err)
+
+		err = writer.Write(export.BookRecord(citation), export.BookRow(book))
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Close())
+
+		reader := marc.NewXMLReader(buf)
+		record, err := reader.Read()
+		assert.Nil(t, err)
+		assert.Equal(t, "Title, with comma", record.Field("245").Subfield('a'))
+		assert.Equal(t, "Toer, Pramoedya Ananta", record.Field("100").Subfield('a'))
+		assert.Contains(t, record.Field("264").Subfield('b'), "Hasta Mitra")
+		assert.Contains(t, record.Field("264").Subfield('c'), "1980")
+		assert.Equal(t, "ind", record.Control("008")[35:38])
+		_, err = reader.Read()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("error: marc needs a record", func(t *testing.T) {
+		writer, err := export.NewWriter(&bytes.Buffer{}, export.FormatMARC, export.BookHeader)
+		assert.Nil(t, err)
+
+		err = writer.Write(book, export.BookRow(book))
+		assert.True(t, errors.Is(err, export.ErrUnsupportedFormat))
+	})
+
+	t.Run("error: unsupported format", func(t *testing.T) {
+		writer, err := export.NewWriter(&bytes.Buffer{}, "parquet", export.BookHeader)
+		assert.Nil(t, writer)
+		assert.True(t, errors.Is(err, export.ErrUnsupportedFormat))
+	})
+}
@@ -0,0 +1,72 @@
+// Package i18n picks the locales a response is served in.
+package i18n
+
+import (
+	"slices"
+
+	"golang.org/x/text/language"
+)
+
+// wildcard is the tag x/text gives the "*" range of an Accept-Language
+// header.
+var wildcard = language.MustParse("mul")
+
+// Canonical returns the canonical form of a BCP 47 language tag, such as
+// "en-US" for "EN_us", or "" when locale is not a valid tag.
+func Canonical(locale string) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return ""
+	}
+
+	return tag.String()
+}
+
+// ParseAcceptLanguage returns the canonical language tags of an
+// Accept-Language header, most preferred first. Wildcards, invalid ranges
+// and ranges with q=0 are dropped.
+func ParseAcceptLanguage(header string) []string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	locales := []string{}
+	for _, tag := range tags {
+		if tag == language.Und || tag == wildcard {
+			continue
+		}
+
+		locales = append(locales, tag.String())
+	}
+
+	return locales
+}
+
+// Chain lists the locales to look a translation up in: each of preferred
+// followed by its base language, then fallback. Invalid and repeated
+// locales are dropped, so "en-GB", "id" gives en-GB, en, id.
+func Chain(fallback string, preferred ...string) []string {
+	chain := []string{}
+	add := func(locale string) {
+		if locale != "" && !slices.Contains(chain, locale) {
+			chain = append(chain, locale)
+		}
+	}
+
+	for _, locale := range preferred {
+		tag, err := language.Parse(locale)
+		if err != nil || tag == language.Und {
+			continue
+		}
+
+		add(tag.String())
+		base, confidence := tag.Base()
+		if confidence != language.No {
+			add(base.String())
+		}
+	}
+
+	add(Canonical(fallback))
+	return chain
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/rhtyx/bayarind-service.git/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonical(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		assert.Equal(t, "en-US", i18n.Canonical("en-us"))
+		assert.Equal(t, "id", i18n.Canonical("ID"))
+	})
+
+	t.Run("error: invalid tag", func(t *testing.T) {
+		assert.Equal(t, "", i18n.Canonical("not a locale"))
+	})
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	t.Run("ok: ordered by quality", func(t *testing.T) {
+		res := i18n.ParseAcceptLanguage("en;q=0.5, id-ID, *;q=0.1, fr;q=0")
+		assert.Equal(t, []string{"id-ID", "en"}, res)
+	})
+
+	t.Run("ok: empty", func(t *testing.T) {
+		assert.Empty(t, i18n.ParseAcceptLanguage(""))
+	})
+}
+
+func TestChain(t *testing.T) {
+	t.Run("ok: base languages then fallback", func(t *testing.T) {
+		res := i18n.Chain("id", "en-GB", "id-ID", "en")
+		assert.Equal(t, []string{"en-GB", "en", "id-ID", "id"}, res)
+	})
+
+	t.Run("ok: invalid locales are dropped", func(t *testing.T) {
+		res := i18n.Chain("id", "??", "")
+		assert.Equal(t, []string{"id"}, res)
+	})
+}
@@ -0,0 +1,121 @@
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidLength    = errors.New("isbn must have 10 or 13 digits")
+	ErrInvalidCharacter = errors.New("isbn contains an invalid character")
+	ErrInvalidChecksum  = errors.New("isbn checksum does not match")
+	ErrInvalidPrefix    = errors.New("isbn-13 must start with 978 or 979")
+	ErrNoISBN10         = errors.New("isbn has no isbn-10 form")
+)
+
+// ISBN is a validated ISBN held in its canonical 13 digit form.
+type ISBN struct {
+	digits string
+}
+
+// Parse accepts an ISBN-10 or ISBN-13 with optional hyphens or spaces,
+// verifies its check digit and converts it to ISBN-13.
+func Parse(s string) (ISBN, error) {
+	compact := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(s)))
+
+	switch len(compact) {
+	case 10:
+		for i, r := range compact {
+			if (r < '0' || r > '9') && !(r == 'X' && i == 9) {
+				return ISBN{}, ErrInvalidCharacter
+			}
+		}
+
+		if checkDigit10(compact[:9]) != compact[9] {
+			return ISBN{}, ErrInvalidChecksum
+		}
+
+		body := "978" + compact[:9]
+		return ISBN{digits: body + string(checkDigit13(body))}, nil
+	case 13:
+		for _, r := range compact {
+			if r < '0' || r > '9' {
+				return ISBN{}, ErrInvalidCharacter
+			}
+		}
+
+		if !strings.HasPrefix(compact, "978") && !strings.HasPrefix(compact, "979") {
+			return ISBN{}, ErrInvalidPrefix
+		}
+
+		if checkDigit13(compact[:12]) != compact[12] {
+			return ISBN{}, ErrInvalidChecksum
+		}
+
+		return ISBN{digits: compact}, nil
+	default:
+		return ISBN{}, ErrInvalidLength
+	}
+}
+
+// Normalize returns the canonical ISBN-13 of s, which is how books are
+// stored and looked up.
+func Normalize(s string) (string, error) {
+	isbn, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+
+	return isbn.ISBN13(), nil
+}
+
+func Valid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+func (i ISBN) String() string {
+	return i.digits
+}
+
+func (i ISBN) ISBN13() string {
+	return i.digits
+}
+
+// ISBN10 returns the 10 digit form, which only exists for the 978 prefix.
+func (i ISBN) ISBN10() (string, error) {
+	if !strings.HasPrefix(i.digits, "978") {
+		return "", ErrNoISBN10
+	}
+
+	body := i.digits[3:12]
+	return body + string(checkDigit10(body)), nil
+}
+
+func checkDigit10(body string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += (10 - i) * int(body[i]-'0')
+	}
+
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 'X'
+	}
+
+	return byte('0' + check)
+}
+
+func checkDigit13(body string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+
+		sum += weight * int(body[i]-'0')
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
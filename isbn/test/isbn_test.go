@@ -0,0 +1,69 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/rhtyx/bayarind-service.git/isbn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("ok: every form of the same book", func(t *testing.T) {
+		for _, value := range []string{"978-0-306-40615-7", "9780306406157", "0-306-40615-2", "0306406152", " 978 0 306 40615 7 "} {
+			res, err := isbn.Parse(value)
+			assert.Nil(t, err, value)
+			assert.Equal(t, "9780306406157", res.ISBN13(), value)
+		}
+	})
+
+	t.Run("ok: isbn-10 with X check digit", func(t *testing.T) {
+		res, err := isbn.Normalize("0-8044-2957-x")
+		assert.Nil(t, err)
+		assert.Equal(t, "9780804429573", res)
+	})
+
+	t.Run("error: checksum", func(t *testing.T) {
+		_, err := isbn.Parse("9780306406158")
+		assert.ErrorIs(t, err, isbn.ErrInvalidChecksum)
+
+		_, err = isbn.Parse("0306406153")
+		assert.ErrorIs(t, err, isbn.ErrInvalidChecksum)
+	})
+
+	t.Run("error: length", func(t *testing.T) {
+		_, err := isbn.Parse("978030640615")
+		assert.ErrorIs(t, err, isbn.ErrInvalidLength)
+	})
+
+	t.Run("error: character", func(t *testing.T) {
+		_, err := isbn.Parse("030640615A")
+		assert.ErrorIs(t, err, isbn.ErrInvalidCharacter)
+
+		_, err = isbn.Parse("X306406152")
+		assert.ErrorIs(t, err, isbn.ErrInvalidCharacter)
+	})
+
+	t.Run("error: prefix", func(t *testing.T) {
+		_, err := isbn.Parse("9770306406156")
+		assert.ErrorIs(t, err, isbn.ErrInvalidPrefix)
+	})
+}
+
+func TestISBN10(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		res, err := isbn.Parse("978-0-306-40615-7")
+		assert.Nil(t, err)
+
+		isbn10, err := res.ISBN10()
+		assert.Nil(t, err)
+		assert.Equal(t, "0306406152", isbn10)
+	})
+
+	t.Run("error: 979 has no isbn-10", func(t *testing.T) {
+		res, err := isbn.Parse("9791032300824")
+		assert.Nil(t, err)
+
+		_, err = res.ISBN10()
+		assert.ErrorIs(t, err, isbn.ErrNoISBN10)
+	})
+}
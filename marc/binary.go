@@ -0,0 +1,206 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+var (
+	ErrMalformed = errors.New("marc record is malformed")
+	ErrMARC8     = errors.New("marc-8 encoded records are not supported")
+	ErrTooLong   = errors.New("marc record is too long")
+)
+
+// Reader reads binary MARC 21 records one at a time. A malformed record is
+// reported on its own: the next call to Read resumes at the record after
+// it.
+type Reader struct {
+	buf *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{buf: bufio.NewReader(r)}
+}
+
+// Read returns the next record, or io.EOF once there are none left.
+func (r *Reader) Read() (*Record, error) {
+	data, err := r.buf.ReadBytes(recordTerminator)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	// Records are often exported one per line.
+	data = bytes.TrimLeft(data, "\r\n")
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, io.EOF
+	}
+
+	if data[len(data)-1] != recordTerminator {
+		return nil, fmt.Errorf("%w: missing record terminator", ErrMalformed)
+	}
+
+	return parseRecord(data)
+}
+
+func parseRecord(data []byte) (*Record, error) {
+	if len(data) < leaderLength+1 {
+		return nil, fmt.Errorf("%w: shorter than its leader", ErrMalformed)
+	}
+
+	leader := string(data[:leaderLength])
+	if leader[9] != 'a' {
+		for _, b := range data {
+			if b >= utf8.RuneSelf {
+				return nil, ErrMARC8
+			}
+		}
+	}
+
+	base, err := strconv.Atoi(leader[12:17])
+	if err != nil || base <= leaderLength || base > len(data) {
+		return nil, fmt.Errorf("%w: invalid base address %q", ErrMalformed, leader[12:17])
+	}
+
+	directory := data[leaderLength : base-1]
+	if data[base-1] != fieldTerminator || len(directory)%directoryEntryLength != 0 {
+		return nil, fmt.Errorf("%w: invalid directory", ErrMalformed)
+	}
+
+	record := &Record{Leader: leader}
+	for i := 0; i < len(directory); i += directoryEntryLength {
+		entry := string(directory[i : i+directoryEntryLength])
+		tag := entry[:3]
+
+		length, lengthErr := strconv.Atoi(entry[3:7])
+		start, startErr := strconv.Atoi(entry[7:12])
+		if lengthErr != nil || startErr != nil || length < 1 || base+start+length > len(data) {
+			return nil, fmt.Errorf("%w: invalid directory entry %q", ErrMalformed, entry)
+		}
+
+		field := data[base+start : base+start+length-1]
+		if !utf8.Valid(field) {
+			return nil, fmt.Errorf("%w: field %s is not valid utf-8", ErrMalformed, tag)
+		}
+
+		if isControlTag(tag) {
+			record.AddControl(tag, string(field))
+			continue
+		}
+
+		if len(field) < 2 {
+			return nil, fmt.Errorf("%w: field %s has no indicators", ErrMalformed, tag)
+		}
+
+		dataField := DataField{Tag: tag, Ind1: field[0], Ind2: field[1]}
+		for _, subfield := range bytes.Split(field[2:], []byte{subfieldDelimiter}) {
+			if len(subfield) == 0 {
+				continue
+			}
+
+			dataField.Subfields = append(dataField.Subfields, Subfield{Code: subfield[0], Value: string(subfield[1:])})
+		}
+		record.DataFields = append(record.DataFields, dataField)
+	}
+
+	return record, nil
+}
+
+// Writer writes binary MARC 21 records, encoded in UTF-8.
+type Writer struct {
+	w io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (w *Writer) Write(record *Record) error {
+	data, err := Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.w.Write(data)
+	return err
+}
+
+// Marshal encodes record in ISO 2709, filling in the lengths, base address
+// and character coding of its leader.
+func Marshal(record *Record) ([]byte, error) {
+	directory := &bytes.Buffer{}
+	fields := &bytes.Buffer{}
+
+	addField := func(tag string, field []byte) error {
+		field = append(field, fieldTerminator)
+		if len(field) > 9999 {
+			return fmt.Errorf("%w: field %s", ErrTooLong, tag)
+		}
+
+		fmt.Fprintf(directory, "%3.3s%04d%05d", tag, len(field), fields.Len())
+		fields.Write(field)
+		return nil
+	}
+
+	for _, field := range record.ControlFields {
+		err := addField(field.Tag, []byte(field.Value))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, field := range record.DataFields {
+		data := []byte{indicator(field.Ind1), indicator(field.Ind2)}
+		for _, subfield := range field.Subfields {
+			data = append(data, subfieldDelimiter, subfield.Code)
+			data = append(data, subfield.Value...)
+		}
+
+		err := addField(field.Tag, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	base := leaderLength + directory.Len() + 1
+	length := base + fields.Len() + 1
+	if length > 99999 {
+		return nil, ErrTooLong
+	}
+
+	leader := []byte(normalLeader(record.Leader))
+	copy(leader[0:5], fmt.Sprintf("%05d", length))
+	leader[9] = 'a'
+	copy(leader[10:12], "22")
+	copy(leader[12:17], fmt.Sprintf("%05d", base))
+	copy(leader[20:24], "4500")
+
+	data := make([]byte, 0, length)
+	data = append(data, leader...)
+	data = append(data, directory.Bytes()...)
+	data = append(data, fieldTerminator)
+	data = append(data, fields.Bytes()...)
+	data = append(data, recordTerminator)
+	return data, nil
+}
+
+// normalLeader pads or cuts leader to its fixed length.
+func normalLeader(leader string) string {
+	if len(leader) >= leaderLength {
+		return leader[:leaderLength]
+	}
+
+	return leader + string(bytes.Repeat([]byte{' '}, leaderLength-len(leader)))
+}
+
+func indicator(b byte) byte {
+	if b == 0 {
+		return ' '
+	}
+
+	return b
+}
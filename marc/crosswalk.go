@@ -0,0 +1,281 @@
+package marc
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/isbn"
+	"github.com/rhtyx/bayarind-service.git/model"
+)
+
+// ErrNoTitle is returned for records without a 245 $a, which every book
+// needs.
+var ErrNoTitle = errors.New("marc record has no title in 245 $a")
+
+var (
+	lifeDates = regexp.MustCompile(`(\d{4})\??-(\d{4})?`)
+	year      = regexp.MustCompile(`\d{4}`)
+)
+
+// Entry is what a bibliographic record says about a book: the book itself,
+// its main author and the edition it describes. Author, Edition and
+// Publisher are nil when the record does not mention them.
+type Entry struct {
+	Book      *model.Book
+	Author    *model.Author
+	Edition   *model.Edition
+	Publisher *model.Publisher
+	Place     string
+}
+
+// ToEntry maps a record through the crosswalk:
+//
+//	020 $a      ISBN, the first that is valid
+//	100 $a $d   author, turned from "Surname, Given" around, and life dates
+//	245 $a $b   title and subtitle
+//	264 / 260   place ($a), publisher ($b) and date ($c) of publication
+//	008/35-37   language
+//
+// MARC only gives years, so birth, death and publication dates fall on
+// January 1 of theirs.
+func ToEntry(record *Record) (*Entry, error) {
+	titleField := record.Field("245")
+	if titleField == nil || trimPunctuation(titleField.Subfield('a')) == "" {
+		return nil, ErrNoTitle
+	}
+
+	entry := &Entry{
+		Book: &model.Book{
+			ISBN:     recordISBN(record),
+			Title:    trimPunctuation(titleField.Subfield('a')),
+			Subtitle: trimPunctuation(titleField.Subfield('b')),
+		},
+		Edition: &model.Edition{},
+	}
+	entry.Edition.ISBN = entry.Book.ISBN
+
+	if authorField := record.Field("100"); authorField != nil && trimPunctuation(authorField.Subfield('a')) != "" {
+		entry.Author = &model.Author{Name: trimPunctuation(authorField.Subfield('a'))}
+		if authorField.Ind1 == '1' {
+			entry.Author.Name = directOrder(entry.Author.Name)
+		}
+
+		if dates := lifeDates.FindStringSubmatch(authorField.Subfield('d')); dates != nil {
+			entry.Author.BirthDate = yearDate(dates[1])
+			if dates[2] != "" {
+				deathDate := yearDate(dates[2])
+				entry.Author.DeathDate = &deathDate
+			}
+		}
+	}
+
+	if publication := publicationField(record); publication != nil {
+		entry.Place = trimPunctuation(publication.Subfield('a'))
+		if name := trimPunctuation(publication.Subfield('b')); name != "" {
+			entry.Publisher = &model.Publisher{Name: name}
+		}
+
+		if published := year.FindString(publication.Subfield('c')); published != "" {
+			publicationDate := yearDate(published)
+			entry.Edition.PublicationDate = &publicationDate
+		}
+	}
+
+	if fixed := record.Control("008"); len(fixed) >= 38 {
+		entry.Edition.Language = languageTag(fixed[35:38])
+	}
+
+	return entry, nil
+}
+
+// FromEntry builds a record from entry, the reverse of ToEntry. Only Book
+// is required.
+func FromEntry(entry *Entry) *Record {
+	book := entry.Book
+	record := &Record{Leader: "00000nam a2200000 c 4500"}
+
+	if book.ID != 0 {
+		record.AddControl("001", strconv.FormatInt(book.ID, 10))
+	}
+
+	updated := book.CreatedAt
+	if book.UpdatedAt != nil {
+		updated = *book.UpdatedAt
+	}
+	if !updated.IsZero() {
+		record.AddControl("005", updated.UTC().Format("20060102150405.0"))
+	}
+
+	var published *time.Time
+	lang := ""
+	if entry.Edition != nil {
+		published = entry.Edition.PublicationDate
+		lang = entry.Edition.Language
+	}
+	record.AddControl("008", fixedField(book.CreatedAt, published, lang))
+
+	record.AddField("020", ' ', ' ', Subfield{Code: 'a', Value: book.ISBN})
+
+	if entry.Author != nil {
+		ind1 := byte('0')
+		name := entry.Author.Name
+		if inverted := invertedOrder(name); inverted != name {
+			ind1 = '1'
+			name = inverted
+		}
+
+		record.AddField("100", ind1, ' ',
+			Subfield{Code: 'a', Value: name},
+			Subfield{Code: 'd', Value: authorDates(entry.Author)},
+		)
+	}
+
+	titleInd1 := byte('0')
+	if entry.Author != nil {
+		titleInd1 = '1'
+	}
+	record.AddField("245", titleInd1, '0',
+		Subfield{Code: 'a', Value: book.Title},
+		Subfield{Code: 'b', Value: book.Subtitle},
+	)
+
+	publisher := ""
+	if entry.Publisher != nil {
+		publisher = entry.Publisher.Name
+	}
+	date := ""
+	if published != nil {
+		date = strconv.Itoa(published.Year())
+	}
+	record.AddField("264", ' ', '1',
+		Subfield{Code: 'a', Value: entry.Place},
+		Subfield{Code: 'b', Value: publisher},
+		Subfield{Code: 'c', Value: date},
+	)
+
+	return record
+}
+
+func recordISBN(record *Record) string {
+	first := ""
+	for _, field := range record.Fields("020") {
+		value := strings.Fields(field.Subfield('a'))
+		if len(value) == 0 {
+			continue
+		}
+
+		canonical, err := isbn.Normalize(value[0])
+		if err == nil {
+			return canonical
+		}
+
+		if first == "" {
+			first = value[0]
+		}
+	}
+
+	return first
+}
+
+// publicationField prefers the RDA 264 publication statement over the
+// older 260.
+func publicationField(record *Record) *DataField {
+	for _, field := range record.Fields("264") {
+		if field.Ind2 == '1' {
+			return &field
+		}
+	}
+
+	return record.Field("260")
+}
+
+// fixedField is the 40 character 008 of a book, leaving unknown positions
+// blank.
+func fixedField(created time.Time, published *time.Time, lang string) string {
+	fixed := []byte(strings.Repeat(" ", 40))
+	if created.IsZero() {
+		created = time.Now()
+	}
+	copy(fixed[0:6], created.UTC().Format("060102"))
+
+	if published != nil {
+		fixed[6] = 's'
+		copy(fixed[7:11], strconv.Itoa(published.Year()))
+	} else {
+		fixed[6] = 'n'
+		copy(fixed[7:11], "uuuu")
+	}
+
+	copy(fixed[15:18], "xx ")
+	if lang != "" {
+		copy(fixed[35:38], languageCode(lang))
+	} else {
+		copy(fixed[35:38], "und")
+	}
+	fixed[39] = 'd'
+
+	return string(fixed)
+}
+
+func authorDates(author *model.Author) string {
+	if author.BirthDate.IsZero() {
+		return ""
+	}
+
+	dates := strconv.Itoa(author.BirthDate.Year()) + "-"
+	if author.DeathDate != nil {
+		dates += strconv.Itoa(author.DeathDate.Year())
+	}
+
+	return dates
+}
+
+// directOrder turns "Tolkien, J. R. R." into "J. R. R. Tolkien".
+func directOrder(name string) string {
+	surname, given, ok := strings.Cut(name, ",")
+	if !ok || strings.TrimSpace(given) == "" {
+		return name
+	}
+
+	return strings.TrimSpace(given) + " " + strings.TrimSpace(surname)
+}
+
+// invertedOrder turns "J. R. R. Tolkien" into "Tolkien, J. R. R.", leaving
+// single names alone.
+func invertedOrder(name string) string {
+	name = strings.TrimSpace(name)
+	idx := strings.LastIndex(name, " ")
+	if idx < 0 || strings.Contains(name, ",") {
+		return name
+	}
+
+	return name[idx+1:] + ", " + name[:idx]
+}
+
+// trimPunctuation drops the ISBD punctuation cataloguers end subfields
+// with, keeping the full stop of a trailing initial such as "R.".
+func trimPunctuation(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimRight(value, " /:;=,")
+	value = strings.TrimSpace(value)
+
+	if strings.HasSuffix(value, ".") && !endsWithInitial(value) {
+		value = strings.TrimSpace(strings.TrimSuffix(value, "."))
+	}
+
+	return strings.Trim(value, "[]")
+}
+
+func endsWithInitial(value string) bool {
+	words := strings.Fields(value)
+	last := words[len(words)-1]
+	return len([]rune(last)) == 2
+}
+
+func yearDate(value string) time.Time {
+	y, _ := strconv.Atoi(value)
+	return time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
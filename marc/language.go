@@ -0,0 +1,69 @@
+package marc
+
+import "golang.org/x/text/language"
+
+// bibliographicCodes are the MARC language codes that differ from the ISO
+// 639-2 terminology codes, keyed by the latter.
+var bibliographicCodes = map[string]string{
+	"sqi": "alb",
+	"hye": "arm",
+	"eus": "baq",
+	"mya": "bur",
+	"zho": "chi",
+	"ces": "cze",
+	"nld": "dut",
+	"fra": "fre",
+	"kat": "geo",
+	"deu": "ger",
+	"ell": "gre",
+	"isl": "ice",
+	"mkd": "mac",
+	"mri": "mao",
+	"msa": "may",
+	"fas": "per",
+	"ron": "rum",
+	"slk": "slo",
+	"bod": "tib",
+	"cym": "wel",
+}
+
+var terminologyCodes = func() map[string]string {
+	codes := map[string]string{}
+	for terminology, bibliographic := range bibliographicCodes {
+		codes[bibliographic] = terminology
+	}
+
+	return codes
+}()
+
+// languageTag turns a MARC language code into a BCP 47 tag, or "" for
+// codes that name no single language.
+func languageTag(code string) string {
+	if terminology, ok := terminologyCodes[code]; ok {
+		code = terminology
+	}
+
+	base, err := language.ParseBase(code)
+	if err != nil || base.String() == "und" || base.String() == "mul" || base.String() == "zxx" {
+		return ""
+	}
+
+	return base.String()
+}
+
+// languageCode turns a BCP 47 tag into a MARC language code, "und" when it
+// has none.
+func languageCode(tag string) string {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return "und"
+	}
+
+	base, _ := parsed.Base()
+	code := base.ISO3()
+	if bibliographic, ok := bibliographicCodes[code]; ok {
+		return bibliographic
+	}
+
+	return code
+}
@@ -0,0 +1,113 @@
+// Package marc reads and writes MARC 21 bibliographic records, in the
+// binary ISO 2709 form and as MARCXML, and maps them onto books, authors
+// and editions.
+package marc
+
+import "strings"
+
+const (
+	// MIMEMARC and MIMEMARCXML are the media types of binary MARC 21 and
+	// MARCXML.
+	MIMEMARC    = "application/marc"
+	MIMEMARCXML = "application/marcxml+xml"
+
+	subfieldDelimiter = 0x1f
+	fieldTerminator   = 0x1e
+	recordTerminator  = 0x1d
+
+	leaderLength         = 24
+	directoryEntryLength = 12
+)
+
+// Record is a MARC 21 record. Tags below 010 are control fields, the others
+// data fields.
+type Record struct {
+	Leader        string
+	ControlFields []ControlField
+	DataFields    []DataField
+}
+
+type ControlField struct {
+	Tag   string
+	Value string
+}
+
+type DataField struct {
+	Tag       string
+	Ind1      byte
+	Ind2      byte
+	Subfields []Subfield
+}
+
+type Subfield struct {
+	Code  byte
+	Value string
+}
+
+// Control returns the value of the first control field tagged tag.
+func (r *Record) Control(tag string) string {
+	for _, field := range r.ControlFields {
+		if field.Tag == tag {
+			return field.Value
+		}
+	}
+
+	return ""
+}
+
+// Fields returns the data fields tagged tag, in record order.
+func (r *Record) Fields(tag string) []DataField {
+	fields := []DataField{}
+	for _, field := range r.DataFields {
+		if field.Tag == tag {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// Field returns the first data field tagged tag, or nil.
+func (r *Record) Field(tag string) *DataField {
+	for i := range r.DataFields {
+		if r.DataFields[i].Tag == tag {
+			return &r.DataFields[i]
+		}
+	}
+
+	return nil
+}
+
+func (r *Record) AddControl(tag, value string) {
+	r.ControlFields = append(r.ControlFields, ControlField{Tag: tag, Value: value})
+}
+
+// AddField appends a data field, leaving out subfields with no value. A
+// field with none left is not added.
+func (r *Record) AddField(tag string, ind1, ind2 byte, subfields ...Subfield) {
+	field := DataField{Tag: tag, Ind1: ind1, Ind2: ind2}
+	for _, subfield := range subfields {
+		if subfield.Value != "" {
+			field.Subfields = append(field.Subfields, subfield)
+		}
+	}
+
+	if len(field.Subfields) > 0 {
+		r.DataFields = append(r.DataFields, field)
+	}
+}
+
+// Subfield returns the value of the first subfield coded code.
+func (f *DataField) Subfield(code byte) string {
+	for _, subfield := range f.Subfields {
+		if subfield.Code == code {
+			return subfield.Value
+		}
+	}
+
+	return ""
+}
+
+func isControlTag(tag string) bool {
+	return strings.HasPrefix(tag, "00")
+}
@@ -0,0 +1,222 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/marc"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRecord() *marc.Record {
+	record := &marc.Record{Leader: "00000nam a2200000 i 4500"}
+	record.AddControl("001", "42")
+	record.AddControl("008", "951030s1995    io            000 1 ind d")
+	record.AddField("020", ' ', ' ', marc.Subfield{Code: 'a', Value: "979-3-780-52599-4 (pbk.)"})
+	record.AddField("020", ' ', ' ', marc.Subfield{Code: 'a', Value: "9780140256352"})
+	record.AddField("100", '1', ' ',
+		marc.Subfield{Code: 'a', Value: "Toer, Pramoedya Ananta,"},
+		marc.Subfield{Code: 'd', Value: "1925-2006."},
+	)
+	record.AddField("245", '1', '0',
+		marc.Subfield{Code: 'a', Value: "Bumi manusia :"},
+		marc.Subfield{Code: 'b', Value: "sebuah roman /"},
+		marc.Subfield{Code: 'c', Value: "Pramoedya Ananta Toer."},
+	)
+	record.AddField("264", ' ', '1',
+		marc.Subfield{Code: 'a', Value: "Jakarta :"},
+		marc.Subfield{Code: 'b', Value: "Hasta Mitra,"},
+		marc.Subfield{Code: 'c', Value: "[1995]"},
+	)
+	return record
+}
+
+func TestBinary(t *testing.T) {
+	t.Run("ok: round trip", func(t *testing.T) {
+		record := newRecord()
+
+		buf := &bytes.Buffer{}
+		writer := marc.NewWriter(buf)
+		assert.Nil(t, writer.Write(record))
+		assert.Nil(t, writer.Write(record))
+
+		reader := marc.NewReader(buf)
+		for i := 0; i < 2; i++ {
+			resRecord, err := reader.Read()
+			assert.Nil(t, err)
+			assert.Equal(t, record.ControlFields, resRecord.ControlFields)
+			assert.Equal(t, record.DataFields, resRecord.DataFields)
+			assert.Equal(t, "a", resRecord.Leader[9:10])
+		}
+
+		_, err := reader.Read()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("ok: record lengths count bytes", func(t *testing.T) {
+		record := newRecord()
+		record.AddField("500", ' ', ' ', marc.Subfield{Code: 'a', Value: "Terjemahan “Earth of Mankind”."})
+
+		data, err := marc.Marshal(record)
+		assert.Nil(t, err)
+		length, _ := strconv.Atoi(string(data[0:5]))
+		assert.Equal(t, len(data), length)
+	})
+
+	t.Run("error: malformed record is skipped", func(t *testing.T) {
+		good, _ := marc.Marshal(newRecord())
+		bad := []byte("00042nam a2200099 i 4500\x1e\x1d")
+
+		reader := marc.NewReader(bytes.NewReader(append(bad, good...)))
+		_, err := reader.Read()
+		assert.ErrorIs(t, err, marc.ErrMalformed)
+
+		resRecord, err := reader.Read()
+		assert.Nil(t, err)
+		assert.Equal(t, "42", resRecord.Control("001"))
+	})
+
+	t.Run("error: marc-8", func(t *testing.T) {
+		data, _ := marc.Marshal(newRecord())
+		data[9] = ' '
+		data = bytes.Replace(data, []byte("Jakarta"), []byte("J\xe2karta"), 1)
+
+		_, err := marc.NewReader(bytes.NewReader(data)).Read()
+		assert.ErrorIs(t, err, marc.ErrMARC8)
+	})
+}
+
+func TestXML(t *testing.T) {
+	t.Run("ok: round trip", func(t *testing.T) {
+		record := newRecord()
+
+		buf := &bytes.Buffer{}
+		writer := marc.NewXMLWriter(buf)
+		assert.Nil(t, writer.Write(record))
+		assert.Nil(t, writer.Close())
+		assert.Contains(t, buf.String(), `<collection xmlns="http://www.loc.gov/MARC21/slim">`)
+		assert.Contains(t, buf.String(), `<datafield tag="245" ind1="1" ind2="0">`)
+
+		reader := marc.NewXMLReader(buf)
+		resRecord, err := reader.Read()
+		assert.Nil(t, err)
+		assert.Equal(t, record.DataFields, resRecord.DataFields)
+
+		_, err = reader.Read()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("ok: single record with prefix", func(t *testing.T) {
+		doc := `<marc:record xmlns:marc="http://www.loc.gov/MARC21/slim">
+  <marc:leader>00000nam a2200000 i 4500</marc:leader>
+  <marc:datafield tag="245" ind1="0" ind2="4"><marc:subfield code="a">The hobbit.</marc:subfield></marc:datafield>
+</marc:record>`
+
+		resRecord, err := marc.NewXMLReader(strings.NewReader(doc)).Read()
+		assert.Nil(t, err)
+		assert.Equal(t, "The hobbit.", resRecord.Field("245").Subfield('a'))
+		assert.Equal(t, byte('4'), resRecord.Field("245").Ind2)
+	})
+
+	t.Run("ok: empty collection", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.Nil(t, marc.NewXMLWriter(buf).Close())
+
+		_, err := marc.NewXMLReader(buf).Read()
+		assert.Equal(t, io.EOF, err)
+	})
+}
+
+func TestToEntry(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		entry, err := marc.ToEntry(newRecord())
+		assert.Nil(t, err)
+		assert.Equal(t, "9780140256352", entry.Book.ISBN)
+		assert.Equal(t, "Bumi manusia", entry.Book.Title)
+		assert.Equal(t, "sebuah roman", entry.Book.Subtitle)
+		assert.Equal(t, "Pramoedya Ananta Toer", entry.Author.Name)
+		assert.Equal(t, 1925, entry.Author.BirthDate.Year())
+		assert.Equal(t, 2006, entry.Author.DeathDate.Year())
+		assert.Equal(t, "Hasta Mitra", entry.Publisher.Name)
+		assert.Equal(t, "Jakarta", entry.Place)
+		assert.Equal(t, 1995, entry.Edition.PublicationDate.Year())
+		assert.Equal(t, "id", entry.Edition.Language)
+	})
+
+	t.Run("ok: 260 and initials", func(t *testing.T) {
+		record := &marc.Record{}
+		record.AddField("100", '1', ' ', marc.Subfield{Code: 'a', Value: "Tolkien, J. R. R."})
+		record.AddField("245", '1', '4', marc.Subfield{Code: 'a', Value: "The hobbit."})
+		record.AddField("260", ' ', ' ', marc.Subfield{Code: 'b', Value: "Allen & Unwin,"}, marc.Subfield{Code: 'c', Value: "c1937."})
+		record.AddControl("008", "750101s1937    enk           000 1 fre d")
+
+		entry, err := marc.ToEntry(record)
+		assert.Nil(t, err)
+		assert.Equal(t, "J. R. R. Tolkien", entry.Author.Name)
+		assert.True(t, entry.Author.BirthDate.IsZero())
+		assert.Equal(t, "The hobbit", entry.Book.Title)
+		assert.Equal(t, "Allen & Unwin", entry.Publisher.Name)
+		assert.Equal(t, 1937, entry.Edition.PublicationDate.Year())
+		assert.Equal(t, "fr", entry.Edition.Language)
+	})
+
+	t.Run("error: no title", func(t *testing.T) {
+		record := &marc.Record{}
+		record.AddField("020", ' ', ' ', marc.Subfield{Code: 'a', Value: "9780140256352"})
+
+		_, err := marc.ToEntry(record)
+		assert.ErrorIs(t, err, marc.ErrNoTitle)
+	})
+}
+
+func TestFromEntry(t *testing.T) {
+	t.Run("ok: round trip", func(t *testing.T) {
+		published := time.Date(1937, time.January, 1, 0, 0, 0, 0, time.UTC)
+		entry := &marc.Entry{
+			Book: &model.Book{
+				ID:        7,
+				ISBN:      "9780261103344",
+				Title:     "The Hobbit",
+				Subtitle:  "There and Back Again",
+				CreatedAt: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Author: &model.Author{
+				Name:      "J. R. R. Tolkien",
+				BirthDate: time.Date(1892, time.January, 3, 0, 0, 0, 0, time.UTC),
+			},
+			Edition:   &model.Edition{Language: "de", PublicationDate: &published},
+			Publisher: &model.Publisher{Name: "Allen & Unwin"},
+			Place:     "London",
+		}
+
+		record := marc.FromEntry(entry)
+		assert.Equal(t, "7", record.Control("001"))
+		assert.Equal(t, "ger", record.Control("008")[35:38])
+		assert.Equal(t, "Tolkien, J. R. R.", record.Field("100").Subfield('a'))
+		assert.Equal(t, "1892-", record.Field("100").Subfield('d'))
+		assert.Equal(t, byte('1'), record.Field("245").Ind1)
+		assert.Equal(t, "1937", record.Field("264").Subfield('c'))
+
+		resEntry, err := marc.ToEntry(record)
+		assert.Nil(t, err)
+		assert.Equal(t, entry.Book.ISBN, resEntry.Book.ISBN)
+		assert.Equal(t, entry.Book.Title, resEntry.Book.Title)
+		assert.Equal(t, entry.Book.Subtitle, resEntry.Book.Subtitle)
+		assert.Equal(t, entry.Author.Name, resEntry.Author.Name)
+		assert.Equal(t, entry.Publisher.Name, resEntry.Publisher.Name)
+		assert.Equal(t, "de", resEntry.Edition.Language)
+	})
+
+	t.Run("ok: book only", func(t *testing.T) {
+		record := marc.FromEntry(&marc.Entry{Book: &model.Book{ISBN: "9780261103344", Title: "The Hobbit"}})
+		assert.Nil(t, record.Field("100"))
+		assert.Nil(t, record.Field("264"))
+		assert.Equal(t, byte('0'), record.Field("245").Ind1)
+		assert.Equal(t, "und", record.Control("008")[35:38])
+	})
+}
@@ -0,0 +1,163 @@
+package marc
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+const nsMARCXML = "http://www.loc.gov/MARC21/slim"
+
+type xmlRecord struct {
+	XMLName       xml.Name          `xml:"record"`
+	Leader        string            `xml:"leader"`
+	ControlFields []xmlControlField `xml:"controlfield"`
+	DataFields    []xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// XMLReader reads the records of a MARCXML collection, or a single
+// MARCXML record, one at a time.
+type XMLReader struct {
+	decoder *xml.Decoder
+}
+
+func NewXMLReader(r io.Reader) *XMLReader {
+	return &XMLReader{decoder: xml.NewDecoder(r)}
+}
+
+// Read returns the next record, or io.EOF once there are none left. Unlike
+// binary MARC, a syntax error ends the document.
+func (r *XMLReader) Read() (*Record, error) {
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		doc := &xmlRecord{}
+		err = r.decoder.DecodeElement(doc, &start)
+		if err != nil {
+			return nil, err
+		}
+
+		return doc.record()
+	}
+}
+
+func (doc *xmlRecord) record() (*Record, error) {
+	record := &Record{Leader: doc.Leader}
+	for _, field := range doc.ControlFields {
+		record.AddControl(field.Tag, field.Value)
+	}
+
+	for _, field := range doc.DataFields {
+		dataField := DataField{Tag: field.Tag, Ind1: xmlIndicator(field.Ind1), Ind2: xmlIndicator(field.Ind2)}
+		for _, subfield := range field.Subfields {
+			if len(subfield.Code) != 1 {
+				return nil, fmt.Errorf("%w: field %s has subfield code %q", ErrMalformed, field.Tag, subfield.Code)
+			}
+
+			dataField.Subfields = append(dataField.Subfields, Subfield{Code: subfield.Code[0], Value: subfield.Value})
+		}
+		record.DataFields = append(record.DataFields, dataField)
+	}
+
+	return record, nil
+}
+
+// XMLWriter writes records into a MARCXML collection, which Close ends.
+type XMLWriter struct {
+	w       io.Writer
+	encoder *xml.Encoder
+	started bool
+}
+
+func NewXMLWriter(w io.Writer) *XMLWriter {
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return &XMLWriter{w: w, encoder: encoder}
+}
+
+func (w *XMLWriter) Write(record *Record) error {
+	err := w.start()
+	if err != nil {
+		return err
+	}
+
+	doc := &xmlRecord{Leader: normalLeader(record.Leader)}
+	for _, field := range record.ControlFields {
+		doc.ControlFields = append(doc.ControlFields, xmlControlField{Tag: field.Tag, Value: field.Value})
+	}
+
+	for _, field := range record.DataFields {
+		dataField := xmlDataField{Tag: field.Tag, Ind1: string(indicator(field.Ind1)), Ind2: string(indicator(field.Ind2))}
+		for _, subfield := range field.Subfields {
+			dataField.Subfields = append(dataField.Subfields, xmlSubfield{Code: string(subfield.Code), Value: subfield.Value})
+		}
+		doc.DataFields = append(doc.DataFields, dataField)
+	}
+
+	return w.encoder.Encode(doc)
+}
+
+// Close ends the collection. It does not close the underlying writer.
+func (w *XMLWriter) Close() error {
+	err := w.start()
+	if err != nil {
+		return err
+	}
+
+	err = w.encoder.EncodeToken(xml.EndElement{Name: xml.Name{Local: "collection"}})
+	if err != nil {
+		return err
+	}
+
+	return w.encoder.Flush()
+}
+
+func (w *XMLWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	_, err := io.WriteString(w.w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	return w.encoder.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "collection"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: nsMARCXML}},
+	})
+}
+
+func xmlIndicator(s string) byte {
+	if s == "" {
+		return ' '
+	}
+
+	return s[0]
+}
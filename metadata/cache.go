@@ -0,0 +1,84 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+)
+
+type cacheEntry struct {
+	metadata  *model.BookMetadata
+	err       error
+	expiresAt time.Time
+}
+
+// CachedProvider remembers lookups, including misses, for ttl so that
+// repeated lookups of the same ISBN do not hit the upstream catalog.
+// Provider failures are not cached.
+type CachedProvider struct {
+	provider model.MetadataProvider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func NewCachedProvider(provider model.MetadataProvider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  map[string]cacheEntry{},
+	}
+}
+
+func (c *CachedProvider) LookupISBN(ctx context.Context, isbn string) (*model.BookMetadata, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[isbn]
+	c.mu.Unlock()
+
+	if ok && c.now().Before(entry.expiresAt) {
+		return copyMetadata(entry.metadata), entry.err
+	}
+
+	metadata, err := c.provider.LookupISBN(ctx, isbn)
+	if err != nil && !errors.Is(err, model.ErrMetadataNotFound) {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evictExpired()
+	c.entries[isbn] = cacheEntry{
+		metadata:  metadata,
+		err:       err,
+		expiresAt: c.now().Add(c.ttl),
+	}
+
+	return copyMetadata(metadata), err
+}
+
+func (c *CachedProvider) evictExpired() {
+	now := c.now()
+	for isbn, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, isbn)
+		}
+	}
+}
+
+// copyMetadata keeps callers from mutating the cached value.
+func copyMetadata(metadata *model.BookMetadata) *model.BookMetadata {
+	if metadata == nil {
+		return nil
+	}
+
+	res := *metadata
+	res.Authors = append([]model.MetadataAuthor{}, metadata.Authors...)
+	return &res
+}
@@ -0,0 +1,116 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+)
+
+// maxResponseBytes bounds what is read of an Open Library answer. A single
+// book is a few kilobytes.
+const maxResponseBytes = 1 << 20
+
+type OpenLibraryProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewOpenLibraryProvider queries the Open Library books API at baseURL,
+// which may point at any server speaking the same protocol.
+func NewOpenLibraryProvider(baseURL string, client *http.Client) model.MetadataProvider {
+	return &OpenLibraryProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+	}
+}
+
+type openLibraryBook struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+	Authors  []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Publishers []struct {
+		Name string `json:"name"`
+	} `json:"publishers"`
+	PublishDate   string `json:"publish_date"`
+	NumberOfPages int    `json:"number_of_pages"`
+	Cover         struct {
+		Small  string `json:"small"`
+		Medium string `json:"medium"`
+		Large  string `json:"large"`
+	} `json:"cover"`
+}
+
+func (o OpenLibraryProvider) LookupISBN(ctx context.Context, isbn string) (*model.BookMetadata, error) {
+	bibkey := "ISBN:" + isbn
+	query := url.Values{
+		"bibkeys": {bibkey},
+		"format":  {"json"},
+		"jscmd":   {"data"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.baseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return nil, errors.Join(model.ErrProviderUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Join(model.ErrProviderUnavailable, fmt.Errorf("unexpected status %d", res.StatusCode))
+	}
+
+	books := map[string]openLibraryBook{}
+	err = json.NewDecoder(io.LimitReader(res.Body, maxResponseBytes)).Decode(&books)
+	if err != nil {
+		return nil, errors.Join(model.ErrProviderUnavailable, err)
+	}
+
+	book, ok := books[bibkey]
+	if !ok {
+		return nil, model.ErrMetadataNotFound
+	}
+
+	metadata := &model.BookMetadata{
+		ISBN:        isbn,
+		Title:       book.Title,
+		Subtitle:    book.Subtitle,
+		Authors:     []model.MetadataAuthor{},
+		PublishDate: book.PublishDate,
+		PageCount:   book.NumberOfPages,
+		CoverURL:    firstNonEmpty(book.Cover.Large, book.Cover.Medium, book.Cover.Small),
+	}
+
+	for _, author := range book.Authors {
+		metadata.Authors = append(metadata.Authors, model.MetadataAuthor{Name: author.Name})
+	}
+
+	if len(book.Publishers) > 0 {
+		metadata.Publisher = book.Publishers[0].Name
+	}
+
+	return metadata, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
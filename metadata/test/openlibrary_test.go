@@ -0,0 +1,138 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/metadata"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFixtureServer serves testdata/openlibrary_<isbn>.json for known ISBNs
+// and an empty object otherwise, like Open Library does.
+func newFixtureServer(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+
+		if r.URL.Path != "/api/books" || r.URL.Query().Get("jscmd") != "data" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		isbn := strings.TrimPrefix(r.URL.Query().Get("bibkeys"), "ISBN:")
+		data, err := os.ReadFile("testdata/openlibrary_" + isbn + ".json")
+		if err != nil {
+			data = []byte(`{}`)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}))
+}
+
+func TestOpenLibraryProvider(t *testing.T) {
+	var hits int32
+	server := newFixtureServer(t, &hits)
+	defer server.Close()
+
+	provider := metadata.NewOpenLibraryProvider(server.URL, server.Client())
+
+	t.Run("ok", func(t *testing.T) {
+		res, err := provider.LookupISBN(context.TODO(), "9780306406157")
+		assert.Nil(t, err)
+		assert.Equal(t, &model.BookMetadata{
+			ISBN:        "9780306406157",
+			Title:       "Fixture Book",
+			Subtitle:    "A Test Edition",
+			Authors:     []model.MetadataAuthor{{Name: "First Author"}, {Name: "Second Author"}},
+			Publisher:   "Fixture Press",
+			PublishDate: "1985",
+			PageCount:   312,
+			CoverURL:    "https://covers.openlibrary.org/b/id/1-L.jpg",
+		}, res)
+	})
+
+	t.Run("error: not found", func(t *testing.T) {
+		res, err := provider.LookupISBN(context.TODO(), "9789295055025")
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, model.ErrMetadataNotFound)
+	})
+
+	t.Run("error: provider unavailable", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+
+		res, err := metadata.NewOpenLibraryProvider(failing.URL, failing.Client()).LookupISBN(context.TODO(), "9780306406157")
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, model.ErrProviderUnavailable)
+	})
+
+	t.Run("error: oversized response", func(t *testing.T) {
+		oversized := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"ISBN:9780306406157": {"title": "`))
+			_, _ = w.Write([]byte(strings.Repeat("a", 2<<20)))
+			_, _ = w.Write([]byte(`"}}`))
+		}))
+		defer oversized.Close()
+
+		res, err := metadata.NewOpenLibraryProvider(oversized.URL, oversized.Client()).LookupISBN(context.TODO(), "9780306406157")
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, model.ErrProviderUnavailable)
+	})
+}
+
+func TestCachedProvider(t *testing.T) {
+	t.Run("ok: hits and misses are cached", func(t *testing.T) {
+		var hits int32
+		server := newFixtureServer(t, &hits)
+		defer server.Close()
+
+		provider := metadata.NewCachedProvider(metadata.NewOpenLibraryProvider(server.URL, server.Client()), time.Hour)
+
+		for i := 0; i < 3; i++ {
+			res, err := provider.LookupISBN(context.TODO(), "9780306406157")
+			assert.Nil(t, err)
+			assert.Equal(t, "Fixture Book", res.Title)
+
+			// Callers may annotate the result without touching the cache.
+			res.Authors[0].AuthorID = 1
+		}
+
+		for i := 0; i < 3; i++ {
+			_, err := provider.LookupISBN(context.TODO(), "9789295055025")
+			assert.ErrorIs(t, err, model.ErrMetadataNotFound)
+		}
+
+		res, err := provider.LookupISBN(context.TODO(), "9780306406157")
+		assert.Nil(t, err)
+		assert.Zero(t, res.Authors[0].AuthorID)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+	})
+
+	t.Run("ok: expired entries are refreshed", func(t *testing.T) {
+		var hits int32
+		server := newFixtureServer(t, &hits)
+		defer server.Close()
+
+		provider := metadata.NewCachedProvider(metadata.NewOpenLibraryProvider(server.URL, server.Client()), time.Nanosecond)
+
+		for i := 0; i < 2; i++ {
+			_, err := provider.LookupISBN(context.TODO(), "9780306406157")
+			assert.Nil(t, err)
+			time.Sleep(time.Millisecond)
+		}
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+	})
+}
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "books" DROP CONSTRAINT IF EXISTS "books_author_id_fkey";
+ALTER TABLE "books" ADD CONSTRAINT "books_author_id_fkey" FOREIGN KEY ("author_id") REFERENCES "authors" ("id") ON DELETE RESTRICT;
+
+-- +migrate Down
+ALTER TABLE "books" DROP CONSTRAINT IF EXISTS "books_author_id_fkey";
+ALTER TABLE "books" ADD CONSTRAINT "books_author_id_fkey" FOREIGN KEY ("author_id") REFERENCES "authors" ("id") ON DELETE CASCADE;
\ No newline at end of file
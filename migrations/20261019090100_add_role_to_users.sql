@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "users" ADD COLUMN "role" text NOT NULL DEFAULT 'reader';
+
+-- +migrate Down
+ALTER TABLE "users" DROP COLUMN IF EXISTS "role";
\ No newline at end of file
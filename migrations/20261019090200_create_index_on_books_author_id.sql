@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX "books_author_id_idx" ON "books" ("author_id");
+
+-- +migrate Down
+DROP INDEX "books_author_id_idx";
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- +migrate Up
+ALTER TABLE "books" ADD COLUMN "deleted_at" timestamp;
+CREATE INDEX "books_deleted_at_idx" ON "books" ("deleted_at");
+DROP INDEX IF EXISTS "books_isbn_idxkey";
+CREATE UNIQUE INDEX "books_isbn_idxkey" ON "books" ("isbn") WHERE "deleted_at" IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS "books_isbn_idxkey";
+DELETE FROM "books" WHERE "deleted_at" IS NOT NULL;
+CREATE UNIQUE INDEX "books_isbn_idxkey" ON "books" ("isbn");
+DROP INDEX IF EXISTS "books_deleted_at_idx";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "deleted_at";
\ No newline at end of file
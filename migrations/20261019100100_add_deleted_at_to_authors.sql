@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE "authors" ADD COLUMN "deleted_at" timestamp;
+CREATE INDEX "authors_deleted_at_idx" ON "authors" ("deleted_at");
+
+-- +migrate Down
+DELETE FROM "books" WHERE "author_id" IN (SELECT "id" FROM "authors" WHERE "deleted_at" IS NOT NULL);
+DELETE FROM "authors" WHERE "deleted_at" IS NOT NULL;
+DROP INDEX IF EXISTS "authors_deleted_at_idx";
+ALTER TABLE "authors" DROP COLUMN IF EXISTS "deleted_at";
\ No newline at end of file
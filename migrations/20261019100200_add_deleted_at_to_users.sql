@@ -0,0 +1,12 @@
+-- +migrate Up
+ALTER TABLE "users" ADD COLUMN "deleted_at" timestamp;
+CREATE INDEX "users_deleted_at_idx" ON "users" ("deleted_at");
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "users_username_key";
+CREATE UNIQUE INDEX "users_username_idxkey" ON "users" ("username") WHERE "deleted_at" IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS "users_username_idxkey";
+DELETE FROM "users" WHERE "deleted_at" IS NOT NULL;
+ALTER TABLE "users" ADD CONSTRAINT "users_username_key" UNIQUE ("username");
+DROP INDEX IF EXISTS "users_deleted_at_idx";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "deleted_at";
\ No newline at end of file
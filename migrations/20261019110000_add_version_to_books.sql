@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "books" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE "books" DROP COLUMN IF EXISTS "version";
\ No newline at end of file
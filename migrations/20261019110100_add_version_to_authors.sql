@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE "authors" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE "authors" DROP COLUMN IF EXISTS "version";
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE "import_jobs" (
+    "id" bigserial PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "format" text NOT NULL,
+    "dry_run" boolean NOT NULL DEFAULT false,
+    "status" text NOT NULL,
+    "total_rows" integer NOT NULL DEFAULT 0,
+    "processed_rows" integer NOT NULL DEFAULT 0,
+    "created_books" integer NOT NULL DEFAULT 0,
+    "created_authors" integer NOT NULL DEFAULT 0,
+    "failed_rows" integer NOT NULL DEFAULT 0,
+    "errors" jsonb,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp,
+    "finished_at" timestamp
+);
+ALTER TABLE "import_jobs" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+CREATE INDEX "import_jobs_user_id_idx" ON "import_jobs" ("user_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "import_jobs";
\ No newline at end of file
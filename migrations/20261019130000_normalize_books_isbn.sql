@@ -0,0 +1,30 @@
+-- +migrate Up
+ALTER TABLE "books" ADD COLUMN "isbn_normalized" text;
+
+UPDATE "books" SET "isbn_normalized" = regexp_replace(upper("isbn"), '[^0-9X]', '', 'g');
+
+UPDATE "books" SET "isbn_normalized" = '978' || substr("isbn_normalized", 1, 9) || ((10 - (
+    SELECT sum(substr('978' || substr("isbn_normalized", 1, 9), i, 1)::int * CASE WHEN i % 2 = 0 THEN 3 ELSE 1 END)
+    FROM generate_series(1, 12) AS i
+) % 10) % 10)::text
+WHERE "isbn_normalized" ~ '^[0-9]{9}[0-9X]$';
+
+-- Live rows that turn out to be the same book are moved to the trash,
+-- keeping the oldest one.
+UPDATE "books" AS b SET "deleted_at" = now()
+WHERE b."deleted_at" IS NULL
+  AND EXISTS (
+    SELECT 1 FROM "books" AS o
+    WHERE o."deleted_at" IS NULL
+      AND o."isbn_normalized" = b."isbn_normalized"
+      AND o."id" < b."id"
+  );
+
+UPDATE "books" SET "isbn" = "isbn_normalized"
+WHERE "isbn_normalized" ~ '^97[89][0-9]{10}$' AND "isbn" <> "isbn_normalized";
+
+ALTER TABLE "books" DROP COLUMN "isbn_normalized";
+
+-- +migrate Down
+-- The original spelling of each ISBN is not kept, so this migration
+-- cannot be reverted.
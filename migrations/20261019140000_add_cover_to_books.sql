@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "books" ADD COLUMN "cover_key" text NOT NULL DEFAULT '';
+ALTER TABLE "books" ADD COLUMN "cover_type" text NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE "books" DROP COLUMN "cover_type";
+ALTER TABLE "books" DROP COLUMN "cover_key";
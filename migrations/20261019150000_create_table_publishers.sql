@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE "publishers" (
+    "id" bigserial PRIMARY KEY,
+    "name" text NOT NULL,
+    "website" text NOT NULL DEFAULT '',
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS "publishers";
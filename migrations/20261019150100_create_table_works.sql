@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE "works" (
+    "id" bigserial PRIMARY KEY,
+    "title" text NOT NULL,
+    "author_id" bigint NOT NULL,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp
+);
+ALTER TABLE "works" ADD FOREIGN KEY ("author_id") REFERENCES "authors" ("id") ON DELETE RESTRICT;
+CREATE INDEX "works_author_id_idx" ON "works" ("author_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "works";
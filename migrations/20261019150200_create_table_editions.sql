@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE "editions" (
+    "id" bigserial PRIMARY KEY,
+    "work_id" bigint NOT NULL,
+    "publisher_id" bigint,
+    "isbn" text NOT NULL DEFAULT '',
+    "format" text NOT NULL DEFAULT 'other',
+    "language" text NOT NULL DEFAULT '',
+    "publication_date" date,
+    "page_count" integer NOT NULL DEFAULT 0,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp
+);
+ALTER TABLE "editions" ADD FOREIGN KEY ("work_id") REFERENCES "works" ("id") ON DELETE RESTRICT;
+ALTER TABLE "editions" ADD FOREIGN KEY ("publisher_id") REFERENCES "publishers" ("id") ON DELETE RESTRICT;
+CREATE INDEX "editions_work_id_idx" ON "editions" ("work_id");
+CREATE INDEX "editions_publisher_id_idx" ON "editions" ("publisher_id");
+CREATE UNIQUE INDEX "editions_isbn_idxkey" ON "editions" ("isbn") WHERE "isbn" <> '';
+
+-- +migrate Down
+DROP TABLE IF EXISTS "editions";
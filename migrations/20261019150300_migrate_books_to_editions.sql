@@ -0,0 +1,19 @@
+-- +migrate Up
+ALTER TABLE "books" ADD COLUMN "edition_id" bigint;
+ALTER TABLE "books" ADD FOREIGN KEY ("edition_id") REFERENCES "editions" ("id") ON DELETE SET NULL;
+
+-- Every existing book becomes one work and one edition sharing its id.
+-- Trashed books keep their edition but not their ISBN, which may have
+-- been reused by a live book since.
+INSERT INTO "works" ("id", "title", "author_id", "created_at")
+SELECT "id", "title", "author_id", "created_at" FROM "books";
+
+INSERT INTO "editions" ("id", "work_id", "isbn", "created_at")
+SELECT "id", "id", CASE WHEN "deleted_at" IS NULL THEN "isbn" ELSE '' END, "created_at" FROM "books";
+
+UPDATE "books" SET "edition_id" = "id";
+
+-- +migrate Down
+ALTER TABLE "books" DROP COLUMN "edition_id";
+DELETE FROM "editions" WHERE "id" IN (SELECT "id" FROM "books");
+DELETE FROM "works" WHERE "id" IN (SELECT "id" FROM "books") AND NOT EXISTS (SELECT 1 FROM "editions" WHERE "editions"."work_id" = "works"."id");
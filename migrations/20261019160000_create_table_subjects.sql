@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE "subjects" (
+    "id" bigserial PRIMARY KEY,
+    "name" text NOT NULL,
+    "parent_id" bigint,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp
+);
+ALTER TABLE "subjects" ADD FOREIGN KEY ("parent_id") REFERENCES "subjects" ("id") ON DELETE RESTRICT;
+CREATE INDEX "subjects_parent_id_idx" ON "subjects" ("parent_id");
+CREATE UNIQUE INDEX "subjects_parent_id_name_idxkey" ON "subjects" (COALESCE("parent_id", 0), LOWER("name"));
+
+CREATE TABLE "book_subjects" (
+    "book_id" bigint NOT NULL,
+    "subject_id" bigint NOT NULL,
+    PRIMARY KEY ("book_id", "subject_id")
+);
+ALTER TABLE "book_subjects" ADD FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON DELETE CASCADE;
+ALTER TABLE "book_subjects" ADD FOREIGN KEY ("subject_id") REFERENCES "subjects" ("id") ON DELETE CASCADE;
+CREATE INDEX "book_subjects_subject_id_idx" ON "book_subjects" ("subject_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "book_subjects";
+DROP TABLE IF EXISTS "subjects";
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE "tags" (
+    "id" bigserial PRIMARY KEY,
+    "name" text NOT NULL,
+    "created_at" timestamp NOT NULL
+);
+CREATE UNIQUE INDEX "tags_name_idxkey" ON "tags" ("name");
+
+CREATE TABLE "book_tags" (
+    "book_id" bigint NOT NULL,
+    "tag_id" bigint NOT NULL,
+    PRIMARY KEY ("book_id", "tag_id")
+);
+ALTER TABLE "book_tags" ADD FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON DELETE CASCADE;
+ALTER TABLE "book_tags" ADD FOREIGN KEY ("tag_id") REFERENCES "tags" ("id") ON DELETE CASCADE;
+CREATE INDEX "book_tags_tag_id_idx" ON "book_tags" ("tag_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "book_tags";
+DROP TABLE IF EXISTS "tags";
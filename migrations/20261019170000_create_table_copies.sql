@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE "copies" (
+    "id" bigserial PRIMARY KEY,
+    "book_id" bigint NOT NULL,
+    "barcode" text NOT NULL,
+    "branch" text NOT NULL,
+    "condition" text NOT NULL DEFAULT 'good',
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp
+);
+ALTER TABLE "copies" ADD FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON DELETE RESTRICT;
+CREATE UNIQUE INDEX "copies_barcode_idxkey" ON "copies" ("barcode");
+CREATE INDEX "copies_book_id_idx" ON "copies" ("book_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "copies";
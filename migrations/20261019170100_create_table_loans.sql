@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE "loans" (
+    "id" bigserial PRIMARY KEY,
+    "copy_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "checked_out_at" timestamp NOT NULL,
+    "due_at" timestamp NOT NULL,
+    "renewals" integer NOT NULL DEFAULT 0,
+    "returned_at" timestamp,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp
+);
+ALTER TABLE "loans" ADD FOREIGN KEY ("copy_id") REFERENCES "copies" ("id") ON DELETE RESTRICT;
+ALTER TABLE "loans" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE RESTRICT;
+CREATE INDEX "loans_user_id_idx" ON "loans" ("user_id");
+-- At most one open loan per copy, whatever the application does.
+CREATE UNIQUE INDEX "loans_copy_id_open_idxkey" ON "loans" ("copy_id") WHERE "returned_at" IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS "loans";
@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE "holds" (
+    "id" bigserial PRIMARY KEY,
+    "book_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "status" text NOT NULL DEFAULT 'waiting',
+    "copy_id" bigint,
+    "placed_at" timestamp NOT NULL,
+    "ready_at" timestamp,
+    "expires_at" timestamp,
+    "closed_at" timestamp,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp
+);
+ALTER TABLE "holds" ADD FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON DELETE CASCADE;
+ALTER TABLE "holds" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "holds" ADD FOREIGN KEY ("copy_id") REFERENCES "copies" ("id") ON DELETE SET NULL;
+CREATE INDEX "holds_book_id_placed_at_idx" ON "holds" ("book_id", "placed_at", "id") WHERE "status" = 'waiting';
+CREATE INDEX "holds_user_id_idx" ON "holds" ("user_id");
+CREATE INDEX "holds_expires_at_idx" ON "holds" ("expires_at") WHERE "status" = 'ready';
+-- A reader queues once per book, and a copy waits for one reader at a time.
+CREATE UNIQUE INDEX "holds_book_id_user_id_active_idxkey" ON "holds" ("book_id", "user_id") WHERE "status" IN ('waiting', 'ready');
+CREATE UNIQUE INDEX "holds_copy_id_ready_idxkey" ON "holds" ("copy_id") WHERE "status" = 'ready';
+
+-- +migrate Down
+DROP TABLE IF EXISTS "holds";
@@ -0,0 +1,33 @@
+-- +migrate Up
+CREATE TABLE "ledger_entries" (
+    "id" bigserial PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "kind" text NOT NULL,
+    "reason" text NOT NULL DEFAULT '',
+    "amount" bigint NOT NULL CHECK ("amount" > 0),
+    "loan_id" bigint,
+    "note" text NOT NULL DEFAULT '',
+    "created_by" bigint,
+    "created_at" timestamp NOT NULL
+);
+ALTER TABLE "ledger_entries" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE RESTRICT;
+ALTER TABLE "ledger_entries" ADD FOREIGN KEY ("loan_id") REFERENCES "loans" ("id") ON DELETE RESTRICT;
+CREATE INDEX "ledger_entries_user_id_created_at_idx" ON "ledger_entries" ("user_id", "created_at");
+-- A loan is fined for lateness once.
+CREATE UNIQUE INDEX "ledger_entries_loan_id_overdue_idxkey" ON "ledger_entries" ("loan_id") WHERE "reason" = 'overdue';
+
+-- The ledger is append-only: mistakes are corrected with a waiver, never by
+-- rewriting history.
+-- +migrate StatementBegin
+CREATE FUNCTION "ledger_entries_append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+CREATE TRIGGER "ledger_entries_append_only" BEFORE UPDATE OR DELETE ON "ledger_entries"
+    FOR EACH ROW EXECUTE FUNCTION "ledger_entries_append_only"();
+
+-- +migrate Down
+DROP TABLE IF EXISTS "ledger_entries";
+DROP FUNCTION IF EXISTS "ledger_entries_append_only"();
@@ -0,0 +1,40 @@
+-- +migrate Up
+ALTER TABLE "books" ADD COLUMN "rating_count" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "books" ADD COLUMN "rating_sum" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "books" ADD COLUMN "rating_average" numeric(3, 2)
+    GENERATED ALWAYS AS (CASE WHEN "rating_count" > 0 THEN "rating_sum"::numeric / "rating_count" END) STORED;
+
+CREATE TABLE "reviews" (
+    "id" bigserial PRIMARY KEY,
+    "book_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "rating" smallint NOT NULL CHECK ("rating" BETWEEN 1 AND 5),
+    "body" text NOT NULL DEFAULT '',
+    "hidden" boolean NOT NULL DEFAULT false,
+    "hidden_reason" text NOT NULL DEFAULT '',
+    "helpful_count" bigint NOT NULL DEFAULT 0,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp
+);
+ALTER TABLE "reviews" ADD FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON DELETE CASCADE;
+ALTER TABLE "reviews" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+CREATE UNIQUE INDEX "reviews_book_id_user_id_idxkey" ON "reviews" ("book_id", "user_id");
+CREATE INDEX "reviews_book_id_helpful_count_idx" ON "reviews" ("book_id", "helpful_count" DESC, "created_at" DESC) WHERE NOT "hidden";
+CREATE INDEX "reviews_user_id_idx" ON "reviews" ("user_id");
+
+CREATE TABLE "review_votes" (
+    "review_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "created_at" timestamp NOT NULL,
+    PRIMARY KEY ("review_id", "user_id")
+);
+ALTER TABLE "review_votes" ADD FOREIGN KEY ("review_id") REFERENCES "reviews" ("id") ON DELETE CASCADE;
+ALTER TABLE "review_votes" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+-- +migrate Down
+DROP TABLE IF EXISTS "review_votes";
+DROP TABLE IF EXISTS "reviews";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "rating_average";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "rating_sum";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "rating_count";
@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE "shelves" (
+    "id" bigserial PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "kind" text NOT NULL DEFAULT 'custom',
+    "privacy" text NOT NULL DEFAULT 'private',
+    "share_token" text,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp
+);
+ALTER TABLE "shelves" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+CREATE UNIQUE INDEX "shelves_user_id_name_idxkey" ON "shelves" ("user_id", LOWER("name"));
+-- Every reader has one shelf of each built-in kind.
+CREATE UNIQUE INDEX "shelves_user_id_kind_idxkey" ON "shelves" ("user_id", "kind") WHERE "kind" <> 'custom';
+CREATE UNIQUE INDEX "shelves_share_token_idxkey" ON "shelves" ("share_token");
+
+CREATE TABLE "shelf_entries" (
+    "shelf_id" bigint NOT NULL,
+    "book_id" bigint NOT NULL,
+    "position" integer NOT NULL,
+    "note" text NOT NULL DEFAULT '',
+    "added_at" timestamp NOT NULL,
+    PRIMARY KEY ("shelf_id", "book_id")
+);
+ALTER TABLE "shelf_entries" ADD FOREIGN KEY ("shelf_id") REFERENCES "shelves" ("id") ON DELETE CASCADE;
+ALTER TABLE "shelf_entries" ADD FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON DELETE CASCADE;
+CREATE INDEX "shelf_entries_shelf_id_position_idx" ON "shelf_entries" ("shelf_id", "position");
+CREATE INDEX "shelf_entries_book_id_idx" ON "shelf_entries" ("book_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "shelf_entries";
+DROP TABLE IF EXISTS "shelves";
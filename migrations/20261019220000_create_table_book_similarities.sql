@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE "book_views" (
+    "user_id" bigint NOT NULL,
+    "book_id" bigint NOT NULL,
+    "view_count" bigint NOT NULL DEFAULT 1,
+    "last_viewed_at" timestamp NOT NULL,
+    PRIMARY KEY ("user_id", "book_id")
+);
+ALTER TABLE "book_views" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "book_views" ADD FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON DELETE CASCADE;
+CREATE INDEX "book_views_book_id_idx" ON "book_views" ("book_id");
+
+CREATE TABLE "book_similarities" (
+    "book_id" bigint NOT NULL,
+    "similar_book_id" bigint NOT NULL,
+    "score" double precision NOT NULL,
+    "computed_at" timestamp NOT NULL,
+    PRIMARY KEY ("book_id", "similar_book_id")
+);
+ALTER TABLE "book_similarities" ADD FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON DELETE CASCADE;
+ALTER TABLE "book_similarities" ADD FOREIGN KEY ("similar_book_id") REFERENCES "books" ("id") ON DELETE CASCADE;
+
+-- +migrate Down
+DROP TABLE IF EXISTS "book_similarities";
+DROP TABLE IF EXISTS "book_views";
@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE "book_versions" (
+    "book_id" bigint NOT NULL,
+    "version" bigint NOT NULL,
+    "isbn" text NOT NULL,
+    "title" text NOT NULL,
+    "author_id" bigint NOT NULL,
+    "cover_key" text NOT NULL DEFAULT '',
+    "cover_type" text NOT NULL DEFAULT '',
+    "valid_from" timestamp NOT NULL,
+    "valid_to" timestamp NOT NULL,
+    PRIMARY KEY ("book_id", "version")
+);
+ALTER TABLE "book_versions" ADD FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "author_versions" (
+    "author_id" bigint NOT NULL,
+    "version" bigint NOT NULL,
+    "name" text NOT NULL,
+    "birth_date" timestamp NOT NULL,
+    "valid_from" timestamp NOT NULL,
+    "valid_to" timestamp NOT NULL,
+    PRIMARY KEY ("author_id", "version")
+);
+ALTER TABLE "author_versions" ADD FOREIGN KEY ("author_id") REFERENCES "authors" ("id") ON DELETE CASCADE;
+
+-- +migrate Down
+DROP TABLE IF EXISTS "author_versions";
+DROP TABLE IF EXISTS "book_versions";
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE "author_aliases" (
+    "id" bigserial PRIMARY KEY,
+    "author_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "created_at" timestamp NOT NULL
+);
+ALTER TABLE "author_aliases" ADD FOREIGN KEY ("author_id") REFERENCES "authors" ("id") ON DELETE CASCADE;
+CREATE UNIQUE INDEX "author_aliases_author_id_name_idxkey" ON "author_aliases" ("author_id", LOWER("name"));
+
+-- A merged author is gone; its id keeps pointing at the author it was
+-- merged into.
+CREATE TABLE "author_redirects" (
+    "author_id" bigint PRIMARY KEY,
+    "target_author_id" bigint NOT NULL,
+    "merged_at" timestamp NOT NULL
+);
+ALTER TABLE "author_redirects" ADD FOREIGN KEY ("target_author_id") REFERENCES "authors" ("id") ON DELETE CASCADE;
+CREATE INDEX "author_redirects_target_author_id_idx" ON "author_redirects" ("target_author_id");
+
+-- +migrate Down
+DROP TABLE IF EXISTS "author_redirects";
+DROP TABLE IF EXISTS "author_aliases";
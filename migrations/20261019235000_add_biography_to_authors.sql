@@ -0,0 +1,41 @@
+-- +migrate Up
+ALTER TABLE "authors" ADD COLUMN "death_date" timestamp;
+ALTER TABLE "authors" ADD COLUMN "nationality" text NOT NULL DEFAULT '';
+ALTER TABLE "authors" ADD COLUMN "biography" text NOT NULL DEFAULT '';
+ALTER TABLE "authors" ADD COLUMN "website" text NOT NULL DEFAULT '';
+ALTER TABLE "authors" ADD COLUMN "viaf" text NOT NULL DEFAULT '';
+ALTER TABLE "authors" ADD COLUMN "isni" text NOT NULL DEFAULT '';
+ALTER TABLE "authors" ADD COLUMN "wikidata_id" text NOT NULL DEFAULT '';
+ALTER TABLE "authors" ADD CONSTRAINT "authors_death_date_check" CHECK ("death_date" > "birth_date");
+
+ALTER TABLE "author_versions" ADD COLUMN "death_date" timestamp;
+ALTER TABLE "author_versions" ADD COLUMN "nationality" text NOT NULL DEFAULT '';
+ALTER TABLE "author_versions" ADD COLUMN "biography" text NOT NULL DEFAULT '';
+ALTER TABLE "author_versions" ADD COLUMN "website" text NOT NULL DEFAULT '';
+ALTER TABLE "author_versions" ADD COLUMN "viaf" text NOT NULL DEFAULT '';
+ALTER TABLE "author_versions" ADD COLUMN "isni" text NOT NULL DEFAULT '';
+ALTER TABLE "author_versions" ADD COLUMN "wikidata_id" text NOT NULL DEFAULT '';
+
+ALTER TABLE "author_aliases" ADD COLUMN "kind" text NOT NULL DEFAULT 'alternate';
+CREATE INDEX "author_aliases_name_idx" ON "author_aliases" (LOWER("name"));
+
+-- +migrate Down
+DROP INDEX IF EXISTS "author_aliases_name_idx";
+ALTER TABLE "author_aliases" DROP COLUMN IF EXISTS "kind";
+
+ALTER TABLE "author_versions" DROP COLUMN IF EXISTS "wikidata_id";
+ALTER TABLE "author_versions" DROP COLUMN IF EXISTS "isni";
+ALTER TABLE "author_versions" DROP COLUMN IF EXISTS "viaf";
+ALTER TABLE "author_versions" DROP COLUMN IF EXISTS "website";
+ALTER TABLE "author_versions" DROP COLUMN IF EXISTS "biography";
+ALTER TABLE "author_versions" DROP COLUMN IF EXISTS "nationality";
+ALTER TABLE "author_versions" DROP COLUMN IF EXISTS "death_date";
+
+ALTER TABLE "authors" DROP CONSTRAINT IF EXISTS "authors_death_date_check";
+ALTER TABLE "authors" DROP COLUMN IF EXISTS "wikidata_id";
+ALTER TABLE "authors" DROP COLUMN IF EXISTS "isni";
+ALTER TABLE "authors" DROP COLUMN IF EXISTS "viaf";
+ALTER TABLE "authors" DROP COLUMN IF EXISTS "website";
+ALTER TABLE "authors" DROP COLUMN IF EXISTS "biography";
+ALTER TABLE "authors" DROP COLUMN IF EXISTS "nationality";
+ALTER TABLE "authors" DROP COLUMN IF EXISTS "death_date";
@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE "books" ADD COLUMN "subtitle" text NOT NULL DEFAULT '';
+ALTER TABLE "books" ADD COLUMN "translations" jsonb NOT NULL DEFAULT '{}';
+ALTER TABLE "book_versions" ADD COLUMN "subtitle" text NOT NULL DEFAULT '';
+ALTER TABLE "authors" ADD COLUMN "translations" jsonb NOT NULL DEFAULT '{}';
+ALTER TABLE "subjects" ADD COLUMN "translations" jsonb NOT NULL DEFAULT '{}';
+
+-- +migrate Down
+ALTER TABLE "subjects" DROP COLUMN IF EXISTS "translations";
+ALTER TABLE "authors" DROP COLUMN IF EXISTS "translations";
+ALTER TABLE "book_versions" DROP COLUMN IF EXISTS "subtitle";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "translations";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "subtitle";
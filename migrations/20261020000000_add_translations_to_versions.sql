@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "book_versions" ADD COLUMN "translations" jsonb NOT NULL DEFAULT '{}';
+ALTER TABLE "author_versions" ADD COLUMN "translations" jsonb NOT NULL DEFAULT '{}';
+
+-- +migrate Down
+ALTER TABLE "author_versions" DROP COLUMN IF EXISTS "translations";
+ALTER TABLE "book_versions" DROP COLUMN IF EXISTS "translations";
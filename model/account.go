@@ -0,0 +1,78 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	EntryKindCharge  = "charge"
+	EntryKindPayment = "payment"
+	EntryKindWaiver  = "waiver"
+)
+
+const (
+	ChargeReasonOverdue = "overdue"
+	ChargeReasonLost    = "lost"
+	ChargeReasonDamage  = "damage"
+	ChargeReasonOther   = "other"
+)
+
+// LedgerEntry is one line of a user's account. Amounts are positive and in
+// minor currency units; charges raise the balance, payments and waivers
+// lower it. Entries are never changed once written.
+type LedgerEntry struct {
+	ID        int64     `json:"id" gorm:"primaryKey"`
+	UserID    int64     `json:"user_id"`
+	Kind      string    `json:"kind"`
+	Reason    string    `json:"reason,omitempty"`
+	Amount    int64     `json:"amount"`
+	LoanID    *int64    `json:"loan_id,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedBy *int64    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at" gorm:"<-:create"`
+}
+
+// Account is the standing of a user: what they owe and whether it is too
+// much to borrow. Entries is only filled in when the history is asked for.
+type Account struct {
+	UserID   int64          `json:"user_id"`
+	Balance  int64          `json:"balance"`
+	Currency string         `json:"currency"`
+	Blocked  bool           `json:"blocked"`
+	Entries  []*LedgerEntry `json:"entries,omitempty"`
+}
+
+// FinePolicy sets the fees charged to readers, in minor currency units.
+type FinePolicy struct {
+	PerDay     int64
+	MaxOverdue int64
+	LostItem   int64
+}
+
+// Overdue returns the fine for a loan due at dueAt and returned at
+// returnedAt: PerDay for every started day late, up to MaxOverdue.
+func (f FinePolicy) Overdue(dueAt, returnedAt time.Time) int64 {
+	late := returnedAt.Sub(dueAt)
+	if late <= 0 {
+		return 0
+	}
+
+	days := int64((late + 24*time.Hour - 1) / (24 * time.Hour))
+	return min(days*f.PerDay, f.MaxOverdue)
+}
+
+type LedgerRepository interface {
+	Create(ctx context.Context, entry *LedgerEntry) (*LedgerEntry, error)
+	FindAllByUserID(ctx context.Context, userID int64) ([]*LedgerEntry, error)
+	Balance(ctx context.Context, userID int64) (int64, error)
+}
+
+type AccountService interface {
+	FindAccount(ctx context.Context, userID int64) (*Account, error)
+	FindStanding(ctx context.Context, userID int64) (*Account, error)
+	CheckStanding(ctx context.Context, userID int64) error
+	Charge(ctx context.Context, entry *LedgerEntry) (*LedgerEntry, error)
+	Credit(ctx context.Context, entry *LedgerEntry) (*LedgerEntry, error)
+	ChargeOverdue(ctx context.Context, loan *Loan) (*LedgerEntry, error)
+}
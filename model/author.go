@@ -3,28 +3,125 @@ package model
 import (
 	"context"
 	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	AuthorAliasAlternate = "alternate"
+	AuthorAliasPseudonym = "pseudonym"
 )
 
 type Author struct {
-	ID        int64      `json:"id" gorm:"primaryKey"`
-	Name      string     `json:"name"`
-	BirthDate time.Time  `json:"birth_date"`
-	CreatedAt time.Time  `json:"created_at" gorm:"<-:create"`
-	UpdatedAt *time.Time `json:"updated_at" gorm:"<-:update"`
+	ID           int64                           `json:"id" gorm:"primaryKey"`
+	Name         string                          `json:"name"`
+	BirthDate    time.Time                       `json:"birth_date"`
+	DeathDate    *time.Time                      `json:"death_date"`
+	Nationality  string                          `json:"nationality"`
+	Biography    string                          `json:"biography"`
+	Website      string                          `json:"website"`
+	VIAF         string                          `json:"viaf"`
+	ISNI         string                          `json:"isni"`
+	WikidataID   string                          `json:"wikidata_id"`
+	Translations Translations[AuthorTranslation] `json:"translations"`
+	Version      int64                           `json:"version" gorm:"default:1"`
+	CreatedAt    time.Time                       `json:"created_at" gorm:"<-:create"`
+	UpdatedAt    *time.Time                      `json:"updated_at" gorm:"<-:update"`
+	DeletedAt    gorm.DeletedAt                  `json:"deleted_at"`
+
+	Aliases []*AuthorAlias `json:"aliases,omitempty" gorm:"foreignKey:AuthorID"`
+}
+
+// AuthorAlias is another name an author is known by: a pseudonym, or an
+// alternate spelling such as the name of an author merged into it.
+type AuthorAlias struct {
+	ID        int64     `json:"id" gorm:"primaryKey"`
+	AuthorID  int64     `json:"-"`
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind" gorm:"default:alternate"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DuplicateAuthor pairs two authors that are likely the same person.
+type DuplicateAuthor struct {
+	Author    *Author `json:"author"`
+	Duplicate *Author `json:"duplicate"`
+	Score     float64 `json:"score"`
+}
+
+// AuthorVersion is a state of an author. Past versions are kept when an
+// update replaces them; the current one has no ValidTo.
+type AuthorVersion struct {
+	AuthorID     int64                           `json:"author_id" gorm:"primaryKey"`
+	Version      int64                           `json:"version" gorm:"primaryKey"`
+	Name         string                          `json:"name"`
+	BirthDate    time.Time                       `json:"birth_date"`
+	DeathDate    *time.Time                      `json:"death_date"`
+	Nationality  string                          `json:"nationality"`
+	Biography    string                          `json:"biography"`
+	Website      string                          `json:"website"`
+	VIAF         string                          `json:"viaf"`
+	ISNI         string                          `json:"isni"`
+	WikidataID   string                          `json:"wikidata_id"`
+	Translations Translations[AuthorTranslation] `json:"translations"`
+	ValidFrom    time.Time                       `json:"valid_from"`
+	ValidTo      *time.Time                      `json:"valid_to"`
+}
+
+// AuthorFilter narrows exports and listings. Zero values are ignored.
+type AuthorFilter struct {
+	Name         string
+	UpdatedSince *time.Time
 }
 
 type AuthorRepository interface {
 	Create(ctx context.Context, author *Author) (*Author, error)
 	FindByID(ctx context.Context, authorID int64) (*Author, error)
+	FindByName(ctx context.Context, name string) (*Author, error)
 	FindAll(ctx context.Context) ([]*Author, error)
+	FindAllByName(ctx context.Context, name string) ([]*Author, error)
+	FindAllByIDs(ctx context.Context, authorIDs []int64) ([]*Author, error)
+	FindPage(ctx context.Context, pagination Pagination) ([]*Author, int64, error)
+	Stream(ctx context.Context, filter AuthorFilter, fn func(*Author) error) error
 	Update(ctx context.Context, author *Author) (*Author, error)
+	UpdateColumns(ctx context.Context, author *Author, columns []string) (*Author, error)
 	Delete(ctx context.Context, authorID int64) error
+	DeleteWithBooks(ctx context.Context, authorID int64) error
+	DeleteAndReassignBooks(ctx context.Context, authorID, targetAuthorID int64) error
+	FindDeletedByID(ctx context.Context, authorID int64) (*Author, error)
+	FindAllDeleted(ctx context.Context) ([]*Author, error)
+	Restore(ctx context.Context, authorID int64) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	FindVersions(ctx context.Context, authorID int64) ([]*AuthorVersion, error)
+	FindVersion(ctx context.Context, authorID, version int64) (*AuthorVersion, error)
+
+	FindAllSharingBirthDate(ctx context.Context) ([]*Author, error)
+	Merge(ctx context.Context, authorID int64, mergedIDs []int64, mergedAt time.Time) error
+	FindRedirect(ctx context.Context, authorID int64) (int64, error)
 }
 
 type AuthorService interface {
 	Create(ctx context.Context, author *Author) (*Author, error)
 	FindByID(ctx context.Context, authorID int64) (*Author, error)
 	FindAll(ctx context.Context) ([]*Author, error)
+	FindAllByName(ctx context.Context, name string) ([]*Author, error)
+	Stream(ctx context.Context, filter AuthorFilter, fn func(*Author) error) error
 	Update(ctx context.Context, author *Author) (*Author, error)
+	Patch(ctx context.Context, author *Author, columns []string) (*Author, error)
 	Delete(ctx context.Context, authorID int64) error
+	DeleteCascade(ctx context.Context, authorID int64) error
+	DeleteAndReassign(ctx context.Context, authorID, targetAuthorID int64) error
+
+	FindAllDeleted(ctx context.Context) ([]*Author, error)
+	Restore(ctx context.Context, authorID int64) (*Author, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	FindVersions(ctx context.Context, authorID int64) ([]*AuthorVersion, error)
+	FindVersion(ctx context.Context, authorID, version int64) (*AuthorVersion, error)
+	DiffVersions(ctx context.Context, authorID, from, to int64) (*VersionDiff, error)
+
+	FindDuplicates(ctx context.Context) ([]*DuplicateAuthor, error)
+	Merge(ctx context.Context, authorID int64, mergedIDs []int64) (*Author, error)
+	FindRedirect(ctx context.Context, authorID int64) (int64, error)
 }
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type BlobInfo struct {
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+// BlobStore keeps binary objects such as cover images outside the
+// database. Keys are slash separated paths and never start with a slash.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, *BlobInfo, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
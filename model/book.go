@@ -3,31 +3,96 @@ package model
 import (
 	"context"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Book struct {
-	ID        int64      `json:"id" gorm:"primaryKey"`
-	ISBN      string     `json:"isbn"`
-	Title     string     `json:"title"`
-	AuthorID  int64      `json:"author"`
-	CreatedAt time.Time  `json:"created_at" gorm:"<-:create"`
-	UpdatedAt *time.Time `json:"updated_at" gorm:"<-:update"`
+	ID            int64                         `json:"id" gorm:"primaryKey"`
+	ISBN          string                        `json:"isbn"`
+	Title         string                        `json:"title"`
+	Subtitle      string                        `json:"subtitle"`
+	AuthorID      int64                         `json:"author"`
+	EditionID     *int64                        `json:"edition_id"`
+	CoverKey      string                        `json:"cover_key,omitempty"`
+	CoverType     string                        `json:"-"`
+	RatingCount   int64                         `json:"rating_count" gorm:"->"`
+	RatingAverage *float64                      `json:"rating_average" gorm:"->"`
+	Translations  Translations[BookTranslation] `json:"translations"`
+	Version       int64                         `json:"version" gorm:"default:1"`
+	CreatedAt     time.Time                     `json:"created_at" gorm:"<-:create"`
+	UpdatedAt     *time.Time                    `json:"updated_at" gorm:"<-:update"`
+	DeletedAt     gorm.DeletedAt                `json:"deleted_at"`
+}
+
+// BookVersion is a state of a book. Past versions are kept when an update
+// replaces them; the current one has no ValidTo.
+type BookVersion struct {
+	BookID       int64                         `json:"book_id" gorm:"primaryKey"`
+	Version      int64                         `json:"version" gorm:"primaryKey"`
+	ISBN         string                        `json:"isbn"`
+	Title        string                        `json:"title"`
+	Subtitle     string                        `json:"subtitle"`
+	AuthorID     int64                         `json:"author"`
+	CoverKey     string                        `json:"cover_key,omitempty"`
+	CoverType    string                        `json:"-"`
+	Translations Translations[BookTranslation] `json:"translations"`
+	ValidFrom    time.Time                     `json:"valid_from"`
+	ValidTo      *time.Time                    `json:"valid_to"`
+}
+
+// BookFilter narrows exports and listings. Zero values are ignored.
+type BookFilter struct {
+	AuthorID     int64
+	ISBN         string
+	Title        string
+	SubjectIDs   []int64
+	Query        string
+	UpdatedSince *time.Time
 }
 
 type BookRepository interface {
 	Create(ctx context.Context, book *Book) (*Book, error)
+	CreateWithEdition(ctx context.Context, book *Book, edition *Edition) (*Book, error)
 	FindByID(ctx context.Context, bookID int64) (*Book, error)
 	FindByISBN(ctx context.Context, isbn string) (*Book, error)
 	FindAll(ctx context.Context) ([]*Book, error)
+	FindAllByIDs(ctx context.Context, bookIDs []int64) ([]*Book, error)
+	FindAllBySubjectIDs(ctx context.Context, subjectIDs []int64) ([]*Book, error)
+	FindAllByTag(ctx context.Context, tag string) ([]*Book, error)
+	Stream(ctx context.Context, filter BookFilter, fn func(*Book) error) error
+	StreamCitations(ctx context.Context, filter BookFilter, fn func(*Citation) error) error
+	FindPage(ctx context.Context, filter BookFilter, pagination Pagination) ([]*Book, int64, error)
+	CountByAuthorID(ctx context.Context, authorID int64) (int64, error)
 	Update(ctx context.Context, book *Book) (*Book, error)
+	UpdateColumns(ctx context.Context, book *Book, columns []string) (*Book, error)
 	Delete(ctx context.Context, bookID int64) error
+	FindDeletedByID(ctx context.Context, bookID int64) (*Book, error)
+	FindAllDeleted(ctx context.Context) ([]*Book, error)
+	Restore(ctx context.Context, bookID int64) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	FindVersions(ctx context.Context, bookID int64) ([]*BookVersion, error)
+	FindVersion(ctx context.Context, bookID, version int64) (*BookVersion, error)
 }
 
 type BookService interface {
 	Create(ctx context.Context, book *Book) (*Book, error)
+	CreateWithEdition(ctx context.Context, book *Book, edition *Edition) (*Book, error)
 	FindByID(ctx context.Context, bookID int64) (*Book, error)
 	FindByISBN(ctx context.Context, isbn string) (*Book, error)
 	FindAll(ctx context.Context) ([]*Book, error)
+	Stream(ctx context.Context, filter BookFilter, fn func(*Book) error) error
+	StreamCitations(ctx context.Context, filter BookFilter, fn func(*Citation) error) error
 	Update(ctx context.Context, book *Book) (*Book, error)
+	Patch(ctx context.Context, book *Book, columns []string) (*Book, error)
 	Delete(ctx context.Context, bookID int64) error
+
+	FindAllDeleted(ctx context.Context) ([]*Book, error)
+	Restore(ctx context.Context, bookID int64) (*Book, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	FindVersions(ctx context.Context, bookID int64) ([]*BookVersion, error)
+	FindVersion(ctx context.Context, bookID, version int64) (*BookVersion, error)
+	DiffVersions(ctx context.Context, bookID, from, to int64) (*VersionDiff, error)
 }
@@ -0,0 +1,39 @@
+package model
+
+import "context"
+
+// CatalogEntry is a book along with the author and subjects a catalog feed
+// describes it with.
+type CatalogEntry struct {
+	Book     *Book
+	Author   *Author
+	Subjects []*Subject
+}
+
+// CatalogPage is one page of catalog entries along with the number of
+// entries on all pages.
+type CatalogPage struct {
+	Items   []*CatalogEntry
+	Page    int
+	PerPage int
+	Total   int64
+}
+
+// AuthorPage is one page of authors along with the number of authors on
+// all pages.
+type AuthorPage struct {
+	Items   []*Author
+	Page    int
+	PerPage int
+	Total   int64
+}
+
+// CatalogService serves the browsable catalog of e-reader feeds.
+type CatalogService interface {
+	FindBooks(ctx context.Context, filter BookFilter, pagination Pagination) (*CatalogPage, error)
+	FindBook(ctx context.Context, bookID int64) (*CatalogEntry, error)
+	FindBooksBySubject(ctx context.Context, subjectID int64, pagination Pagination) (*Subject, *CatalogPage, error)
+	FindBooksByAuthor(ctx context.Context, authorID int64, pagination Pagination) (*Author, *CatalogPage, error)
+	FindAuthors(ctx context.Context, pagination Pagination) (*AuthorPage, error)
+	FindSubjects(ctx context.Context) ([]*Subject, error)
+}
@@ -0,0 +1,16 @@
+package model
+
+import "context"
+
+// Citation is what a reference to a book is built from. Edition and
+// Publisher are nil when no edition with the book's ISBN is catalogued.
+type Citation struct {
+	Book      *Book
+	Author    *Author
+	Edition   *Edition
+	Publisher *Publisher
+}
+
+type CitationService interface {
+	FindByBookIDs(ctx context.Context, bookIDs []int64) ([]*Citation, error)
+}
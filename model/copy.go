@@ -0,0 +1,44 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	CopyConditionNew     = "new"
+	CopyConditionGood    = "good"
+	CopyConditionFair    = "fair"
+	CopyConditionPoor    = "poor"
+	CopyConditionDamaged = "damaged"
+)
+
+// Copy is a physical item of a book held by a branch, identified by the
+// barcode on its label.
+type Copy struct {
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	BookID    int64      `json:"book_id"`
+	Barcode   string     `json:"barcode"`
+	Branch    string     `json:"branch"`
+	Condition string     `json:"condition" gorm:"default:good"`
+	Version   int64      `json:"version" gorm:"default:1"`
+	CreatedAt time.Time  `json:"created_at" gorm:"<-:create"`
+	UpdatedAt *time.Time `json:"updated_at" gorm:"<-:update"`
+}
+
+type CopyRepository interface {
+	Create(ctx context.Context, bookCopy *Copy) (*Copy, error)
+	FindByID(ctx context.Context, copyID int64) (*Copy, error)
+	FindByBarcode(ctx context.Context, barcode string) (*Copy, error)
+	FindAllByBookID(ctx context.Context, bookID int64) ([]*Copy, error)
+	Update(ctx context.Context, bookCopy *Copy) (*Copy, error)
+	Delete(ctx context.Context, copyID int64) error
+}
+
+type CopyService interface {
+	Create(ctx context.Context, bookCopy *Copy) (*Copy, error)
+	FindByID(ctx context.Context, copyID int64) (*Copy, error)
+	FindAllByBookID(ctx context.Context, bookID int64) ([]*Copy, error)
+	Update(ctx context.Context, bookCopy *Copy) (*Copy, error)
+	Delete(ctx context.Context, copyID int64) error
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	CoverOriginal = "original"
+	CoverLarge    = "large"
+	CoverMedium   = "medium"
+	CoverSmall    = "small"
+)
+
+// Cover lists signed URLs for every stored size of a book cover.
+type Cover struct {
+	BookID    int64             `json:"book_id"`
+	Version   int64             `json:"version"`
+	URLs      map[string]string `json:"urls"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+type CoverService interface {
+	Upload(ctx context.Context, bookID int64, data []byte) (*Cover, error)
+	FindByBookID(ctx context.Context, bookID int64) (*Cover, error)
+	URL(ctx context.Context, bookID int64, size string) (string, time.Time, error)
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	EditionFormatHardcover = "hardcover"
+	EditionFormatPaperback = "paperback"
+	EditionFormatEbook     = "ebook"
+	EditionFormatAudiobook = "audiobook"
+	EditionFormatOther     = "other"
+)
+
+// Edition is a published form of a work. Its ISBN is optional, since
+// older and self-published editions often have none.
+type Edition struct {
+	ID              int64      `json:"id" gorm:"primaryKey"`
+	WorkID          int64      `json:"work_id"`
+	PublisherID     *int64     `json:"publisher_id"`
+	ISBN            string     `json:"isbn"`
+	Format          string     `json:"format" gorm:"default:other"`
+	Language        string     `json:"language"`
+	PublicationDate *time.Time `json:"publication_date"`
+	PageCount       int        `json:"page_count"`
+	Version         int64      `json:"version" gorm:"default:1"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"<-:create"`
+	UpdatedAt       *time.Time `json:"updated_at" gorm:"<-:update"`
+}
+
+type EditionRepository interface {
+	Create(ctx context.Context, edition *Edition) (*Edition, error)
+	FindByID(ctx context.Context, editionID int64) (*Edition, error)
+	FindByISBN(ctx context.Context, isbn string) (*Edition, error)
+	FindAllByIDs(ctx context.Context, editionIDs []int64) ([]*Edition, error)
+	FindAll(ctx context.Context) ([]*Edition, error)
+	FindAllByWorkID(ctx context.Context, workID int64) ([]*Edition, error)
+	CountByWorkID(ctx context.Context, workID int64) (int64, error)
+	CountByPublisherID(ctx context.Context, publisherID int64) (int64, error)
+	Update(ctx context.Context, edition *Edition) (*Edition, error)
+	Delete(ctx context.Context, editionID int64) error
+}
+
+type EditionService interface {
+	Create(ctx context.Context, edition *Edition) (*Edition, error)
+	FindByID(ctx context.Context, editionID int64) (*Edition, error)
+	FindAll(ctx context.Context) ([]*Edition, error)
+	Update(ctx context.Context, edition *Edition) (*Edition, error)
+	Delete(ctx context.Context, editionID int64) error
+}
@@ -0,0 +1,38 @@
+package model
+
+import "errors"
+
+// ErrStaleVersion is returned by repositories when a conditional update
+// does not match the stored version of the row.
+var ErrStaleVersion = errors.New("stale version")
+
+// ErrMetadataNotFound is returned by metadata providers that do not know
+// the requested ISBN.
+var ErrMetadataNotFound = errors.New("metadata not found")
+
+// ErrProviderUnavailable is returned by metadata providers when the
+// upstream catalog cannot be reached or answers with an error.
+var ErrProviderUnavailable = errors.New("metadata provider unavailable")
+
+// ErrBlobNotFound is returned by blob stores for keys that do not exist.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// ErrCopyOnLoan is returned by loan repositories when checking out a copy
+// that already has an open loan.
+var ErrCopyOnLoan = errors.New("copy is on loan")
+
+// ErrCopyNotOnLoan is returned by loan repositories when checking in a copy
+// that has no open loan.
+var ErrCopyNotOnLoan = errors.New("copy is not on loan")
+
+// ErrCopyOnHold is returned by loan repositories when checking out a copy
+// that is set aside for another reader's hold.
+var ErrCopyOnHold = errors.New("copy is on hold")
+
+// ErrHoldClosed is returned by hold repositories when cancelling a hold
+// that is no longer waiting or ready.
+var ErrHoldClosed = errors.New("hold is closed")
+
+// ErrExceedsBalance is returned by ledger repositories when a payment or
+// waiver is larger than what the user owes.
+var ErrExceedsBalance = errors.New("amount exceeds balance")
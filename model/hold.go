@@ -0,0 +1,68 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	HoldStatusWaiting   = "waiting"
+	HoldStatusReady     = "ready"
+	HoldStatusFulfilled = "fulfilled"
+	HoldStatusCancelled = "cancelled"
+	HoldStatusExpired   = "expired"
+)
+
+// Hold is a reader's place in the queue for a book. A waiting hold becomes
+// ready when a copy is set aside for it, and is fulfilled when that copy is
+// checked out to the reader before ExpiresAt.
+type Hold struct {
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	BookID    int64      `json:"book_id"`
+	UserID    int64      `json:"user_id"`
+	Status    string     `json:"status" gorm:"default:waiting"`
+	CopyID    *int64     `json:"copy_id"`
+	PlacedAt  time.Time  `json:"placed_at"`
+	ReadyAt   *time.Time `json:"ready_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+	// Position is the 1-based place of a waiting hold in its book's queue,
+	// and 0 for holds in any other status.
+	Position  int64      `json:"position" gorm:"->;-:migration"`
+	Version   int64      `json:"version" gorm:"default:1"`
+	CreatedAt time.Time  `json:"created_at" gorm:"<-:create"`
+	UpdatedAt *time.Time `json:"updated_at" gorm:"<-:update"`
+}
+
+// Availability summarizes the copies of a book. Available copies are
+// neither on loan nor set aside for a hold.
+type Availability struct {
+	BookID       int64 `json:"book_id"`
+	Copies       int64 `json:"copies"`
+	Available    int64 `json:"available"`
+	WaitingHolds int64 `json:"waiting_holds"`
+}
+
+type HoldRepository interface {
+	Create(ctx context.Context, hold *Hold, readyAt, expiresAt time.Time) (*Hold, error)
+	FindByID(ctx context.Context, holdID int64) (*Hold, error)
+	FindActive(ctx context.Context, bookID, userID int64) (*Hold, error)
+	FindAllByBookID(ctx context.Context, bookID int64) ([]*Hold, error)
+	FindAllByUserID(ctx context.Context, userID int64, activeOnly bool) ([]*Hold, error)
+	FindAvailability(ctx context.Context, bookID int64) (*Availability, error)
+	AssignNext(ctx context.Context, bookID int64, readyAt, expiresAt time.Time) (*Hold, error)
+	Cancel(ctx context.Context, holdID int64, closedAt time.Time) (*Hold, error)
+	ExpireReady(ctx context.Context, now time.Time) ([]*Hold, error)
+	FindPendingBookIDs(ctx context.Context) ([]int64, error)
+}
+
+type HoldService interface {
+	Place(ctx context.Context, bookID, userID int64) (*Hold, error)
+	FindByID(ctx context.Context, holdID int64) (*Hold, error)
+	FindAllByBookID(ctx context.Context, bookID int64) ([]*Hold, error)
+	FindAllByUserID(ctx context.Context, userID int64, activeOnly bool) ([]*Hold, error)
+	FindAvailability(ctx context.Context, bookID int64) (*Availability, error)
+	Allocate(ctx context.Context, bookID int64) error
+	Cancel(ctx context.Context, holdID int64) (*Hold, error)
+	ExpirePickups(ctx context.Context) (int, error)
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ImportFormatCSV     = "csv"
+	ImportFormatNDJSON  = "ndjson"
+	ImportFormatMARC    = "marc"
+	ImportFormatMARCXML = "marcxml"
+
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+
+	ImportInterrupted = "interrupted by a server shutdown"
+)
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportJob struct {
+	ID             int64            `json:"id" gorm:"primaryKey"`
+	UserID         int64            `json:"user_id"`
+	Format         string           `json:"format"`
+	DryRun         bool             `json:"dry_run"`
+	Status         string           `json:"status"`
+	TotalRows      int              `json:"total_rows"`
+	ProcessedRows  int              `json:"processed_rows"`
+	CreatedBooks   int              `json:"created_books"`
+	CreatedAuthors int              `json:"created_authors"`
+	FailedRows     int              `json:"failed_rows"`
+	Errors         []ImportRowError `json:"errors" gorm:"serializer:json"`
+	CreatedAt      time.Time        `json:"created_at" gorm:"<-:create"`
+	UpdatedAt      *time.Time       `json:"updated_at" gorm:"<-:update"`
+	FinishedAt     *time.Time       `json:"finished_at"`
+}
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *ImportJob) (*ImportJob, error)
+	FindByID(ctx context.Context, jobID int64) (*ImportJob, error)
+	FindAllByUserID(ctx context.Context, userID int64) ([]*ImportJob, error)
+	Update(ctx context.Context, job *ImportJob) (*ImportJob, error)
+	FailUnfinished(ctx context.Context, finishedAt time.Time) (int64, error)
+}
+
+type ImportService interface {
+	Create(ctx context.Context, job *ImportJob, data []byte) (*ImportJob, error)
+	FindByID(ctx context.Context, jobID, userID int64) (*ImportJob, error)
+	FindAllByUserID(ctx context.Context, userID int64) ([]*ImportJob, error)
+
+	FailUnfinished(ctx context.Context) (int64, error)
+
+	Run(ctx context.Context, job *ImportJob, data []byte)
+	Wait()
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Loan records a copy lent to a user. It is open until ReturnedAt is set.
+type Loan struct {
+	ID           int64      `json:"id" gorm:"primaryKey"`
+	CopyID       int64      `json:"copy_id"`
+	UserID       int64      `json:"user_id"`
+	CheckedOutAt time.Time  `json:"checked_out_at"`
+	DueAt        time.Time  `json:"due_at"`
+	Renewals     int        `json:"renewals"`
+	ReturnedAt   *time.Time `json:"returned_at"`
+	Version      int64      `json:"version" gorm:"default:1"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"<-:create"`
+	UpdatedAt    *time.Time `json:"updated_at" gorm:"<-:update"`
+}
+
+type LoanRepository interface {
+	Checkout(ctx context.Context, loan *Loan) (*Loan, error)
+	Checkin(ctx context.Context, copyID int64, returnedAt time.Time) (*Loan, error)
+	Renew(ctx context.Context, loan *Loan) (*Loan, error)
+	FindByID(ctx context.Context, loanID int64) (*Loan, error)
+	FindAllByUserID(ctx context.Context, userID int64, openOnly bool) ([]*Loan, error)
+	CountByCopyID(ctx context.Context, copyID int64) (int64, error)
+}
+
+type LoanService interface {
+	Checkout(ctx context.Context, barcode string, userID int64) (*Loan, error)
+	Checkin(ctx context.Context, barcode string) (*Loan, error)
+	Renew(ctx context.Context, loanID int64) (*Loan, error)
+	FindByID(ctx context.Context, loanID int64) (*Loan, error)
+	FindAllByUserID(ctx context.Context, userID int64, openOnly bool) ([]*Loan, error)
+}
@@ -0,0 +1,31 @@
+package model
+
+import "context"
+
+// BookMetadata is what an external catalog knows about an ISBN. It is
+// never stored, only used to prefill book and author forms.
+type BookMetadata struct {
+	ISBN        string           `json:"isbn"`
+	Title       string           `json:"title"`
+	Subtitle    string           `json:"subtitle,omitempty"`
+	Authors     []MetadataAuthor `json:"authors"`
+	Publisher   string           `json:"publisher"`
+	PublishDate string           `json:"publish_date"`
+	PageCount   int              `json:"page_count"`
+	CoverURL    string           `json:"cover_url"`
+}
+
+// MetadataAuthor carries the ID of the matching local author when one
+// with the same name already exists.
+type MetadataAuthor struct {
+	Name     string `json:"name"`
+	AuthorID int64  `json:"author_id,omitempty"`
+}
+
+type MetadataProvider interface {
+	LookupISBN(ctx context.Context, isbn string) (*BookMetadata, error)
+}
+
+type MetadataService interface {
+	Lookup(ctx context.Context, isbn string) (*BookMetadata, error)
+}
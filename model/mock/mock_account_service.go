@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: AccountService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_account_service.go -package=mock github.com/rhtyx/bayarind-service.git/model AccountService
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountService is a mock of AccountService interface.
+type MockAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountServiceMockRecorder
+}
+
+// MockAccountServiceMockRecorder is the mock recorder for MockAccountService.
+type MockAccountServiceMockRecorder struct {
+	mock *MockAccountService
+}
+
+// NewMockAccountService creates a new mock instance.
+func NewMockAccountService(ctrl *gomock.Controller) *MockAccountService {
+	mock := &MockAccountService{ctrl: ctrl}
+	mock.recorder = &MockAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// Charge mocks base method.
+func (m *MockAccountService) Charge(arg0 context.Context, arg1 *model.LedgerEntry) (*model.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Charge", arg0, arg1)
+	ret0, _ := ret[0].(*model.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Charge indicates an expected call of Charge.
+func (mr *MockAccountServiceMockRecorder) Charge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Charge", reflect.TypeOf((*MockAccountService)(nil).Charge), arg0, arg1)
+}
+
+// ChargeOverdue mocks base method.
+func (m *MockAccountService) ChargeOverdue(arg0 context.Context, arg1 *model.Loan) (*model.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeOverdue", arg0, arg1)
+	ret0, _ := ret[0].(*model.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeOverdue indicates an expected call of ChargeOverdue.
+func (mr *MockAccountServiceMockRecorder) ChargeOverdue(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeOverdue", reflect.TypeOf((*MockAccountService)(nil).ChargeOverdue), arg0, arg1)
+}
+
+// CheckStanding mocks base method.
+func (m *MockAccountService) CheckStanding(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckStanding", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckStanding indicates an expected call of CheckStanding.
+func (mr *MockAccountServiceMockRecorder) CheckStanding(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckStanding", reflect.TypeOf((*MockAccountService)(nil).CheckStanding), arg0, arg1)
+}
+
+// Credit mocks base method.
+func (m *MockAccountService) Credit(arg0 context.Context, arg1 *model.LedgerEntry) (*model.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Credit", arg0, arg1)
+	ret0, _ := ret[0].(*model.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Credit indicates an expected call of Credit.
+func (mr *MockAccountServiceMockRecorder) Credit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Credit", reflect.TypeOf((*MockAccountService)(nil).Credit), arg0, arg1)
+}
+
+// FindAccount mocks base method.
+func (m *MockAccountService) FindAccount(arg0 context.Context, arg1 int64) (*model.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAccount", arg0, arg1)
+	ret0, _ := ret[0].(*model.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAccount indicates an expected call of FindAccount.
+func (mr *MockAccountServiceMockRecorder) FindAccount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAccount", reflect.TypeOf((*MockAccountService)(nil).FindAccount), arg0, arg1)
+}
+
+// FindStanding mocks base method.
+func (m *MockAccountService) FindStanding(arg0 context.Context, arg1 int64) (*model.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStanding", arg0, arg1)
+	ret0, _ := ret[0].(*model.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStanding indicates an expected call of FindStanding.
+func (mr *MockAccountServiceMockRecorder) FindStanding(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStanding", reflect.TypeOf((*MockAccountService)(nil).FindStanding), arg0, arg1)
+}
@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/rhtyx/bayarind-service.git/model"
 	gomock "go.uber.org/mock/gomock"
@@ -69,6 +70,34 @@ func (mr *MockAuthorRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAuthorRepository)(nil).Delete), arg0, arg1)
 }
 
+// DeleteAndReassignBooks mocks base method.
+func (m *MockAuthorRepository) DeleteAndReassignBooks(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAndReassignBooks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAndReassignBooks indicates an expected call of DeleteAndReassignBooks.
+func (mr *MockAuthorRepositoryMockRecorder) DeleteAndReassignBooks(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAndReassignBooks", reflect.TypeOf((*MockAuthorRepository)(nil).DeleteAndReassignBooks), arg0, arg1, arg2)
+}
+
+// DeleteWithBooks mocks base method.
+func (m *MockAuthorRepository) DeleteWithBooks(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithBooks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWithBooks indicates an expected call of DeleteWithBooks.
+func (mr *MockAuthorRepositoryMockRecorder) DeleteWithBooks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithBooks", reflect.TypeOf((*MockAuthorRepository)(nil).DeleteWithBooks), arg0, arg1)
+}
+
 // FindAll mocks base method.
 func (m *MockAuthorRepository) FindAll(arg0 context.Context) ([]*model.Author, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +113,66 @@ func (mr *MockAuthorRepositoryMockRecorder) FindAll(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAuthorRepository)(nil).FindAll), arg0)
 }
 
+// FindAllByIDs mocks base method.
+func (m *MockAuthorRepository) FindAllByIDs(arg0 context.Context, arg1 []int64) ([]*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByIDs indicates an expected call of FindAllByIDs.
+func (mr *MockAuthorRepositoryMockRecorder) FindAllByIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByIDs", reflect.TypeOf((*MockAuthorRepository)(nil).FindAllByIDs), arg0, arg1)
+}
+
+// FindAllByName mocks base method.
+func (m *MockAuthorRepository) FindAllByName(arg0 context.Context, arg1 string) ([]*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByName", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByName indicates an expected call of FindAllByName.
+func (mr *MockAuthorRepositoryMockRecorder) FindAllByName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByName", reflect.TypeOf((*MockAuthorRepository)(nil).FindAllByName), arg0, arg1)
+}
+
+// FindAllDeleted mocks base method.
+func (m *MockAuthorRepository) FindAllDeleted(arg0 context.Context) ([]*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllDeleted", arg0)
+	ret0, _ := ret[0].([]*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllDeleted indicates an expected call of FindAllDeleted.
+func (mr *MockAuthorRepositoryMockRecorder) FindAllDeleted(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllDeleted", reflect.TypeOf((*MockAuthorRepository)(nil).FindAllDeleted), arg0)
+}
+
+// FindAllSharingBirthDate mocks base method.
+func (m *MockAuthorRepository) FindAllSharingBirthDate(arg0 context.Context) ([]*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllSharingBirthDate", arg0)
+	ret0, _ := ret[0].([]*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllSharingBirthDate indicates an expected call of FindAllSharingBirthDate.
+func (mr *MockAuthorRepositoryMockRecorder) FindAllSharingBirthDate(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllSharingBirthDate", reflect.TypeOf((*MockAuthorRepository)(nil).FindAllSharingBirthDate), arg0)
+}
+
 // FindByID mocks base method.
 func (m *MockAuthorRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Author, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +188,154 @@ func (mr *MockAuthorRepositoryMockRecorder) FindByID(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAuthorRepository)(nil).FindByID), arg0, arg1)
 }
 
+// FindByName mocks base method.
+func (m *MockAuthorRepository) FindByName(arg0 context.Context, arg1 string) (*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", arg0, arg1)
+	ret0, _ := ret[0].(*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockAuthorRepositoryMockRecorder) FindByName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockAuthorRepository)(nil).FindByName), arg0, arg1)
+}
+
+// FindDeletedByID mocks base method.
+func (m *MockAuthorRepository) FindDeletedByID(arg0 context.Context, arg1 int64) (*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByID indicates an expected call of FindDeletedByID.
+func (mr *MockAuthorRepositoryMockRecorder) FindDeletedByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByID", reflect.TypeOf((*MockAuthorRepository)(nil).FindDeletedByID), arg0, arg1)
+}
+
+// FindPage mocks base method.
+func (m *MockAuthorRepository) FindPage(arg0 context.Context, arg1 model.Pagination) ([]*model.Author, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPage", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Author)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPage indicates an expected call of FindPage.
+func (mr *MockAuthorRepositoryMockRecorder) FindPage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPage", reflect.TypeOf((*MockAuthorRepository)(nil).FindPage), arg0, arg1)
+}
+
+// FindRedirect mocks base method.
+func (m *MockAuthorRepository) FindRedirect(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRedirect", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRedirect indicates an expected call of FindRedirect.
+func (mr *MockAuthorRepositoryMockRecorder) FindRedirect(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRedirect", reflect.TypeOf((*MockAuthorRepository)(nil).FindRedirect), arg0, arg1)
+}
+
+// FindVersion mocks base method.
+func (m *MockAuthorRepository) FindVersion(arg0 context.Context, arg1, arg2 int64) (*model.AuthorVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.AuthorVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockAuthorRepositoryMockRecorder) FindVersion(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockAuthorRepository)(nil).FindVersion), arg0, arg1, arg2)
+}
+
+// FindVersions mocks base method.
+func (m *MockAuthorRepository) FindVersions(arg0 context.Context, arg1 int64) ([]*model.AuthorVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersions", arg0, arg1)
+	ret0, _ := ret[0].([]*model.AuthorVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersions indicates an expected call of FindVersions.
+func (mr *MockAuthorRepositoryMockRecorder) FindVersions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersions", reflect.TypeOf((*MockAuthorRepository)(nil).FindVersions), arg0, arg1)
+}
+
+// Merge mocks base method.
+func (m *MockAuthorRepository) Merge(arg0 context.Context, arg1 int64, arg2 []int64, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockAuthorRepositoryMockRecorder) Merge(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockAuthorRepository)(nil).Merge), arg0, arg1, arg2, arg3)
+}
+
+// Purge mocks base method.
+func (m *MockAuthorRepository) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockAuthorRepositoryMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockAuthorRepository)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockAuthorRepository) Restore(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockAuthorRepositoryMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAuthorRepository)(nil).Restore), arg0, arg1)
+}
+
+// Stream mocks base method.
+func (m *MockAuthorRepository) Stream(arg0 context.Context, arg1 model.AuthorFilter, arg2 func(*model.Author) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockAuthorRepositoryMockRecorder) Stream(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockAuthorRepository)(nil).Stream), arg0, arg1, arg2)
+}
+
 // Update mocks base method.
 func (m *MockAuthorRepository) Update(arg0 context.Context, arg1 *model.Author) (*model.Author, error) {
 	m.ctrl.T.Helper()
@@ -113,3 +350,18 @@ func (mr *MockAuthorRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAuthorRepository)(nil).Update), arg0, arg1)
 }
+
+// UpdateColumns mocks base method.
+func (m *MockAuthorRepository) UpdateColumns(arg0 context.Context, arg1 *model.Author, arg2 []string) (*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateColumns", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateColumns indicates an expected call of UpdateColumns.
+func (mr *MockAuthorRepositoryMockRecorder) UpdateColumns(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumns", reflect.TypeOf((*MockAuthorRepository)(nil).UpdateColumns), arg0, arg1, arg2)
+}
@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/rhtyx/bayarind-service.git/model"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,21 @@ func (m *MockBookRepository) EXPECT() *MockBookRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByAuthorID mocks base method.
+func (m *MockBookRepository) CountByAuthorID(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByAuthorID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByAuthorID indicates an expected call of CountByAuthorID.
+func (mr *MockBookRepositoryMockRecorder) CountByAuthorID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByAuthorID", reflect.TypeOf((*MockBookRepository)(nil).CountByAuthorID), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockBookRepository) Create(arg0 context.Context, arg1 *model.Book) (*model.Book, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +71,21 @@ func (mr *MockBookRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookRepository)(nil).Create), arg0, arg1)
 }
 
+// CreateWithEdition mocks base method.
+func (m *MockBookRepository) CreateWithEdition(arg0 context.Context, arg1 *model.Book, arg2 *model.Edition) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithEdition", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithEdition indicates an expected call of CreateWithEdition.
+func (mr *MockBookRepositoryMockRecorder) CreateWithEdition(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithEdition", reflect.TypeOf((*MockBookRepository)(nil).CreateWithEdition), arg0, arg1, arg2)
+}
+
 // Delete mocks base method.
 func (m *MockBookRepository) Delete(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -84,6 +115,66 @@ func (mr *MockBookRepositoryMockRecorder) FindAll(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBookRepository)(nil).FindAll), arg0)
 }
 
+// FindAllByIDs mocks base method.
+func (m *MockBookRepository) FindAllByIDs(arg0 context.Context, arg1 []int64) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByIDs indicates an expected call of FindAllByIDs.
+func (mr *MockBookRepositoryMockRecorder) FindAllByIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByIDs", reflect.TypeOf((*MockBookRepository)(nil).FindAllByIDs), arg0, arg1)
+}
+
+// FindAllBySubjectIDs mocks base method.
+func (m *MockBookRepository) FindAllBySubjectIDs(arg0 context.Context, arg1 []int64) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllBySubjectIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllBySubjectIDs indicates an expected call of FindAllBySubjectIDs.
+func (mr *MockBookRepositoryMockRecorder) FindAllBySubjectIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllBySubjectIDs", reflect.TypeOf((*MockBookRepository)(nil).FindAllBySubjectIDs), arg0, arg1)
+}
+
+// FindAllByTag mocks base method.
+func (m *MockBookRepository) FindAllByTag(arg0 context.Context, arg1 string) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByTag", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByTag indicates an expected call of FindAllByTag.
+func (mr *MockBookRepositoryMockRecorder) FindAllByTag(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByTag", reflect.TypeOf((*MockBookRepository)(nil).FindAllByTag), arg0, arg1)
+}
+
+// FindAllDeleted mocks base method.
+func (m *MockBookRepository) FindAllDeleted(arg0 context.Context) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllDeleted", arg0)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllDeleted indicates an expected call of FindAllDeleted.
+func (mr *MockBookRepositoryMockRecorder) FindAllDeleted(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllDeleted", reflect.TypeOf((*MockBookRepository)(nil).FindAllDeleted), arg0)
+}
+
 // FindByID mocks base method.
 func (m *MockBookRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Book, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +205,124 @@ func (mr *MockBookRepositoryMockRecorder) FindByISBN(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByISBN", reflect.TypeOf((*MockBookRepository)(nil).FindByISBN), arg0, arg1)
 }
 
+// FindDeletedByID mocks base method.
+func (m *MockBookRepository) FindDeletedByID(arg0 context.Context, arg1 int64) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByID indicates an expected call of FindDeletedByID.
+func (mr *MockBookRepositoryMockRecorder) FindDeletedByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByID", reflect.TypeOf((*MockBookRepository)(nil).FindDeletedByID), arg0, arg1)
+}
+
+// FindPage mocks base method.
+func (m *MockBookRepository) FindPage(arg0 context.Context, arg1 model.BookFilter, arg2 model.Pagination) ([]*model.Book, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPage indicates an expected call of FindPage.
+func (mr *MockBookRepositoryMockRecorder) FindPage(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPage", reflect.TypeOf((*MockBookRepository)(nil).FindPage), arg0, arg1, arg2)
+}
+
+// FindVersion mocks base method.
+func (m *MockBookRepository) FindVersion(arg0 context.Context, arg1, arg2 int64) (*model.BookVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.BookVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockBookRepositoryMockRecorder) FindVersion(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockBookRepository)(nil).FindVersion), arg0, arg1, arg2)
+}
+
+// FindVersions mocks base method.
+func (m *MockBookRepository) FindVersions(arg0 context.Context, arg1 int64) ([]*model.BookVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersions", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BookVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersions indicates an expected call of FindVersions.
+func (mr *MockBookRepositoryMockRecorder) FindVersions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersions", reflect.TypeOf((*MockBookRepository)(nil).FindVersions), arg0, arg1)
+}
+
+// Purge mocks base method.
+func (m *MockBookRepository) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockBookRepositoryMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockBookRepository)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockBookRepository) Restore(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBookRepositoryMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBookRepository)(nil).Restore), arg0, arg1)
+}
+
+// Stream mocks base method.
+func (m *MockBookRepository) Stream(arg0 context.Context, arg1 model.BookFilter, arg2 func(*model.Book) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockBookRepositoryMockRecorder) Stream(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockBookRepository)(nil).Stream), arg0, arg1, arg2)
+}
+
+// StreamCitations mocks base method.
+func (m *MockBookRepository) StreamCitations(arg0 context.Context, arg1 model.BookFilter, arg2 func(*model.Citation) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCitations", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamCitations indicates an expected call of StreamCitations.
+func (mr *MockBookRepositoryMockRecorder) StreamCitations(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCitations", reflect.TypeOf((*MockBookRepository)(nil).StreamCitations), arg0, arg1, arg2)
+}
+
 // Update mocks base method.
 func (m *MockBookRepository) Update(arg0 context.Context, arg1 *model.Book) (*model.Book, error) {
 	m.ctrl.T.Helper()
@@ -128,3 +337,18 @@ func (mr *MockBookRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookRepository)(nil).Update), arg0, arg1)
 }
+
+// UpdateColumns mocks base method.
+func (m *MockBookRepository) UpdateColumns(arg0 context.Context, arg1 *model.Book, arg2 []string) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateColumns", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateColumns indicates an expected call of UpdateColumns.
+func (mr *MockBookRepositoryMockRecorder) UpdateColumns(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateColumns", reflect.TypeOf((*MockBookRepository)(nil).UpdateColumns), arg0, arg1, arg2)
+}
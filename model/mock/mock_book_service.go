@@ -0,0 +1,279 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: BookService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_book_service.go -package=mock github.com/rhtyx/bayarind-service.git/model BookService
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBookService is a mock of BookService interface.
+type MockBookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookServiceMockRecorder
+}
+
+// MockBookServiceMockRecorder is the mock recorder for MockBookService.
+type MockBookServiceMockRecorder struct {
+	mock *MockBookService
+}
+
+// NewMockBookService creates a new mock instance.
+func NewMockBookService(ctrl *gomock.Controller) *MockBookService {
+	mock := &MockBookService{ctrl: ctrl}
+	mock.recorder = &MockBookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookService) EXPECT() *MockBookServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBookService) Create(arg0 context.Context, arg1 *model.Book) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookService)(nil).Create), arg0, arg1)
+}
+
+// CreateWithEdition mocks base method.
+func (m *MockBookService) CreateWithEdition(arg0 context.Context, arg1 *model.Book, arg2 *model.Edition) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithEdition", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithEdition indicates an expected call of CreateWithEdition.
+func (mr *MockBookServiceMockRecorder) CreateWithEdition(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithEdition", reflect.TypeOf((*MockBookService)(nil).CreateWithEdition), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockBookService) Delete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBookServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBookService)(nil).Delete), arg0, arg1)
+}
+
+// DiffVersions mocks base method.
+func (m *MockBookService) DiffVersions(arg0 context.Context, arg1, arg2, arg3 int64) (*model.VersionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffVersions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.VersionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffVersions indicates an expected call of DiffVersions.
+func (mr *MockBookServiceMockRecorder) DiffVersions(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffVersions", reflect.TypeOf((*MockBookService)(nil).DiffVersions), arg0, arg1, arg2, arg3)
+}
+
+// FindAll mocks base method.
+func (m *MockBookService) FindAll(arg0 context.Context) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockBookServiceMockRecorder) FindAll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBookService)(nil).FindAll), arg0)
+}
+
+// FindAllDeleted mocks base method.
+func (m *MockBookService) FindAllDeleted(arg0 context.Context) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllDeleted", arg0)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllDeleted indicates an expected call of FindAllDeleted.
+func (mr *MockBookServiceMockRecorder) FindAllDeleted(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllDeleted", reflect.TypeOf((*MockBookService)(nil).FindAllDeleted), arg0)
+}
+
+// FindByID mocks base method.
+func (m *MockBookService) FindByID(arg0 context.Context, arg1 int64) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBookServiceMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBookService)(nil).FindByID), arg0, arg1)
+}
+
+// FindByISBN mocks base method.
+func (m *MockBookService) FindByISBN(arg0 context.Context, arg1 string) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByISBN", arg0, arg1)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByISBN indicates an expected call of FindByISBN.
+func (mr *MockBookServiceMockRecorder) FindByISBN(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByISBN", reflect.TypeOf((*MockBookService)(nil).FindByISBN), arg0, arg1)
+}
+
+// FindVersion mocks base method.
+func (m *MockBookService) FindVersion(arg0 context.Context, arg1, arg2 int64) (*model.BookVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.BookVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockBookServiceMockRecorder) FindVersion(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockBookService)(nil).FindVersion), arg0, arg1, arg2)
+}
+
+// FindVersions mocks base method.
+func (m *MockBookService) FindVersions(arg0 context.Context, arg1 int64) ([]*model.BookVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersions", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BookVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersions indicates an expected call of FindVersions.
+func (mr *MockBookServiceMockRecorder) FindVersions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersions", reflect.TypeOf((*MockBookService)(nil).FindVersions), arg0, arg1)
+}
+
+// Patch mocks base method.
+func (m *MockBookService) Patch(arg0 context.Context, arg1 *model.Book, arg2 []string) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockBookServiceMockRecorder) Patch(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockBookService)(nil).Patch), arg0, arg1, arg2)
+}
+
+// Purge mocks base method.
+func (m *MockBookService) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockBookServiceMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockBookService)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockBookService) Restore(arg0 context.Context, arg1 int64) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBookServiceMockRecorder) Restore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBookService)(nil).Restore), arg0, arg1)
+}
+
+// Stream mocks base method.
+func (m *MockBookService) Stream(arg0 context.Context, arg1 model.BookFilter, arg2 func(*model.Book) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockBookServiceMockRecorder) Stream(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockBookService)(nil).Stream), arg0, arg1, arg2)
+}
+
+// StreamCitations mocks base method.
+func (m *MockBookService) StreamCitations(arg0 context.Context, arg1 model.BookFilter, arg2 func(*model.Citation) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCitations", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamCitations indicates an expected call of StreamCitations.
+func (mr *MockBookServiceMockRecorder) StreamCitations(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCitations", reflect.TypeOf((*MockBookService)(nil).StreamCitations), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockBookService) Update(arg0 context.Context, arg1 *model.Book) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookServiceMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookService)(nil).Update), arg0, arg1)
+}
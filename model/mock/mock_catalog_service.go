@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: CatalogService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_catalog_service.go -package=mock github.com/rhtyx/bayarind-service.git/model CatalogService
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogService is a mock of CatalogService interface.
+type MockCatalogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogServiceMockRecorder
+}
+
+// MockCatalogServiceMockRecorder is the mock recorder for MockCatalogService.
+type MockCatalogServiceMockRecorder struct {
+	mock *MockCatalogService
+}
+
+// NewMockCatalogService creates a new mock instance.
+func NewMockCatalogService(ctrl *gomock.Controller) *MockCatalogService {
+	mock := &MockCatalogService{ctrl: ctrl}
+	mock.recorder = &MockCatalogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogService) EXPECT() *MockCatalogServiceMockRecorder {
+	return m.recorder
+}
+
+// FindAuthors mocks base method.
+func (m *MockCatalogService) FindAuthors(arg0 context.Context, arg1 model.Pagination) (*model.AuthorPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAuthors", arg0, arg1)
+	ret0, _ := ret[0].(*model.AuthorPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAuthors indicates an expected call of FindAuthors.
+func (mr *MockCatalogServiceMockRecorder) FindAuthors(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAuthors", reflect.TypeOf((*MockCatalogService)(nil).FindAuthors), arg0, arg1)
+}
+
+// FindBook mocks base method.
+func (m *MockCatalogService) FindBook(arg0 context.Context, arg1 int64) (*model.CatalogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBook", arg0, arg1)
+	ret0, _ := ret[0].(*model.CatalogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBook indicates an expected call of FindBook.
+func (mr *MockCatalogServiceMockRecorder) FindBook(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBook", reflect.TypeOf((*MockCatalogService)(nil).FindBook), arg0, arg1)
+}
+
+// FindBooks mocks base method.
+func (m *MockCatalogService) FindBooks(arg0 context.Context, arg1 model.BookFilter, arg2 model.Pagination) (*model.CatalogPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBooks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.CatalogPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBooks indicates an expected call of FindBooks.
+func (mr *MockCatalogServiceMockRecorder) FindBooks(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBooks", reflect.TypeOf((*MockCatalogService)(nil).FindBooks), arg0, arg1, arg2)
+}
+
+// FindBooksByAuthor mocks base method.
+func (m *MockCatalogService) FindBooksByAuthor(arg0 context.Context, arg1 int64, arg2 model.Pagination) (*model.Author, *model.CatalogPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBooksByAuthor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Author)
+	ret1, _ := ret[1].(*model.CatalogPage)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindBooksByAuthor indicates an expected call of FindBooksByAuthor.
+func (mr *MockCatalogServiceMockRecorder) FindBooksByAuthor(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBooksByAuthor", reflect.TypeOf((*MockCatalogService)(nil).FindBooksByAuthor), arg0, arg1, arg2)
+}
+
+// FindBooksBySubject mocks base method.
+func (m *MockCatalogService) FindBooksBySubject(arg0 context.Context, arg1 int64, arg2 model.Pagination) (*model.Subject, *model.CatalogPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBooksBySubject", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Subject)
+	ret1, _ := ret[1].(*model.CatalogPage)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindBooksBySubject indicates an expected call of FindBooksBySubject.
+func (mr *MockCatalogServiceMockRecorder) FindBooksBySubject(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBooksBySubject", reflect.TypeOf((*MockCatalogService)(nil).FindBooksBySubject), arg0, arg1, arg2)
+}
+
+// FindSubjects mocks base method.
+func (m *MockCatalogService) FindSubjects(arg0 context.Context) ([]*model.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSubjects", arg0)
+	ret0, _ := ret[0].([]*model.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSubjects indicates an expected call of FindSubjects.
+func (mr *MockCatalogServiceMockRecorder) FindSubjects(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSubjects", reflect.TypeOf((*MockCatalogService)(nil).FindSubjects), arg0)
+}
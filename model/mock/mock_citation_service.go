@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: CitationService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_citation_service.go -package=mock github.com/rhtyx/bayarind-service.git/model CitationService
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCitationService is a mock of CitationService interface.
+type MockCitationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCitationServiceMockRecorder
+}
+
+// MockCitationServiceMockRecorder is the mock recorder for MockCitationService.
+type MockCitationServiceMockRecorder struct {
+	mock *MockCitationService
+}
+
+// NewMockCitationService creates a new mock instance.
+func NewMockCitationService(ctrl *gomock.Controller) *MockCitationService {
+	mock := &MockCitationService{ctrl: ctrl}
+	mock.recorder = &MockCitationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCitationService) EXPECT() *MockCitationServiceMockRecorder {
+	return m.recorder
+}
+
+// FindByBookIDs mocks base method.
+func (m *MockCitationService) FindByBookIDs(arg0 context.Context, arg1 []int64) ([]*model.Citation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBookIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Citation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBookIDs indicates an expected call of FindByBookIDs.
+func (mr *MockCitationServiceMockRecorder) FindByBookIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBookIDs", reflect.TypeOf((*MockCitationService)(nil).FindByBookIDs), arg0, arg1)
+}
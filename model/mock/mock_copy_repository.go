@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: CopyRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_copy_repository.go -package=mock github.com/rhtyx/bayarind-service.git/model CopyRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCopyRepository is a mock of CopyRepository interface.
+type MockCopyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCopyRepositoryMockRecorder
+}
+
+// MockCopyRepositoryMockRecorder is the mock recorder for MockCopyRepository.
+type MockCopyRepositoryMockRecorder struct {
+	mock *MockCopyRepository
+}
+
+// NewMockCopyRepository creates a new mock instance.
+func NewMockCopyRepository(ctrl *gomock.Controller) *MockCopyRepository {
+	mock := &MockCopyRepository{ctrl: ctrl}
+	mock.recorder = &MockCopyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCopyRepository) EXPECT() *MockCopyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCopyRepository) Create(arg0 context.Context, arg1 *model.Copy) (*model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCopyRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCopyRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockCopyRepository) Delete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCopyRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCopyRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockCopyRepository) FindAllByBookID(arg0 context.Context, arg1 int64) ([]*model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockCopyRepositoryMockRecorder) FindAllByBookID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockCopyRepository)(nil).FindAllByBookID), arg0, arg1)
+}
+
+// FindByBarcode mocks base method.
+func (m *MockCopyRepository) FindByBarcode(arg0 context.Context, arg1 string) (*model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBarcode", arg0, arg1)
+	ret0, _ := ret[0].(*model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBarcode indicates an expected call of FindByBarcode.
+func (mr *MockCopyRepositoryMockRecorder) FindByBarcode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBarcode", reflect.TypeOf((*MockCopyRepository)(nil).FindByBarcode), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockCopyRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCopyRepositoryMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCopyRepository)(nil).FindByID), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockCopyRepository) Update(arg0 context.Context, arg1 *model.Copy) (*model.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*model.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCopyRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCopyRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,190 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: EditionRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_edition_repository.go -package=mock github.com/rhtyx/bayarind-service.git/model EditionRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEditionRepository is a mock of EditionRepository interface.
+type MockEditionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEditionRepositoryMockRecorder
+}
+
+// MockEditionRepositoryMockRecorder is the mock recorder for MockEditionRepository.
+type MockEditionRepositoryMockRecorder struct {
+	mock *MockEditionRepository
+}
+
+// NewMockEditionRepository creates a new mock instance.
+func NewMockEditionRepository(ctrl *gomock.Controller) *MockEditionRepository {
+	mock := &MockEditionRepository{ctrl: ctrl}
+	mock.recorder = &MockEditionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEditionRepository) EXPECT() *MockEditionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByPublisherID mocks base method.
+func (m *MockEditionRepository) CountByPublisherID(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByPublisherID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByPublisherID indicates an expected call of CountByPublisherID.
+func (mr *MockEditionRepositoryMockRecorder) CountByPublisherID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByPublisherID", reflect.TypeOf((*MockEditionRepository)(nil).CountByPublisherID), arg0, arg1)
+}
+
+// CountByWorkID mocks base method.
+func (m *MockEditionRepository) CountByWorkID(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByWorkID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByWorkID indicates an expected call of CountByWorkID.
+func (mr *MockEditionRepositoryMockRecorder) CountByWorkID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByWorkID", reflect.TypeOf((*MockEditionRepository)(nil).CountByWorkID), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockEditionRepository) Create(arg0 context.Context, arg1 *model.Edition) (*model.Edition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEditionRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEditionRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockEditionRepository) Delete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockEditionRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEditionRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockEditionRepository) FindAll(arg0 context.Context) ([]*model.Edition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockEditionRepositoryMockRecorder) FindAll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockEditionRepository)(nil).FindAll), arg0)
+}
+
+// FindAllByIDs mocks base method.
+func (m *MockEditionRepository) FindAllByIDs(arg0 context.Context, arg1 []int64) ([]*model.Edition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByIDs indicates an expected call of FindAllByIDs.
+func (mr *MockEditionRepositoryMockRecorder) FindAllByIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByIDs", reflect.TypeOf((*MockEditionRepository)(nil).FindAllByIDs), arg0, arg1)
+}
+
+// FindAllByWorkID mocks base method.
+func (m *MockEditionRepository) FindAllByWorkID(arg0 context.Context, arg1 int64) ([]*model.Edition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByWorkID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByWorkID indicates an expected call of FindAllByWorkID.
+func (mr *MockEditionRepositoryMockRecorder) FindAllByWorkID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByWorkID", reflect.TypeOf((*MockEditionRepository)(nil).FindAllByWorkID), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockEditionRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Edition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockEditionRepositoryMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockEditionRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindByISBN mocks base method.
+func (m *MockEditionRepository) FindByISBN(arg0 context.Context, arg1 string) (*model.Edition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByISBN", arg0, arg1)
+	ret0, _ := ret[0].(*model.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByISBN indicates an expected call of FindByISBN.
+func (mr *MockEditionRepositoryMockRecorder) FindByISBN(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByISBN", reflect.TypeOf((*MockEditionRepository)(nil).FindByISBN), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockEditionRepository) Update(arg0 context.Context, arg1 *model.Edition) (*model.Edition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*model.Edition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEditionRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEditionRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: HoldRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_hold_repository.go -package=mock github.com/rhtyx/bayarind-service.git/model HoldRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHoldRepository is a mock of HoldRepository interface.
+type MockHoldRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldRepositoryMockRecorder
+}
+
+// MockHoldRepositoryMockRecorder is the mock recorder for MockHoldRepository.
+type MockHoldRepositoryMockRecorder struct {
+	mock *MockHoldRepository
+}
+
+// NewMockHoldRepository creates a new mock instance.
+func NewMockHoldRepository(ctrl *gomock.Controller) *MockHoldRepository {
+	mock := &MockHoldRepository{ctrl: ctrl}
+	mock.recorder = &MockHoldRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldRepository) EXPECT() *MockHoldRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignNext mocks base method.
+func (m *MockHoldRepository) AssignNext(arg0 context.Context, arg1 int64, arg2, arg3 time.Time) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignNext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignNext indicates an expected call of AssignNext.
+func (mr *MockHoldRepositoryMockRecorder) AssignNext(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignNext", reflect.TypeOf((*MockHoldRepository)(nil).AssignNext), arg0, arg1, arg2, arg3)
+}
+
+// Cancel mocks base method.
+func (m *MockHoldRepository) Cancel(arg0 context.Context, arg1 int64, arg2 time.Time) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockHoldRepositoryMockRecorder) Cancel(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockHoldRepository)(nil).Cancel), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockHoldRepository) Create(arg0 context.Context, arg1 *model.Hold, arg2, arg3 time.Time) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHoldRepositoryMockRecorder) Create(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHoldRepository)(nil).Create), arg0, arg1, arg2, arg3)
+}
+
+// ExpireReady mocks base method.
+func (m *MockHoldRepository) ExpireReady(arg0 context.Context, arg1 time.Time) ([]*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReady", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReady indicates an expected call of ExpireReady.
+func (mr *MockHoldRepositoryMockRecorder) ExpireReady(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReady", reflect.TypeOf((*MockHoldRepository)(nil).ExpireReady), arg0, arg1)
+}
+
+// FindActive mocks base method.
+func (m *MockHoldRepository) FindActive(arg0 context.Context, arg1, arg2 int64) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockHoldRepositoryMockRecorder) FindActive(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockHoldRepository)(nil).FindActive), arg0, arg1, arg2)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockHoldRepository) FindAllByBookID(arg0 context.Context, arg1 int64) ([]*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockHoldRepositoryMockRecorder) FindAllByBookID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockHoldRepository)(nil).FindAllByBookID), arg0, arg1)
+}
+
+// FindAllByUserID mocks base method.
+func (m *MockHoldRepository) FindAllByUserID(arg0 context.Context, arg1 int64, arg2 bool) ([]*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserID indicates an expected call of FindAllByUserID.
+func (mr *MockHoldRepositoryMockRecorder) FindAllByUserID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserID", reflect.TypeOf((*MockHoldRepository)(nil).FindAllByUserID), arg0, arg1, arg2)
+}
+
+// FindAvailability mocks base method.
+func (m *MockHoldRepository) FindAvailability(arg0 context.Context, arg1 int64) (*model.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAvailability", arg0, arg1)
+	ret0, _ := ret[0].(*model.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAvailability indicates an expected call of FindAvailability.
+func (mr *MockHoldRepositoryMockRecorder) FindAvailability(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAvailability", reflect.TypeOf((*MockHoldRepository)(nil).FindAvailability), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockHoldRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockHoldRepositoryMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockHoldRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindPendingBookIDs mocks base method.
+func (m *MockHoldRepository) FindPendingBookIDs(arg0 context.Context) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingBookIDs", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingBookIDs indicates an expected call of FindPendingBookIDs.
+func (mr *MockHoldRepositoryMockRecorder) FindPendingBookIDs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingBookIDs", reflect.TypeOf((*MockHoldRepository)(nil).FindPendingBookIDs), arg0)
+}
@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: HoldService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_hold_service.go -package=mock github.com/rhtyx/bayarind-service.git/model HoldService
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHoldService is a mock of HoldService interface.
+type MockHoldService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldServiceMockRecorder
+}
+
+// MockHoldServiceMockRecorder is the mock recorder for MockHoldService.
+type MockHoldServiceMockRecorder struct {
+	mock *MockHoldService
+}
+
+// NewMockHoldService creates a new mock instance.
+func NewMockHoldService(ctrl *gomock.Controller) *MockHoldService {
+	mock := &MockHoldService{ctrl: ctrl}
+	mock.recorder = &MockHoldServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldService) EXPECT() *MockHoldServiceMockRecorder {
+	return m.recorder
+}
+
+// Allocate mocks base method.
+func (m *MockHoldService) Allocate(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allocate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allocate indicates an expected call of Allocate.
+func (mr *MockHoldServiceMockRecorder) Allocate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allocate", reflect.TypeOf((*MockHoldService)(nil).Allocate), arg0, arg1)
+}
+
+// Cancel mocks base method.
+func (m *MockHoldService) Cancel(arg0 context.Context, arg1 int64) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", arg0, arg1)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockHoldServiceMockRecorder) Cancel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockHoldService)(nil).Cancel), arg0, arg1)
+}
+
+// ExpirePickups mocks base method.
+func (m *MockHoldService) ExpirePickups(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePickups", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePickups indicates an expected call of ExpirePickups.
+func (mr *MockHoldServiceMockRecorder) ExpirePickups(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePickups", reflect.TypeOf((*MockHoldService)(nil).ExpirePickups), arg0)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockHoldService) FindAllByBookID(arg0 context.Context, arg1 int64) ([]*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockHoldServiceMockRecorder) FindAllByBookID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockHoldService)(nil).FindAllByBookID), arg0, arg1)
+}
+
+// FindAllByUserID mocks base method.
+func (m *MockHoldService) FindAllByUserID(arg0 context.Context, arg1 int64, arg2 bool) ([]*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserID indicates an expected call of FindAllByUserID.
+func (mr *MockHoldServiceMockRecorder) FindAllByUserID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserID", reflect.TypeOf((*MockHoldService)(nil).FindAllByUserID), arg0, arg1, arg2)
+}
+
+// FindAvailability mocks base method.
+func (m *MockHoldService) FindAvailability(arg0 context.Context, arg1 int64) (*model.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAvailability", arg0, arg1)
+	ret0, _ := ret[0].(*model.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAvailability indicates an expected call of FindAvailability.
+func (mr *MockHoldServiceMockRecorder) FindAvailability(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAvailability", reflect.TypeOf((*MockHoldService)(nil).FindAvailability), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockHoldService) FindByID(arg0 context.Context, arg1 int64) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockHoldServiceMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockHoldService)(nil).FindByID), arg0, arg1)
+}
+
+// Place mocks base method.
+func (m *MockHoldService) Place(arg0 context.Context, arg1, arg2 int64) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Place", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Place indicates an expected call of Place.
+func (mr *MockHoldServiceMockRecorder) Place(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Place", reflect.TypeOf((*MockHoldService)(nil).Place), arg0, arg1, arg2)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: MetadataProvider)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_metadata_provider.go -package=mock github.com/rhtyx/bayarind-service.git/model MetadataProvider
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMetadataProvider is a mock of MetadataProvider interface.
+type MockMetadataProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetadataProviderMockRecorder
+}
+
+// MockMetadataProviderMockRecorder is the mock recorder for MockMetadataProvider.
+type MockMetadataProviderMockRecorder struct {
+	mock *MockMetadataProvider
+}
+
+// NewMockMetadataProvider creates a new mock instance.
+func NewMockMetadataProvider(ctrl *gomock.Controller) *MockMetadataProvider {
+	mock := &MockMetadataProvider{ctrl: ctrl}
+	mock.recorder = &MockMetadataProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetadataProvider) EXPECT() *MockMetadataProviderMockRecorder {
+	return m.recorder
+}
+
+// LookupISBN mocks base method.
+func (m *MockMetadataProvider) LookupISBN(arg0 context.Context, arg1 string) (*model.BookMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupISBN", arg0, arg1)
+	ret0, _ := ret[0].(*model.BookMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupISBN indicates an expected call of LookupISBN.
+func (mr *MockMetadataProviderMockRecorder) LookupISBN(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupISBN", reflect.TypeOf((*MockMetadataProvider)(nil).LookupISBN), arg0, arg1)
+}
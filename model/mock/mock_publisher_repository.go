@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: PublisherRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_publisher_repository.go -package=mock github.com/rhtyx/bayarind-service.git/model PublisherRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPublisherRepository is a mock of PublisherRepository interface.
+type MockPublisherRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherRepositoryMockRecorder
+}
+
+// MockPublisherRepositoryMockRecorder is the mock recorder for MockPublisherRepository.
+type MockPublisherRepositoryMockRecorder struct {
+	mock *MockPublisherRepository
+}
+
+// NewMockPublisherRepository creates a new mock instance.
+func NewMockPublisherRepository(ctrl *gomock.Controller) *MockPublisherRepository {
+	mock := &MockPublisherRepository{ctrl: ctrl}
+	mock.recorder = &MockPublisherRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisherRepository) EXPECT() *MockPublisherRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPublisherRepository) Create(arg0 context.Context, arg1 *model.Publisher) (*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPublisherRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPublisherRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockPublisherRepository) Delete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPublisherRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPublisherRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockPublisherRepository) FindAll(arg0 context.Context) ([]*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPublisherRepositoryMockRecorder) FindAll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPublisherRepository)(nil).FindAll), arg0)
+}
+
+// FindAllByIDs mocks base method.
+func (m *MockPublisherRepository) FindAllByIDs(arg0 context.Context, arg1 []int64) ([]*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByIDs indicates an expected call of FindAllByIDs.
+func (mr *MockPublisherRepositoryMockRecorder) FindAllByIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByIDs", reflect.TypeOf((*MockPublisherRepository)(nil).FindAllByIDs), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockPublisherRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPublisherRepositoryMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPublisherRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindByName mocks base method.
+func (m *MockPublisherRepository) FindByName(arg0 context.Context, arg1 string) (*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", arg0, arg1)
+	ret0, _ := ret[0].(*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockPublisherRepositoryMockRecorder) FindByName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockPublisherRepository)(nil).FindByName), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockPublisherRepository) Update(arg0 context.Context, arg1 *model.Publisher) (*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPublisherRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPublisherRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: RecommendationRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_recommendation_repository.go -package=mock github.com/rhtyx/bayarind-service.git/model RecommendationRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecommendationRepository is a mock of RecommendationRepository interface.
+type MockRecommendationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationRepositoryMockRecorder
+}
+
+// MockRecommendationRepositoryMockRecorder is the mock recorder for MockRecommendationRepository.
+type MockRecommendationRepositoryMockRecorder struct {
+	mock *MockRecommendationRepository
+}
+
+// NewMockRecommendationRepository creates a new mock instance.
+func NewMockRecommendationRepository(ctrl *gomock.Controller) *MockRecommendationRepository {
+	mock := &MockRecommendationRepository{ctrl: ctrl}
+	mock.recorder = &MockRecommendationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationRepository) EXPECT() *MockRecommendationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindForUser mocks base method.
+func (m *MockRecommendationRepository) FindForUser(arg0 context.Context, arg1 int64, arg2 model.Pagination) ([]*model.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForUser indicates an expected call of FindForUser.
+func (mr *MockRecommendationRepositoryMockRecorder) FindForUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForUser", reflect.TypeOf((*MockRecommendationRepository)(nil).FindForUser), arg0, arg1, arg2)
+}
+
+// FindSimilar mocks base method.
+func (m *MockRecommendationRepository) FindSimilar(arg0 context.Context, arg1, arg2 int64, arg3 model.Pagination) ([]*model.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSimilar", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSimilar indicates an expected call of FindSimilar.
+func (mr *MockRecommendationRepositoryMockRecorder) FindSimilar(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSimilar", reflect.TypeOf((*MockRecommendationRepository)(nil).FindSimilar), arg0, arg1, arg2, arg3)
+}
+
+// Recompute mocks base method.
+func (m *MockRecommendationRepository) Recompute(arg0 context.Context, arg1 int, arg2 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recompute", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recompute indicates an expected call of Recompute.
+func (mr *MockRecommendationRepositoryMockRecorder) Recompute(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recompute", reflect.TypeOf((*MockRecommendationRepository)(nil).Recompute), arg0, arg1, arg2)
+}
+
+// RecordView mocks base method.
+func (m *MockRecommendationRepository) RecordView(arg0 context.Context, arg1, arg2 int64, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordView", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordView indicates an expected call of RecordView.
+func (mr *MockRecommendationRepositoryMockRecorder) RecordView(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordView", reflect.TypeOf((*MockRecommendationRepository)(nil).RecordView), arg0, arg1, arg2, arg3)
+}
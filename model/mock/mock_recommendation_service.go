@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: RecommendationService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_recommendation_service.go -package=mock github.com/rhtyx/bayarind-service.git/model RecommendationService
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecommendationService is a mock of RecommendationService interface.
+type MockRecommendationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationServiceMockRecorder
+}
+
+// MockRecommendationServiceMockRecorder is the mock recorder for MockRecommendationService.
+type MockRecommendationServiceMockRecorder struct {
+	mock *MockRecommendationService
+}
+
+// NewMockRecommendationService creates a new mock instance.
+func NewMockRecommendationService(ctrl *gomock.Controller) *MockRecommendationService {
+	mock := &MockRecommendationService{ctrl: ctrl}
+	mock.recorder = &MockRecommendationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationService) EXPECT() *MockRecommendationServiceMockRecorder {
+	return m.recorder
+}
+
+// FindForUser mocks base method.
+func (m *MockRecommendationService) FindForUser(arg0 context.Context, arg1 int64, arg2 model.Pagination) ([]*model.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForUser indicates an expected call of FindForUser.
+func (mr *MockRecommendationServiceMockRecorder) FindForUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForUser", reflect.TypeOf((*MockRecommendationService)(nil).FindForUser), arg0, arg1, arg2)
+}
+
+// FindSimilar mocks base method.
+func (m *MockRecommendationService) FindSimilar(arg0 context.Context, arg1, arg2 int64, arg3 model.Pagination) ([]*model.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSimilar", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSimilar indicates an expected call of FindSimilar.
+func (mr *MockRecommendationServiceMockRecorder) FindSimilar(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSimilar", reflect.TypeOf((*MockRecommendationService)(nil).FindSimilar), arg0, arg1, arg2, arg3)
+}
+
+// RecordView mocks base method.
+func (m *MockRecommendationService) RecordView(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordView", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordView indicates an expected call of RecordView.
+func (mr *MockRecommendationServiceMockRecorder) RecordView(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordView", reflect.TypeOf((*MockRecommendationService)(nil).RecordView), arg0, arg1, arg2)
+}
+
+// Refresh mocks base method.
+func (m *MockRecommendationService) Refresh(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockRecommendationServiceMockRecorder) Refresh(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockRecommendationService)(nil).Refresh), arg0)
+}
@@ -0,0 +1,233 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: ShelfRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_shelf_repository.go -package=mock github.com/rhtyx/bayarind-service.git/model ShelfRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShelfRepository is a mock of ShelfRepository interface.
+type MockShelfRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShelfRepositoryMockRecorder
+}
+
+// MockShelfRepositoryMockRecorder is the mock recorder for MockShelfRepository.
+type MockShelfRepositoryMockRecorder struct {
+	mock *MockShelfRepository
+}
+
+// NewMockShelfRepository creates a new mock instance.
+func NewMockShelfRepository(ctrl *gomock.Controller) *MockShelfRepository {
+	mock := &MockShelfRepository{ctrl: ctrl}
+	mock.recorder = &MockShelfRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShelfRepository) EXPECT() *MockShelfRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddEntry mocks base method.
+func (m *MockShelfRepository) AddEntry(arg0 context.Context, arg1 *model.ShelfEntry) (*model.ShelfEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEntry", arg0, arg1)
+	ret0, _ := ret[0].(*model.ShelfEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEntry indicates an expected call of AddEntry.
+func (mr *MockShelfRepositoryMockRecorder) AddEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEntry", reflect.TypeOf((*MockShelfRepository)(nil).AddEntry), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockShelfRepository) Create(arg0 context.Context, arg1 *model.Shelf) (*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShelfRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShelfRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockShelfRepository) Delete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockShelfRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockShelfRepository)(nil).Delete), arg0, arg1)
+}
+
+// EnsureBuiltins mocks base method.
+func (m *MockShelfRepository) EnsureBuiltins(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureBuiltins", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureBuiltins indicates an expected call of EnsureBuiltins.
+func (mr *MockShelfRepositoryMockRecorder) EnsureBuiltins(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBuiltins", reflect.TypeOf((*MockShelfRepository)(nil).EnsureBuiltins), arg0, arg1)
+}
+
+// FindAllByUserID mocks base method.
+func (m *MockShelfRepository) FindAllByUserID(arg0 context.Context, arg1 int64, arg2 bool) ([]*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserID indicates an expected call of FindAllByUserID.
+func (mr *MockShelfRepositoryMockRecorder) FindAllByUserID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserID", reflect.TypeOf((*MockShelfRepository)(nil).FindAllByUserID), arg0, arg1, arg2)
+}
+
+// FindByID mocks base method.
+func (m *MockShelfRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockShelfRepositoryMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockShelfRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindByName mocks base method.
+func (m *MockShelfRepository) FindByName(arg0 context.Context, arg1 int64, arg2 string) (*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockShelfRepositoryMockRecorder) FindByName(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockShelfRepository)(nil).FindByName), arg0, arg1, arg2)
+}
+
+// FindByShareToken mocks base method.
+func (m *MockShelfRepository) FindByShareToken(arg0 context.Context, arg1 string) (*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByShareToken", arg0, arg1)
+	ret0, _ := ret[0].(*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByShareToken indicates an expected call of FindByShareToken.
+func (mr *MockShelfRepositoryMockRecorder) FindByShareToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByShareToken", reflect.TypeOf((*MockShelfRepository)(nil).FindByShareToken), arg0, arg1)
+}
+
+// FindEntries mocks base method.
+func (m *MockShelfRepository) FindEntries(arg0 context.Context, arg1 int64) ([]*model.ShelfEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEntries", arg0, arg1)
+	ret0, _ := ret[0].([]*model.ShelfEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEntries indicates an expected call of FindEntries.
+func (mr *MockShelfRepositoryMockRecorder) FindEntries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEntries", reflect.TypeOf((*MockShelfRepository)(nil).FindEntries), arg0, arg1)
+}
+
+// FindEntry mocks base method.
+func (m *MockShelfRepository) FindEntry(arg0 context.Context, arg1, arg2 int64) (*model.ShelfEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEntry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ShelfEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEntry indicates an expected call of FindEntry.
+func (mr *MockShelfRepositoryMockRecorder) FindEntry(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEntry", reflect.TypeOf((*MockShelfRepository)(nil).FindEntry), arg0, arg1, arg2)
+}
+
+// MoveEntry mocks base method.
+func (m *MockShelfRepository) MoveEntry(arg0 context.Context, arg1 *model.ShelfEntry) (*model.ShelfEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveEntry", arg0, arg1)
+	ret0, _ := ret[0].(*model.ShelfEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveEntry indicates an expected call of MoveEntry.
+func (mr *MockShelfRepositoryMockRecorder) MoveEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveEntry", reflect.TypeOf((*MockShelfRepository)(nil).MoveEntry), arg0, arg1)
+}
+
+// RemoveEntry mocks base method.
+func (m *MockShelfRepository) RemoveEntry(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveEntry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveEntry indicates an expected call of RemoveEntry.
+func (mr *MockShelfRepositoryMockRecorder) RemoveEntry(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEntry", reflect.TypeOf((*MockShelfRepository)(nil).RemoveEntry), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockShelfRepository) Update(arg0 context.Context, arg1 *model.Shelf) (*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockShelfRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockShelfRepository)(nil).Update), arg0, arg1)
+}
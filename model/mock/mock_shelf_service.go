@@ -0,0 +1,204 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: ShelfService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_shelf_service.go -package=mock github.com/rhtyx/bayarind-service.git/model ShelfService
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShelfService is a mock of ShelfService interface.
+type MockShelfService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShelfServiceMockRecorder
+}
+
+// MockShelfServiceMockRecorder is the mock recorder for MockShelfService.
+type MockShelfServiceMockRecorder struct {
+	mock *MockShelfService
+}
+
+// NewMockShelfService creates a new mock instance.
+func NewMockShelfService(ctrl *gomock.Controller) *MockShelfService {
+	mock := &MockShelfService{ctrl: ctrl}
+	mock.recorder = &MockShelfServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShelfService) EXPECT() *MockShelfServiceMockRecorder {
+	return m.recorder
+}
+
+// AddEntry mocks base method.
+func (m *MockShelfService) AddEntry(arg0 context.Context, arg1 *model.ShelfEntry) (*model.ShelfEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEntry", arg0, arg1)
+	ret0, _ := ret[0].(*model.ShelfEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEntry indicates an expected call of AddEntry.
+func (mr *MockShelfServiceMockRecorder) AddEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEntry", reflect.TypeOf((*MockShelfService)(nil).AddEntry), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockShelfService) Create(arg0 context.Context, arg1 *model.Shelf) (*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShelfServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShelfService)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockShelfService) Delete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockShelfServiceMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockShelfService)(nil).Delete), arg0, arg1)
+}
+
+// FindAllByUserID mocks base method.
+func (m *MockShelfService) FindAllByUserID(arg0 context.Context, arg1 int64) ([]*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserID indicates an expected call of FindAllByUserID.
+func (mr *MockShelfServiceMockRecorder) FindAllByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserID", reflect.TypeOf((*MockShelfService)(nil).FindAllByUserID), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockShelfService) FindByID(arg0 context.Context, arg1 int64) (*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockShelfServiceMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockShelfService)(nil).FindByID), arg0, arg1)
+}
+
+// FindEntries mocks base method.
+func (m *MockShelfService) FindEntries(arg0 context.Context, arg1 int64) ([]*model.ShelfEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEntries", arg0, arg1)
+	ret0, _ := ret[0].([]*model.ShelfEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEntries indicates an expected call of FindEntries.
+func (mr *MockShelfServiceMockRecorder) FindEntries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEntries", reflect.TypeOf((*MockShelfService)(nil).FindEntries), arg0, arg1)
+}
+
+// FindPublicByUserID mocks base method.
+func (m *MockShelfService) FindPublicByUserID(arg0 context.Context, arg1 int64) ([]*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPublicByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPublicByUserID indicates an expected call of FindPublicByUserID.
+func (mr *MockShelfServiceMockRecorder) FindPublicByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPublicByUserID", reflect.TypeOf((*MockShelfService)(nil).FindPublicByUserID), arg0, arg1)
+}
+
+// FindShared mocks base method.
+func (m *MockShelfService) FindShared(arg0 context.Context, arg1 string) (*model.SharedShelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShared", arg0, arg1)
+	ret0, _ := ret[0].(*model.SharedShelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShared indicates an expected call of FindShared.
+func (mr *MockShelfServiceMockRecorder) FindShared(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShared", reflect.TypeOf((*MockShelfService)(nil).FindShared), arg0, arg1)
+}
+
+// MoveEntry mocks base method.
+func (m *MockShelfService) MoveEntry(arg0 context.Context, arg1 *model.ShelfEntry) (*model.ShelfEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveEntry", arg0, arg1)
+	ret0, _ := ret[0].(*model.ShelfEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveEntry indicates an expected call of MoveEntry.
+func (mr *MockShelfServiceMockRecorder) MoveEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveEntry", reflect.TypeOf((*MockShelfService)(nil).MoveEntry), arg0, arg1)
+}
+
+// RemoveEntry mocks base method.
+func (m *MockShelfService) RemoveEntry(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveEntry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveEntry indicates an expected call of RemoveEntry.
+func (mr *MockShelfServiceMockRecorder) RemoveEntry(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEntry", reflect.TypeOf((*MockShelfService)(nil).RemoveEntry), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockShelfService) Update(arg0 context.Context, arg1 *model.Shelf) (*model.Shelf, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*model.Shelf)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockShelfServiceMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockShelfService)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,219 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/rhtyx/bayarind-service.git/model (interfaces: SubjectRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=model/mock/mock_subject_repository.go -package=mock github.com/rhtyx/bayarind-service.git/model SubjectRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/rhtyx/bayarind-service.git/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubjectRepository is a mock of SubjectRepository interface.
+type MockSubjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubjectRepositoryMockRecorder
+}
+
+// MockSubjectRepositoryMockRecorder is the mock recorder for MockSubjectRepository.
+type MockSubjectRepositoryMockRecorder struct {
+	mock *MockSubjectRepository
+}
+
+// NewMockSubjectRepository creates a new mock instance.
+func NewMockSubjectRepository(ctrl *gomock.Controller) *MockSubjectRepository {
+	mock := &MockSubjectRepository{ctrl: ctrl}
+	mock.recorder = &MockSubjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubjectRepository) EXPECT() *MockSubjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountBooks mocks base method.
+func (m *MockSubjectRepository) CountBooks(arg0 context.Context) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBooks", arg0)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBooks indicates an expected call of CountBooks.
+func (mr *MockSubjectRepositoryMockRecorder) CountBooks(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBooks", reflect.TypeOf((*MockSubjectRepository)(nil).CountBooks), arg0)
+}
+
+// CountChildren mocks base method.
+func (m *MockSubjectRepository) CountChildren(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountChildren", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountChildren indicates an expected call of CountChildren.
+func (mr *MockSubjectRepositoryMockRecorder) CountChildren(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChildren", reflect.TypeOf((*MockSubjectRepository)(nil).CountChildren), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockSubjectRepository) Create(arg0 context.Context, arg1 *model.Subject) (*model.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*model.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSubjectRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSubjectRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockSubjectRepository) Delete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubjectRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubjectRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockSubjectRepository) FindAll(arg0 context.Context) ([]*model.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSubjectRepositoryMockRecorder) FindAll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSubjectRepository)(nil).FindAll), arg0)
+}
+
+// FindByBookID mocks base method.
+func (m *MockSubjectRepository) FindByBookID(arg0 context.Context, arg1 int64) ([]*model.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBookID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBookID indicates an expected call of FindByBookID.
+func (mr *MockSubjectRepositoryMockRecorder) FindByBookID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBookID", reflect.TypeOf((*MockSubjectRepository)(nil).FindByBookID), arg0, arg1)
+}
+
+// FindByBookIDs mocks base method.
+func (m *MockSubjectRepository) FindByBookIDs(arg0 context.Context, arg1 []int64) (map[int64][]*model.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBookIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[int64][]*model.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBookIDs indicates an expected call of FindByBookIDs.
+func (mr *MockSubjectRepositoryMockRecorder) FindByBookIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBookIDs", reflect.TypeOf((*MockSubjectRepository)(nil).FindByBookIDs), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockSubjectRepository) FindByID(arg0 context.Context, arg1 int64) (*model.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*model.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockSubjectRepositoryMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSubjectRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindByName mocks base method.
+func (m *MockSubjectRepository) FindByName(arg0 context.Context, arg1 *int64, arg2 string) (*model.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockSubjectRepositoryMockRecorder) FindByName(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockSubjectRepository)(nil).FindByName), arg0, arg1, arg2)
+}
+
+// FindDescendantIDs mocks base method.
+func (m *MockSubjectRepository) FindDescendantIDs(arg0 context.Context, arg1 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDescendantIDs", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDescendantIDs indicates an expected call of FindDescendantIDs.
+func (mr *MockSubjectRepositoryMockRecorder) FindDescendantIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDescendantIDs", reflect.TypeOf((*MockSubjectRepository)(nil).FindDescendantIDs), arg0, arg1)
+}
+
+// ReplaceBookSubjects mocks base method.
+func (m *MockSubjectRepository) ReplaceBookSubjects(arg0 context.Context, arg1 int64, arg2 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceBookSubjects", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceBookSubjects indicates an expected call of ReplaceBookSubjects.
+func (mr *MockSubjectRepositoryMockRecorder) ReplaceBookSubjects(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceBookSubjects", reflect.TypeOf((*MockSubjectRepository)(nil).ReplaceBookSubjects), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockSubjectRepository) Update(arg0 context.Context, arg1 *model.Subject) (*model.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*model.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSubjectRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubjectRepository)(nil).Update), arg0, arg1)
+}
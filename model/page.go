@@ -0,0 +1,11 @@
+package model
+
+// Pagination selects a page of a listing. Pages are numbered from 1.
+type Pagination struct {
+	Page    int
+	PerPage int
+}
+
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
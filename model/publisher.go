@@ -0,0 +1,33 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type Publisher struct {
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	Name      string     `json:"name"`
+	Website   string     `json:"website"`
+	Version   int64      `json:"version" gorm:"default:1"`
+	CreatedAt time.Time  `json:"created_at" gorm:"<-:create"`
+	UpdatedAt *time.Time `json:"updated_at" gorm:"<-:update"`
+}
+
+type PublisherRepository interface {
+	Create(ctx context.Context, publisher *Publisher) (*Publisher, error)
+	FindByID(ctx context.Context, publisherID int64) (*Publisher, error)
+	FindByName(ctx context.Context, name string) (*Publisher, error)
+	FindAll(ctx context.Context) ([]*Publisher, error)
+	FindAllByIDs(ctx context.Context, publisherIDs []int64) ([]*Publisher, error)
+	Update(ctx context.Context, publisher *Publisher) (*Publisher, error)
+	Delete(ctx context.Context, publisherID int64) error
+}
+
+type PublisherService interface {
+	Create(ctx context.Context, publisher *Publisher) (*Publisher, error)
+	FindByID(ctx context.Context, publisherID int64) (*Publisher, error)
+	FindAll(ctx context.Context) ([]*Publisher, error)
+	Update(ctx context.Context, publisher *Publisher) (*Publisher, error)
+	Delete(ctx context.Context, publisherID int64) error
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Recommendation is a book suggested to a reader with the score it was
+// ranked by. Scores only compare within one listing.
+type Recommendation struct {
+	Book  `gorm:"embedded"`
+	Score float64 `json:"score"`
+}
+
+type RecommendationRepository interface {
+	RecordView(ctx context.Context, userID, bookID int64, viewedAt time.Time) error
+	Recompute(ctx context.Context, perBook int, computedAt time.Time) (int64, error)
+	FindSimilar(ctx context.Context, bookID, userID int64, pagination Pagination) ([]*Recommendation, error)
+	FindForUser(ctx context.Context, userID int64, pagination Pagination) ([]*Recommendation, error)
+}
+
+type RecommendationService interface {
+	RecordView(ctx context.Context, userID, bookID int64) error
+	FindSimilar(ctx context.Context, bookID, userID int64, pagination Pagination) ([]*Recommendation, error)
+	FindForUser(ctx context.Context, userID int64, pagination Pagination) ([]*Recommendation, error)
+	Refresh(ctx context.Context) (int64, error)
+}
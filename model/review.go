@@ -0,0 +1,63 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ReviewSortHelpful = "helpful"
+	ReviewSortNewest  = "newest"
+	ReviewSortHighest = "highest"
+	ReviewSortLowest  = "lowest"
+)
+
+// Review is the rating a user gives a book, from 1 to 5 stars, with an
+// optional text. Hidden reviews are left out of listings and of the rating
+// of the book.
+type Review struct {
+	ID           int64      `json:"id" gorm:"primaryKey"`
+	BookID       int64      `json:"book_id"`
+	UserID       int64      `json:"user_id"`
+	Rating       int        `json:"rating"`
+	Body         string     `json:"body"`
+	Hidden       bool       `json:"hidden"`
+	HiddenReason string     `json:"hidden_reason,omitempty"`
+	HelpfulCount int64      `json:"helpful_count" gorm:"->"`
+	Version      int64      `json:"version" gorm:"default:1"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"<-:create"`
+	UpdatedAt    *time.Time `json:"updated_at" gorm:"<-:update"`
+}
+
+// ReviewPage is one page of the reviews of a book along with the number
+// of reviews on all pages.
+type ReviewPage struct {
+	Items   []*Review `json:"items"`
+	Page    int       `json:"page"`
+	PerPage int       `json:"per_page"`
+	Total   int64     `json:"total"`
+}
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *Review) (*Review, error)
+	FindByID(ctx context.Context, reviewID int64) (*Review, error)
+	FindByBookAndUser(ctx context.Context, bookID, userID int64) (*Review, error)
+	FindAllByBookID(ctx context.Context, bookID int64, sort string, includeHidden bool, pagination Pagination) ([]*Review, int64, error)
+	Update(ctx context.Context, review *Review) (*Review, error)
+	SetHidden(ctx context.Context, reviewID int64, hidden bool, reason string) (*Review, error)
+	Delete(ctx context.Context, reviewID int64) error
+	AddVote(ctx context.Context, reviewID, userID int64) error
+	RemoveVote(ctx context.Context, reviewID, userID int64) error
+}
+
+type ReviewService interface {
+	Create(ctx context.Context, review *Review) (*Review, error)
+	FindByID(ctx context.Context, reviewID int64) (*Review, error)
+	FindAllByBookID(ctx context.Context, bookID int64, sort string, includeHidden bool, pagination Pagination) (*ReviewPage, error)
+	Update(ctx context.Context, review *Review) (*Review, error)
+	Delete(ctx context.Context, reviewID int64) error
+	Hide(ctx context.Context, reviewID int64, reason string) (*Review, error)
+	Unhide(ctx context.Context, reviewID int64) (*Review, error)
+	Vote(ctx context.Context, reviewID, userID int64) (*Review, error)
+	Unvote(ctx context.Context, reviewID, userID int64) (*Review, error)
+}
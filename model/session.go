@@ -25,6 +25,7 @@ type SessionRepository interface {
 
 type SessionService interface {
 	Create(ctx context.Context, username, password string) (*Session, error)
+	Authenticate(ctx context.Context, username, password string) (*User, error)
 	FindByRefreshToken(ctx context.Context, refreshToken string) (*Session, error)
 	DeleteByRefreshToken(ctx context.Context, refreshToken string) error
 
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ShelfKindWantToRead = "want_to_read"
+	ShelfKindReading    = "reading"
+	ShelfKindRead       = "read"
+	ShelfKindFavorites  = "favorites"
+	ShelfKindCustom     = "custom"
+)
+
+const (
+	ShelfPrivacyPrivate = "private"
+	ShelfPrivacyPublic  = "public"
+	ShelfPrivacyLink    = "link"
+)
+
+// BuiltinShelves are the shelves every reader has, by kind, with their
+// names. They cannot be renamed or deleted.
+var BuiltinShelves = []Shelf{
+	{Kind: ShelfKindWantToRead, Name: "Want to read"},
+	{Kind: ShelfKindReading, Name: "Reading"},
+	{Kind: ShelfKindRead, Name: "Read"},
+	{Kind: ShelfKindFavorites, Name: "Favorites"},
+}
+
+// ReadingStatusKinds are the shelves that say where a reader is with a
+// book. A book sits on at most one of them per reader.
+var ReadingStatusKinds = []string{ShelfKindWantToRead, ShelfKindReading, ShelfKindRead}
+
+// Shelf is a list of books kept by a user. Public shelves can be seen by
+// any signed-in user, and link shelves by anyone holding their share token.
+type Shelf struct {
+	ID         int64      `json:"id" gorm:"primaryKey"`
+	UserID     int64      `json:"user_id"`
+	Name       string     `json:"name"`
+	Kind       string     `json:"kind" gorm:"default:custom"`
+	Privacy    string     `json:"privacy" gorm:"default:private"`
+	ShareToken *string    `json:"share_token,omitempty"`
+	BookCount  int64      `json:"book_count" gorm:"->;-:migration"`
+	Version    int64      `json:"version" gorm:"default:1"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"<-:create"`
+	UpdatedAt  *time.Time `json:"updated_at" gorm:"<-:update"`
+}
+
+// ShelfEntry places a book on a shelf. Positions start at 1 and have no
+// gaps.
+type ShelfEntry struct {
+	ShelfID  int64     `json:"shelf_id" gorm:"primaryKey"`
+	BookID   int64     `json:"book_id" gorm:"primaryKey"`
+	Position int       `json:"position"`
+	Note     string    `json:"note"`
+	AddedAt  time.Time `json:"added_at"`
+	Book     *Book     `json:"book,omitempty" gorm:"foreignKey:BookID"`
+}
+
+// SharedShelf is the read-only view of a shelf given to visitors.
+type SharedShelf struct {
+	Name    string        `json:"name"`
+	Kind    string        `json:"kind"`
+	Entries []*ShelfEntry `json:"entries"`
+}
+
+type ShelfRepository interface {
+	EnsureBuiltins(ctx context.Context, userID int64) error
+	Create(ctx context.Context, shelf *Shelf) (*Shelf, error)
+	FindByID(ctx context.Context, shelfID int64) (*Shelf, error)
+	FindByName(ctx context.Context, userID int64, name string) (*Shelf, error)
+	FindByShareToken(ctx context.Context, shareToken string) (*Shelf, error)
+	FindAllByUserID(ctx context.Context, userID int64, publicOnly bool) ([]*Shelf, error)
+	Update(ctx context.Context, shelf *Shelf) (*Shelf, error)
+	Delete(ctx context.Context, shelfID int64) error
+
+	FindEntry(ctx context.Context, shelfID, bookID int64) (*ShelfEntry, error)
+	FindEntries(ctx context.Context, shelfID int64) ([]*ShelfEntry, error)
+	AddEntry(ctx context.Context, entry *ShelfEntry) (*ShelfEntry, error)
+	MoveEntry(ctx context.Context, entry *ShelfEntry) (*ShelfEntry, error)
+	RemoveEntry(ctx context.Context, shelfID, bookID int64) error
+}
+
+type ShelfService interface {
+	Create(ctx context.Context, shelf *Shelf) (*Shelf, error)
+	FindByID(ctx context.Context, shelfID int64) (*Shelf, error)
+	FindAllByUserID(ctx context.Context, userID int64) ([]*Shelf, error)
+	FindPublicByUserID(ctx context.Context, userID int64) ([]*Shelf, error)
+	Update(ctx context.Context, shelf *Shelf) (*Shelf, error)
+	Delete(ctx context.Context, shelfID int64) error
+
+	FindEntries(ctx context.Context, shelfID int64) ([]*ShelfEntry, error)
+	AddEntry(ctx context.Context, entry *ShelfEntry) (*ShelfEntry, error)
+	MoveEntry(ctx context.Context, entry *ShelfEntry) (*ShelfEntry, error)
+	RemoveEntry(ctx context.Context, shelfID, bookID int64) error
+
+	FindShared(ctx context.Context, shareToken string) (*SharedShelf, error)
+}
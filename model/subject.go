@@ -0,0 +1,53 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Subject is a node of the hierarchical taxonomy. Root subjects have no
+// parent.
+type Subject struct {
+	ID           int64                            `json:"id" gorm:"primaryKey"`
+	Name         string                           `json:"name"`
+	ParentID     *int64                           `json:"parent_id"`
+	Translations Translations[SubjectTranslation] `json:"translations"`
+	Version      int64                            `json:"version" gorm:"default:1"`
+	CreatedAt    time.Time                        `json:"created_at" gorm:"<-:create"`
+	UpdatedAt    *time.Time                       `json:"updated_at" gorm:"<-:update"`
+}
+
+// SubjectNode is a subject in the browsable tree. BookCount counts the
+// distinct live books filed under the subject or any of its descendants.
+type SubjectNode struct {
+	Subject
+	BookCount int64          `json:"book_count"`
+	Children  []*SubjectNode `json:"children"`
+}
+
+type SubjectRepository interface {
+	Create(ctx context.Context, subject *Subject) (*Subject, error)
+	FindByID(ctx context.Context, subjectID int64) (*Subject, error)
+	FindByName(ctx context.Context, parentID *int64, name string) (*Subject, error)
+	FindAll(ctx context.Context) ([]*Subject, error)
+	FindDescendantIDs(ctx context.Context, subjectID int64) ([]int64, error)
+	FindByBookID(ctx context.Context, bookID int64) ([]*Subject, error)
+	FindByBookIDs(ctx context.Context, bookIDs []int64) (map[int64][]*Subject, error)
+	CountChildren(ctx context.Context, subjectID int64) (int64, error)
+	CountBooks(ctx context.Context) (map[int64]int64, error)
+	ReplaceBookSubjects(ctx context.Context, bookID int64, subjectIDs []int64) error
+	Update(ctx context.Context, subject *Subject) (*Subject, error)
+	Delete(ctx context.Context, subjectID int64) error
+}
+
+type SubjectService interface {
+	Create(ctx context.Context, subject *Subject) (*Subject, error)
+	FindByID(ctx context.Context, subjectID int64) (*Subject, error)
+	FindTree(ctx context.Context) ([]*SubjectNode, error)
+	FindSubtree(ctx context.Context, subjectID int64) (*SubjectNode, error)
+	FindBooks(ctx context.Context, subjectID int64) ([]*Book, error)
+	FindByBookID(ctx context.Context, bookID int64) ([]*Subject, error)
+	SetBookSubjects(ctx context.Context, bookID int64, subjectIDs []int64) ([]*Subject, error)
+	Update(ctx context.Context, subject *Subject) (*Subject, error)
+	Delete(ctx context.Context, subjectID int64) error
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Tag is a free-form label. Names are stored trimmed and lower-cased so
+// that "Sci-Fi" and "sci-fi " are the same tag.
+type Tag struct {
+	ID        int64     `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name"`
+	BookCount int64     `json:"book_count" gorm:"->;-:migration"`
+	CreatedAt time.Time `json:"created_at" gorm:"<-:create"`
+}
+
+type TagRepository interface {
+	FindByID(ctx context.Context, tagID int64) (*Tag, error)
+	FindAll(ctx context.Context) ([]*Tag, error)
+	FindByBookID(ctx context.Context, bookID int64) ([]*Tag, error)
+	ReplaceBookTags(ctx context.Context, bookID int64, names []string) error
+	Delete(ctx context.Context, tagID int64) error
+}
+
+type TagService interface {
+	FindAll(ctx context.Context) ([]*Tag, error)
+	FindBooks(ctx context.Context, name string) ([]*Book, error)
+	FindByBookID(ctx context.Context, bookID int64) ([]*Tag, error)
+	SetBookTags(ctx context.Context, bookID int64, names []string) ([]*Tag, error)
+	Delete(ctx context.Context, tagID int64) error
+}
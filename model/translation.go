@@ -0,0 +1,120 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Translations holds translated fields by canonical locale. The fields on
+// the entity itself are in the default locale.
+type Translations[T any] map[string]T
+
+func (t Translations[T]) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+
+	value, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(value), nil
+}
+
+func (t *Translations[T]) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, t)
+	case string:
+		return json.Unmarshal([]byte(value), t)
+	default:
+		return fmt.Errorf("cannot scan %T into translations", value)
+	}
+}
+
+type BookTranslation struct {
+	Title    string `json:"title,omitempty"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type AuthorTranslation struct {
+	Biography string `json:"biography,omitempty"`
+}
+
+type SubjectTranslation struct {
+	Name string `json:"name,omitempty"`
+}
+
+// Localize replaces the title and subtitle of b with the first
+// translation found along locales. It returns the locales they are served
+// in, "" standing for the untranslated fields.
+func (b *Book) Localize(locales []string) []string {
+	served := []string{}
+	b.Title = localize(locales, b.Title, &served, func(locale string) string {
+		return b.Translations[locale].Title
+	})
+	b.Subtitle = localize(locales, b.Subtitle, &served, func(locale string) string {
+		return b.Translations[locale].Subtitle
+	})
+	return served
+}
+
+// Localize replaces the biography of a with the first translation found
+// along locales, returning the locale it is served in like Book.Localize.
+func (a *Author) Localize(locales []string) []string {
+	served := []string{}
+	a.Biography = localize(locales, a.Biography, &served, func(locale string) string {
+		return a.Translations[locale].Biography
+	})
+	return served
+}
+
+// Localize replaces the name of s with the first translation found along
+// locales, returning the locale it is served in like Book.Localize.
+func (s *Subject) Localize(locales []string) []string {
+	served := []string{}
+	s.Name = localize(locales, s.Name, &served, func(locale string) string {
+		return s.Translations[locale].Name
+	})
+	return served
+}
+
+// Localize localizes n and its descendants, returning the locales any of
+// them is served in.
+func (n *SubjectNode) Localize(locales []string) []string {
+	served := n.Subject.Localize(locales)
+	for _, child := range n.Children {
+		for _, locale := range child.Localize(locales) {
+			if !slices.Contains(served, locale) {
+				served = append(served, locale)
+			}
+		}
+	}
+
+	return served
+}
+
+// localize returns the first translation of a field found along locales,
+// or else its untranslated value, adding the locale it chose to served.
+// Empty fields are served in no locale.
+func localize(locales []string, value string, served *[]string, translation func(locale string) string) string {
+	chosen := ""
+	for _, locale := range locales {
+		if translated := translation(locale); translated != "" {
+			value, chosen = translated, locale
+			break
+		}
+	}
+
+	if value != "" && !slices.Contains(*served, chosen) {
+		*served = append(*served, chosen)
+	}
+
+	return value
+}
@@ -3,14 +3,24 @@ package model
 import (
 	"context"
 	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	RoleReader    = "reader"
+	RoleLibrarian = "librarian"
+	RoleAdmin     = "admin"
 )
 
 type User struct {
-	ID        int64      `json:"id" gorm:"primaryKey"`
-	Username  string     `json:"username"`
-	Password  string     `json:"password,omitempty"`
-	CreatedAt time.Time  `json:"created_at" gorm:"<-:create"`
-	UpdatedAt *time.Time `json:"updated_at" gorm:"<-:update"`
+	ID        int64          `json:"id" gorm:"primaryKey"`
+	Username  string         `json:"username"`
+	Password  string         `json:"password,omitempty"`
+	Role      string         `json:"role" gorm:"default:reader"`
+	CreatedAt time.Time      `json:"created_at" gorm:"<-:create"`
+	UpdatedAt *time.Time     `json:"updated_at" gorm:"<-:update"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
 }
 
 type UserRepository interface {
@@ -18,7 +28,12 @@ type UserRepository interface {
 	FindByID(ctx context.Context, userID int64) (*User, error)
 	FindByUsername(ctx context.Context, username string) (*User, error)
 	Update(ctx context.Context, user *User) (*User, error)
+	UpdateColumns(ctx context.Context, user *User, columns []string) (*User, error)
 	Delete(ctx context.Context, userID int64) error
+	FindDeletedByID(ctx context.Context, userID int64) (*User, error)
+	FindAllDeleted(ctx context.Context) ([]*User, error)
+	Restore(ctx context.Context, userID int64) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
 type UserService interface {
@@ -26,5 +41,10 @@ type UserService interface {
 	FindByID(ctx context.Context, userID int64) (*User, error)
 	FindByUsername(ctx context.Context, username string) (*User, error)
 	Update(ctx context.Context, user *User) (*User, error)
+	Patch(ctx context.Context, user *User, columns []string) (*User, error)
 	Delete(ctx context.Context, userID int64) error
+
+	FindAllDeleted(ctx context.Context) ([]*User, error)
+	Restore(ctx context.Context, userID int64) (*User, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
@@ -0,0 +1,15 @@
+package model
+
+// FieldChange is a field that differs between two versions of a record.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// VersionDiff lists what changed from one version of a record to another.
+type VersionDiff struct {
+	From    int64          `json:"from"`
+	To      int64          `json:"to"`
+	Changes []*FieldChange `json:"changes"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Work is the abstract creation shared by all editions of a book, such as
+// the hardcover, paperback and ebook of the same title.
+type Work struct {
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	Title     string     `json:"title"`
+	AuthorID  int64      `json:"author_id"`
+	Version   int64      `json:"version" gorm:"default:1"`
+	CreatedAt time.Time  `json:"created_at" gorm:"<-:create"`
+	UpdatedAt *time.Time `json:"updated_at" gorm:"<-:update"`
+}
+
+type WorkRepository interface {
+	Create(ctx context.Context, work *Work) (*Work, error)
+	FindByID(ctx context.Context, workID int64) (*Work, error)
+	FindAll(ctx context.Context) ([]*Work, error)
+	Update(ctx context.Context, work *Work) (*Work, error)
+	Delete(ctx context.Context, workID int64) error
+}
+
+type WorkService interface {
+	Create(ctx context.Context, work *Work) (*Work, error)
+	FindByID(ctx context.Context, workID int64) (*Work, error)
+	FindAll(ctx context.Context) ([]*Work, error)
+	FindEditions(ctx context.Context, workID int64) ([]*Edition, error)
+	Update(ctx context.Context, work *Work) (*Work, error)
+	Delete(ctx context.Context, workID int64) error
+}
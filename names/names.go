@@ -0,0 +1,101 @@
+// Package names compares personal names written in different ways, such as
+// "J.R.R. Tolkien", "Tolkien, J. R. R." and "John Ronald Reuel Tolkien".
+package names
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// initialsScore is the similarity of two names that share a surname and
+// whose given names agree initial by initial.
+const initialsScore = 0.9
+
+// Normalize lowercases name, strips accents and punctuation, turns
+// "Surname, Given" around and separates the remaining words by single
+// spaces.
+func Normalize(name string) string {
+	if surname, given, ok := strings.Cut(name, ","); ok {
+		name = given + " " + surname
+	}
+
+	var b strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Similarity scores how likely two names are to name the same person, from
+// 0 for unrelated names to 1 for names that only differ in case, accents,
+// punctuation or spacing.
+func Similarity(a, b string) float64 {
+	a, b = Normalize(a), Normalize(b)
+	compactA, compactB := strings.ReplaceAll(a, " ", ""), strings.ReplaceAll(b, " ", "")
+	if compactA == "" || compactB == "" {
+		return 0
+	}
+
+	longest := max(len([]rune(compactA)), len([]rune(compactB)))
+	score := 1 - float64(distance(compactA, compactB))/float64(longest)
+	if score < initialsScore && sameInitials(strings.Fields(a), strings.Fields(b)) {
+		score = initialsScore
+	}
+
+	return score
+}
+
+// sameInitials reports whether two names share their last word and their
+// other words pairwise, where a word matches any word it is a prefix of.
+func sameInitials(a, b []string) bool {
+	if len(a) != len(b) || len(a) < 2 || a[len(a)-1] != b[len(b)-1] {
+		return false
+	}
+
+	for i := range a[:len(a)-1] {
+		if !strings.HasPrefix(a[i], b[i]) && !strings.HasPrefix(b[i], a[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// distance is the edit distance between a and b in runes, counting the
+// swap of two neighbouring runes as a single edit.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
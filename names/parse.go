@@ -0,0 +1,123 @@
+package names
+
+import (
+	"strings"
+	"unicode"
+)
+
+// suffixes are the generational and numeral suffixes that follow a name.
+var suffixes = map[string]bool{
+	"jr": true, "jr.": true,
+	"sr": true, "sr.": true,
+	"ii": true, "iii": true, "iv": true,
+}
+
+// Name is a personal name split into the parts citation styles arrange
+// differently. Literal holds names that cannot be split, such as mononyms.
+type Name struct {
+	Given    string
+	Particle string
+	Family   string
+	Suffix   string
+	Literal  string
+}
+
+// Inverted writes n as "Last, First, Suffix", the order catalogues sort
+// by. Literal names are written as they are.
+func (n Name) Inverted() string {
+	if n.Literal != "" {
+		return n.Literal
+	}
+
+	formatted := n.Family
+	if n.Particle != "" {
+		formatted = n.Particle + " " + formatted
+	}
+	if n.Given != "" || n.Suffix != "" {
+		formatted += ", " + n.Given
+	}
+	if n.Suffix != "" {
+		formatted += ", " + n.Suffix
+	}
+
+	return formatted
+}
+
+// Parse splits a name written "Given Family", "Family, Given" or "Family,
+// Given, Suffix". Lowercase words in front of the family name, as in
+// "Ludwig van Beethoven", become its particle.
+func Parse(name string) Name {
+	name = strings.Join(strings.Fields(name), " ")
+
+	parts := strings.Split(name, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	suffix := ""
+	if len(parts) > 1 && isSuffix(parts[len(parts)-1]) {
+		suffix = parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+
+	if len(parts) > 1 && parts[0] != "" && parts[1] != "" {
+		particle, family := splitParticle(strings.Fields(parts[0]))
+		return Name{
+			Given:    strings.Join(parts[1:], ", "),
+			Particle: particle,
+			Family:   family,
+			Suffix:   suffix,
+		}
+	}
+
+	words := strings.Fields(strings.Join(parts, " "))
+	if suffix == "" && len(words) > 1 && isSuffix(words[len(words)-1]) {
+		suffix = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	if len(words) < 2 {
+		return Name{Literal: strings.Join(words, " "), Suffix: suffix}
+	}
+
+	start := len(words) - 1
+	for start > 0 && isParticle(words[start-1]) {
+		start--
+	}
+
+	if start == 0 {
+		// Every word but the last is lowercase, as in "de Gaulle".
+		particle, family := splitParticle(words)
+		return Name{Particle: particle, Family: family, Suffix: suffix}
+	}
+
+	particle, family := splitParticle(words[start:])
+	return Name{
+		Given:    strings.Join(words[:start], " "),
+		Particle: particle,
+		Family:   family,
+		Suffix:   suffix,
+	}
+}
+
+// splitParticle separates the leading lowercase words of a family name.
+func splitParticle(words []string) (string, string) {
+	idx := 0
+	for idx < len(words)-1 && isParticle(words[idx]) {
+		idx++
+	}
+
+	return strings.Join(words[:idx], " "), strings.Join(words[idx:], " ")
+}
+
+func isParticle(word string) bool {
+	for _, r := range word {
+		return unicode.IsLower(r)
+	}
+
+	return false
+}
+
+func isSuffix(word string) bool {
+	return suffixes[strings.ToLower(word)]
+}
@@ -0,0 +1,65 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/rhtyx/bayarind-service.git/names"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		assert.Equal(t, "j r r tolkien", names.Normalize("J.R.R. Tolkien"))
+		assert.Equal(t, "j r r tolkien", names.Normalize("Tolkien, J. R. R."))
+		assert.Equal(t, "gabriel garcia marquez", names.Normalize("  Gabriel García  Márquez "))
+		assert.Equal(t, "", names.Normalize("..."))
+	})
+}
+
+func TestSimilarity(t *testing.T) {
+	t.Run("ok: same name written differently", func(t *testing.T) {
+		assert.Equal(t, 1.0, names.Similarity("J.R.R. Tolkien", "JRR Tolkien"))
+		assert.Equal(t, 1.0, names.Similarity("Tolkien, J.R.R.", "j. r. r. tolkien"))
+	})
+
+	t.Run("ok: initials", func(t *testing.T) {
+		assert.Equal(t, 0.9, names.Similarity("J.R.R. Tolkien", "John Ronald Reuel Tolkien"))
+	})
+
+	t.Run("ok: typo", func(t *testing.T) {
+		assert.InDelta(t, 0.9, names.Similarity("J.R.R. Tolkein", "J.R.R. Tolkien"), 0.05)
+	})
+
+	t.Run("ok: different people", func(t *testing.T) {
+		assert.Less(t, names.Similarity("Christopher Tolkien", "J.R.R. Tolkien"), 0.85)
+		assert.Equal(t, 0.0, names.Similarity("", "J.R.R. Tolkien"))
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("ok: direct order", func(t *testing.T) {
+		assert.Equal(t, names.Name{Given: "Pramoedya Ananta", Family: "Toer"}, names.Parse("Pramoedya Ananta Toer"))
+		assert.Equal(t, names.Name{Given: "Ludwig", Particle: "van", Family: "Beethoven"}, names.Parse("Ludwig van Beethoven"))
+		assert.Equal(t, names.Name{Given: "Martin Luther", Family: "King", Suffix: "Jr."}, names.Parse("Martin Luther King Jr."))
+		assert.Equal(t, names.Name{Given: "Martin Luther", Family: "King", Suffix: "Jr."}, names.Parse("Martin Luther King, Jr."))
+	})
+
+	t.Run("ok: inverted order", func(t *testing.T) {
+		assert.Equal(t, names.Name{Given: "J. R. R.", Family: "Tolkien"}, names.Parse("Tolkien, J. R. R."))
+		assert.Equal(t, names.Name{Given: "Vincent", Particle: "van", Family: "Gogh"}, names.Parse("van Gogh, Vincent"))
+		assert.Equal(t, names.Name{Given: "Martin Luther", Family: "King", Suffix: "Jr."}, names.Parse("King, Martin Luther, Jr."))
+		assert.Equal(t, names.Name{Given: "Gabriel", Family: "García Márquez"}, names.Parse("García Márquez, Gabriel"))
+	})
+
+	t.Run("ok: unsplit", func(t *testing.T) {
+		assert.Equal(t, names.Name{Literal: "Sukarno"}, names.Parse(" Sukarno "))
+		assert.Equal(t, names.Name{Particle: "de", Family: "Gaulle"}, names.Parse("de Gaulle"))
+	})
+}
+
+func TestInverted(t *testing.T) {
+	assert.Equal(t, "Toer, Pramoedya Ananta", names.Parse("Pramoedya Ananta Toer").Inverted())
+	assert.Equal(t, "van Beethoven, Ludwig", names.Parse("Ludwig van Beethoven").Inverted())
+	assert.Equal(t, "King, Martin Luther, Jr.", names.Parse("Martin Luther King Jr.").Inverted())
+	assert.Equal(t, "Sukarno", names.Parse("Sukarno").Inverted())
+}
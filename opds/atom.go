@@ -0,0 +1,209 @@
+package opds
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+const (
+	nsAtom       = "http://www.w3.org/2005/Atom"
+	nsOPDS       = "http://opds-spec.org/2010/catalog"
+	nsDC         = "http://purl.org/dc/terms/"
+	nsOpenSearch = "http://a9.com/-/spec/opensearch/1.1/"
+)
+
+type atomFeed struct {
+	XMLName      xml.Name    `xml:"feed"`
+	Xmlns        string      `xml:"xmlns,attr"`
+	XmlnsOPDS    string      `xml:"xmlns:opds,attr"`
+	XmlnsDC      string      `xml:"xmlns:dc,attr"`
+	XmlnsSearch  string      `xml:"xmlns:opensearch,attr"`
+	ID           string      `xml:"id"`
+	Title        string      `xml:"title"`
+	Updated      string      `xml:"updated"`
+	Links        []atomLink  `xml:"link"`
+	TotalResults *int64      `xml:"opensearch:totalResults,omitempty"`
+	ItemsPerPage *int        `xml:"opensearch:itemsPerPage,omitempty"`
+	StartIndex   *int        `xml:"opensearch:startIndex,omitempty"`
+	Entries      []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	XMLName    xml.Name       `xml:"entry"`
+	Xmlns      string         `xml:"xmlns,attr,omitempty"`
+	XmlnsOPDS  string         `xml:"xmlns:opds,attr,omitempty"`
+	XmlnsDC    string         `xml:"xmlns:dc,attr,omitempty"`
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Authors    []atomAuthor   `xml:"author"`
+	Identifier string         `xml:"dc:identifier,omitempty"`
+	Language   string         `xml:"dc:language,omitempty"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+	Links      []atomLink     `xml:"link"`
+}
+
+type atomLink struct {
+	Rel   string `xml:"rel,attr,omitempty"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// WriteAtom writes feed as an OPDS 1.2 catalog feed.
+func WriteAtom(w io.Writer, feed *Feed) error {
+	doc := atomFeed{
+		Xmlns:       nsAtom,
+		XmlnsOPDS:   nsOPDS,
+		XmlnsDC:     nsDC,
+		XmlnsSearch: nsOpenSearch,
+		ID:          feed.ID,
+		Title:       feed.Title,
+		Updated:     atomTime(feed.Updated),
+		Links:       atomLinks(feed.Links),
+		Entries:     []atomEntry{},
+	}
+
+	if feed.SearchHref != "" {
+		doc.Links = append(doc.Links, atomLink{Rel: RelSearch, Href: feed.SearchHref, Type: MIMEOpenSearch})
+	}
+
+	if feed.PerPage > 0 {
+		startIndex := (feed.Page-1)*feed.PerPage + 1
+		doc.TotalResults = &feed.Total
+		doc.ItemsPerPage = &feed.PerPage
+		doc.StartIndex = &startIndex
+	}
+
+	for _, navigation := range feed.Navigation {
+		entry := atomEntry{
+			ID:      navigation.ID,
+			Title:   navigation.Title,
+			Updated: atomTime(navigation.Updated),
+			Links: []atomLink{{
+				Rel:  RelSubsection,
+				Href: navigation.Href,
+				Type: FeedType(navigation.Kind),
+			}},
+		}
+		if navigation.Summary != "" {
+			entry.Content = &atomText{Type: "text", Text: navigation.Summary}
+		}
+
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	for _, publication := range feed.Publications {
+		doc.Entries = append(doc.Entries, atomPublication(publication))
+	}
+
+	return writeXML(w, doc)
+}
+
+// WriteAtomEntry writes publication as a complete OPDS 1.2 catalog entry.
+func WriteAtomEntry(w io.Writer, publication Publication) error {
+	entry := atomPublication(publication)
+	entry.Xmlns = nsAtom
+	entry.XmlnsOPDS = nsOPDS
+	entry.XmlnsDC = nsDC
+
+	return writeXML(w, entry)
+}
+
+func atomPublication(publication Publication) atomEntry {
+	// Atom has no subtitle of an entry, so it joins the title.
+	title := publication.Title
+	if publication.Subtitle != "" {
+		title += ": " + publication.Subtitle
+	}
+
+	entry := atomEntry{
+		ID:         publication.ID,
+		Title:      title,
+		Updated:    atomTime(publication.Updated),
+		Identifier: publication.Identifier,
+		Language:   publication.Language,
+	}
+
+	for _, author := range publication.Authors {
+		entry.Authors = append(entry.Authors, atomAuthor{Name: author.Name, URI: author.Href})
+	}
+
+	for _, subject := range publication.Subjects {
+		entry.Categories = append(entry.Categories, atomCategory{Term: subject, Label: subject})
+	}
+
+	if publication.Summary != "" {
+		entry.Summary = &atomText{Type: "text", Text: publication.Summary}
+	}
+
+	if publication.Href != "" {
+		entry.Links = append(entry.Links, atomLink{Rel: "alternate", Href: publication.Href, Type: MIMEAtomEntry})
+	}
+
+	for _, acquisition := range publication.Acquisitions {
+		link := atomLinks([]Link{acquisition})[0]
+		if link.Type == "" {
+			link.Type = MIMEAtomEntry
+		}
+
+		entry.Links = append(entry.Links, link)
+	}
+
+	if publication.Image != "" {
+		entry.Links = append(entry.Links, atomLink{Rel: RelImage, Href: publication.Image})
+	}
+
+	if publication.Thumbnail != "" {
+		entry.Links = append(entry.Links, atomLink{Rel: RelThumbnail, Href: publication.Thumbnail})
+	}
+
+	return entry
+}
+
+func atomLinks(links []Link) []atomLink {
+	res := []atomLink{}
+	for _, link := range links {
+		typ := link.Type
+		if typ == "" && link.Kind != "" {
+			typ = FeedType(link.Kind)
+		}
+
+		res = append(res, atomLink{Rel: link.Rel, Href: link.Href, Type: typ, Title: link.Title})
+	}
+
+	return res
+}
+
+func atomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func writeXML(w io.Writer, doc interface{}) error {
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(doc)
+}
@@ -0,0 +1,33 @@
+package opds
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type authenticationDocument struct {
+	ID             string               `json:"id"`
+	Title          string               `json:"title"`
+	Authentication []authenticationFlow `json:"authentication"`
+}
+
+type authenticationFlow struct {
+	Type   string            `json:"type"`
+	Labels map[string]string `json:"labels"`
+}
+
+// WriteAuthentication writes the OPDS authentication document telling an
+// app to ask its user for a username and password.
+func WriteAuthentication(w io.Writer, id, title string) error {
+	return json.NewEncoder(w).Encode(authenticationDocument{
+		ID:    id,
+		Title: title,
+		Authentication: []authenticationFlow{{
+			Type: AuthBasic,
+			Labels: map[string]string{
+				"login":    "Username",
+				"password": "Password",
+			},
+		}},
+	})
+}
@@ -0,0 +1,169 @@
+package opds
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type jsonFeed struct {
+	Metadata     jsonFeedMetadata  `json:"metadata"`
+	Links        []jsonLink        `json:"links"`
+	Navigation   []jsonLink        `json:"navigation,omitempty"`
+	Publications []jsonPublication `json:"publications,omitempty"`
+}
+
+type jsonFeedMetadata struct {
+	Title         string `json:"title"`
+	Modified      string `json:"modified"`
+	NumberOfItems *int64 `json:"numberOfItems,omitempty"`
+	ItemsPerPage  *int   `json:"itemsPerPage,omitempty"`
+	CurrentPage   *int   `json:"currentPage,omitempty"`
+}
+
+type jsonPublication struct {
+	Metadata jsonPublicationMetadata `json:"metadata"`
+	Links    []jsonLink              `json:"links"`
+	Images   []jsonLink              `json:"images,omitempty"`
+}
+
+type jsonPublicationMetadata struct {
+	Type        string        `json:"@type"`
+	Identifier  string        `json:"identifier,omitempty"`
+	Title       string        `json:"title"`
+	Subtitle    string        `json:"subtitle,omitempty"`
+	Language    string        `json:"language,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Author      []jsonContrib `json:"author,omitempty"`
+	Subject     []jsonContrib `json:"subject,omitempty"`
+	Modified    string        `json:"modified"`
+}
+
+type jsonContrib struct {
+	Name  string     `json:"name"`
+	Links []jsonLink `json:"links,omitempty"`
+}
+
+type jsonLink struct {
+	Rel       string `json:"rel,omitempty"`
+	Href      string `json:"href"`
+	Type      string `json:"type,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Templated bool   `json:"templated,omitempty"`
+}
+
+// WriteJSON writes feed as an OPDS 2.0 feed.
+func WriteJSON(w io.Writer, feed *Feed) error {
+	doc := jsonFeed{
+		Metadata: jsonFeedMetadata{
+			Title:    feed.Title,
+			Modified: jsonTime(feed.Updated),
+		},
+		Links: jsonLinks(feed.Links),
+	}
+
+	if feed.SearchTemplate != "" {
+		doc.Links = append(doc.Links, jsonLink{Rel: RelSearch, Href: feed.SearchTemplate, Type: MIMEJSON, Templated: true})
+	}
+
+	if feed.PerPage > 0 {
+		doc.Metadata.NumberOfItems = &feed.Total
+		doc.Metadata.ItemsPerPage = &feed.PerPage
+		doc.Metadata.CurrentPage = &feed.Page
+	}
+
+	for _, navigation := range feed.Navigation {
+		doc.Navigation = append(doc.Navigation, jsonLink{
+			Rel:   RelSubsection,
+			Href:  navigation.Href,
+			Type:  MIMEJSON,
+			Title: navigation.Title,
+		})
+	}
+
+	// A feed of either kind holds a collection, so an empty acquisition
+	// feed still lists no publications.
+	if feed.Kind == KindAcquisition {
+		doc.Publications = []jsonPublication{}
+	}
+
+	for _, publication := range feed.Publications {
+		doc.Publications = append(doc.Publications, jsonPublicationOf(publication))
+	}
+
+	return json.NewEncoder(w).Encode(doc)
+}
+
+// WriteJSONPublication writes publication as an OPDS 2.0 publication.
+func WriteJSONPublication(w io.Writer, publication Publication) error {
+	return json.NewEncoder(w).Encode(jsonPublicationOf(publication))
+}
+
+func jsonPublicationOf(publication Publication) jsonPublication {
+	res := jsonPublication{
+		Metadata: jsonPublicationMetadata{
+			Type:        "http://schema.org/Book",
+			Identifier:  publication.Identifier,
+			Title:       publication.Title,
+			Subtitle:    publication.Subtitle,
+			Language:    publication.Language,
+			Description: publication.Summary,
+			Modified:    jsonTime(publication.Updated),
+		},
+		Links: []jsonLink{},
+	}
+
+	for _, author := range publication.Authors {
+		contrib := jsonContrib{Name: author.Name}
+		if author.Href != "" {
+			contrib.Links = []jsonLink{{Href: author.Href, Type: MIMEJSON}}
+		}
+
+		res.Metadata.Author = append(res.Metadata.Author, contrib)
+	}
+
+	for _, subject := range publication.Subjects {
+		res.Metadata.Subject = append(res.Metadata.Subject, jsonContrib{Name: subject})
+	}
+
+	if publication.Href != "" {
+		res.Links = append(res.Links, jsonLink{Rel: RelSelf, Href: publication.Href, Type: MIMEPublication})
+	}
+
+	for _, acquisition := range publication.Acquisitions {
+		link := jsonLinks([]Link{acquisition})[0]
+		if link.Type == "" {
+			link.Type = MIMEPublication
+		}
+
+		res.Links = append(res.Links, link)
+	}
+
+	if publication.Image != "" {
+		res.Images = append(res.Images, jsonLink{Href: publication.Image})
+	}
+
+	if publication.Thumbnail != "" {
+		res.Images = append(res.Images, jsonLink{Href: publication.Thumbnail})
+	}
+
+	return res
+}
+
+func jsonLinks(links []Link) []jsonLink {
+	res := []jsonLink{}
+	for _, link := range links {
+		typ := link.Type
+		if typ == "" && link.Kind != "" {
+			typ = MIMEJSON
+		}
+
+		res = append(res, jsonLink{Rel: link.Rel, Href: link.Href, Type: typ, Title: link.Title})
+	}
+
+	return res
+}
+
+func jsonTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,144 @@
+// Package opds writes catalog feeds for e-reader apps, as Atom for OPDS
+// 1.2 and as JSON for OPDS 2.0, from one description of the feed.
+package opds
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	KindNavigation  = "navigation"
+	KindAcquisition = "acquisition"
+)
+
+const (
+	MIMEAtom           = "application/atom+xml"
+	MIMEAtomEntry      = "application/atom+xml;type=entry;profile=opds-catalog"
+	MIMEJSON           = "application/opds+json"
+	MIMEPublication    = "application/opds-publication+json"
+	MIMEOpenSearch     = "application/opensearchdescription+xml"
+	MIMEAuthentication = "application/opds-authentication+json"
+)
+
+const (
+	RelSelf       = "self"
+	RelStart      = "start"
+	RelUp         = "up"
+	RelSearch     = "search"
+	RelFirst      = "first"
+	RelPrevious   = "previous"
+	RelNext       = "next"
+	RelLast       = "last"
+	RelSubsection = "subsection"
+	RelBorrow     = "http://opds-spec.org/acquisition/borrow"
+	RelImage      = "http://opds-spec.org/image"
+	RelThumbnail  = "http://opds-spec.org/image/thumbnail"
+)
+
+// FeedType is the media type of a feed of kind in OPDS 1.2.
+func FeedType(kind string) string {
+	return MIMEAtom + ";profile=opds-catalog;kind=" + kind
+}
+
+// Link points to another resource. A link to another feed sets Kind and
+// leaves Type empty, so that each format can fill in its own media type.
+type Link struct {
+	Rel   string
+	Href  string
+	Type  string
+	Title string
+	Kind  string
+}
+
+// Navigation is an entry of a navigation feed leading to another feed.
+type Navigation struct {
+	ID      string
+	Title   string
+	Summary string
+	Href    string
+	Kind    string
+	Updated time.Time
+}
+
+type Contributor struct {
+	Name string
+	Href string
+}
+
+// Publication is an entry of an acquisition feed. Href is the complete
+// entry of the publication and Acquisitions the ways to obtain it.
+type Publication struct {
+	ID           string
+	Title        string
+	Subtitle     string
+	Identifier   string
+	Language     string
+	Summary      string
+	Authors      []Contributor
+	Subjects     []string
+	Updated      time.Time
+	Href         string
+	Acquisitions []Link
+	Image        string
+	Thumbnail    string
+}
+
+// Feed is a navigation or acquisition feed. SearchHref is the OpenSearch
+// description an OPDS 1.2 feed points to and SearchTemplate the URI
+// template an OPDS 2.0 feed points to.
+type Feed struct {
+	ID             string
+	Title          string
+	Kind           string
+	Updated        time.Time
+	Links          []Link
+	SearchHref     string
+	SearchTemplate string
+	Navigation     []Navigation
+	Publications   []Publication
+
+	// Page, PerPage and Total describe the page a paginated feed holds.
+	Page    int
+	PerPage int
+	Total   int64
+}
+
+// Paginate sets the page of feed and adds its first, previous, next and
+// last links, keeping the rest of the query of self.
+func (f *Feed) Paginate(self *url.URL, page, perPage int, total int64) {
+	f.Page = page
+	f.PerPage = perPage
+	f.Total = total
+
+	last := int((total + int64(perPage) - 1) / int64(perPage))
+	if last < 1 {
+		last = 1
+	}
+
+	pageLink := func(rel string, page int) Link {
+		query := self.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(perPage))
+		href := url.URL{Path: self.Path, RawQuery: query.Encode()}
+		return Link{Rel: rel, Href: href.String(), Kind: f.Kind}
+	}
+
+	f.Links = append(f.Links, pageLink(RelFirst, 1))
+	if page > 1 {
+		f.Links = append(f.Links, pageLink(RelPrevious, min(page-1, last)))
+	}
+	if page < last {
+		f.Links = append(f.Links, pageLink(RelNext, page+1))
+	}
+	f.Links = append(f.Links, pageLink(RelLast, last))
+}
+
+// ISBNURN is the URN identifying a book by ISBN.
+func ISBNURN(isbn string) string {
+	return "urn:isbn:" + isbn
+}
+
+// AuthBasic is the OPDS authentication type of HTTP Basic credentials.
+const AuthBasic = "http://opds-spec.org/auth/basic"
@@ -0,0 +1,36 @@
+package opds
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+type openSearchDescription struct {
+	XMLName       xml.Name        `xml:"OpenSearchDescription"`
+	Xmlns         string          `xml:"xmlns,attr"`
+	ShortName     string          `xml:"ShortName"`
+	Description   string          `xml:"Description"`
+	InputEncoding string          `xml:"InputEncoding"`
+	URLs          []openSearchURL `xml:"Url"`
+}
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Template string `xml:"template,attr"`
+}
+
+// WriteOpenSearch writes the OpenSearch description of a catalog whose
+// search results are the acquisition feed at template, with
+// {searchTerms} standing for the query.
+func WriteOpenSearch(w io.Writer, shortName, description, template string) error {
+	return writeXML(w, openSearchDescription{
+		Xmlns:         nsOpenSearch,
+		ShortName:     shortName,
+		Description:   description,
+		InputEncoding: "UTF-8",
+		URLs: []openSearchURL{{
+			Type:     FeedType(KindAcquisition),
+			Template: template,
+		}},
+	})
+}
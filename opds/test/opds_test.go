@@ -0,0 +1,147 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/opds"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFeed() *opds.Feed {
+	updated := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	return &opds.Feed{
+		ID:      "https://library.example/opds/books/",
+		Title:   "New books",
+		Kind:    opds.KindAcquisition,
+		Updated: updated,
+		Links: []opds.Link{
+			{Rel: opds.RelSelf, Href: "/opds/books/", Kind: opds.KindAcquisition},
+		},
+		SearchHref:     "/opds/opensearch/",
+		SearchTemplate: "/opds/search/{?query}",
+		Publications: []opds.Publication{{
+			ID:         opds.ISBNURN("9780261103344"),
+			Title:      "The Hobbit",
+			Subtitle:   "There and Back Again",
+			Identifier: opds.ISBNURN("9780261103344"),
+			Authors:    []opds.Contributor{{Name: "J. R. R. Tolkien", Href: "/opds/authors/1/"}},
+			Subjects:   []string{"Fantasy"},
+			Updated:    updated,
+			Href:       "/opds/books/1/",
+			Acquisitions: []opds.Link{
+				{Rel: opds.RelBorrow, Href: "/opds/books/1/borrow/"},
+			},
+		}},
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	t.Run("ok: middle page", func(t *testing.T) {
+		self, _ := url.Parse("/opds/search/?query=hobbit&page=2")
+		feed := &opds.Feed{Kind: opds.KindAcquisition}
+		feed.Paginate(self, 2, 10, 35)
+
+		hrefs := map[string]string{}
+		for _, link := range feed.Links {
+			hrefs[link.Rel] = link.Href
+		}
+		assert.Equal(t, "/opds/search/?page=1&per_page=10&query=hobbit", hrefs[opds.RelFirst])
+		assert.Equal(t, "/opds/search/?page=1&per_page=10&query=hobbit", hrefs[opds.RelPrevious])
+		assert.Equal(t, "/opds/search/?page=3&per_page=10&query=hobbit", hrefs[opds.RelNext])
+		assert.Equal(t, "/opds/search/?page=4&per_page=10&query=hobbit", hrefs[opds.RelLast])
+		assert.Equal(t, int64(35), feed.Total)
+	})
+
+	t.Run("ok: single page", func(t *testing.T) {
+		self, _ := url.Parse("/opds/books/")
+		feed := &opds.Feed{Kind: opds.KindAcquisition}
+		feed.Paginate(self, 1, 20, 0)
+
+		rels := []string{}
+		for _, link := range feed.Links {
+			rels = append(rels, link.Rel)
+		}
+		assert.Equal(t, []string{opds.RelFirst, opds.RelLast}, rels)
+	})
+}
+
+func TestWriteAtom(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		feed := newFeed()
+		self, _ := url.Parse("/opds/books/")
+		feed.Paginate(self, 1, 1, 2)
+
+		buf := &bytes.Buffer{}
+		err := opds.WriteAtom(buf, feed)
+		assert.Nil(t, err)
+
+		atom := buf.String()
+		assert.Contains(t, atom, `<feed xmlns="http://www.w3.org/2005/Atom"`)
+		assert.Contains(t, atom, "<title>The Hobbit: There and Back Again</title>")
+		assert.Contains(t, atom, "<opensearch:totalResults>2</opensearch:totalResults>")
+		assert.Contains(t, atom, `rel="http://opds-spec.org/acquisition/borrow"`)
+		assert.Contains(t, atom, `href="/opds/books/?page=2&amp;per_page=1"`)
+		assert.Contains(t, atom, `href="/opds/opensearch/"`)
+	})
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		feed := newFeed()
+		self, _ := url.Parse("/opds/books/")
+		feed.Paginate(self, 1, 1, 2)
+
+		buf := &bytes.Buffer{}
+		err := opds.WriteJSON(buf, feed)
+		assert.Nil(t, err)
+
+		var doc struct {
+			Metadata struct {
+				Title         string `json:"title"`
+				NumberOfItems int64  `json:"numberOfItems"`
+				CurrentPage   int    `json:"currentPage"`
+			} `json:"metadata"`
+			Links []struct {
+				Rel       string `json:"rel"`
+				Href      string `json:"href"`
+				Templated bool   `json:"templated"`
+			} `json:"links"`
+			Publications []struct {
+				Metadata struct {
+					Title      string `json:"title"`
+					Identifier string `json:"identifier"`
+				} `json:"metadata"`
+			} `json:"publications"`
+		}
+		err = json.Unmarshal(buf.Bytes(), &doc)
+		assert.Nil(t, err)
+		assert.Equal(t, "New books", doc.Metadata.Title)
+		assert.Equal(t, int64(2), doc.Metadata.NumberOfItems)
+		assert.Equal(t, 1, doc.Metadata.CurrentPage)
+		assert.Len(t, doc.Publications, 1)
+		assert.Equal(t, "The Hobbit", doc.Publications[0].Metadata.Title)
+		assert.Equal(t, "urn:isbn:9780261103344", doc.Publications[0].Metadata.Identifier)
+
+		templated := false
+		for _, link := range doc.Links {
+			if link.Rel == opds.RelSearch {
+				templated = link.Templated && link.Href == "/opds/search/{?query}"
+			}
+		}
+		assert.True(t, templated)
+	})
+}
+
+func TestWriteOpenSearch(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := opds.WriteOpenSearch(buf, "Library", "Search the catalog.", "/opds/search/?query={searchTerms}")
+		assert.Nil(t, err)
+		assert.Contains(t, buf.String(), "<ShortName>Library</ShortName>")
+		assert.Contains(t, buf.String(), `template="/opds/search/?query={searchTerms}"`)
+	})
+}
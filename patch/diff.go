@@ -0,0 +1,35 @@
+package patch
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Changed compares two pointers to the same struct type and returns the
+// Go names of the fields that differ along with their json tag names,
+// which double as column names for the request DTOs.
+func Changed(before, after interface{}) (fields []string, columns []string) {
+	b := reflect.Indirect(reflect.ValueOf(before))
+	a := reflect.Indirect(reflect.ValueOf(after))
+
+	for i := 0; i < b.NumField(); i++ {
+		field := b.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if reflect.DeepEqual(b.Field(i).Interface(), a.Field(i).Interface()) {
+			continue
+		}
+
+		column := strings.Split(field.Tag.Get("json"), ",")[0]
+		if column == "" || column == "-" {
+			column = field.Name
+		}
+
+		fields = append(fields, field.Name)
+		columns = append(columns, column)
+	}
+
+	return fields, columns
+}
@@ -0,0 +1,369 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	MIMEMergePatch = "application/merge-patch+json"
+	MIMEJSONPatch  = "application/json-patch+json"
+)
+
+var (
+	ErrInvalidDocument = errors.New("invalid patch document")
+	ErrInvalidPointer  = errors.New("invalid json pointer")
+	ErrPathNotFound    = errors.New("path not found")
+	ErrTestFailed      = errors.New("test operation failed")
+)
+
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// MergePatch applies an RFC 7396 merge patch to doc.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := decode(patch)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+
+		t[key] = merge(t[key], value)
+	}
+
+	return t
+}
+
+// JSONPatch applies an RFC 6902 JSON Patch to doc. Operations are applied
+// in order and the whole patch fails if any of them does.
+func JSONPatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := []Operation{}
+	err = json.Unmarshal(patch, &ops)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	}
+
+	for _, op := range ops {
+		target, err = apply(target, op)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op.Op, op.Path, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func apply(doc interface{}, op Operation) (interface{}, error) {
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, errors.Join(ErrInvalidDocument, errors.New("missing value"))
+		}
+
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidDocument, err)
+		}
+
+		switch op.Op {
+		case "add":
+			return add(doc, op.Path, value)
+		case "replace":
+			doc, _, err = remove(doc, op.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			return add(doc, op.Path, value)
+		default:
+			curr, err := get(doc, op.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			if !reflect.DeepEqual(curr, value) {
+				return nil, ErrTestFailed
+			}
+
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err := remove(doc, op.Path)
+		return doc, err
+	case "move":
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, errors.Join(ErrInvalidDocument, errors.New("cannot move into own child"))
+		}
+
+		doc, value, err := remove(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		return add(doc, op.Path, value)
+	case "copy":
+		value, err := get(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		return add(doc, op.Path, deepCopy(value))
+	default:
+		return nil, errors.Join(ErrInvalidDocument, fmt.Errorf("unknown op %q", op.Op))
+	}
+}
+
+func get(doc interface{}, path string) (interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	curr := doc
+	for _, token := range tokens {
+		switch node := curr.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, ErrPathNotFound
+			}
+
+			curr = value
+		case []interface{}:
+			idx, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			curr = node[idx]
+		default:
+			return nil, ErrPathNotFound
+		}
+	}
+
+	return curr, nil
+}
+
+func add(doc interface{}, path string, value interface{}) (interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(doc, joinPointer(tokens[:len(tokens)-1]))
+	if err != nil {
+		return nil, err
+	}
+
+	last := tokens[len(tokens)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return doc, nil
+	case []interface{}:
+		idx := len(node)
+		if last != "-" {
+			idx, err = arrayIndex(last, len(node))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		node = append(node, nil)
+		copy(node[idx+1:], node[idx:])
+		node[idx] = value
+		return replaceParent(doc, tokens[:len(tokens)-1], node)
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+func remove(doc interface{}, path string) (interface{}, interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, doc, nil
+	}
+
+	parent, err := get(doc, joinPointer(tokens[:len(tokens)-1]))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	last := tokens[len(tokens)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		value, ok := node[last]
+		if !ok {
+			return nil, nil, ErrPathNotFound
+		}
+
+		delete(node, last)
+		return doc, value, nil
+	case []interface{}:
+		idx, err := arrayIndex(last, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		value := node[idx]
+		node = append(node[:idx:idx], node[idx+1:]...)
+		doc, err = replaceParent(doc, tokens[:len(tokens)-1], node)
+		return doc, value, err
+	default:
+		return nil, nil, ErrPathNotFound
+	}
+}
+
+// replaceParent stores a resized array back into its parent, since
+// appending to a slice may not be visible through the parent's reference.
+func replaceParent(doc interface{}, tokens []string, node []interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return node, nil
+	}
+
+	parent, err := get(doc, joinPointer(tokens[:len(tokens)-1]))
+	if err != nil {
+		return nil, err
+	}
+
+	last := tokens[len(tokens)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[last] = node
+	case []interface{}:
+		idx, err := arrayIndex(last, len(p)-1)
+		if err != nil {
+			return nil, err
+		}
+
+		p[idx] = node
+	}
+
+	return doc, nil
+}
+
+func parsePointer(path string) ([]string, error) {
+	if path == "" {
+		return []string{}, nil
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return nil, ErrInvalidPointer
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func joinPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+
+	return sb.String()
+}
+
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, ErrInvalidPointer
+	}
+
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 {
+		return 0, ErrInvalidPointer
+	}
+
+	if idx > max {
+		return 0, ErrPathNotFound
+	}
+
+	return idx, nil
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			res[key] = deepCopy(item)
+		}
+
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			res[i] = deepCopy(item)
+		}
+
+		return res
+	default:
+		return v
+	}
+}
+
+// decode keeps numbers as json.Number so that int64 identifiers survive
+// the round trip without float rounding.
+func decode(data []byte) (interface{}, error) {
+	var res interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
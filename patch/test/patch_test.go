@@ -0,0 +1,84 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/patch"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		doc := []byte(`{"isbn":"9789295055025","title":"Old","author_id":1729327188000000001}`)
+
+		res, err := patch.MergePatch(doc, []byte(`{"title":"New","extra":null}`))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"isbn":"9789295055025","title":"New","author_id":1729327188000000001}`, string(res))
+	})
+
+	t.Run("ok: nested remove", func(t *testing.T) {
+		doc := []byte(`{"a":{"b":1,"c":2}}`)
+
+		res, err := patch.MergePatch(doc, []byte(`{"a":{"b":null}}`))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"a":{"c":2}}`, string(res))
+	})
+
+	t.Run("error: invalid patch", func(t *testing.T) {
+		res, err := patch.MergePatch([]byte(`{}`), []byte(`{`))
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, patch.ErrInvalidDocument)
+	})
+}
+
+func TestJSONPatch(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		doc := []byte(`{"title":"Old","tags":["a","b"],"author_id":1729327188000000001}`)
+		ops := []byte(`[
+			{"op":"test","path":"/title","value":"Old"},
+			{"op":"replace","path":"/title","value":"New"},
+			{"op":"add","path":"/tags/1","value":"x"},
+			{"op":"remove","path":"/tags/0"},
+			{"op":"copy","from":"/title","path":"/subtitle"},
+			{"op":"move","from":"/subtitle","path":"/name"}
+		]`)
+
+		res, err := patch.JSONPatch(doc, ops)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"title":"New","tags":["x","b"],"name":"New","author_id":1729327188000000001}`, string(res))
+	})
+
+	t.Run("ok: escaped pointer", func(t *testing.T) {
+		res, err := patch.JSONPatch([]byte(`{"a/b":1,"m~n":2}`), []byte(`[{"op":"remove","path":"/a~1b"},{"op":"replace","path":"/m~0n","value":3}]`))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"m~n":3}`, string(res))
+	})
+
+	t.Run("error: test failed", func(t *testing.T) {
+		res, err := patch.JSONPatch([]byte(`{"title":"Old"}`), []byte(`[{"op":"test","path":"/title","value":"Other"}]`))
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, patch.ErrTestFailed)
+	})
+
+	t.Run("error: path not found", func(t *testing.T) {
+		res, err := patch.JSONPatch([]byte(`{"title":"Old"}`), []byte(`[{"op":"replace","path":"/missing","value":1}]`))
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, patch.ErrPathNotFound)
+	})
+
+	t.Run("error: unknown op", func(t *testing.T) {
+		res, err := patch.JSONPatch([]byte(`{}`), []byte(`[{"op":"merge","path":"/a"}]`))
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, patch.ErrInvalidDocument)
+	})
+}
+
+func TestChanged(t *testing.T) {
+	before := &dto.BookRequest{ISBN: "9789295055025", Title: "Old", AuthorID: 1}
+	after := &dto.BookRequest{ISBN: "9789295055025", Title: "New", AuthorID: 2}
+
+	fields, columns := patch.Changed(before, after)
+	assert.Equal(t, []string{"Title", "AuthorID"}, fields)
+	assert.Equal(t, []string{"title", "author_id"}, columns)
+}
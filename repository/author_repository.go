@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/rhtyx/bayarind-service.git/model"
 	"github.com/rhtyx/bayarind-service.git/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/sirupsen/logrus"
 )
@@ -15,6 +19,22 @@ type AuthorRepository struct {
 	db *gorm.DB
 }
 
+// authorColumns are the columns a full update writes. aliases is not a
+// column of authors: it replaces the aliases of the author.
+var authorColumns = []string{"name", "birth_date", "death_date", "nationality", "biography", "website", "viaf", "isni", "wikidata_id", "translations", "aliases"}
+
+// aliasMatch matches the authors with an alias like the argument.
+const aliasMatch = "EXISTS (SELECT 1 FROM author_aliases WHERE author_aliases.author_id = authors.id AND author_aliases.name ILIKE ?)"
+
+// notMerged leaves out the authors merged into another. They are kept
+// soft-deleted for their history but are not in the trash.
+const notMerged = "NOT EXISTS (SELECT 1 FROM author_redirects WHERE author_redirects.author_id = authors.id)"
+
+// aliasOrder lists the aliases of an author by name.
+func aliasOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("name")
+}
+
 func NewAuthorRepository(db *gorm.DB) model.AuthorRepository {
 	return &AuthorRepository{db: db}
 }
@@ -40,7 +60,28 @@ func (a AuthorRepository) FindByID(ctx context.Context, authorID int64) (*model.
 		WithField("authorID", authorID)
 
 	author := &model.Author{}
-	err := a.db.WithContext(ctx).Take(author, "id = ?", authorID).Error
+	err := a.db.WithContext(ctx).Preload("Aliases", aliasOrder).Take(author, "id = ?", authorID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return author, nil
+}
+
+// FindByName matches names and aliases case-insensitively. It prefers an
+// author by that name to one with that alias, and the oldest author among
+// equals.
+func (a AuthorRepository) FindByName(ctx context.Context, name string) (*model.Author, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("name", name)
+
+	author := &model.Author{}
+	err := a.db.WithContext(ctx).
+		Where("LOWER(name) = LOWER(?) OR EXISTS (SELECT 1 FROM author_aliases WHERE author_aliases.author_id = authors.id AND LOWER(author_aliases.name) = LOWER(?))", name, name).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "LOWER(name) = LOWER(?) DESC, created_at", Vars: []interface{}{name}}}).
+		Take(author).Error
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -62,19 +103,180 @@ func (a AuthorRepository) FindAll(ctx context.Context) ([]*model.Author, error)
 	return authors, nil
 }
 
+// FindAllByName lists the authors whose name or one of whose aliases
+// contains name, ignoring case.
+func (a AuthorRepository) FindAllByName(ctx context.Context, name string) ([]*model.Author, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("name", name)
+
+	pattern := "%" + name + "%"
+	authors := []*model.Author{}
+	err := a.db.WithContext(ctx).
+		Preload("Aliases", aliasOrder).
+		Where("name ILIKE ? OR "+aliasMatch, pattern, pattern).
+		Order("name, id").
+		Find(&authors).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+// FindAllByIDs returns the live authors among authorIDs.
+func (a AuthorRepository) FindAllByIDs(ctx context.Context, authorIDs []int64) ([]*model.Author, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorIDs", authorIDs)
+
+	authors := []*model.Author{}
+	err := a.db.WithContext(ctx).Where("id IN ?", authorIDs).Find(&authors).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+// FindPage returns one page of the live authors by name along with the
+// number of live authors.
+func (a AuthorRepository) FindPage(ctx context.Context, pagination model.Pagination) ([]*model.Author, int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("pagination", utils.Dump(pagination))
+
+	var total int64
+	err := a.db.WithContext(ctx).Model(&model.Author{}).Count(&total).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	authors := []*model.Author{}
+	err = a.db.WithContext(ctx).
+		Order("name, id").
+		Limit(pagination.PerPage).
+		Offset(pagination.Offset()).
+		Find(&authors).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return authors, total, nil
+}
+
+func (a AuthorRepository) Stream(ctx context.Context, filter model.AuthorFilter, fn func(*model.Author) error) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("filter", utils.Dump(filter))
+
+	conditions := []string{"deleted_at IS NULL"}
+	args := []interface{}{}
+	if filter.Name != "" {
+		conditions = append(conditions, "(name ILIKE ? OR "+aliasMatch+")")
+		args = append(args, "%"+filter.Name+"%", "%"+filter.Name+"%")
+	}
+	if filter.UpdatedSince != nil {
+		conditions = append(conditions, "COALESCE(updated_at, created_at) >= ?")
+		args = append(args, *filter.UpdatedSince)
+	}
+
+	query := "SELECT * FROM authors WHERE " + strings.Join(conditions, " AND ") + " ORDER BY id"
+	err := streamCursor(ctx, a.db, "authors_export", query, args, fn)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 func (a AuthorRepository) Update(ctx context.Context, author *model.Author) (*model.Author, error) {
+	return a.UpdateColumns(ctx, author, authorColumns)
+}
+
+// UpdateColumns writes only the given columns of author, bumping its
+// version and updated_at.
+func (a AuthorRepository) UpdateColumns(ctx context.Context, author *model.Author, columns []string) (*model.Author, error) {
 	logger := logrus.
 		WithContext(ctx).
-		WithField("author", utils.Dump(author))
+		WithFields(logrus.Fields{
+			"author":  utils.Dump(author),
+			"columns": columns,
+		})
+
+	values := map[string]interface{}{
+		"name":         author.Name,
+		"birth_date":   author.BirthDate,
+		"death_date":   author.DeathDate,
+		"nationality":  author.Nationality,
+		"biography":    author.Biography,
+		"website":      author.Website,
+		"viaf":         author.VIAF,
+		"isni":         author.ISNI,
+		"wikidata_id":  author.WikidataID,
+		"translations": author.Translations,
+	}
+
+	now := time.Now()
+	fields := map[string]interface{}{
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": now,
+	}
+	setAliases := false
+	for _, column := range columns {
+		if column == "aliases" {
+			setAliases = true
+			continue
+		}
+
+		value, ok := values[column]
+		if !ok {
+			return nil, fmt.Errorf("unknown author column %q", column)
+		}
+
+		fields[column] = value
+	}
 
 	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Save(author).Error
+		currAuthor := &model.Author{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(currAuthor, "id = ?", author.ID).Error
 		if err != nil {
 			logger.Error(err)
 			return err
 		}
 
-		err = tx.Take(author).Error
+		if currAuthor.Version != author.Version {
+			return model.ErrStaleVersion
+		}
+
+		err = tx.Create(newAuthorVersion(currAuthor, now)).Error
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		err = tx.Model(&model.Author{}).
+			Where("id = ? AND version = ?", author.ID, author.Version).
+			Updates(fields).Error
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		if setAliases {
+			err = replaceAliases(tx, author.ID, author.Aliases)
+			if err != nil {
+				logger.Error(err)
+				return err
+			}
+		}
+
+		err = tx.Preload("Aliases", aliasOrder).Take(author).Error
 		if err != nil {
 			logger.Error(err)
 			return err
@@ -103,3 +305,392 @@ func (a AuthorRepository) Delete(ctx context.Context, authorID int64) error {
 
 	return nil
 }
+
+func (a AuthorRepository) DeleteWithBooks(ctx context.Context, authorID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&model.Book{}, "author_id = ?", authorID).Error
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		res := tx.Delete(&model.Author{}, "id = ?", authorID)
+		if res.Error != nil {
+			logger.Error(res.Error)
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAndReassignBooks moves the books of an author to targetAuthorID
+// as new versions, the way Merge does, and moves the author to the trash.
+func (a AuthorRepository) DeleteAndReassignBooks(ctx context.Context, authorID, targetAuthorID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"authorID":       authorID,
+			"targetAuthorID": targetAuthorID,
+		})
+
+	now := time.Now()
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO book_versions (book_id, version, isbn, title, subtitle, author_id, cover_key, cover_type, translations, valid_from, valid_to)
+			SELECT id, version, isbn, title, subtitle, author_id, cover_key, cover_type, translations, COALESCE(updated_at, created_at), ?
+			FROM books WHERE author_id = ? AND deleted_at IS NULL`,
+			now, authorID,
+		).Error
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		err = tx.Model(&model.Book{}).
+			Where("author_id = ?", authorID).
+			Updates(map[string]interface{}{
+				"author_id":  targetAuthorID,
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": now,
+			}).Error
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		res := tx.Delete(&model.Author{}, "id = ?", authorID)
+		if res.Error != nil {
+			logger.Error(res.Error)
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (a AuthorRepository) FindDeletedByID(ctx context.Context, authorID int64) (*model.Author, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	author := &model.Author{}
+	err := a.db.WithContext(ctx).Unscoped().Take(author, "id = ? AND deleted_at IS NOT NULL", authorID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (a AuthorRepository) FindAllDeleted(ctx context.Context) ([]*model.Author, error) {
+	logger := logrus.WithContext(ctx)
+
+	authors := []*model.Author{}
+	err := a.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Where(notMerged).
+		Order("deleted_at DESC").
+		Find(&authors).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+func (a AuthorRepository) Restore(ctx context.Context, authorID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	res := a.db.WithContext(ctx).Unscoped().
+		Model(&model.Author{}).
+		Where("id = ? AND deleted_at IS NOT NULL", authorID).
+		Where(notMerged).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (a AuthorRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("deletedBefore", deletedBefore)
+
+	// Authors still referenced by a book, live or trashed, are kept until
+	// those books are purged. Authors of a work are kept as well.
+	res := a.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Where(notMerged).
+		Where("NOT EXISTS (SELECT 1 FROM books WHERE books.author_id = authors.id)").
+		Where("NOT EXISTS (SELECT 1 FROM works WHERE works.author_id = authors.id)").
+		Delete(&model.Author{})
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
+
+// FindVersions lists the past versions of an author, newest first.
+func (a AuthorRepository) FindVersions(ctx context.Context, authorID int64) ([]*model.AuthorVersion, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	var versions []*model.AuthorVersion
+	err := a.db.WithContext(ctx).Where("author_id = ?", authorID).Order("version DESC").Find(&versions).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (a AuthorRepository) FindVersion(ctx context.Context, authorID, version int64) (*model.AuthorVersion, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"authorID": authorID,
+			"version":  version,
+		})
+
+	authorVersion := &model.AuthorVersion{}
+	err := a.db.WithContext(ctx).Take(authorVersion, "author_id = ? AND version = ?", authorID, version).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return authorVersion, nil
+}
+
+// replaceAliases makes aliases the only aliases of an author.
+func replaceAliases(tx *gorm.DB, authorID int64, aliases []*model.AuthorAlias) error {
+	err := tx.Where("author_id = ?", authorID).Delete(&model.AuthorAlias{}).Error
+	if err != nil {
+		return err
+	}
+
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	for _, alias := range aliases {
+		alias.ID = 0
+		alias.AuthorID = authorID
+	}
+
+	return tx.Create(aliases).Error
+}
+
+// newAuthorVersion snapshots author as replaced at validTo.
+func newAuthorVersion(author *model.Author, validTo time.Time) *model.AuthorVersion {
+	validFrom := author.CreatedAt
+	if author.UpdatedAt != nil {
+		validFrom = *author.UpdatedAt
+	}
+
+	return &model.AuthorVersion{
+		AuthorID:     author.ID,
+		Version:      author.Version,
+		Name:         author.Name,
+		BirthDate:    author.BirthDate,
+		DeathDate:    author.DeathDate,
+		Nationality:  author.Nationality,
+		Biography:    author.Biography,
+		Website:      author.Website,
+		VIAF:         author.VIAF,
+		ISNI:         author.ISNI,
+		WikidataID:   author.WikidataID,
+		Translations: author.Translations,
+		ValidFrom:    validFrom,
+		ValidTo:      &validTo,
+	}
+}
+
+// FindAllSharingBirthDate lists the live authors born on the same day as
+// another live author, grouped by birth date.
+func (a AuthorRepository) FindAllSharingBirthDate(ctx context.Context) ([]*model.Author, error) {
+	logger := logrus.WithContext(ctx)
+
+	authors := []*model.Author{}
+	err := a.db.WithContext(ctx).
+		Where("birth_date IN (SELECT birth_date FROM authors WHERE deleted_at IS NULL GROUP BY birth_date HAVING COUNT(*) > 1)").
+		Order("birth_date, id").
+		Find(&authors).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+// Merge folds the authors in mergedIDs into authorID in one transaction.
+// Their books and works move over as new versions, their names and aliases
+// become aliases of authorID, which gets a new version as well, and their
+// ids redirect to it. The merged authors are soft-deleted, keeping their
+// history, and stay out of the trash.
+func (a AuthorRepository) Merge(ctx context.Context, authorID int64, mergedIDs []int64, mergedAt time.Time) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"authorID":  authorID,
+			"mergedIDs": mergedIDs,
+		})
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		author := &model.Author{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(author, "id = ?", authorID).Error
+		if err != nil {
+			return err
+		}
+
+		var merged []*model.Author
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&merged, "id IN ?", mergedIDs).Error
+		if err != nil {
+			return err
+		}
+
+		if len(merged) != len(mergedIDs) {
+			return gorm.ErrRecordNotFound
+		}
+
+		err = tx.Exec(`
+			INSERT INTO book_versions (book_id, version, isbn, title, subtitle, author_id, cover_key, cover_type, translations, valid_from, valid_to)
+			SELECT id, version, isbn, title, subtitle, author_id, cover_key, cover_type, translations, COALESCE(updated_at, created_at), ?
+			FROM books WHERE author_id IN ?`,
+			mergedAt, mergedIDs,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&model.Book{}).Unscoped().
+			Where("author_id IN ?", mergedIDs).
+			Updates(map[string]interface{}{
+				"author_id":  authorID,
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": mergedAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec("UPDATE works SET author_id = ? WHERE author_id IN ?", authorID, mergedIDs).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`
+			INSERT INTO author_aliases (author_id, name, created_at)
+			SELECT ?, name, ? FROM (
+				SELECT name FROM authors WHERE id IN ?
+				UNION SELECT name FROM author_aliases WHERE author_id IN ?
+			) names
+			WHERE LOWER(name) <> LOWER(?)
+			ON CONFLICT (author_id, LOWER(name)) DO NOTHING`,
+			authorID, mergedAt, mergedIDs, mergedIDs, author.Name,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec("UPDATE author_redirects SET target_author_id = ? WHERE target_author_id IN ?", authorID, mergedIDs).Error
+		if err != nil {
+			return err
+		}
+
+		for _, mergedID := range mergedIDs {
+			err = tx.Exec(
+				"INSERT INTO author_redirects (author_id, target_author_id, merged_at) VALUES (?, ?, ?)",
+				mergedID, authorID, mergedAt,
+			).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Create(newAuthorVersion(author, mergedAt)).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(author).Updates(map[string]interface{}{
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": mergedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Author{}).Where("id IN ?", mergedIDs).Update("deleted_at", mergedAt).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// FindRedirect returns the author a merged author id now points at.
+func (a AuthorRepository) FindRedirect(ctx context.Context, authorID int64) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	var targetAuthorIDs []int64
+	err := a.db.WithContext(ctx).
+		Table("author_redirects").
+		Where("author_id = ?", authorID).
+		Pluck("target_author_id", &targetAuthorIDs).Error
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if len(targetAuthorIDs) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return targetAuthorIDs[0], nil
+}
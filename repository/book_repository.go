@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/rhtyx/bayarind-service.git/model"
 	"github.com/rhtyx/bayarind-service.git/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/sirupsen/logrus"
 )
@@ -15,17 +19,37 @@ type BookRepository struct {
 	db *gorm.DB
 }
 
+var bookColumns = []string{"isbn", "title", "subtitle", "author_id", "translations"}
+
 func NewBookRepository(db *gorm.DB) model.BookRepository {
 	return &BookRepository{db: db}
 }
 
 func (b BookRepository) Create(ctx context.Context, book *model.Book) (*model.Book, error) {
+	return b.CreateWithEdition(ctx, book, &model.Edition{})
+}
+
+// CreateWithEdition creates the book and, when it is not linked to one
+// yet, the work and edition it is published as, in one transaction.
+// edition supplies the edition's publication details.
+func (b BookRepository) CreateWithEdition(ctx context.Context, book *model.Book, edition *model.Edition) (*model.Book, error) {
 	logger := logrus.
 		WithContext(ctx).
 		WithField("book", utils.Dump(book))
 
 	book.ID = utils.GenerateID()
-	err := b.db.WithContext(ctx).Create(book).Error
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if book.EditionID == nil {
+			err := createBookEdition(tx, book, edition)
+			if err != nil {
+				return err
+			}
+
+			book.EditionID = &edition.ID
+		}
+
+		return tx.Create(book).Error
+	})
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -34,6 +58,25 @@ func (b BookRepository) Create(ctx context.Context, book *model.Book) (*model.Bo
 	return book, nil
 }
 
+// createBookEdition creates the work and edition a book without one is
+// published as, the way existing books were given theirs on migration.
+func createBookEdition(tx *gorm.DB, book *model.Book, edition *model.Edition) error {
+	work := &model.Work{
+		ID:       utils.GenerateID(),
+		Title:    book.Title,
+		AuthorID: book.AuthorID,
+	}
+	err := tx.Create(work).Error
+	if err != nil {
+		return err
+	}
+
+	edition.ID = utils.GenerateID()
+	edition.WorkID = work.ID
+	edition.ISBN = book.ISBN
+	return tx.Create(edition).Error
+}
+
 func (b BookRepository) FindByID(ctx context.Context, bookID int64) (*model.Book, error) {
 	logger := logrus.
 		WithContext(ctx).
@@ -78,23 +121,332 @@ func (b BookRepository) FindAll(ctx context.Context) ([]*model.Book, error) {
 	return book, nil
 }
 
+func (b BookRepository) FindAllByIDs(ctx context.Context, bookIDs []int64) ([]*model.Book, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookIDs", bookIDs)
+
+	books := []*model.Book{}
+	err := b.db.WithContext(ctx).Where("id IN ?", bookIDs).Find(&books).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// FindAllBySubjectIDs returns the books filed under any of the given
+// subjects, each book once.
+func (b BookRepository) FindAllBySubjectIDs(ctx context.Context, subjectIDs []int64) ([]*model.Book, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subjectIDs", subjectIDs)
+
+	books := []*model.Book{}
+	err := b.db.WithContext(ctx).
+		Where("id IN (SELECT book_id FROM book_subjects WHERE subject_id IN ?)", subjectIDs).
+		Order("title").
+		Find(&books).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (b BookRepository) FindAllByTag(ctx context.Context, tag string) ([]*model.Book, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("tag", tag)
+
+	books := []*model.Book{}
+	err := b.db.WithContext(ctx).
+		Where("id IN (SELECT book_tags.book_id FROM book_tags JOIN tags ON tags.id = book_tags.tag_id WHERE tags.name = ?)", tag).
+		Order("title").
+		Find(&books).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (b BookRepository) Stream(ctx context.Context, filter model.BookFilter, fn func(*model.Book) error) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("filter", utils.Dump(filter))
+
+	conditions, args := bookConditions(filter)
+	query := "SELECT * FROM books WHERE " + strings.Join(conditions, " AND ") + " ORDER BY id"
+	err := streamCursor(ctx, b.db, "books_export", query, args, fn)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// StreamCitations is Stream for MARC exports, passing each book along with
+// its author, edition and publisher. They are looked up a cursor batch at a
+// time on the cursor's own transaction.
+func (b BookRepository) StreamCitations(ctx context.Context, filter model.BookFilter, fn func(*model.Citation) error) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("filter", utils.Dump(filter))
+
+	conditions, args := bookConditions(filter)
+	query := "SELECT * FROM books WHERE " + strings.Join(conditions, " AND ") + " ORDER BY id"
+	err := streamCursorBatches(ctx, b.db, "books_citation_export", query, args, func(tx *gorm.DB, books []*model.Book) error {
+		citations, err := findCitations(tx, books)
+		if err != nil {
+			return err
+		}
+
+		for _, citation := range citations {
+			err = fn(citation)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// findCitations gathers the authors, editions and publishers of books in
+// one query each. Like CitationService.FindByBookIDs, it leaves out what
+// cannot be found rather than failing.
+func findCitations(tx *gorm.DB, books []*model.Book) ([]*model.Citation, error) {
+	authorIDs := []int64{}
+	editionIDs := []int64{}
+	for _, book := range books {
+		authorIDs = append(authorIDs, book.AuthorID)
+		if book.EditionID != nil {
+			editionIDs = append(editionIDs, *book.EditionID)
+		}
+	}
+
+	authors := []*model.Author{}
+	err := tx.Where("id IN ?", authorIDs).Find(&authors).Error
+	if err != nil {
+		return nil, err
+	}
+
+	authorsByID := map[int64]*model.Author{}
+	for _, author := range authors {
+		authorsByID[author.ID] = author
+	}
+
+	editionsByID := map[int64]*model.Edition{}
+	publishersByID := map[int64]*model.Publisher{}
+	if len(editionIDs) > 0 {
+		editions := []*model.Edition{}
+		err = tx.Where("id IN ?", editionIDs).Find(&editions).Error
+		if err != nil {
+			return nil, err
+		}
+
+		publisherIDs := []int64{}
+		for _, edition := range editions {
+			editionsByID[edition.ID] = edition
+			if edition.PublisherID != nil {
+				publisherIDs = append(publisherIDs, *edition.PublisherID)
+			}
+		}
+
+		if len(publisherIDs) > 0 {
+			publishers := []*model.Publisher{}
+			err = tx.Where("id IN ?", publisherIDs).Find(&publishers).Error
+			if err != nil {
+				return nil, err
+			}
+
+			for _, publisher := range publishers {
+				publishersByID[publisher.ID] = publisher
+			}
+		}
+	}
+
+	citations := []*model.Citation{}
+	for _, book := range books {
+		citation := &model.Citation{
+			Book:   book,
+			Author: authorsByID[book.AuthorID],
+		}
+
+		if book.EditionID != nil {
+			citation.Edition = editionsByID[*book.EditionID]
+		}
+
+		if citation.Edition != nil && citation.Edition.PublisherID != nil {
+			citation.Publisher = publishersByID[*citation.Edition.PublisherID]
+		}
+
+		citations = append(citations, citation)
+	}
+
+	return citations, nil
+}
+
+// FindPage returns one page of the live books matching filter, most
+// recently changed first, along with the number of matches on all pages.
+func (b BookRepository) FindPage(ctx context.Context, filter model.BookFilter, pagination model.Pagination) ([]*model.Book, int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"filter":     utils.Dump(filter),
+			"pagination": utils.Dump(pagination),
+		})
+
+	conditions, args := bookConditions(filter)
+	query := b.db.WithContext(ctx).Model(&model.Book{}).Where(strings.Join(conditions, " AND "), args...)
+
+	var total int64
+	err := query.Count(&total).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	books := []*model.Book{}
+	err = query.
+		Order("COALESCE(updated_at, created_at) DESC, id DESC").
+		Limit(pagination.PerPage).
+		Offset(pagination.Offset()).
+		Find(&books).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return books, total, nil
+}
+
+// bookConditions turns filter into the where clause of a query on the live
+// books.
+func bookConditions(filter model.BookFilter) ([]string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL"}
+	args := []interface{}{}
+	if filter.AuthorID != 0 {
+		conditions = append(conditions, "author_id = ?")
+		args = append(args, filter.AuthorID)
+	}
+	if filter.ISBN != "" {
+		conditions = append(conditions, "isbn = ?")
+		args = append(args, filter.ISBN)
+	}
+	if filter.Title != "" {
+		conditions = append(conditions, "title ILIKE ?")
+		args = append(args, "%"+filter.Title+"%")
+	}
+	if len(filter.SubjectIDs) > 0 {
+		conditions = append(conditions, "id IN (SELECT book_id FROM book_subjects WHERE subject_id IN ?)")
+		args = append(args, filter.SubjectIDs)
+	}
+	if filter.Query != "" {
+		conditions = append(conditions, "(title ILIKE ? OR subtitle ILIKE ? OR author_id IN (SELECT id FROM authors WHERE name ILIKE ? OR "+aliasMatch+"))")
+		args = append(args, "%"+filter.Query+"%", "%"+filter.Query+"%", "%"+filter.Query+"%", "%"+filter.Query+"%")
+	}
+	if filter.UpdatedSince != nil {
+		conditions = append(conditions, "COALESCE(updated_at, created_at) >= ?")
+		args = append(args, *filter.UpdatedSince)
+	}
+
+	return conditions, args
+}
+
+func (b BookRepository) CountByAuthorID(ctx context.Context, authorID int64) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	var count int64
+	err := b.db.WithContext(ctx).Model(&model.Book{}).Where("author_id = ?", authorID).Count(&count).Error
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (b BookRepository) Update(ctx context.Context, book *model.Book) (*model.Book, error) {
+	return b.UpdateColumns(ctx, book, bookColumns)
+}
+
+// UpdateColumns writes only the given columns of book, bumping its
+// version and updated_at.
+func (b BookRepository) UpdateColumns(ctx context.Context, book *model.Book, columns []string) (*model.Book, error) {
 	logger := logrus.
 		WithContext(ctx).
-		WithField("book", utils.Dump(book))
+		WithFields(logrus.Fields{
+			"book":    utils.Dump(book),
+			"columns": columns,
+		})
+
+	values := map[string]interface{}{
+		"isbn":         book.ISBN,
+		"title":        book.Title,
+		"subtitle":     book.Subtitle,
+		"author_id":    book.AuthorID,
+		"cover_key":    book.CoverKey,
+		"cover_type":   book.CoverType,
+		"translations": book.Translations,
+	}
+
+	now := time.Now()
+	fields := map[string]interface{}{
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": now,
+	}
+	for _, column := range columns {
+		value, ok := values[column]
+		if !ok {
+			return nil, fmt.Errorf("unknown book column %q", column)
+		}
+
+		fields[column] = value
+	}
 
 	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Save(book).Error
+		currBook := &model.Book{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(currBook, "id = ?", book.ID).Error
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		if currBook.Version != book.Version {
+			return model.ErrStaleVersion
+		}
+
+		err = tx.Create(newBookVersion(currBook, now)).Error
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		err = tx.Model(&model.Book{}).
+			Where("id = ? AND version = ?", book.ID, book.Version).
+			Updates(fields).Error
 		if err != nil {
 			logger.Error(err)
-			tx.Rollback()
 			return err
 		}
 
 		err = tx.Take(book).Error
 		if err != nil {
 			logger.Error(err)
-			tx.Rollback()
 			return err
 		}
 
@@ -121,3 +473,127 @@ func (b BookRepository) Delete(ctx context.Context, bookID int64) error {
 
 	return nil
 }
+
+func (b BookRepository) FindDeletedByID(ctx context.Context, bookID int64) (*model.Book, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	book := &model.Book{}
+	err := b.db.WithContext(ctx).Unscoped().Take(book, "id = ? AND deleted_at IS NOT NULL", bookID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return book, nil
+}
+
+func (b BookRepository) FindAllDeleted(ctx context.Context) ([]*model.Book, error) {
+	logger := logrus.WithContext(ctx)
+
+	books := []*model.Book{}
+	err := b.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&books).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (b BookRepository) Restore(ctx context.Context, bookID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	res := b.db.WithContext(ctx).Unscoped().
+		Model(&model.Book{}).
+		Where("id = ? AND deleted_at IS NOT NULL", bookID).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (b BookRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("deletedBefore", deletedBefore)
+
+	// Books that still have physical copies are kept until the copies are
+	// withdrawn, so that their loan history survives.
+	res := b.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Where("NOT EXISTS (SELECT 1 FROM copies WHERE copies.book_id = books.id)").
+		Delete(&model.Book{})
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
+
+// FindVersions lists the past versions of a book, newest first.
+func (b BookRepository) FindVersions(ctx context.Context, bookID int64) ([]*model.BookVersion, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	var versions []*model.BookVersion
+	err := b.db.WithContext(ctx).Where("book_id = ?", bookID).Order("version DESC").Find(&versions).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (b BookRepository) FindVersion(ctx context.Context, bookID, version int64) (*model.BookVersion, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID":  bookID,
+			"version": version,
+		})
+
+	bookVersion := &model.BookVersion{}
+	err := b.db.WithContext(ctx).Take(bookVersion, "book_id = ? AND version = ?", bookID, version).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return bookVersion, nil
+}
+
+// newBookVersion snapshots book as replaced at validTo.
+func newBookVersion(book *model.Book, validTo time.Time) *model.BookVersion {
+	validFrom := book.CreatedAt
+	if book.UpdatedAt != nil {
+		validFrom = *book.UpdatedAt
+	}
+
+	return &model.BookVersion{
+		BookID:       book.ID,
+		Version:      book.Version,
+		ISBN:         book.ISBN,
+		Title:        book.Title,
+		Subtitle:     book.Subtitle,
+		AuthorID:     book.AuthorID,
+		CoverKey:     book.CoverKey,
+		CoverType:    book.CoverType,
+		Translations: book.Translations,
+		ValidFrom:    validFrom,
+		ValidTo:      &validTo,
+	}
+}
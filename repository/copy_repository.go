@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CopyRepository struct {
+	db *gorm.DB
+}
+
+func NewCopyRepository(db *gorm.DB) model.CopyRepository {
+	return &CopyRepository{db: db}
+}
+
+func (c CopyRepository) Create(ctx context.Context, bookCopy *model.Copy) (*model.Copy, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("copy", utils.Dump(bookCopy))
+
+	bookCopy.ID = utils.GenerateID()
+	err := c.db.WithContext(ctx).Create(bookCopy).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (c CopyRepository) FindByID(ctx context.Context, copyID int64) (*model.Copy, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("copyID", copyID)
+
+	bookCopy := &model.Copy{}
+	err := c.db.WithContext(ctx).Take(bookCopy, "id = ?", copyID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (c CopyRepository) FindByBarcode(ctx context.Context, barcode string) (*model.Copy, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("barcode", barcode)
+
+	bookCopy := &model.Copy{}
+	err := c.db.WithContext(ctx).Take(bookCopy, "barcode = ?", barcode).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (c CopyRepository) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Copy, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	copies := []*model.Copy{}
+	err := c.db.WithContext(ctx).Where("book_id = ?", bookID).Order("barcode").Find(&copies).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return copies, nil
+}
+
+// Update writes the copy if its version still matches the stored one,
+// bumping the version and updated_at.
+func (c CopyRepository) Update(ctx context.Context, bookCopy *model.Copy) (*model.Copy, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("copy", utils.Dump(bookCopy))
+
+	fields := map[string]interface{}{
+		"barcode":    bookCopy.Barcode,
+		"branch":     bookCopy.Branch,
+		"condition":  bookCopy.Condition,
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": time.Now(),
+	}
+
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Copy{}).
+			Where("id = ? AND version = ?", bookCopy.ID, bookCopy.Version).
+			Updates(fields)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			err := tx.Take(&model.Copy{}, "id = ?", bookCopy.ID).Error
+			if err != nil {
+				return err
+			}
+
+			return model.ErrStaleVersion
+		}
+
+		return tx.Take(bookCopy).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (c CopyRepository) Delete(ctx context.Context, copyID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("copyID", copyID)
+
+	res := c.db.WithContext(ctx).Delete(&model.Copy{}, "id = ?", copyID)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+const cursorBatchSize = 500
+
+// streamCursor declares a server-side cursor for query inside a read-only
+// transaction and passes each row to fn, fetching cursorBatchSize rows at
+// a time so the whole result set is never held in memory.
+func streamCursor[T any](ctx context.Context, db *gorm.DB, name, query string, args []interface{}, fn func(*T) error) error {
+	return streamCursorBatches(ctx, db, name, query, args, func(tx *gorm.DB, batch []*T) error {
+		for _, row := range batch {
+			err := fn(row)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// streamCursorBatches is streamCursor handing over whole batches along with
+// the cursor's transaction, so that whatever else a batch needs is read on
+// the connection the cursor already holds rather than another one from the
+// pool.
+func streamCursorBatches[T any](ctx context.Context, db *gorm.DB, name, query string, args []interface{}, fn func(tx *gorm.DB, batch []*T) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("SET TRANSACTION READ ONLY").Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(fmt.Sprintf("DECLARE %s NO SCROLL CURSOR FOR %s", name, query), args...).Error
+		if err != nil {
+			return err
+		}
+
+		for {
+			batch := []*T{}
+			err = tx.Raw(fmt.Sprintf("FETCH FORWARD %d FROM %s", cursorBatchSize, name)).Scan(&batch).Error
+			if err != nil {
+				return err
+			}
+
+			if len(batch) == 0 {
+				break
+			}
+
+			err = fn(tx, batch)
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Exec("CLOSE " + name).Error
+	})
+}
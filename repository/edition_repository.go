@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+
+	"github.com/sirupsen/logrus"
+)
+
+type EditionRepository struct {
+	db *gorm.DB
+}
+
+func NewEditionRepository(db *gorm.DB) model.EditionRepository {
+	return &EditionRepository{db: db}
+}
+
+func (e EditionRepository) Create(ctx context.Context, edition *model.Edition) (*model.Edition, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("edition", utils.Dump(edition))
+
+	edition.ID = utils.GenerateID()
+	err := e.db.WithContext(ctx).Create(edition).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return edition, nil
+}
+
+func (e EditionRepository) FindByID(ctx context.Context, editionID int64) (*model.Edition, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("editionID", editionID)
+
+	edition := &model.Edition{}
+	err := e.db.WithContext(ctx).Take(edition, "id = ?", editionID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return edition, nil
+}
+
+func (e EditionRepository) FindAll(ctx context.Context) ([]*model.Edition, error) {
+	logger := logrus.WithContext(ctx)
+
+	editions := []*model.Edition{}
+	err := e.db.WithContext(ctx).Order("id").Find(&editions).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return editions, nil
+}
+
+// FindByISBN ignores editions without an ISBN.
+func (e EditionRepository) FindByISBN(ctx context.Context, isbn string) (*model.Edition, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("isbn", isbn)
+
+	edition := &model.Edition{}
+	err := e.db.WithContext(ctx).Take(edition, "isbn = ? AND isbn <> ''", isbn).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return edition, nil
+}
+
+func (e EditionRepository) FindAllByIDs(ctx context.Context, editionIDs []int64) ([]*model.Edition, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("editionIDs", editionIDs)
+
+	editions := []*model.Edition{}
+	err := e.db.WithContext(ctx).Where("id IN ?", editionIDs).Find(&editions).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return editions, nil
+}
+
+func (e EditionRepository) FindAllByWorkID(ctx context.Context, workID int64) ([]*model.Edition, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("workID", workID)
+
+	editions := []*model.Edition{}
+	err := e.db.WithContext(ctx).Where("work_id = ?", workID).Order("publication_date NULLS LAST, id").Find(&editions).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return editions, nil
+}
+
+func (e EditionRepository) CountByWorkID(ctx context.Context, workID int64) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("workID", workID)
+
+	var count int64
+	err := e.db.WithContext(ctx).Model(&model.Edition{}).Where("work_id = ?", workID).Count(&count).Error
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (e EditionRepository) CountByPublisherID(ctx context.Context, publisherID int64) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("publisherID", publisherID)
+
+	var count int64
+	err := e.db.WithContext(ctx).Model(&model.Edition{}).Where("publisher_id = ?", publisherID).Count(&count).Error
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Update writes edition if its version still matches the stored one,
+// bumping the version and updated_at.
+func (e EditionRepository) Update(ctx context.Context, edition *model.Edition) (*model.Edition, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("edition", utils.Dump(edition))
+
+	fields := map[string]interface{}{
+		"work_id":          edition.WorkID,
+		"publisher_id":     edition.PublisherID,
+		"isbn":             edition.ISBN,
+		"format":           edition.Format,
+		"language":         edition.Language,
+		"publication_date": edition.PublicationDate,
+		"page_count":       edition.PageCount,
+		"version":          gorm.Expr("version + 1"),
+		"updated_at":       time.Now(),
+	}
+
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Edition{}).
+			Where("id = ? AND version = ?", edition.ID, edition.Version).
+			Updates(fields)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			err := tx.Take(&model.Edition{}, "id = ?", edition.ID).Error
+			if err != nil {
+				return err
+			}
+
+			return model.ErrStaleVersion
+		}
+
+		return tx.Take(edition).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return edition, nil
+}
+
+func (e EditionRepository) Delete(ctx context.Context, editionID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("editionID", editionID)
+
+	res := e.db.WithContext(ctx).Delete(&model.Edition{}, "id = ?", editionID)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sirupsen/logrus"
+)
+
+// holdColumns selects a hold with its queue position, counting the waiting
+// holds of the same book placed before it.
+const holdColumns = `holds.*, CASE WHEN holds.status = 'waiting' THEN (
+	SELECT COUNT(*) FROM holds queue
+	WHERE queue.book_id = holds.book_id AND queue.status = 'waiting'
+	AND (queue.placed_at, queue.id) <= (holds.placed_at, holds.id)
+) ELSE 0 END AS position`
+
+// freeCopy matches the copies of a book that are neither on loan nor set
+// aside for a hold.
+const freeCopy = `copies.book_id = ?
+	AND NOT EXISTS (SELECT 1 FROM loans WHERE loans.copy_id = copies.id AND loans.returned_at IS NULL)
+	AND NOT EXISTS (SELECT 1 FROM holds WHERE holds.copy_id = copies.id AND holds.status = 'ready')`
+
+type HoldRepository struct {
+	db *gorm.DB
+}
+
+func NewHoldRepository(db *gorm.DB) model.HoldRepository {
+	return &HoldRepository{db: db}
+}
+
+// Create queues the hold and, in the same transaction, sets aside free
+// copies of the book for its waiting holds in queue order, so a hold is
+// never left waiting next to a free copy.
+func (h HoldRepository) Create(ctx context.Context, hold *model.Hold, readyAt, expiresAt time.Time) (*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("hold", utils.Dump(hold))
+
+	hold.ID = utils.GenerateID()
+	err := h.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(hold).Error
+		if err != nil {
+			return err
+		}
+
+		for {
+			_, err = assignNext(tx, hold.BookID, readyAt, expiresAt)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return h.FindByID(ctx, hold.ID)
+}
+
+func (h HoldRepository) FindByID(ctx context.Context, holdID int64) (*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("holdID", holdID)
+
+	hold := &model.Hold{}
+	err := h.db.WithContext(ctx).Select(holdColumns).Take(hold, "holds.id = ?", holdID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// FindActive returns the waiting or ready hold of a user on a book.
+func (h HoldRepository) FindActive(ctx context.Context, bookID, userID int64) (*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID": bookID,
+			"userID": userID,
+		})
+
+	hold := &model.Hold{}
+	err := h.db.WithContext(ctx).
+		Select(holdColumns).
+		Where("holds.book_id = ? AND holds.user_id = ?", bookID, userID).
+		Where("holds.status IN ?", []string{model.HoldStatusWaiting, model.HoldStatusReady}).
+		Take(hold).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// FindAllByBookID returns the active holds of a book, ready ones first and
+// then the waiting ones in queue order.
+func (h HoldRepository) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	holds := []*model.Hold{}
+	err := h.db.WithContext(ctx).
+		Select(holdColumns).
+		Where("holds.book_id = ?", bookID).
+		Where("holds.status IN ?", []string{model.HoldStatusWaiting, model.HoldStatusReady}).
+		Order("holds.status = 'waiting', holds.placed_at, holds.id").
+		Find(&holds).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (h HoldRepository) FindAllByUserID(ctx context.Context, userID int64, activeOnly bool) ([]*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"userID":     userID,
+			"activeOnly": activeOnly,
+		})
+
+	query := h.db.WithContext(ctx).Select(holdColumns).Where("holds.user_id = ?", userID)
+	if activeOnly {
+		query = query.Where("holds.status IN ?", []string{model.HoldStatusWaiting, model.HoldStatusReady})
+	}
+
+	holds := []*model.Hold{}
+	err := query.Order("holds.placed_at DESC").Find(&holds).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (h HoldRepository) FindAvailability(ctx context.Context, bookID int64) (*model.Availability, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	availability := &model.Availability{BookID: bookID}
+	err := h.db.WithContext(ctx).Model(&model.Copy{}).Where("book_id = ?", bookID).Count(&availability.Copies).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = h.db.WithContext(ctx).Model(&model.Copy{}).Where(freeCopy, bookID).Count(&availability.Available).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	err = h.db.WithContext(ctx).Model(&model.Hold{}).
+		Where("book_id = ? AND status = ?", bookID, model.HoldStatusWaiting).
+		Count(&availability.WaitingHolds).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return availability, nil
+}
+
+// AssignNext sets aside a free copy of the book for the first waiting hold
+// and returns that hold. It returns gorm.ErrRecordNotFound when there is no
+// free copy or no waiting hold.
+func (h HoldRepository) AssignNext(ctx context.Context, bookID int64, readyAt, expiresAt time.Time) (*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	var hold *model.Hold
+	err := h.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		hold, err = assignNext(tx, bookID, readyAt, expiresAt)
+		return err
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return h.FindByID(ctx, hold.ID)
+}
+
+// assignNext locks the head of the queue, waiting for a concurrent
+// allocation rather than skipping past it, so holds are served in the
+// order they were placed. Copies are interchangeable, so one locked by a
+// concurrent checkout is skipped.
+func assignNext(tx *gorm.DB, bookID int64, readyAt, expiresAt time.Time) (*model.Hold, error) {
+	hold := &model.Hold{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("book_id = ? AND status = ?", bookID, model.HoldStatusWaiting).
+		Order("placed_at, id").
+		Take(hold).Error
+	if err != nil {
+		return nil, err
+	}
+
+	bookCopy := &model.Copy{}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where(freeCopy, bookID).
+		Order("id").
+		Take(bookCopy).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Model(hold).Updates(map[string]interface{}{
+		"status":     model.HoldStatusReady,
+		"copy_id":    bookCopy.ID,
+		"ready_at":   readyAt,
+		"expires_at": expiresAt,
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": time.Now(),
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// Cancel closes a waiting or ready hold, releasing its copy.
+func (h HoldRepository) Cancel(ctx context.Context, holdID int64, closedAt time.Time) (*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("holdID", holdID)
+
+	err := h.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Hold{}).
+			Where("id = ? AND status IN ?", holdID, []string{model.HoldStatusWaiting, model.HoldStatusReady}).
+			Updates(map[string]interface{}{
+				"status":     model.HoldStatusCancelled,
+				"closed_at":  closedAt,
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": time.Now(),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			err := tx.Take(&model.Hold{}, "id = ?", holdID).Error
+			if err != nil {
+				return err
+			}
+
+			return model.ErrHoldClosed
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return h.FindByID(ctx, holdID)
+}
+
+// ExpireReady closes the ready holds whose pickup deadline has passed and
+// returns them.
+func (h HoldRepository) ExpireReady(ctx context.Context, now time.Time) ([]*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("now", now)
+
+	holds := []*model.Hold{}
+	err := h.db.WithContext(ctx).Raw(`
+		UPDATE holds
+		SET status = ?, closed_at = ?, version = version + 1, updated_at = ?
+		WHERE status = ? AND expires_at < ?
+		RETURNING *`,
+		model.HoldStatusExpired, now, now, model.HoldStatusReady, now).
+		Scan(&holds).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+// FindPendingBookIDs returns the books that have both a waiting hold and a
+// free copy, which happens when an allocation failed or was skipped.
+func (h HoldRepository) FindPendingBookIDs(ctx context.Context) ([]int64, error) {
+	logger := logrus.WithContext(ctx)
+
+	bookIDs := []int64{}
+	err := h.db.WithContext(ctx).Raw(`
+		SELECT DISTINCT holds.book_id FROM holds
+		WHERE holds.status = ? AND EXISTS (SELECT 1 FROM copies WHERE `+freeCopy+`)
+		ORDER BY holds.book_id`,
+		model.HoldStatusWaiting, gorm.Expr("holds.book_id")).
+		Scan(&bookIDs).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return bookIDs, nil
+}
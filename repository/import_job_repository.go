@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ImportJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) model.ImportJobRepository {
+	return &ImportJobRepository{db: db}
+}
+
+func (i ImportJobRepository) Create(ctx context.Context, job *model.ImportJob) (*model.ImportJob, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("job", utils.Dump(job))
+
+	job.ID = utils.GenerateID()
+	err := i.db.WithContext(ctx).Create(job).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (i ImportJobRepository) FindByID(ctx context.Context, jobID int64) (*model.ImportJob, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("jobID", jobID)
+
+	job := &model.ImportJob{}
+	err := i.db.WithContext(ctx).Take(job, "id = ?", jobID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (i ImportJobRepository) FindAllByUserID(ctx context.Context, userID int64) ([]*model.ImportJob, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	jobs := []*model.ImportJob{}
+	err := i.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&jobs).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (i ImportJobRepository) Update(ctx context.Context, job *model.ImportJob) (*model.ImportJob, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("jobID", job.ID)
+
+	err := i.db.WithContext(ctx).Save(job).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// FailUnfinished fails the jobs left pending or running, which no process
+// is working on once the server restarts.
+func (i ImportJobRepository) FailUnfinished(ctx context.Context, finishedAt time.Time) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("finishedAt", finishedAt)
+
+	res := i.db.WithContext(ctx).
+		Model(&model.ImportJob{}).
+		Where("status IN ?", []string{model.ImportStatusPending, model.ImportStatusRunning}).
+		Updates(map[string]any{
+			"status":      model.ImportStatusFailed,
+			"finished_at": finishedAt,
+			"errors":      gorm.Expr("COALESCE(errors, '[]'::jsonb) || ?::jsonb", `[{"row":0,"message":"`+model.ImportInterrupted+`"}]`),
+		})
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
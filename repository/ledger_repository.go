@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sirupsen/logrus"
+)
+
+// balanceColumn sums the entries of a user, charges up and the rest down.
+const balanceColumn = `COALESCE(SUM(CASE WHEN kind = 'charge' THEN amount ELSE -amount END), 0)`
+
+type LedgerRepository struct {
+	db *gorm.DB
+}
+
+func NewLedgerRepository(db *gorm.DB) model.LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// Create appends entry to the ledger. The user row is locked so that
+// entries of the same user are written one at a time, and a payment or
+// waiver larger than the balance is refused with model.ErrExceedsBalance.
+func (l LedgerRepository) Create(ctx context.Context, entry *model.LedgerEntry) (*model.LedgerEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("entry", utils.Dump(entry))
+
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&model.User{}, "id = ?", entry.UserID).Error
+		if err != nil {
+			return err
+		}
+
+		if entry.Kind != model.EntryKindCharge {
+			var balance int64
+			err = tx.Model(&model.LedgerEntry{}).Select(balanceColumn).Where("user_id = ?", entry.UserID).Scan(&balance).Error
+			if err != nil {
+				return err
+			}
+
+			if entry.Amount > balance {
+				return model.ErrExceedsBalance
+			}
+		}
+
+		entry.ID = utils.GenerateID()
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (l LedgerRepository) FindAllByUserID(ctx context.Context, userID int64) ([]*model.LedgerEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	entries := []*model.LedgerEntry{}
+	err := l.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&entries).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (l LedgerRepository) Balance(ctx context.Context, userID int64) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	var balance int64
+	err := l.db.WithContext(ctx).Model(&model.LedgerEntry{}).Select(balanceColumn).Where("user_id = ?", userID).Scan(&balance).Error
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return balance, nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sirupsen/logrus"
+)
+
+type LoanRepository struct {
+	db *gorm.DB
+}
+
+func NewLoanRepository(db *gorm.DB) model.LoanRepository {
+	return &LoanRepository{db: db}
+}
+
+// Checkout opens loan for its copy. The copy row is locked for the duration
+// of the transaction so that concurrent checkouts of the same copy are
+// serialized, and the second one sees the loan of the first. A copy set
+// aside for a hold only goes to the reader who placed it; any active hold of
+// the borrower on the same book is fulfilled by the loan.
+func (l LoanRepository) Checkout(ctx context.Context, loan *model.Loan) (*model.Loan, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("loan", utils.Dump(loan))
+
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bookCopy := &model.Copy{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(bookCopy, "id = ?", loan.CopyID).Error
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		err = tx.Model(&model.Loan{}).Where("copy_id = ? AND returned_at IS NULL", loan.CopyID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return model.ErrCopyOnLoan
+		}
+
+		err = tx.Model(&model.Hold{}).
+			Where("copy_id = ? AND status = ? AND user_id <> ?", loan.CopyID, model.HoldStatusReady, loan.UserID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return model.ErrCopyOnHold
+		}
+
+		err = tx.Model(&model.Hold{}).
+			Where("book_id = ? AND user_id = ? AND status IN ?", bookCopy.BookID, loan.UserID,
+				[]string{model.HoldStatusWaiting, model.HoldStatusReady}).
+			Updates(map[string]interface{}{
+				"status":     model.HoldStatusFulfilled,
+				"closed_at":  loan.CheckedOutAt,
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		loan.ID = utils.GenerateID()
+		return tx.Create(loan).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+// Checkin closes the open loan of a copy.
+func (l LoanRepository) Checkin(ctx context.Context, copyID int64, returnedAt time.Time) (*model.Loan, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"copyID":     copyID,
+			"returnedAt": returnedAt,
+		})
+
+	loan := &model.Loan{}
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&model.Copy{}, "id = ?", copyID).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Take(loan, "copy_id = ? AND returned_at IS NULL", copyID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrCopyNotOnLoan
+		}
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(loan).Updates(map[string]interface{}{
+			"returned_at": returnedAt,
+			"version":     gorm.Expr("version + 1"),
+			"updated_at":  time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Take(loan).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+// Renew writes the due date and renewal count of an open loan if its
+// version still matches the stored one.
+func (l LoanRepository) Renew(ctx context.Context, loan *model.Loan) (*model.Loan, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("loan", utils.Dump(loan))
+
+	fields := map[string]interface{}{
+		"due_at":     loan.DueAt,
+		"renewals":   loan.Renewals,
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": time.Now(),
+	}
+
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Loan{}).
+			Where("id = ? AND version = ? AND returned_at IS NULL", loan.ID, loan.Version).
+			Updates(fields)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			err := tx.Take(&model.Loan{}, "id = ?", loan.ID).Error
+			if err != nil {
+				return err
+			}
+
+			return model.ErrStaleVersion
+		}
+
+		return tx.Take(loan).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (l LoanRepository) FindByID(ctx context.Context, loanID int64) (*model.Loan, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("loanID", loanID)
+
+	loan := &model.Loan{}
+	err := l.db.WithContext(ctx).Take(loan, "id = ?", loanID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (l LoanRepository) FindAllByUserID(ctx context.Context, userID int64, openOnly bool) ([]*model.Loan, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"userID":   userID,
+			"openOnly": openOnly,
+		})
+
+	query := l.db.WithContext(ctx).Where("user_id = ?", userID)
+	if openOnly {
+		query = query.Where("returned_at IS NULL")
+	}
+
+	loans := []*model.Loan{}
+	err := query.Order("checked_out_at DESC").Find(&loans).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+func (l LoanRepository) CountByCopyID(ctx context.Context, copyID int64) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("copyID", copyID)
+
+	var count int64
+	err := l.db.WithContext(ctx).Model(&model.Loan{}).Where("copy_id = ?", copyID).Count(&count).Error
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
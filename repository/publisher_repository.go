@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+
+	"github.com/sirupsen/logrus"
+)
+
+type PublisherRepository struct {
+	db *gorm.DB
+}
+
+func NewPublisherRepository(db *gorm.DB) model.PublisherRepository {
+	return &PublisherRepository{db: db}
+}
+
+func (p PublisherRepository) Create(ctx context.Context, publisher *model.Publisher) (*model.Publisher, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("publisher", utils.Dump(publisher))
+
+	publisher.ID = utils.GenerateID()
+	err := p.db.WithContext(ctx).Create(publisher).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return publisher, nil
+}
+
+func (p PublisherRepository) FindByID(ctx context.Context, publisherID int64) (*model.Publisher, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("publisherID", publisherID)
+
+	publisher := &model.Publisher{}
+	err := p.db.WithContext(ctx).Take(publisher, "id = ?", publisherID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return publisher, nil
+}
+
+// FindByName matches names case-insensitively, preferring the oldest
+// publisher among equals.
+func (p PublisherRepository) FindByName(ctx context.Context, name string) (*model.Publisher, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("name", name)
+
+	publisher := &model.Publisher{}
+	err := p.db.WithContext(ctx).Order("created_at").Take(publisher, "LOWER(name) = LOWER(?)", name).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return publisher, nil
+}
+
+func (p PublisherRepository) FindAllByIDs(ctx context.Context, publisherIDs []int64) ([]*model.Publisher, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("publisherIDs", publisherIDs)
+
+	publishers := []*model.Publisher{}
+	err := p.db.WithContext(ctx).Where("id IN ?", publisherIDs).Find(&publishers).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return publishers, nil
+}
+
+func (p PublisherRepository) FindAll(ctx context.Context) ([]*model.Publisher, error) {
+	logger := logrus.WithContext(ctx)
+
+	publishers := []*model.Publisher{}
+	err := p.db.WithContext(ctx).Order("name").Find(&publishers).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return publishers, nil
+}
+
+// Update writes publisher if its version still matches the stored one,
+// bumping the version and updated_at.
+func (p PublisherRepository) Update(ctx context.Context, publisher *model.Publisher) (*model.Publisher, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("publisher", utils.Dump(publisher))
+
+	fields := map[string]interface{}{
+		"name":       publisher.Name,
+		"website":    publisher.Website,
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": time.Now(),
+	}
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Publisher{}).
+			Where("id = ? AND version = ?", publisher.ID, publisher.Version).
+			Updates(fields)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			err := tx.Take(&model.Publisher{}, "id = ?", publisher.ID).Error
+			if err != nil {
+				return err
+			}
+
+			return model.ErrStaleVersion
+		}
+
+		return tx.Take(publisher).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return publisher, nil
+}
+
+func (p PublisherRepository) Delete(ctx context.Context, publisherID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("publisherID", publisherID)
+
+	res := p.db.WithContext(ctx).Delete(&model.Publisher{}, "id = ?", publisherID)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"gorm.io/gorm"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Weights of the signals a similarity score adds up. Every signal lies
+// between 0 and 1.
+const (
+	similarityAuthorWeight  = 1.0
+	similarityTitleWeight   = 0.5
+	similaritySubjectWeight = 1.0
+	similarityReaderWeight  = 2.0
+)
+
+// interactions lists every reader and book the service has seen together:
+// views, loans, reviews and shelf entries.
+const interactions = `SELECT user_id, book_id FROM book_views
+	UNION SELECT loans.user_id, copies.book_id FROM loans JOIN copies ON copies.id = loans.copy_id
+	UNION SELECT user_id, book_id FROM reviews
+	UNION SELECT shelves.user_id, shelf_entries.book_id FROM shelf_entries JOIN shelves ON shelves.id = shelf_entries.shelf_id`
+
+// readBooks lists the books the reader @user_id has read: borrowed,
+// reviewed or put on their read shelf.
+const readBooks = `SELECT copies.book_id FROM loans JOIN copies ON copies.id = loans.copy_id WHERE loans.user_id = @user_id
+	UNION SELECT book_id FROM reviews WHERE user_id = @user_id
+	UNION SELECT shelf_entries.book_id FROM shelf_entries JOIN shelves ON shelves.id = shelf_entries.shelf_id
+	WHERE shelves.user_id = @user_id AND shelves.kind = 'read'`
+
+// recomputeSimilarities scores every pair of live books by shared author,
+// title words and subjects (Jaccard) and shared readers (cosine), and keeps
+// the @per_book best matches of each book.
+const recomputeSimilarities = `WITH live AS (
+	SELECT id, author_id, title FROM books WHERE deleted_at IS NULL
+), words AS (
+	SELECT DISTINCT live.id AS book_id, word
+	FROM live, regexp_split_to_table(lower(live.title), '[^[:alnum:]]+') AS word
+	WHERE length(word) >= 3
+), word_counts AS (
+	SELECT book_id, COUNT(*) AS n FROM words GROUP BY book_id
+), subjects AS (
+	SELECT book_id, subject_id FROM book_subjects WHERE book_id IN (SELECT id FROM live)
+), subject_counts AS (
+	SELECT book_id, COUNT(*) AS n FROM subjects GROUP BY book_id
+), readers AS (
+	SELECT user_id, book_id FROM (` + interactions + `) interactions WHERE book_id IN (SELECT id FROM live)
+), reader_counts AS (
+	SELECT book_id, COUNT(*) AS n FROM readers GROUP BY book_id
+), signals AS (
+	SELECT a.id AS book_id, b.id AS similar_book_id, @author::float8 AS score
+	FROM live a JOIN live b ON b.author_id = a.author_id AND b.id <> a.id
+	UNION ALL
+	SELECT a.book_id, b.book_id, @title * COUNT(*)::float8 / (MAX(na.n) + MAX(nb.n) - COUNT(*))
+	FROM words a
+	JOIN words b ON b.word = a.word AND b.book_id <> a.book_id
+	JOIN word_counts na ON na.book_id = a.book_id
+	JOIN word_counts nb ON nb.book_id = b.book_id
+	GROUP BY a.book_id, b.book_id
+	UNION ALL
+	SELECT a.book_id, b.book_id, @subject * COUNT(*)::float8 / (MAX(na.n) + MAX(nb.n) - COUNT(*))
+	FROM subjects a
+	JOIN subjects b ON b.subject_id = a.subject_id AND b.book_id <> a.book_id
+	JOIN subject_counts na ON na.book_id = a.book_id
+	JOIN subject_counts nb ON nb.book_id = b.book_id
+	GROUP BY a.book_id, b.book_id
+	UNION ALL
+	SELECT a.book_id, b.book_id, @reader * COUNT(*)::float8 / sqrt(MAX(na.n) * MAX(nb.n))
+	FROM readers a
+	JOIN readers b ON b.user_id = a.user_id AND b.book_id <> a.book_id
+	JOIN reader_counts na ON na.book_id = a.book_id
+	JOIN reader_counts nb ON nb.book_id = b.book_id
+	GROUP BY a.book_id, b.book_id
+), ranked AS (
+	SELECT book_id, similar_book_id, SUM(score) AS score,
+		ROW_NUMBER() OVER (PARTITION BY book_id ORDER BY SUM(score) DESC, similar_book_id) AS rank
+	FROM signals
+	GROUP BY book_id, similar_book_id
+)
+INSERT INTO book_similarities (book_id, similar_book_id, score, computed_at)
+SELECT book_id, similar_book_id, score, @computed_at FROM ranked WHERE rank <= @per_book`
+
+type RecommendationRepository struct {
+	db *gorm.DB
+}
+
+func NewRecommendationRepository(db *gorm.DB) model.RecommendationRepository {
+	return &RecommendationRepository{db: db}
+}
+
+// RecordView counts a reader opening a book.
+func (r RecommendationRepository) RecordView(ctx context.Context, userID, bookID int64, viewedAt time.Time) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"userID": userID,
+			"bookID": bookID,
+		})
+
+	err := r.db.WithContext(ctx).Exec(`
+		INSERT INTO book_views (user_id, book_id, view_count, last_viewed_at) VALUES (?, ?, 1, ?)
+		ON CONFLICT (user_id, book_id) DO UPDATE
+		SET view_count = book_views.view_count + 1, last_viewed_at = EXCLUDED.last_viewed_at`,
+		userID, bookID, viewedAt,
+	).Error
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Recompute replaces every similarity in one transaction, so readers keep
+// the previous scores until the new ones are complete. It returns how many
+// pairs were stored.
+func (r RecommendationRepository) Recompute(ctx context.Context, perBook int, computedAt time.Time) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("perBook", perBook)
+
+	var stored int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM book_similarities").Error
+		if err != nil {
+			return err
+		}
+
+		res := tx.Exec(recomputeSimilarities, map[string]interface{}{
+			"author":      similarityAuthorWeight,
+			"title":       similarityTitleWeight,
+			"subject":     similaritySubjectWeight,
+			"reader":      similarityReaderWeight,
+			"computed_at": computedAt,
+			"per_book":    perBook,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		stored = res.RowsAffected
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return stored, nil
+}
+
+// FindSimilar lists the live books most similar to a book, leaving out the
+// books userID has read.
+func (r RecommendationRepository) FindSimilar(ctx context.Context, bookID, userID int64, pagination model.Pagination) ([]*model.Recommendation, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID": bookID,
+			"userID": userID,
+		})
+
+	var recommendations []*model.Recommendation
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT books.*, book_similarities.score FROM book_similarities
+		JOIN books ON books.id = book_similarities.similar_book_id AND books.deleted_at IS NULL
+		WHERE book_similarities.book_id = @book_id
+		AND book_similarities.similar_book_id NOT IN (`+readBooks+`)
+		ORDER BY book_similarities.score DESC, books.id
+		LIMIT @limit OFFSET @offset`,
+		map[string]interface{}{
+			"book_id": bookID,
+			"user_id": userID,
+			"limit":   pagination.PerPage,
+			"offset":  pagination.Offset(),
+		},
+	).Scan(&recommendations).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return recommendations, nil
+}
+
+// FindForUser adds up the similarities of every book a reader has
+// interacted with and lists the best scoring books they have not read.
+func (r RecommendationRepository) FindForUser(ctx context.Context, userID int64, pagination model.Pagination) ([]*model.Recommendation, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	var recommendations []*model.Recommendation
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT books.*, SUM(book_similarities.score) AS score FROM book_similarities
+		JOIN (`+interactions+`) interactions ON interactions.book_id = book_similarities.book_id
+		JOIN books ON books.id = book_similarities.similar_book_id AND books.deleted_at IS NULL
+		WHERE interactions.user_id = @user_id
+		AND book_similarities.similar_book_id NOT IN (`+readBooks+`)
+		GROUP BY books.id
+		ORDER BY score DESC, books.id
+		LIMIT @limit OFFSET @offset`,
+		map[string]interface{}{
+			"user_id": userID,
+			"limit":   pagination.PerPage,
+			"offset":  pagination.Offset(),
+		},
+	).Scan(&recommendations).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return recommendations, nil
+}
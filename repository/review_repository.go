@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sirupsen/logrus"
+)
+
+var reviewOrders = map[string]string{
+	model.ReviewSortHelpful: "helpful_count DESC, created_at DESC, id",
+	model.ReviewSortNewest:  "created_at DESC, id",
+	model.ReviewSortHighest: "rating DESC, helpful_count DESC, id",
+	model.ReviewSortLowest:  "rating, helpful_count DESC, id",
+}
+
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) model.ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// Create writes review and counts its rating into the book in the same
+// transaction.
+func (r ReviewRepository) Create(ctx context.Context, review *model.Review) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("review", utils.Dump(review))
+
+	review.ID = utils.GenerateID()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(review).Error
+		if err != nil {
+			return err
+		}
+
+		if review.Hidden {
+			return nil
+		}
+
+		return addRating(tx, review.BookID, 1, review.Rating)
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return r.FindByID(ctx, review.ID)
+}
+
+func (r ReviewRepository) FindByID(ctx context.Context, reviewID int64) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("reviewID", reviewID)
+
+	review := &model.Review{}
+	err := r.db.WithContext(ctx).Take(review, "id = ?", reviewID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r ReviewRepository) FindByBookAndUser(ctx context.Context, bookID, userID int64) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID": bookID,
+			"userID": userID,
+		})
+
+	review := &model.Review{}
+	err := r.db.WithContext(ctx).Take(review, "book_id = ? AND user_id = ?", bookID, userID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// FindAllByBookID returns one page of the reviews of a book in the given
+// order, along with the number of reviews on all pages.
+func (r ReviewRepository) FindAllByBookID(
+	ctx context.Context,
+	bookID int64,
+	sort string,
+	includeHidden bool,
+	pagination model.Pagination,
+) ([]*model.Review, int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID":        bookID,
+			"sort":          sort,
+			"includeHidden": includeHidden,
+			"pagination":    utils.Dump(pagination),
+		})
+
+	order, ok := reviewOrders[sort]
+	if !ok {
+		order = reviewOrders[model.ReviewSortHelpful]
+	}
+
+	query := r.db.WithContext(ctx).Model(&model.Review{}).Where("book_id = ?", bookID)
+	if !includeHidden {
+		query = query.Where("NOT hidden")
+	}
+
+	var total int64
+	err := query.Count(&total).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	reviews := []*model.Review{}
+	err = query.Order(order).Limit(pagination.PerPage).Offset(pagination.Offset()).Find(&reviews).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// Update writes the rating and text of a review if its version still
+// matches the stored one, moving the rating of the book by the difference.
+func (r ReviewRepository) Update(ctx context.Context, review *model.Review) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("review", utils.Dump(review))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		currReview := &model.Review{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(currReview, "id = ?", review.ID).Error
+		if err != nil {
+			return err
+		}
+
+		if currReview.Version != review.Version {
+			return model.ErrStaleVersion
+		}
+
+		err = tx.Model(currReview).Updates(map[string]interface{}{
+			"rating":     review.Rating,
+			"body":       review.Body,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if currReview.Hidden {
+			return nil
+		}
+
+		return addRating(tx, currReview.BookID, 0, review.Rating-currReview.Rating)
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return r.FindByID(ctx, review.ID)
+}
+
+// SetHidden hides or shows a review, taking its rating out of or back into
+// the rating of the book.
+func (r ReviewRepository) SetHidden(ctx context.Context, reviewID int64, hidden bool, reason string) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"reviewID": reviewID,
+			"hidden":   hidden,
+		})
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		currReview := &model.Review{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(currReview, "id = ?", reviewID).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(currReview).Updates(map[string]interface{}{
+			"hidden":        hidden,
+			"hidden_reason": reason,
+			"version":       gorm.Expr("version + 1"),
+			"updated_at":    time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case hidden && !currReview.Hidden:
+			return addRating(tx, currReview.BookID, -1, -currReview.Rating)
+		case !hidden && currReview.Hidden:
+			return addRating(tx, currReview.BookID, 1, currReview.Rating)
+		default:
+			return nil
+		}
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return r.FindByID(ctx, reviewID)
+}
+
+func (r ReviewRepository) Delete(ctx context.Context, reviewID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("reviewID", reviewID)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		review := &model.Review{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(review, "id = ?", reviewID).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Delete(review).Error
+		if err != nil {
+			return err
+		}
+
+		if review.Hidden {
+			return nil
+		}
+
+		return addRating(tx, review.BookID, -1, -review.Rating)
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// AddVote marks a review as helpful to a user. Voting twice counts once.
+func (r ReviewRepository) AddVote(ctx context.Context, reviewID, userID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"reviewID": reviewID,
+			"userID":   userID,
+		})
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Exec("INSERT INTO review_votes (review_id, user_id, created_at) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+			reviewID, userID, time.Now())
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+
+		return tx.Exec("UPDATE reviews SET helpful_count = helpful_count + 1 WHERE id = ?", reviewID).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r ReviewRepository) RemoveVote(ctx context.Context, reviewID, userID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"reviewID": reviewID,
+			"userID":   userID,
+		})
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Exec("DELETE FROM review_votes WHERE review_id = ? AND user_id = ?", reviewID, userID)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+
+		return tx.Exec("UPDATE reviews SET helpful_count = helpful_count - 1 WHERE id = ?", reviewID).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// addRating moves the rating count and sum of a book. It does not bump the
+// version of the book, since ratings are not part of what editors change;
+// the book's ETag carries the rating instead.
+func addRating(tx *gorm.DB, bookID int64, count, sum int) error {
+	return tx.Exec("UPDATE books SET rating_count = rating_count + ?, rating_sum = rating_sum + ? WHERE id = ?",
+		count, sum, bookID).Error
+}
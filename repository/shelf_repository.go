@@ -0,0 +1,401 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/sirupsen/logrus"
+)
+
+// shelfColumns selects a shelf with the number of books on it.
+const shelfColumns = `shelves.*, (SELECT COUNT(*) FROM shelf_entries WHERE shelf_entries.shelf_id = shelves.id) AS book_count`
+
+type ShelfRepository struct {
+	db *gorm.DB
+}
+
+func NewShelfRepository(db *gorm.DB) model.ShelfRepository {
+	return &ShelfRepository{db: db}
+}
+
+// EnsureBuiltins creates the built-in shelves a user does not have yet.
+func (s ShelfRepository) EnsureBuiltins(ctx context.Context, userID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, builtin := range model.BuiltinShelves {
+			err := tx.Exec(`INSERT INTO shelves (id, user_id, name, kind, privacy, created_at) VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT DO NOTHING`,
+				utils.GenerateID(), userID, builtin.Name, builtin.Kind, model.ShelfPrivacyPrivate, time.Now()).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s ShelfRepository) Create(ctx context.Context, shelf *model.Shelf) (*model.Shelf, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("shelf", utils.Dump(shelf))
+
+	shelf.ID = utils.GenerateID()
+	err := s.db.WithContext(ctx).Create(shelf).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return shelf, nil
+}
+
+func (s ShelfRepository) FindByID(ctx context.Context, shelfID int64) (*model.Shelf, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("shelfID", shelfID)
+
+	shelf := &model.Shelf{}
+	err := s.db.WithContext(ctx).Select(shelfColumns).Take(shelf, "shelves.id = ?", shelfID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return shelf, nil
+}
+
+// FindByName looks a shelf of a user up by name, ignoring case.
+func (s ShelfRepository) FindByName(ctx context.Context, userID int64, name string) (*model.Shelf, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"userID": userID,
+			"name":   name,
+		})
+
+	shelf := &model.Shelf{}
+	err := s.db.WithContext(ctx).Take(shelf, "user_id = ? AND LOWER(name) = LOWER(?)", userID, name).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return shelf, nil
+}
+
+// FindByShareToken finds the shelf a link shares.
+func (s ShelfRepository) FindByShareToken(ctx context.Context, shareToken string) (*model.Shelf, error) {
+	logger := logrus.WithContext(ctx)
+
+	shelf := &model.Shelf{}
+	err := s.db.WithContext(ctx).
+		Select(shelfColumns).
+		Take(shelf, "shelves.share_token = ?", shareToken).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return shelf, nil
+}
+
+// FindAllByUserID returns the shelves of a user, built-in ones first.
+func (s ShelfRepository) FindAllByUserID(ctx context.Context, userID int64, publicOnly bool) ([]*model.Shelf, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"userID":     userID,
+			"publicOnly": publicOnly,
+		})
+
+	query := s.db.WithContext(ctx).Select(shelfColumns).Where("shelves.user_id = ?", userID)
+	if publicOnly {
+		query = query.Where("shelves.privacy = ?", model.ShelfPrivacyPublic)
+	}
+
+	shelves := []*model.Shelf{}
+	err := query.Order("shelves.kind = 'custom', shelves.created_at, shelves.id").Find(&shelves).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return shelves, nil
+}
+
+// Update writes the name and privacy of a shelf if its version still
+// matches the stored one.
+func (s ShelfRepository) Update(ctx context.Context, shelf *model.Shelf) (*model.Shelf, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("shelf", utils.Dump(shelf))
+
+	fields := map[string]interface{}{
+		"name":        shelf.Name,
+		"privacy":     shelf.Privacy,
+		"share_token": shelf.ShareToken,
+		"version":     gorm.Expr("version + 1"),
+		"updated_at":  time.Now(),
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Shelf{}).
+			Where("id = ? AND version = ?", shelf.ID, shelf.Version).
+			Updates(fields)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			err := tx.Take(&model.Shelf{}, "id = ?", shelf.ID).Error
+			if err != nil {
+				return err
+			}
+
+			return model.ErrStaleVersion
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return s.FindByID(ctx, shelf.ID)
+}
+
+func (s ShelfRepository) Delete(ctx context.Context, shelfID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("shelfID", shelfID)
+
+	res := s.db.WithContext(ctx).Delete(&model.Shelf{}, "id = ?", shelfID)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s ShelfRepository) FindEntry(ctx context.Context, shelfID, bookID int64) (*model.ShelfEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"shelfID": shelfID,
+			"bookID":  bookID,
+		})
+
+	entry := &model.ShelfEntry{}
+	err := s.db.WithContext(ctx).Preload("Book").Take(entry, "shelf_id = ? AND book_id = ?", shelfID, bookID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// FindEntries returns the live books of a shelf in shelf order.
+func (s ShelfRepository) FindEntries(ctx context.Context, shelfID int64) ([]*model.ShelfEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("shelfID", shelfID)
+
+	entries := []*model.ShelfEntry{}
+	err := s.db.WithContext(ctx).
+		Preload("Book").
+		Where("shelf_id = ?", shelfID).
+		Where("book_id IN (SELECT id FROM books WHERE deleted_at IS NULL)").
+		Order("position").
+		Find(&entries).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// AddEntry puts a book on a shelf at the position of entry, or at the end
+// when that position is out of range. Putting a book on a reading status
+// shelf takes it off the other reading status shelves of the same user.
+func (s ShelfRepository) AddEntry(ctx context.Context, entry *model.ShelfEntry) (*model.ShelfEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("entry", utils.Dump(entry))
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		shelf := &model.Shelf{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(shelf, "id = ?", entry.ShelfID).Error
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		err = tx.Model(&model.ShelfEntry{}).Where("shelf_id = ?", entry.ShelfID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		if entry.Position < 1 || entry.Position > int(count)+1 {
+			entry.Position = int(count) + 1
+		}
+
+		err = tx.Exec("UPDATE shelf_entries SET position = position + 1 WHERE shelf_id = ? AND position >= ?",
+			entry.ShelfID, entry.Position).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Omit("Book").Create(entry).Error
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(model.ReadingStatusKinds, shelf.Kind) {
+			return nil
+		}
+
+		otherShelfIDs := []int64{}
+		err = tx.Model(&model.ShelfEntry{}).
+			Joins("JOIN shelves ON shelves.id = shelf_entries.shelf_id").
+			Where("shelves.user_id = ? AND shelves.kind IN ? AND shelves.id <> ?", shelf.UserID, model.ReadingStatusKinds, shelf.ID).
+			Where("shelf_entries.book_id = ?", entry.BookID).
+			Pluck("shelf_entries.shelf_id", &otherShelfIDs).Error
+		if err != nil {
+			return err
+		}
+
+		for _, otherShelfID := range otherShelfIDs {
+			err = removeEntry(tx, otherShelfID, entry.BookID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return s.FindEntry(ctx, entry.ShelfID, entry.BookID)
+}
+
+// MoveEntry moves a book to another position on its shelf, clamped to the
+// shelf, and updates its note.
+func (s ShelfRepository) MoveEntry(ctx context.Context, entry *model.ShelfEntry) (*model.ShelfEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("entry", utils.Dump(entry))
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&model.Shelf{}, "id = ?", entry.ShelfID).Error
+		if err != nil {
+			return err
+		}
+
+		currEntry := &model.ShelfEntry{}
+		err = tx.Take(currEntry, "shelf_id = ? AND book_id = ?", entry.ShelfID, entry.BookID).Error
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		err = tx.Model(&model.ShelfEntry{}).Where("shelf_id = ?", entry.ShelfID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		position := min(max(entry.Position, 1), int(count))
+		switch {
+		case position < currEntry.Position:
+			err = tx.Exec("UPDATE shelf_entries SET position = position + 1 WHERE shelf_id = ? AND position >= ? AND position < ?",
+				entry.ShelfID, position, currEntry.Position).Error
+		case position > currEntry.Position:
+			err = tx.Exec("UPDATE shelf_entries SET position = position - 1 WHERE shelf_id = ? AND position > ? AND position <= ?",
+				entry.ShelfID, currEntry.Position, position).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&model.ShelfEntry{}).
+			Where("shelf_id = ? AND book_id = ?", entry.ShelfID, entry.BookID).
+			Updates(map[string]interface{}{
+				"position": position,
+				"note":     entry.Note,
+			}).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return s.FindEntry(ctx, entry.ShelfID, entry.BookID)
+}
+
+func (s ShelfRepository) RemoveEntry(ctx context.Context, shelfID, bookID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"shelfID": shelfID,
+			"bookID":  bookID,
+		})
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&model.Shelf{}, "id = ?", shelfID).Error
+		if err != nil {
+			return err
+		}
+
+		return removeEntry(tx, shelfID, bookID)
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// removeEntry takes a book off a shelf and closes the gap it leaves.
+func removeEntry(tx *gorm.DB, shelfID, bookID int64) error {
+	entry := &model.ShelfEntry{}
+	err := tx.Take(entry, "shelf_id = ? AND book_id = ?", shelfID, bookID).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Delete(&model.ShelfEntry{}, "shelf_id = ? AND book_id = ?", shelfID, bookID).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Exec("UPDATE shelf_entries SET position = position - 1 WHERE shelf_id = ? AND position > ?",
+		shelfID, entry.Position).Error
+}
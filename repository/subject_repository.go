@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SubjectRepository struct {
+	db *gorm.DB
+}
+
+func NewSubjectRepository(db *gorm.DB) model.SubjectRepository {
+	return &SubjectRepository{db: db}
+}
+
+func (s SubjectRepository) Create(ctx context.Context, subject *model.Subject) (*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subject", utils.Dump(subject))
+
+	subject.ID = utils.GenerateID()
+	err := s.db.WithContext(ctx).Create(subject).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return subject, nil
+}
+
+func (s SubjectRepository) FindByID(ctx context.Context, subjectID int64) (*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subjectID", subjectID)
+
+	subject := &model.Subject{}
+	err := s.db.WithContext(ctx).Take(subject, "id = ?", subjectID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return subject, nil
+}
+
+// FindByName matches name case-insensitively among the children of
+// parentID, or among the root subjects when parentID is nil.
+func (s SubjectRepository) FindByName(ctx context.Context, parentID *int64, name string) (*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"parentID": parentID,
+			"name":     name,
+		})
+
+	query := s.db.WithContext(ctx).Where("LOWER(name) = LOWER(?)", name)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	subject := &model.Subject{}
+	err := query.Take(subject).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return subject, nil
+}
+
+func (s SubjectRepository) FindAll(ctx context.Context) ([]*model.Subject, error) {
+	logger := logrus.WithContext(ctx)
+
+	subjects := []*model.Subject{}
+	err := s.db.WithContext(ctx).Order("name").Find(&subjects).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return subjects, nil
+}
+
+// FindDescendantIDs returns subjectID followed by the ids of every subject
+// below it. It returns gorm.ErrRecordNotFound when subjectID does not exist.
+func (s SubjectRepository) FindDescendantIDs(ctx context.Context, subjectID int64) ([]int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subjectID", subjectID)
+
+	ids := []int64{}
+	err := s.db.WithContext(ctx).Raw(`
+		WITH RECURSIVE tree (id, depth) AS (
+			SELECT id, 0 FROM subjects WHERE id = ?
+			UNION ALL
+			SELECT subjects.id, tree.depth + 1 FROM subjects JOIN tree ON subjects.parent_id = tree.id
+		)
+		SELECT id FROM tree ORDER BY depth, id`, subjectID).
+		Scan(&ids).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return ids, nil
+}
+
+func (s SubjectRepository) FindByBookID(ctx context.Context, bookID int64) ([]*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	subjects := []*model.Subject{}
+	err := s.db.WithContext(ctx).
+		Where("id IN (SELECT subject_id FROM book_subjects WHERE book_id = ?)", bookID).
+		Order("name").
+		Find(&subjects).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return subjects, nil
+}
+
+// FindByBookIDs returns the subjects of each of bookIDs by book id.
+func (s SubjectRepository) FindByBookIDs(ctx context.Context, bookIDs []int64) (map[int64][]*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookIDs", bookIDs)
+
+	rows := []struct {
+		BookID int64
+		model.Subject
+	}{}
+	err := s.db.WithContext(ctx).
+		Table("subjects").
+		Select("book_subjects.book_id, subjects.*").
+		Joins("JOIN book_subjects ON book_subjects.subject_id = subjects.id").
+		Where("book_subjects.book_id IN ?", bookIDs).
+		Order("subjects.name").
+		Scan(&rows).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	subjects := map[int64][]*model.Subject{}
+	for _, row := range rows {
+		subject := row.Subject
+		subjects[row.BookID] = append(subjects[row.BookID], &subject)
+	}
+
+	return subjects, nil
+}
+
+func (s SubjectRepository) CountChildren(ctx context.Context, subjectID int64) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subjectID", subjectID)
+
+	var count int64
+	err := s.db.WithContext(ctx).Model(&model.Subject{}).Where("parent_id = ?", subjectID).Count(&count).Error
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountBooks maps each subject id to the number of distinct live books filed
+// under it or any of its descendants. Subjects without books are left out.
+func (s SubjectRepository) CountBooks(ctx context.Context) (map[int64]int64, error) {
+	logger := logrus.WithContext(ctx)
+
+	rows := []struct {
+		SubjectID int64
+		BookCount int64
+	}{}
+	err := s.db.WithContext(ctx).Raw(`
+		WITH RECURSIVE tree (ancestor_id, subject_id) AS (
+			SELECT id, id FROM subjects
+			UNION ALL
+			SELECT tree.ancestor_id, subjects.id FROM subjects JOIN tree ON subjects.parent_id = tree.subject_id
+		)
+		SELECT tree.ancestor_id AS subject_id, COUNT(DISTINCT books.id) AS book_count
+		FROM tree
+		JOIN book_subjects ON book_subjects.subject_id = tree.subject_id
+		JOIN books ON books.id = book_subjects.book_id AND books.deleted_at IS NULL
+		GROUP BY tree.ancestor_id`).
+		Scan(&rows).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	counts := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		counts[row.SubjectID] = row.BookCount
+	}
+
+	return counts, nil
+}
+
+// ReplaceBookSubjects sets the subjects of a book to exactly subjectIDs.
+func (s SubjectRepository) ReplaceBookSubjects(ctx context.Context, bookID int64, subjectIDs []int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID":     bookID,
+			"subjectIDs": subjectIDs,
+		})
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM book_subjects WHERE book_id = ?", bookID).Error
+		if err != nil {
+			return err
+		}
+
+		for _, subjectID := range subjectIDs {
+			err = tx.Exec("INSERT INTO book_subjects (book_id, subject_id) VALUES (?, ?)", bookID, subjectID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Update writes subject if its version still matches the stored one,
+// bumping the version and updated_at.
+func (s SubjectRepository) Update(ctx context.Context, subject *model.Subject) (*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subject", utils.Dump(subject))
+
+	fields := map[string]interface{}{
+		"name":         subject.Name,
+		"parent_id":    subject.ParentID,
+		"translations": subject.Translations,
+		"version":      gorm.Expr("version + 1"),
+		"updated_at":   time.Now(),
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Subject{}).
+			Where("id = ? AND version = ?", subject.ID, subject.Version).
+			Updates(fields)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			err := tx.Take(&model.Subject{}, "id = ?", subject.ID).Error
+			if err != nil {
+				return err
+			}
+
+			return model.ErrStaleVersion
+		}
+
+		return tx.Take(subject).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return subject, nil
+}
+
+func (s SubjectRepository) Delete(ctx context.Context, subjectID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subjectID", subjectID)
+
+	res := s.db.WithContext(ctx).Delete(&model.Subject{}, "id = ?", subjectID)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
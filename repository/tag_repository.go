@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+
+	"github.com/sirupsen/logrus"
+)
+
+type TagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) model.TagRepository {
+	return &TagRepository{db: db}
+}
+
+func (t TagRepository) FindByID(ctx context.Context, tagID int64) (*model.Tag, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("tagID", tagID)
+
+	tag := &model.Tag{}
+	err := t.db.WithContext(ctx).Take(tag, "id = ?", tagID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// FindAll returns every tag with the number of live books carrying it.
+func (t TagRepository) FindAll(ctx context.Context) ([]*model.Tag, error) {
+	logger := logrus.WithContext(ctx)
+
+	tags := []*model.Tag{}
+	err := t.db.WithContext(ctx).
+		Select("tags.*, COUNT(books.id) AS book_count").
+		Joins("LEFT JOIN book_tags ON book_tags.tag_id = tags.id").
+		Joins("LEFT JOIN books ON books.id = book_tags.book_id AND books.deleted_at IS NULL").
+		Group("tags.id").
+		Order("tags.name").
+		Find(&tags).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (t TagRepository) FindByBookID(ctx context.Context, bookID int64) ([]*model.Tag, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	tags := []*model.Tag{}
+	err := t.db.WithContext(ctx).
+		Where("id IN (SELECT tag_id FROM book_tags WHERE book_id = ?)", bookID).
+		Order("name").
+		Find(&tags).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// ReplaceBookTags sets the tags of a book to exactly names, creating the
+// tags that do not exist yet.
+func (t TagRepository) ReplaceBookTags(ctx context.Context, bookID int64, names []string) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID": bookID,
+			"names":  names,
+		})
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM book_tags WHERE book_id = ?", bookID).Error
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			err = tx.Exec("INSERT INTO tags (id, name, created_at) VALUES (?, ?, ?) ON CONFLICT (name) DO NOTHING",
+				utils.GenerateID(), name, time.Now()).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Exec("INSERT INTO book_tags (book_id, tag_id) SELECT ?, id FROM tags WHERE name = ?", bookID, name).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (t TagRepository) Delete(ctx context.Context, tagID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("tagID", tagID)
+
+	res := t.db.WithContext(ctx).Delete(&model.Tag{}, "id = ?", tagID)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rhtyx/bayarind-service.git/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorDeleteAndReassignBooks(t *testing.T) {
+	t.Run("ok: reassigned books get a new version", func(t *testing.T) {
+		db, pool := openRecordingDB(t, 1)
+
+		authorRepository := repository.NewAuthorRepository(db)
+		err := authorRepository.DeleteAndReassignBooks(context.TODO(), 1, 2)
+		assert.Nil(t, err)
+		assert.True(t, pool.committed)
+
+		assert.Len(t, pool.statements, 3)
+		assert.Contains(t, pool.statements[0], "INSERT INTO book_versions")
+		assert.True(t, strings.HasPrefix(pool.statements[1], `UPDATE "books" SET`))
+		assert.Contains(t, pool.statements[1], `"version"=version + 1`)
+		assert.True(t, strings.HasPrefix(pool.statements[2], `UPDATE "authors" SET "deleted_at"`))
+	})
+
+	t.Run("error: author not found", func(t *testing.T) {
+		db, pool := openRecordingDB(t, 0)
+
+		authorRepository := repository.NewAuthorRepository(db)
+		err := authorRepository.DeleteAndReassignBooks(context.TODO(), 1, 2)
+		assert.Error(t, err)
+		assert.False(t, pool.committed)
+	})
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordingPool is a gorm connection pool that records the statements it
+// is given instead of running them, affecting rowsAffected rows each.
+// Queries fail with sql.ErrConnDone once recorded.
+type recordingPool struct {
+	statements   []string
+	rowsAffected int64
+	committed    bool
+}
+
+// recordingTx is a transaction of a recordingPool. It cannot begin
+// transactions of its own, so that gorm does not nest them.
+type recordingTx struct {
+	gorm.ConnPool
+	pool *recordingPool
+}
+
+// connPool hides the BeginTx of a recordingPool.
+type connPool struct {
+	gorm.ConnPool
+}
+
+type result int64
+
+func (r result) LastInsertId() (int64, error) { return 0, nil }
+func (r result) RowsAffected() (int64, error) { return int64(r), nil }
+
+func (p *recordingPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, sql.ErrConnDone
+}
+
+func (p *recordingPool) ExecContext(_ context.Context, query string, _ ...interface{}) (sql.Result, error) {
+	p.statements = append(p.statements, query)
+	return result(p.rowsAffected), nil
+}
+
+func (p *recordingPool) QueryContext(_ context.Context, query string, _ ...interface{}) (*sql.Rows, error) {
+	p.statements = append(p.statements, query)
+	return nil, sql.ErrConnDone
+}
+
+func (p *recordingPool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func (p *recordingPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return &recordingTx{ConnPool: connPool{p}, pool: p}, nil
+}
+
+func (tx *recordingTx) Commit() error {
+	tx.pool.committed = true
+	return nil
+}
+
+func (tx *recordingTx) Rollback() error {
+	return nil
+}
+
+// openRecordingDB opens a gorm database on a new recordingPool.
+func openRecordingDB(t *testing.T, rowsAffected int64) (*gorm.DB, *recordingPool) {
+	pool := &recordingPool{rowsAffected: rowsAffected}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	assert.Nil(t, err)
+
+	return db, pool
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserPurge(t *testing.T) {
+	t.Run("ok: review counts are adjusted before users are deleted", func(t *testing.T) {
+		db, pool := openRecordingDB(t, 2)
+
+		userRepository := repository.NewUserRepository(db)
+		count, err := userRepository.Purge(context.TODO(), time.Now())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), count)
+		assert.True(t, pool.committed)
+
+		assert.Len(t, pool.statements, 3)
+		assert.True(t, strings.HasPrefix(pool.statements[0], "UPDATE reviews SET helpful_count"))
+		assert.True(t, strings.HasPrefix(pool.statements[1], "UPDATE books SET rating_count"))
+		assert.True(t, strings.HasPrefix(pool.statements[2], `DELETE FROM "users"`))
+		for _, statement := range pool.statements {
+			assert.Contains(t, statement, "FOR UPDATE")
+		}
+	})
+}
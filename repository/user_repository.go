@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/rhtyx/bayarind-service.git/model"
 	"github.com/rhtyx/bayarind-service.git/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/sirupsen/logrus"
 )
@@ -15,6 +18,8 @@ type UserRepository struct {
 	db *gorm.DB
 }
 
+var userColumns = []string{"username", "password"}
+
 func NewUserRepository(db *gorm.DB) model.UserRepository {
 	return &UserRepository{db: db}
 }
@@ -65,22 +70,53 @@ func (u UserRepository) FindByUsername(ctx context.Context, username string) (*m
 }
 
 func (u UserRepository) Update(ctx context.Context, user *model.User) (*model.User, error) {
+	return u.UpdateColumns(ctx, user, userColumns)
+}
+
+// UpdateColumns writes only the given columns of user along with
+// updated_at.
+func (u UserRepository) UpdateColumns(ctx context.Context, user *model.User, columns []string) (*model.User, error) {
 	logger := logrus.
 		WithContext(ctx).
-		WithField("user", utils.Dump(user))
+		WithFields(logrus.Fields{
+			"userID":  user.ID,
+			"columns": columns,
+		})
+
+	values := map[string]interface{}{
+		"username": user.Username,
+		"password": user.Password,
+		"role":     user.Role,
+	}
+
+	fields := map[string]interface{}{
+		"updated_at": time.Now(),
+	}
+	for _, column := range columns {
+		value, ok := values[column]
+		if !ok {
+			return nil, fmt.Errorf("unknown user column %q", column)
+		}
+
+		fields[column] = value
+	}
 
 	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Save(user).Error
-		if err != nil {
-			logger.Error(err)
-			tx.Rollback()
-			return err
+		res := tx.Model(&model.User{}).
+			Where("id = ?", user.ID).
+			Updates(fields)
+		if res.Error != nil {
+			logger.Error(res.Error)
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
 		}
 
-		err = tx.Take(user).Error
+		err := tx.Take(user).Error
 		if err != nil {
 			logger.Error(err)
-			tx.Rollback()
 			return err
 		}
 
@@ -99,7 +135,21 @@ func (u UserRepository) Delete(ctx context.Context, userID int64) error {
 		WithContext(ctx).
 		WithField("userID", userID)
 
-	err := u.db.WithContext(ctx).Delete(&model.User{}, "id = ?", userID).Error
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&model.Session{}, "user_id = ?", userID).Error
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		err = tx.Delete(&model.User{}, "id = ?", userID).Error
+		if err != nil {
+			logger.Error(err)
+			return err
+		}
+
+		return nil
+	})
 	if err != nil {
 		logger.Error(err)
 		return err
@@ -107,3 +157,97 @@ func (u UserRepository) Delete(ctx context.Context, userID int64) error {
 
 	return nil
 }
+
+func (u UserRepository) FindDeletedByID(ctx context.Context, userID int64) (*model.User, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	user := &model.User{}
+	err := u.db.WithContext(ctx).Unscoped().Take(user, "id = ? AND deleted_at IS NOT NULL", userID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u UserRepository) FindAllDeleted(ctx context.Context) ([]*model.User, error) {
+	logger := logrus.WithContext(ctx)
+
+	users := []*model.User{}
+	err := u.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&users).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (u UserRepository) Restore(ctx context.Context, userID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	res := u.db.WithContext(ctx).Unscoped().
+		Model(&model.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", userID).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (u UserRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("deletedBefore", deletedBefore)
+
+	var count int64
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Users with loans, open or returned, or with ledger entries are
+		// kept for the loan and account history. The rest are locked so
+		// that the set does not change between the statements below.
+		purged := tx.Unscoped().Model(&model.User{}).
+			Select("id").
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+			Where("NOT EXISTS (SELECT 1 FROM loans WHERE loans.user_id = users.id)").
+			Where("NOT EXISTS (SELECT 1 FROM ledger_entries WHERE ledger_entries.user_id = users.id)").
+			Clauses(clause.Locking{Strength: "UPDATE"})
+
+		// Reviews and votes go with their users through ON DELETE CASCADE,
+		// which leaves the counts they were part of behind.
+		err := tx.Exec(`UPDATE reviews SET helpful_count = reviews.helpful_count - votes.count
+			FROM (SELECT review_id, count(*) AS count FROM review_votes WHERE user_id IN (?) GROUP BY review_id) AS votes
+			WHERE reviews.id = votes.review_id`, purged).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`UPDATE books SET rating_count = books.rating_count - ratings.count, rating_sum = books.rating_sum - ratings.sum
+			FROM (SELECT book_id, count(*) AS count, sum(rating) AS sum FROM reviews WHERE user_id IN (?) AND NOT hidden GROUP BY book_id) AS ratings
+			WHERE books.id = ratings.book_id`, purged).Error
+		if err != nil {
+			return err
+		}
+
+		res := tx.Unscoped().Where("id IN (?)", purged).Delete(&model.User{})
+		count = res.RowsAffected
+		return res.Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
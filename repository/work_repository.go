@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"gorm.io/gorm"
+
+	"github.com/sirupsen/logrus"
+)
+
+type WorkRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkRepository(db *gorm.DB) model.WorkRepository {
+	return &WorkRepository{db: db}
+}
+
+func (w WorkRepository) Create(ctx context.Context, work *model.Work) (*model.Work, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("work", utils.Dump(work))
+
+	work.ID = utils.GenerateID()
+	err := w.db.WithContext(ctx).Create(work).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return work, nil
+}
+
+func (w WorkRepository) FindByID(ctx context.Context, workID int64) (*model.Work, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("workID", workID)
+
+	work := &model.Work{}
+	err := w.db.WithContext(ctx).Take(work, "id = ?", workID).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return work, nil
+}
+
+func (w WorkRepository) FindAll(ctx context.Context) ([]*model.Work, error) {
+	logger := logrus.WithContext(ctx)
+
+	works := []*model.Work{}
+	err := w.db.WithContext(ctx).Order("id").Find(&works).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return works, nil
+}
+
+// Update writes work if its version still matches the stored one,
+// bumping the version and updated_at.
+func (w WorkRepository) Update(ctx context.Context, work *model.Work) (*model.Work, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("work", utils.Dump(work))
+
+	fields := map[string]interface{}{
+		"title":      work.Title,
+		"author_id":  work.AuthorID,
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": time.Now(),
+	}
+
+	err := w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Work{}).
+			Where("id = ? AND version = ?", work.ID, work.Version).
+			Updates(fields)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			err := tx.Take(&model.Work{}, "id = ?", work.ID).Error
+			if err != nil {
+				return err
+			}
+
+			return model.ErrStaleVersion
+		}
+
+		return tx.Take(work).Error
+	})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return work, nil
+}
+
+func (w WorkRepository) Delete(ctx context.Context, workID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("workID", workID)
+
+	res := w.db.WithContext(ctx).Delete(&model.Work{}, "id = ?", workID)
+	if res.Error != nil {
+		logger.Error(res.Error)
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+// Package schemaorg describes books and authors as schema.org Book and
+// Person nodes for JSON-LD.
+package schemaorg
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+)
+
+const (
+	Context    = "https://schema.org"
+	MIMEJSONLD = "application/ld+json"
+)
+
+// dateLayout is the ISO 8601 date schema.org expects in its Date
+// properties.
+const dateLayout = "2006-01-02"
+
+// maxRating is the highest rating a review gives, the bestRating of every
+// aggregate rating.
+const maxRating = 5
+
+// Book is a schema.org Book. Context is only set on the top node of a
+// document.
+type Book struct {
+	Context             string           `json:"@context,omitempty"`
+	Type                string           `json:"@type"`
+	ID                  string           `json:"@id"`
+	Name                string           `json:"name"`
+	AlternativeHeadline string           `json:"alternativeHeadline,omitempty"`
+	ISBN                string           `json:"isbn,omitempty"`
+	Author              *Person          `json:"author,omitempty"`
+	Publisher           *Organization    `json:"publisher,omitempty"`
+	DatePublished       string           `json:"datePublished,omitempty"`
+	InLanguage          string           `json:"inLanguage,omitempty"`
+	BookFormat          string           `json:"bookFormat,omitempty"`
+	NumberOfPages       int              `json:"numberOfPages,omitempty"`
+	AggregateRating     *AggregateRating `json:"aggregateRating,omitempty"`
+	DateCreated         string           `json:"dateCreated,omitempty"`
+	DateModified        string           `json:"dateModified,omitempty"`
+}
+
+// Person is a schema.org Person. SameAs links the person to their VIAF,
+// ISNI and Wikidata records.
+type Person struct {
+	Context       string   `json:"@context,omitempty"`
+	Type          string   `json:"@type"`
+	ID            string   `json:"@id"`
+	Name          string   `json:"name,omitempty"`
+	AlternateName []string `json:"alternateName,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	BirthDate     string   `json:"birthDate,omitempty"`
+	DeathDate     string   `json:"deathDate,omitempty"`
+	Nationality   *Country `json:"nationality,omitempty"`
+	URL           string   `json:"url,omitempty"`
+	SameAs        []string `json:"sameAs,omitempty"`
+}
+
+type Organization struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// Country is a country known by its ISO 3166-1 alpha-2 code.
+type Country struct {
+	Type       string `json:"@type"`
+	Identifier string `json:"identifier"`
+}
+
+type AggregateRating struct {
+	Type        string  `json:"@type"`
+	RatingValue float64 `json:"ratingValue"`
+	RatingCount int64   `json:"ratingCount"`
+	BestRating  int     `json:"bestRating"`
+	WorstRating int     `json:"worstRating"`
+}
+
+// bookFormats maps edition formats to schema.org BookFormatType members.
+var bookFormats = map[string]string{
+	model.EditionFormatHardcover: "https://schema.org/Hardcover",
+	model.EditionFormatPaperback: "https://schema.org/Paperback",
+	model.EditionFormatEbook:     "https://schema.org/EBook",
+	model.EditionFormatAudiobook: "https://schema.org/AudiobookFormat",
+}
+
+// NewBook describes the book of citation under the URI id, embedding its
+// author under authorID. The edition the book is linked to, when there
+// is one, adds its publisher, date, language, format and length. Without
+// the author record, the author node only carries authorID.
+func NewBook(id, authorID string, citation *model.Citation) *Book {
+	book := citation.Book
+	node := &Book{
+		Context:             Context,
+		Type:                "Book",
+		ID:                  id,
+		Name:                book.Title,
+		AlternativeHeadline: book.Subtitle,
+		ISBN:                book.ISBN,
+		DateCreated:         book.CreatedAt.UTC().Format(dateLayout),
+	}
+
+	if book.UpdatedAt != nil {
+		node.DateModified = book.UpdatedAt.UTC().Format(dateLayout)
+	}
+
+	if citation.Author != nil {
+		node.Author = NewPerson(authorID, citation.Author)
+		node.Author.Context = ""
+	} else if authorID != "" {
+		node.Author = &Person{Type: "Person", ID: authorID}
+	}
+
+	if edition := citation.Edition; edition != nil {
+		node.InLanguage = edition.Language
+		node.BookFormat = bookFormats[edition.Format]
+		node.NumberOfPages = edition.PageCount
+		if edition.PublicationDate != nil {
+			node.DatePublished = edition.PublicationDate.Format(dateLayout)
+		}
+	}
+
+	if citation.Publisher != nil {
+		node.Publisher = &Organization{Type: "Organization", Name: citation.Publisher.Name}
+	}
+
+	if book.RatingAverage != nil && book.RatingCount > 0 {
+		node.AggregateRating = &AggregateRating{
+			Type:        "AggregateRating",
+			RatingValue: *book.RatingAverage,
+			RatingCount: book.RatingCount,
+			BestRating:  maxRating,
+			WorstRating: 1,
+		}
+	}
+
+	return node
+}
+
+// NewPerson describes author under the URI id, their aliases as alternate
+// names.
+func NewPerson(id string, author *model.Author) *Person {
+	node := &Person{
+		Context:     Context,
+		Type:        "Person",
+		ID:          id,
+		Name:        author.Name,
+		Description: author.Biography,
+		URL:         author.Website,
+	}
+
+	for _, alias := range author.Aliases {
+		node.AlternateName = append(node.AlternateName, alias.Name)
+	}
+
+	if !author.BirthDate.IsZero() {
+		node.BirthDate = author.BirthDate.Format(dateLayout)
+	}
+
+	if author.DeathDate != nil {
+		node.DeathDate = author.DeathDate.Format(dateLayout)
+	}
+
+	if author.Nationality != "" {
+		node.Nationality = &Country{Type: "Country", Identifier: author.Nationality}
+	}
+
+	if author.VIAF != "" {
+		node.SameAs = append(node.SameAs, "https://viaf.org/viaf/"+author.VIAF)
+	}
+
+	if author.ISNI != "" {
+		node.SameAs = append(node.SameAs, "https://isni.org/isni/"+author.ISNI)
+	}
+
+	if author.WikidataID != "" {
+		node.SameAs = append(node.SameAs, "http://www.wikidata.org/entity/"+author.WikidataID)
+	}
+
+	return node
+}
+
+// Write writes node as an indented JSON-LD document.
+func Write(w io.Writer, node any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(node)
+}
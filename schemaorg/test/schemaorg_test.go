@@ -0,0 +1,110 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/schemaorg"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAuthor() *model.Author {
+	died := time.Date(2006, time.April, 30, 0, 0, 0, 0, time.UTC)
+	return &model.Author{
+		ID:          2,
+		Name:        "Pramoedya Ananta Toer",
+		BirthDate:   time.Date(1925, time.February, 6, 0, 0, 0, 0, time.UTC),
+		DeathDate:   &died,
+		Nationality: "ID",
+		Biography:   "Indonesian novelist.",
+		VIAF:        "56618452",
+		ISNI:        "0000000121281232",
+		WikidataID:  "Q312556",
+		Aliases:     []*model.AuthorAlias{{Name: "Pramudya Ananta Tur"}},
+	}
+}
+
+func TestNewPerson(t *testing.T) {
+	person := schemaorg.NewPerson("https://library.example/api/v1/authors/2/", newAuthor())
+	assert.Equal(t, schemaorg.Context, person.Context)
+	assert.Equal(t, "Person", person.Type)
+	assert.Equal(t, "https://library.example/api/v1/authors/2/", person.ID)
+	assert.Equal(t, []string{"Pramudya Ananta Tur"}, person.AlternateName)
+	assert.Equal(t, "1925-02-06", person.BirthDate)
+	assert.Equal(t, "2006-04-30", person.DeathDate)
+	assert.Equal(t, &schemaorg.Country{Type: "Country", Identifier: "ID"}, person.Nationality)
+	assert.Equal(t, []string{
+		"https://viaf.org/viaf/56618452",
+		"https://isni.org/isni/0000000121281232",
+		"http://www.wikidata.org/entity/Q312556",
+	}, person.SameAs)
+}
+
+func TestNewBook(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		published := time.Date(1980, time.August, 25, 0, 0, 0, 0, time.UTC)
+		average := 4.5
+		citation := &model.Citation{
+			Book: &model.Book{
+				ID:            1,
+				Title:         "Bumi Manusia",
+				ISBN:          "9789799731234",
+				AuthorID:      2,
+				RatingCount:   12,
+				RatingAverage: &average,
+				CreatedAt:     time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC),
+			},
+			Author:    newAuthor(),
+			Edition:   &model.Edition{Language: "id", Format: model.EditionFormatPaperback, PageCount: 535, PublicationDate: &published},
+			Publisher: &model.Publisher{Name: "Hasta Mitra"},
+		}
+
+		book := schemaorg.NewBook("https://library.example/api/v1/books/1/", "https://library.example/api/v1/authors/2/", citation)
+		assert.Equal(t, "Book", book.Type)
+		assert.Equal(t, "https://library.example/api/v1/books/1/", book.ID)
+		assert.Equal(t, "", book.Author.Context)
+		assert.Equal(t, "https://library.example/api/v1/authors/2/", book.Author.ID)
+		assert.Equal(t, "Pramoedya Ananta Toer", book.Author.Name)
+		assert.Equal(t, &schemaorg.Organization{Type: "Organization", Name: "Hasta Mitra"}, book.Publisher)
+		assert.Equal(t, "1980-08-25", book.DatePublished)
+		assert.Equal(t, "id", book.InLanguage)
+		assert.Equal(t, "https://schema.org/Paperback", book.BookFormat)
+		assert.Equal(t, 535, book.NumberOfPages)
+		assert.Equal(t, 4.5, book.AggregateRating.RatingValue)
+		assert.Equal(t, 5, book.AggregateRating.BestRating)
+		assert.Equal(t, "2026-03-01", book.DateCreated)
+		assert.Empty(t, book.DateModified)
+	})
+
+	t.Run("ok: author not loaded", func(t *testing.T) {
+		citation := &model.Citation{Book: &model.Book{ID: 1, Title: "Bumi Manusia", AuthorID: 2}}
+
+		book := schemaorg.NewBook("urn:book", "urn:author", citation)
+		assert.Equal(t, &schemaorg.Person{Type: "Person", ID: "urn:author"}, book.Author)
+		assert.Nil(t, book.Publisher)
+		assert.Nil(t, book.AggregateRating)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	buf := &bytes.Buffer{}
+	citation := &model.Citation{
+		Book:   &model.Book{ID: 1, Title: "Salt & Light", AuthorID: 2},
+		Author: newAuthor(),
+	}
+	err := schemaorg.Write(buf, schemaorg.NewBook("urn:book", "urn:author", citation))
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), `"name": "Salt & Light"`)
+
+	doc := map[string]any{}
+	err = json.Unmarshal(buf.Bytes(), &doc)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://schema.org", doc["@context"])
+	assert.Equal(t, "urn:book", doc["@id"])
+	author := doc["author"].(map[string]any)
+	assert.Equal(t, "Person", author["@type"])
+	assert.NotContains(t, author, "@context")
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+type AccountService struct {
+	ledgerRepository model.LedgerRepository
+	userRepository   model.UserRepository
+	loanRepository   model.LoanRepository
+	policy           model.FinePolicy
+	currency         string
+	blockThreshold   int64
+}
+
+// NewAccountService charges fees following policy and blocks borrowing for
+// users who owe more than blockThreshold. Amounts are in minor units of
+// currency.
+func NewAccountService(
+	ledgerRepository model.LedgerRepository,
+	userRepository model.UserRepository,
+	loanRepository model.LoanRepository,
+	policy model.FinePolicy,
+	currency string,
+	blockThreshold int64,
+) model.AccountService {
+	return &AccountService{
+		ledgerRepository: ledgerRepository,
+		userRepository:   userRepository,
+		loanRepository:   loanRepository,
+		policy:           policy,
+		currency:         currency,
+		blockThreshold:   blockThreshold,
+	}
+}
+
+// FindAccount returns the standing of a user along with their ledger,
+// newest entry first.
+func (a AccountService) FindAccount(ctx context.Context, userID int64) (*model.Account, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	account, err := a.FindStanding(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	account.Entries, err = a.ledgerRepository.FindAllByUserID(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "ledger")
+	}
+
+	return account, nil
+}
+
+func (a AccountService) FindStanding(ctx context.Context, userID int64) (*model.Account, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	_, err := a.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "user")
+	}
+
+	balance, err := a.ledgerRepository.Balance(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "ledger")
+	}
+
+	return &model.Account{
+		UserID:   userID,
+		Balance:  balance,
+		Currency: a.currency,
+		Blocked:  balance > a.blockThreshold,
+	}, nil
+}
+
+// CheckStanding refuses users whose balance is above the block threshold.
+func (a AccountService) CheckStanding(ctx context.Context, userID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	balance, err := a.ledgerRepository.Balance(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "ledger")
+	}
+
+	if balance > a.blockThreshold {
+		return errors.Join(controller.ErrForbidden, errors.New(": account balance exceeds limit"))
+	}
+
+	return nil
+}
+
+// Charge records a fee. A lost item charged without an amount costs the
+// lost item fee of the policy.
+func (a AccountService) Charge(ctx context.Context, entry *model.LedgerEntry) (*model.LedgerEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("entry", utils.Dump(entry))
+
+	entry.Kind = model.EntryKindCharge
+	if entry.Amount == 0 && entry.Reason == model.ChargeReasonLost {
+		entry.Amount = a.policy.LostItem
+	}
+
+	if entry.Amount <= 0 {
+		return nil, errors.Join(controller.ErrBadRequest, errors.New(": amount"))
+	}
+
+	if entry.LoanID != nil {
+		loan, err := a.loanRepository.FindByID(ctx, *entry.LoanID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.Join(controller.ErrNotFound, errors.New(": loan"))
+			}
+
+			logger.Error(err)
+			return nil, parseError(err, "loan")
+		}
+
+		if loan.UserID != entry.UserID {
+			return nil, errors.Join(controller.ErrBadRequest, errors.New(": loan belongs to another user"))
+		}
+	}
+
+	return a.create(ctx, entry)
+}
+
+// Credit records a payment or a waiver, which may not exceed the balance.
+func (a AccountService) Credit(ctx context.Context, entry *model.LedgerEntry) (*model.LedgerEntry, error) {
+	if entry.Kind != model.EntryKindPayment && entry.Kind != model.EntryKindWaiver {
+		return nil, errors.Join(controller.ErrBadRequest, errors.New(": kind"))
+	}
+
+	if entry.Amount <= 0 {
+		return nil, errors.Join(controller.ErrBadRequest, errors.New(": amount"))
+	}
+
+	entry.Reason = ""
+	entry.LoanID = nil
+	return a.create(ctx, entry)
+}
+
+// ChargeOverdue fines a returned loan for every day it was late. It returns
+// nil when the loan came back in time or the policy charges nothing.
+func (a AccountService) ChargeOverdue(ctx context.Context, loan *model.Loan) (*model.LedgerEntry, error) {
+	if loan.ReturnedAt == nil {
+		return nil, nil
+	}
+
+	amount := a.policy.Overdue(loan.DueAt, *loan.ReturnedAt)
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	return a.create(ctx, &model.LedgerEntry{
+		UserID: loan.UserID,
+		Kind:   model.EntryKindCharge,
+		Reason: model.ChargeReasonOverdue,
+		Amount: amount,
+		LoanID: &loan.ID,
+	})
+}
+
+func (a AccountService) create(ctx context.Context, entry *model.LedgerEntry) (*model.LedgerEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("entry", utils.Dump(entry))
+
+	entry.CreatedAt = time.Now()
+	entry, err := a.ledgerRepository.Create(ctx, entry)
+	if err != nil {
+		if errors.Is(err, model.ErrExceedsBalance) {
+			return nil, errors.Join(controller.ErrConflict, errors.New(": amount exceeds balance"))
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(controller.ErrNotFound, errors.New(": user"))
+		}
+
+		logger.Error(err)
+		return nil, parseError(err, "ledger")
+	}
+
+	return entry, nil
+}
@@ -2,19 +2,37 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
 	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/names"
 	"github.com/rhtyx/bayarind-service.git/utils"
 
 	"github.com/sirupsen/logrus"
 )
 
+// duplicateNameThreshold is the least name similarity of two authors
+// reported as likely duplicates.
+const duplicateNameThreshold = 0.85
+
 type AuthorService struct {
 	authorRepository model.AuthorRepository
+	bookRepository   model.BookRepository
 }
 
-func NewAuthorService(authoRepository model.AuthorRepository) model.AuthorService {
-	return &AuthorService{authorRepository: authoRepository}
+func NewAuthorService(authoRepository model.AuthorRepository, bookRepository model.BookRepository) model.AuthorService {
+	return &AuthorService{
+		authorRepository: authoRepository,
+		bookRepository:   bookRepository,
+	}
 }
 
 func (a AuthorService) Create(ctx context.Context, author *model.Author) (*model.Author, error) {
@@ -22,6 +40,8 @@ func (a AuthorService) Create(ctx context.Context, author *model.Author) (*model
 		WithContext(ctx).
 		WithField("author", utils.Dump(author))
 
+	normalizeAliases(author)
+	author.Translations = canonicalTranslations(author.Translations)
 	author, err := a.authorRepository.Create(ctx, author)
 	if err != nil {
 		logger.Error(err)
@@ -57,11 +77,42 @@ func (a AuthorService) FindAll(ctx context.Context) ([]*model.Author, error) {
 	return authors, nil
 }
 
+// FindAllByName lists the authors whose name or alias contains name.
+func (a AuthorService) FindAllByName(ctx context.Context, name string) ([]*model.Author, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("name", name)
+
+	authors, err := a.authorRepository.FindAllByName(ctx, name)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	return authors, nil
+}
+
+func (a AuthorService) Stream(ctx context.Context, filter model.AuthorFilter, fn func(*model.Author) error) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("filter", utils.Dump(filter))
+
+	err := a.authorRepository.Stream(ctx, filter, fn)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "author")
+	}
+
+	return nil
+}
+
 func (a AuthorService) Update(ctx context.Context, author *model.Author) (*model.Author, error) {
 	logger := logrus.
 		WithContext(ctx).
 		WithField("author", utils.Dump(author))
 
+	normalizeAliases(author)
+	author.Translations = canonicalTranslations(author.Translations)
 	author, err := a.authorRepository.Update(ctx, author)
 	if err != nil {
 		logger.Error(err)
@@ -71,12 +122,41 @@ func (a AuthorService) Update(ctx context.Context, author *model.Author) (*model
 	return author, nil
 }
 
+func (a AuthorService) Patch(ctx context.Context, author *model.Author, columns []string) (*model.Author, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"author":  utils.Dump(author),
+			"columns": columns,
+		})
+
+	normalizeAliases(author)
+	author.Translations = canonicalTranslations(author.Translations)
+	author, err := a.authorRepository.UpdateColumns(ctx, author, columns)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	return author, nil
+}
+
 func (a AuthorService) Delete(ctx context.Context, authorID int64) error {
 	logger := logrus.
 		WithContext(ctx).
 		WithField("authorID", authorID)
 
-	err := a.authorRepository.Delete(ctx, authorID)
+	count, err := a.bookRepository.CountByAuthorID(ctx, authorID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "book")
+	}
+
+	if count > 0 {
+		return errors.Join(controller.ErrDependency, fmt.Errorf(": author has %d books", count))
+	}
+
+	err = a.authorRepository.Delete(ctx, authorID)
 	if err != nil {
 		logger.Error(err)
 		return parseError(err, "author")
@@ -84,3 +164,267 @@ func (a AuthorService) Delete(ctx context.Context, authorID int64) error {
 
 	return nil
 }
+
+func (a AuthorService) DeleteCascade(ctx context.Context, authorID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	err := a.authorRepository.DeleteWithBooks(ctx, authorID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "author")
+	}
+
+	return nil
+}
+
+func (a AuthorService) DeleteAndReassign(ctx context.Context, authorID, targetAuthorID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"authorID":       authorID,
+			"targetAuthorID": targetAuthorID,
+		})
+
+	if authorID == targetAuthorID {
+		return errors.Join(controller.ErrBadRequest, errors.New(": reassign_to must differ from id"))
+	}
+
+	_, err := a.authorRepository.FindByID(ctx, targetAuthorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Join(controller.ErrNotFound, errors.New(": reassign_to"))
+		}
+
+		logger.Error(err)
+		return parseError(err, "author")
+	}
+
+	err = a.authorRepository.DeleteAndReassignBooks(ctx, authorID, targetAuthorID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "author")
+	}
+
+	return nil
+}
+
+func (a AuthorService) FindAllDeleted(ctx context.Context) ([]*model.Author, error) {
+	logger := logrus.WithContext(ctx)
+
+	authors, err := a.authorRepository.FindAllDeleted(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	return authors, nil
+}
+
+func (a AuthorService) Restore(ctx context.Context, authorID int64) (*model.Author, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	err := a.authorRepository.Restore(ctx, authorID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	author, err := a.authorRepository.FindByID(ctx, authorID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	return author, nil
+}
+
+func (a AuthorService) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("deletedBefore", deletedBefore)
+
+	count, err := a.authorRepository.Purge(ctx, deletedBefore)
+	if err != nil {
+		logger.Error(err)
+		return 0, parseError(err, "author")
+	}
+
+	return count, nil
+}
+
+// FindVersions lists every version of an author, the current one first.
+func (a AuthorService) FindVersions(ctx context.Context, authorID int64) ([]*model.AuthorVersion, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	author, err := a.authorRepository.FindByID(ctx, authorID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	versions, err := a.authorRepository.FindVersions(ctx, authorID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	return append([]*model.AuthorVersion{currentAuthorVersion(author)}, versions...), nil
+}
+
+func (a AuthorService) FindVersion(ctx context.Context, authorID, version int64) (*model.AuthorVersion, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"authorID": authorID,
+			"version":  version,
+		})
+
+	author, err := a.authorRepository.FindByID(ctx, authorID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	if version == author.Version {
+		return currentAuthorVersion(author), nil
+	}
+
+	authorVersion, err := a.authorRepository.FindVersion(ctx, authorID, version)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "version")
+	}
+
+	return authorVersion, nil
+}
+
+func (a AuthorService) DiffVersions(ctx context.Context, authorID, from, to int64) (*model.VersionDiff, error) {
+	fromVersion, err := a.FindVersion(ctx, authorID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toVersion, err := a.FindVersion(ctx, authorID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.VersionDiff{
+		From:    from,
+		To:      to,
+		Changes: diffFields(authorVersionFields, authorVersionValues(fromVersion), authorVersionValues(toVersion)),
+	}, nil
+}
+
+// FindDuplicates pairs the live authors that share a birth date and whose
+// names are at least duplicateNameThreshold alike, best matches first.
+func (a AuthorService) FindDuplicates(ctx context.Context) ([]*model.DuplicateAuthor, error) {
+	logger := logrus.WithContext(ctx)
+
+	authors, err := a.authorRepository.FindAllSharingBirthDate(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	duplicates := []*model.DuplicateAuthor{}
+	for i, author := range authors {
+		for _, other := range authors[i+1:] {
+			if !other.BirthDate.Equal(author.BirthDate) {
+				break
+			}
+
+			score := names.Similarity(author.Name, other.Name)
+			if score < duplicateNameThreshold {
+				continue
+			}
+
+			duplicates = append(duplicates, &model.DuplicateAuthor{
+				Author:    author,
+				Duplicate: other,
+				Score:     score,
+			})
+		}
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Score > duplicates[j].Score
+	})
+
+	return duplicates, nil
+}
+
+// Merge folds the authors in mergedIDs into authorID and returns the
+// surviving author.
+func (a AuthorService) Merge(ctx context.Context, authorID int64, mergedIDs []int64) (*model.Author, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"authorID":  authorID,
+			"mergedIDs": mergedIDs,
+		})
+
+	slices.Sort(mergedIDs)
+	mergedIDs = slices.Compact(mergedIDs)
+	if slices.Contains(mergedIDs, authorID) {
+		return nil, errors.Join(controller.ErrBadRequest, errors.New(": an author cannot be merged into itself"))
+	}
+
+	err := a.authorRepository.Merge(ctx, authorID, mergedIDs, time.Now())
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	author, err := a.authorRepository.FindByID(ctx, authorID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	return author, nil
+}
+
+// FindRedirect returns the author a merged author was folded into.
+func (a AuthorService) FindRedirect(ctx context.Context, authorID int64) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	targetAuthorID, err := a.authorRepository.FindRedirect(ctx, authorID)
+	if err != nil {
+		logger.Error(err)
+		return 0, parseError(err, "author")
+	}
+
+	return targetAuthorID, nil
+}
+
+// normalizeAliases trims the aliases of author and drops empty ones,
+// repeats and the author's own name, all ignoring case.
+func normalizeAliases(author *model.Author) {
+	seen := map[string]bool{strings.ToLower(strings.TrimSpace(author.Name)): true}
+	aliases := []*model.AuthorAlias{}
+	for _, alias := range author.Aliases {
+		alias.Name = strings.TrimSpace(alias.Name)
+		key := strings.ToLower(alias.Name)
+		if alias.Name == "" || seen[key] {
+			continue
+		}
+
+		if alias.Kind == "" {
+			alias.Kind = model.AuthorAliasAlternate
+		}
+
+		seen[key] = true
+		aliases = append(aliases, alias)
+	}
+
+	author.Aliases = aliases
+}
@@ -3,10 +3,14 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"gorm.io/gorm"
 
 	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/isbn"
 	"github.com/rhtyx/bayarind-service.git/model"
 	"github.com/rhtyx/bayarind-service.git/utils"
 
@@ -14,20 +18,35 @@ import (
 )
 
 type BookService struct {
-	bookRepository   model.BookRepository
-	authorRepository model.AuthorRepository
+	bookRepository    model.BookRepository
+	authorRepository  model.AuthorRepository
+	editionRepository model.EditionRepository
 }
 
-func NewBookService(bookRepository model.BookRepository, authorRepository model.AuthorRepository) model.BookService {
+func NewBookService(bookRepository model.BookRepository, authorRepository model.AuthorRepository, editionRepository model.EditionRepository) model.BookService {
 	return &BookService{
-		bookRepository:   bookRepository,
-		authorRepository: authorRepository,
+		bookRepository:    bookRepository,
+		authorRepository:  authorRepository,
+		editionRepository: editionRepository,
 	}
 }
 
 func (b BookService) Create(ctx context.Context, book *model.Book) (*model.Book, error) {
+	return b.CreateWithEdition(ctx, book, nil)
+}
+
+// CreateWithEdition creates the book together with the edition it is
+// published as, unless its ISBN already names one. A nil edition leaves
+// the repository to create a bare one.
+func (b BookService) CreateWithEdition(ctx context.Context, book *model.Book, edition *model.Edition) (*model.Book, error) {
 	logger := logrus.WithContext(ctx)
 
+	book.Translations = canonicalTranslations(book.Translations)
+	err := normalizeISBN(book)
+	if err != nil {
+		return nil, err
+	}
+
 	currBook, err := b.bookRepository.FindByISBN(ctx, book.ISBN)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		logger.WithField("bookID", book.ID).Error(err)
@@ -48,7 +67,24 @@ func (b BookService) Create(ctx context.Context, book *model.Book) (*model.Book,
 		return nil, parseError(err, "author")
 	}
 
-	book, err = b.bookRepository.Create(ctx, book)
+	// A book whose ISBN already names an edition is that edition; any
+	// other gets a work and edition of its own from the repository.
+	currEdition, err := b.editionRepository.FindByISBN(ctx, book.ISBN)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.WithField("isbn", book.ISBN).Error(err)
+		return nil, parseError(err, "edition")
+	}
+
+	book.EditionID = nil
+	if currEdition != nil {
+		book.EditionID = &currEdition.ID
+	}
+
+	if edition != nil && book.EditionID == nil {
+		book, err = b.bookRepository.CreateWithEdition(ctx, book, edition)
+	} else {
+		book, err = b.bookRepository.Create(ctx, book)
+	}
 	if err != nil {
 		logger.WithField("book", utils.Dump(book)).Error(err)
 		return nil, parseError(err, "book")
@@ -71,12 +107,17 @@ func (b BookService) FindByID(ctx context.Context, bookID int64) (*model.Book, e
 	return book, nil
 }
 
-func (b BookService) FindByISBN(ctx context.Context, isbn string) (*model.Book, error) {
+func (b BookService) FindByISBN(ctx context.Context, value string) (*model.Book, error) {
 	logger := logrus.
 		WithContext(ctx).
-		WithField("isbn", isbn)
+		WithField("isbn", value)
 
-	book, err := b.bookRepository.FindByISBN(ctx, isbn)
+	canonical, err := isbn.Normalize(value)
+	if err != nil {
+		return nil, errors.Join(controller.ErrBadRequest, fmt.Errorf(": isbn %s", err))
+	}
+
+	book, err := b.bookRepository.FindByISBN(ctx, canonical)
 	if err != nil {
 		logger.Error(err)
 		return nil, parseError(err, "book")
@@ -98,17 +139,73 @@ func (b BookService) FindAll(ctx context.Context) ([]*model.Book, error) {
 	return books, nil
 }
 
+func (b BookService) Stream(ctx context.Context, filter model.BookFilter, fn func(*model.Book) error) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("filter", utils.Dump(filter))
+
+	if filter.ISBN != "" {
+		canonical, err := isbn.Normalize(filter.ISBN)
+		if err != nil {
+			return errors.Join(controller.ErrBadRequest, fmt.Errorf(": isbn %s", err))
+		}
+
+		filter.ISBN = canonical
+	}
+
+	err := b.bookRepository.Stream(ctx, filter, fn)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "book")
+	}
+
+	return nil
+}
+
+func (b BookService) StreamCitations(ctx context.Context, filter model.BookFilter, fn func(*model.Citation) error) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("filter", utils.Dump(filter))
+
+	if filter.ISBN != "" {
+		canonical, err := isbn.Normalize(filter.ISBN)
+		if err != nil {
+			return errors.Join(controller.ErrBadRequest, fmt.Errorf(": isbn %s", err))
+		}
+
+		filter.ISBN = canonical
+	}
+
+	err := b.bookRepository.StreamCitations(ctx, filter, fn)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "book")
+	}
+
+	return nil
+}
+
 func (b BookService) Update(ctx context.Context, book *model.Book) (*model.Book, error) {
 	logger := logrus.
 		WithContext(ctx).
 		WithField("book", utils.Dump(book))
 
+	book.Translations = canonicalTranslations(book.Translations)
+	err := normalizeISBN(book)
+	if err != nil {
+		return nil, err
+	}
+
 	currBook, err := b.bookRepository.FindByID(ctx, book.ID)
 	if err != nil {
 		logger.WithField("bookID", book.ID).Error(err)
 		return nil, parseError(err, "book")
 	}
 
+	if currBook.Version != book.Version {
+		return nil, errors.Join(controller.ErrPreconditionFailed, errors.New(": book"))
+	}
+
 	if currBook.ISBN != book.ISBN {
 		currBook, err = b.bookRepository.FindByISBN(ctx, book.ISBN)
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -140,6 +237,65 @@ func (b BookService) Update(ctx context.Context, book *model.Book) (*model.Book,
 	return book, nil
 }
 
+func (b BookService) Patch(ctx context.Context, book *model.Book, columns []string) (*model.Book, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"book":    utils.Dump(book),
+			"columns": columns,
+		})
+
+	book.Translations = canonicalTranslations(book.Translations)
+	if slices.Contains(columns, "isbn") {
+		err := normalizeISBN(book)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	currBook, err := b.bookRepository.FindByID(ctx, book.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	if currBook.Version != book.Version {
+		return nil, errors.Join(controller.ErrPreconditionFailed, errors.New(": book"))
+	}
+
+	if slices.Contains(columns, "isbn") && currBook.ISBN != book.ISBN {
+		currBook, err = b.bookRepository.FindByISBN(ctx, book.ISBN)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error(err)
+			return nil, parseError(err, "isbn")
+		}
+
+		if currBook != nil {
+			return nil, errors.Join(controller.ErrDuplicate, errors.New(": isbn"))
+		}
+	}
+
+	if slices.Contains(columns, "author_id") {
+		_, err = b.authorRepository.FindByID(ctx, book.AuthorID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.Join(controller.ErrNotFound, errors.New(": author"))
+			}
+
+			logger.WithField("authorID", book.AuthorID).Error(err)
+			return nil, parseError(err, "author")
+		}
+	}
+
+	book, err = b.bookRepository.UpdateColumns(ctx, book, columns)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	return book, nil
+}
+
 func (b BookService) Delete(ctx context.Context, bookID int64) error {
 	logger := logrus.
 		WithContext(ctx)
@@ -152,3 +308,154 @@ func (b BookService) Delete(ctx context.Context, bookID int64) error {
 
 	return nil
 }
+
+func (b BookService) FindAllDeleted(ctx context.Context) ([]*model.Book, error) {
+	logger := logrus.
+		WithContext(ctx)
+
+	books, err := b.bookRepository.FindAllDeleted(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	return books, nil
+}
+
+func (b BookService) Restore(ctx context.Context, bookID int64) (*model.Book, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	book, err := b.bookRepository.FindDeletedByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	currBook, err := b.bookRepository.FindByISBN(ctx, book.ISBN)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error(err)
+		return nil, parseError(err, "isbn")
+	}
+
+	if currBook != nil {
+		return nil, errors.Join(controller.ErrDuplicate, errors.New(": isbn"))
+	}
+
+	_, err = b.authorRepository.FindByID(ctx, book.AuthorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(controller.ErrNotFound, errors.New(": author"))
+		}
+
+		logger.WithField("authorID", book.AuthorID).Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	err = b.bookRepository.Restore(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	book, err = b.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	return book, nil
+}
+
+func (b BookService) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("deletedBefore", deletedBefore)
+
+	count, err := b.bookRepository.Purge(ctx, deletedBefore)
+	if err != nil {
+		logger.Error(err)
+		return 0, parseError(err, "book")
+	}
+
+	return count, nil
+}
+
+// normalizeISBN rewrites the book's ISBN to its canonical ISBN-13 so that
+// hyphenated and ISBN-10 forms of the same book are detected as duplicates.
+func normalizeISBN(book *model.Book) error {
+	canonical, err := isbn.Normalize(book.ISBN)
+	if err != nil {
+		return errors.Join(controller.ErrBadRequest, fmt.Errorf(": isbn %s", err))
+	}
+
+	book.ISBN = canonical
+	return nil
+}
+
+// FindVersions lists every version of a book, the current one first.
+func (b BookService) FindVersions(ctx context.Context, bookID int64) ([]*model.BookVersion, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	book, err := b.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	versions, err := b.bookRepository.FindVersions(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	return append([]*model.BookVersion{currentBookVersion(book)}, versions...), nil
+}
+
+func (b BookService) FindVersion(ctx context.Context, bookID, version int64) (*model.BookVersion, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID":  bookID,
+			"version": version,
+		})
+
+	book, err := b.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	if version == book.Version {
+		return currentBookVersion(book), nil
+	}
+
+	bookVersion, err := b.bookRepository.FindVersion(ctx, bookID, version)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "version")
+	}
+
+	return bookVersion, nil
+}
+
+func (b BookService) DiffVersions(ctx context.Context, bookID, from, to int64) (*model.VersionDiff, error) {
+	fromVersion, err := b.FindVersion(ctx, bookID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toVersion, err := b.FindVersion(ctx, bookID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.VersionDiff{
+		From:    from,
+		To:      to,
+		Changes: diffFields(bookVersionFields, bookVersionValues(fromVersion), bookVersionValues(toVersion)),
+	}, nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CatalogService struct {
+	bookRepository    model.BookRepository
+	authorRepository  model.AuthorRepository
+	subjectRepository model.SubjectRepository
+}
+
+func NewCatalogService(
+	bookRepository model.BookRepository,
+	authorRepository model.AuthorRepository,
+	subjectRepository model.SubjectRepository,
+) model.CatalogService {
+	return &CatalogService{
+		bookRepository:    bookRepository,
+		authorRepository:  authorRepository,
+		subjectRepository: subjectRepository,
+	}
+}
+
+// FindBooks returns one page of the live books matching filter, most
+// recently changed first.
+func (c CatalogService) FindBooks(ctx context.Context, filter model.BookFilter, pagination model.Pagination) (*model.CatalogPage, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"filter":     utils.Dump(filter),
+			"pagination": utils.Dump(pagination),
+		})
+
+	books, total, err := c.bookRepository.FindPage(ctx, filter, pagination)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	entries, err := c.entries(ctx, books)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &model.CatalogPage{
+		Items:   entries,
+		Page:    pagination.Page,
+		PerPage: pagination.PerPage,
+		Total:   total,
+	}, nil
+}
+
+func (c CatalogService) FindBook(ctx context.Context, bookID int64) (*model.CatalogEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	book, err := c.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	entries, err := c.entries(ctx, []*model.Book{book})
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return entries[0], nil
+}
+
+// FindBooksBySubject returns a subject and one page of the books filed
+// under it or any subject below it.
+func (c CatalogService) FindBooksBySubject(ctx context.Context, subjectID int64, pagination model.Pagination) (*model.Subject, *model.CatalogPage, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subjectID", subjectID)
+
+	subject, err := c.subjectRepository.FindByID(ctx, subjectID)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, parseError(err, "subject")
+	}
+
+	subjectIDs, err := c.subjectRepository.FindDescendantIDs(ctx, subjectID)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, parseError(err, "subject")
+	}
+
+	page, err := c.FindBooks(ctx, model.BookFilter{SubjectIDs: subjectIDs}, pagination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return subject, page, nil
+}
+
+// FindBooksByAuthor returns an author and one page of their books.
+func (c CatalogService) FindBooksByAuthor(ctx context.Context, authorID int64, pagination model.Pagination) (*model.Author, *model.CatalogPage, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("authorID", authorID)
+
+	author, err := c.authorRepository.FindByID(ctx, authorID)
+	if err != nil {
+		logger.Error(err)
+		return nil, nil, parseError(err, "author")
+	}
+
+	page, err := c.FindBooks(ctx, model.BookFilter{AuthorID: authorID}, pagination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return author, page, nil
+}
+
+func (c CatalogService) FindAuthors(ctx context.Context, pagination model.Pagination) (*model.AuthorPage, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("pagination", utils.Dump(pagination))
+
+	authors, total, err := c.authorRepository.FindPage(ctx, pagination)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	return &model.AuthorPage{
+		Items:   authors,
+		Page:    pagination.Page,
+		PerPage: pagination.PerPage,
+		Total:   total,
+	}, nil
+}
+
+func (c CatalogService) FindSubjects(ctx context.Context) ([]*model.Subject, error) {
+	logger := logrus.WithContext(ctx)
+
+	subjects, err := c.subjectRepository.FindAll(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	return subjects, nil
+}
+
+// entries pairs books with their authors and subjects, looking each up
+// once for all of them.
+func (c CatalogService) entries(ctx context.Context, books []*model.Book) ([]*model.CatalogEntry, error) {
+	entries := []*model.CatalogEntry{}
+	if len(books) == 0 {
+		return entries, nil
+	}
+
+	bookIDs := []int64{}
+	authorIDs := []int64{}
+	for _, book := range books {
+		bookIDs = append(bookIDs, book.ID)
+		authorIDs = append(authorIDs, book.AuthorID)
+	}
+
+	authors, err := c.authorRepository.FindAllByIDs(ctx, authorIDs)
+	if err != nil {
+		return nil, parseError(err, "author")
+	}
+
+	authorsByID := map[int64]*model.Author{}
+	for _, author := range authors {
+		authorsByID[author.ID] = author
+	}
+
+	subjects, err := c.subjectRepository.FindByBookIDs(ctx, bookIDs)
+	if err != nil {
+		return nil, parseError(err, "subject")
+	}
+
+	for _, book := range books {
+		entries = append(entries, &model.CatalogEntry{
+			Book:     book,
+			Author:   authorsByID[book.AuthorID],
+			Subjects: subjects[book.ID],
+		})
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CitationService struct {
+	bookRepository      model.BookRepository
+	authorRepository    model.AuthorRepository
+	editionRepository   model.EditionRepository
+	publisherRepository model.PublisherRepository
+}
+
+func NewCitationService(
+	bookRepository model.BookRepository,
+	authorRepository model.AuthorRepository,
+	editionRepository model.EditionRepository,
+	publisherRepository model.PublisherRepository,
+) model.CitationService {
+	return &CitationService{
+		bookRepository:      bookRepository,
+		authorRepository:    authorRepository,
+		editionRepository:   editionRepository,
+		publisherRepository: publisherRepository,
+	}
+}
+
+// FindByBookIDs returns what the given books are cited from, in the order
+// they were asked for and each only once. The edition a book is linked to
+// supplies its publisher, year and language.
+func (c CitationService) FindByBookIDs(ctx context.Context, bookIDs []int64) ([]*model.Citation, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookIDs", bookIDs)
+
+	ids := []int64{}
+	seen := map[int64]bool{}
+	for _, bookID := range bookIDs {
+		if !seen[bookID] {
+			seen[bookID] = true
+			ids = append(ids, bookID)
+		}
+	}
+
+	books, err := c.bookRepository.FindAllByIDs(ctx, ids)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	booksByID := map[int64]*model.Book{}
+	authorIDs := []int64{}
+	editionIDs := []int64{}
+	for _, book := range books {
+		booksByID[book.ID] = book
+		authorIDs = append(authorIDs, book.AuthorID)
+		if book.EditionID != nil {
+			editionIDs = append(editionIDs, *book.EditionID)
+		}
+	}
+
+	for _, bookID := range ids {
+		if booksByID[bookID] == nil {
+			return nil, errors.Join(controller.ErrNotFound, fmt.Errorf(": book %d", bookID))
+		}
+	}
+
+	authors, err := c.authorRepository.FindAllByIDs(ctx, authorIDs)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	authorsByID := map[int64]*model.Author{}
+	for _, author := range authors {
+		authorsByID[author.ID] = author
+	}
+
+	editionsByID := map[int64]*model.Edition{}
+	publishersByID := map[int64]*model.Publisher{}
+	if len(editionIDs) > 0 {
+		editions, err := c.editionRepository.FindAllByIDs(ctx, editionIDs)
+		if err != nil {
+			logger.Error(err)
+			return nil, parseError(err, "edition")
+		}
+
+		publisherIDs := []int64{}
+		for _, edition := range editions {
+			editionsByID[edition.ID] = edition
+			if edition.PublisherID != nil {
+				publisherIDs = append(publisherIDs, *edition.PublisherID)
+			}
+		}
+
+		if len(publisherIDs) > 0 {
+			publishers, err := c.publisherRepository.FindAllByIDs(ctx, publisherIDs)
+			if err != nil {
+				logger.Error(err)
+				return nil, parseError(err, "publisher")
+			}
+
+			for _, publisher := range publishers {
+				publishersByID[publisher.ID] = publisher
+			}
+		}
+	}
+
+	citations := []*model.Citation{}
+	for _, bookID := range ids {
+		book := booksByID[bookID]
+		citation := &model.Citation{
+			Book:   book,
+			Author: authorsByID[book.AuthorID],
+		}
+
+		if book.EditionID != nil {
+			citation.Edition = editionsByID[*book.EditionID]
+		}
+
+		if citation.Edition != nil && citation.Edition.PublisherID != nil {
+			citation.Publisher = publishersByID[*citation.Edition.PublisherID]
+		}
+
+		citations = append(citations, citation)
+	}
+
+	return citations, nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+type CopyService struct {
+	copyRepository model.CopyRepository
+	bookRepository model.BookRepository
+	loanRepository model.LoanRepository
+}
+
+func NewCopyService(copyRepository model.CopyRepository, bookRepository model.BookRepository, loanRepository model.LoanRepository) model.CopyService {
+	return &CopyService{
+		copyRepository: copyRepository,
+		bookRepository: bookRepository,
+		loanRepository: loanRepository,
+	}
+}
+
+func (c CopyService) Create(ctx context.Context, bookCopy *model.Copy) (*model.Copy, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("copy", utils.Dump(bookCopy))
+
+	_, err := c.bookRepository.FindByID(ctx, bookCopy.BookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(controller.ErrNotFound, errors.New(": book"))
+		}
+
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	err = c.checkBarcode(ctx, bookCopy.Barcode)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	bookCopy, err = c.copyRepository.Create(ctx, bookCopy)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "copy")
+	}
+
+	return bookCopy, nil
+}
+
+func (c CopyService) FindByID(ctx context.Context, copyID int64) (*model.Copy, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("copyID", copyID)
+
+	bookCopy, err := c.copyRepository.FindByID(ctx, copyID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "copy")
+	}
+
+	return bookCopy, nil
+}
+
+func (c CopyService) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Copy, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	_, err := c.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	copies, err := c.copyRepository.FindAllByBookID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "copy")
+	}
+
+	return copies, nil
+}
+
+// Update writes the barcode, branch and condition of a copy. A copy never
+// moves to another book.
+func (c CopyService) Update(ctx context.Context, bookCopy *model.Copy) (*model.Copy, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("copy", utils.Dump(bookCopy))
+
+	currCopy, err := c.copyRepository.FindByID(ctx, bookCopy.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "copy")
+	}
+
+	if currCopy.Version != bookCopy.Version {
+		return nil, errors.Join(controller.ErrPreconditionFailed, errors.New(": copy"))
+	}
+
+	if currCopy.Barcode != bookCopy.Barcode {
+		err = c.checkBarcode(ctx, bookCopy.Barcode)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+	}
+
+	bookCopy.BookID = currCopy.BookID
+	if bookCopy.Condition == "" {
+		bookCopy.Condition = model.CopyConditionGood
+	}
+	bookCopy, err = c.copyRepository.Update(ctx, bookCopy)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "copy")
+	}
+
+	return bookCopy, nil
+}
+
+func (c CopyService) Delete(ctx context.Context, copyID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("copyID", copyID)
+
+	count, err := c.loanRepository.CountByCopyID(ctx, copyID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "loan")
+	}
+
+	if count > 0 {
+		return errors.Join(controller.ErrDependency, fmt.Errorf(": copy has %d loans", count))
+	}
+
+	err = c.copyRepository.Delete(ctx, copyID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "copy")
+	}
+
+	return nil
+}
+
+func (c CopyService) checkBarcode(ctx context.Context, barcode string) error {
+	_, err := c.copyRepository.FindByBarcode(ctx, barcode)
+	if err == nil {
+		return errors.Join(controller.ErrDuplicate, errors.New(": barcode"))
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return parseError(err, "copy")
+	}
+
+	return nil
+}
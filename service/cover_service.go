@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/thumbnail"
+
+	"github.com/sirupsen/logrus"
+)
+
+var coverSizes = []thumbnail.Size{
+	{Name: model.CoverLarge, MaxWidth: 600, MaxHeight: 900},
+	{Name: model.CoverMedium, MaxWidth: 300, MaxHeight: 450},
+	{Name: model.CoverSmall, MaxWidth: 100, MaxHeight: 150},
+}
+
+var coverNames = []string{model.CoverOriginal, model.CoverLarge, model.CoverMedium, model.CoverSmall}
+
+type CoverService struct {
+	bookRepository model.BookRepository
+	blobStore      model.BlobStore
+	urlTTL         time.Duration
+}
+
+func NewCoverService(bookRepository model.BookRepository, blobStore model.BlobStore, urlTTL time.Duration) model.CoverService {
+	return &CoverService{
+		bookRepository: bookRepository,
+		blobStore:      blobStore,
+		urlTTL:         urlTTL,
+	}
+}
+
+// Upload stores the image and its thumbnails under a content addressed
+// prefix, points the book at it and removes the previous cover.
+func (c CoverService) Upload(ctx context.Context, bookID int64, data []byte) (*model.Cover, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	book, err := c.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	renditions, err := thumbnail.Generate(data, coverSizes, thumbnail.DefaultMaxPixels)
+	if err != nil {
+		logger.Error(err)
+		if errors.Is(err, thumbnail.ErrTooManyPixels) {
+			return nil, errors.Join(controller.ErrTooLarge, errors.New(": cover dimensions"))
+		}
+
+		return nil, errors.Join(controller.ErrMediaType, errors.New(": cover must be jpeg or png"))
+	}
+
+	renditions = append(renditions, thumbnail.Rendition{
+		Name:        model.CoverOriginal,
+		ContentType: renditions[0].ContentType,
+		Extension:   renditions[0].Extension,
+		Data:        data,
+	})
+
+	sum := sha256.Sum256(data)
+	prev := *book
+	book.CoverKey = fmt.Sprintf("covers/%d/%s", book.ID, hex.EncodeToString(sum[:8]))
+	book.CoverType = renditions[0].ContentType
+
+	stored := []string{}
+	for _, rendition := range renditions {
+		key := coverKey(book, rendition.Name)
+		err = c.blobStore.Put(ctx, key, rendition.Data, rendition.ContentType)
+		if err != nil {
+			logger.WithField("key", key).Error(err)
+			c.deleteBlobs(ctx, stored)
+			return nil, controller.ErrInternalServer
+		}
+
+		stored = append(stored, key)
+	}
+
+	updated, err := c.bookRepository.UpdateColumns(ctx, book, []string{"cover_key", "cover_type"})
+	if err != nil {
+		logger.Error(err)
+		if prev.CoverKey != book.CoverKey {
+			c.deleteBlobs(ctx, stored)
+		}
+
+		return nil, parseError(err, "book")
+	}
+
+	if prev.CoverKey != "" && prev.CoverKey != book.CoverKey {
+		c.deleteBlobs(ctx, coverKeys(&prev))
+	}
+
+	return c.cover(ctx, updated)
+}
+
+func (c CoverService) FindByBookID(ctx context.Context, bookID int64) (*model.Cover, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	book, err := c.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	if book.CoverKey == "" {
+		return nil, errors.Join(controller.ErrNotFound, errors.New(": cover"))
+	}
+
+	return c.cover(ctx, book)
+}
+
+func (c CoverService) URL(ctx context.Context, bookID int64, size string) (string, time.Time, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID": bookID,
+			"size":   size,
+		})
+
+	if !slices.Contains(coverNames, size) {
+		return "", time.Time{}, errors.Join(controller.ErrBadRequest, errors.New(": invalid cover size"))
+	}
+
+	book, err := c.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return "", time.Time{}, parseError(err, "book")
+	}
+
+	if book.CoverKey == "" {
+		return "", time.Time{}, errors.Join(controller.ErrNotFound, errors.New(": cover"))
+	}
+
+	expiresAt := time.Now().Add(c.urlTTL)
+	url, err := c.blobStore.SignedURL(ctx, coverKey(book, size), c.urlTTL)
+	if err != nil {
+		logger.Error(err)
+		return "", time.Time{}, controller.ErrInternalServer
+	}
+
+	return url, expiresAt, nil
+}
+
+func (c CoverService) cover(ctx context.Context, book *model.Book) (*model.Cover, error) {
+	cover := &model.Cover{
+		BookID:    book.ID,
+		Version:   book.Version,
+		URLs:      map[string]string{},
+		ExpiresAt: time.Now().Add(c.urlTTL),
+	}
+
+	for _, name := range coverNames {
+		url, err := c.blobStore.SignedURL(ctx, coverKey(book, name), c.urlTTL)
+		if err != nil {
+			logrus.WithContext(ctx).WithField("bookID", book.ID).Error(err)
+			return nil, controller.ErrInternalServer
+		}
+
+		cover.URLs[name] = url
+	}
+
+	return cover, nil
+}
+
+// deleteBlobs is best effort; leftovers are unreferenced and harmless.
+func (c CoverService) deleteBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		err := c.blobStore.Delete(ctx, key)
+		if err != nil {
+			logrus.WithContext(ctx).WithField("key", key).Error(err)
+		}
+	}
+}
+
+func coverKey(book *model.Book, name string) string {
+	ext := ".jpg"
+	if book.CoverType == thumbnail.MIMEPNG {
+		ext = ".png"
+	}
+
+	return book.CoverKey + "/" + name + ext
+}
+
+func coverKeys(book *model.Book) []string {
+	keys := []string{}
+	for _, name := range coverNames {
+		keys = append(keys, coverKey(book, name))
+	}
+
+	return keys
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/isbn"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+type EditionService struct {
+	editionRepository   model.EditionRepository
+	workRepository      model.WorkRepository
+	publisherRepository model.PublisherRepository
+}
+
+func NewEditionService(editionRepository model.EditionRepository, workRepository model.WorkRepository, publisherRepository model.PublisherRepository) model.EditionService {
+	return &EditionService{
+		editionRepository:   editionRepository,
+		workRepository:      workRepository,
+		publisherRepository: publisherRepository,
+	}
+}
+
+func (e EditionService) Create(ctx context.Context, edition *model.Edition) (*model.Edition, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("edition", utils.Dump(edition))
+
+	err := e.validate(ctx, edition, "")
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	edition, err = e.editionRepository.Create(ctx, edition)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "edition")
+	}
+
+	return edition, nil
+}
+
+func (e EditionService) FindByID(ctx context.Context, editionID int64) (*model.Edition, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("editionID", editionID)
+
+	edition, err := e.editionRepository.FindByID(ctx, editionID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "edition")
+	}
+
+	return edition, nil
+}
+
+func (e EditionService) FindAll(ctx context.Context) ([]*model.Edition, error) {
+	logger := logrus.WithContext(ctx)
+
+	editions, err := e.editionRepository.FindAll(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "edition")
+	}
+
+	return editions, nil
+}
+
+func (e EditionService) Update(ctx context.Context, edition *model.Edition) (*model.Edition, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("edition", utils.Dump(edition))
+
+	currEdition, err := e.editionRepository.FindByID(ctx, edition.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "edition")
+	}
+
+	if currEdition.Version != edition.Version {
+		return nil, errors.Join(controller.ErrPreconditionFailed, errors.New(": edition"))
+	}
+
+	err = e.validate(ctx, edition, currEdition.ISBN)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	edition, err = e.editionRepository.Update(ctx, edition)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "edition")
+	}
+
+	return edition, nil
+}
+
+func (e EditionService) Delete(ctx context.Context, editionID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("editionID", editionID)
+
+	err := e.editionRepository.Delete(ctx, editionID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "edition")
+	}
+
+	return nil
+}
+
+// validate normalizes the ISBN and checks that it is unique and that the
+// referenced work and publisher exist. currISBN is the ISBN the edition
+// already holds, which does not count as a duplicate.
+func (e EditionService) validate(ctx context.Context, edition *model.Edition, currISBN string) error {
+	if edition.ISBN != "" {
+		canonical, err := isbn.Normalize(edition.ISBN)
+		if err != nil {
+			return errors.Join(controller.ErrBadRequest, fmt.Errorf(": isbn %s", err))
+		}
+		edition.ISBN = canonical
+
+		if edition.ISBN != currISBN {
+			_, err = e.editionRepository.FindByISBN(ctx, edition.ISBN)
+			if err == nil {
+				return errors.Join(controller.ErrDuplicate, errors.New(": isbn"))
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return parseError(err, "edition")
+			}
+		}
+	}
+
+	_, err := e.workRepository.FindByID(ctx, edition.WorkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Join(controller.ErrNotFound, errors.New(": work"))
+		}
+
+		return parseError(err, "work")
+	}
+
+	if edition.PublisherID != nil {
+		_, err = e.publisherRepository.FindByID(ctx, *edition.PublisherID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.Join(controller.ErrNotFound, errors.New(": publisher"))
+			}
+
+			return parseError(err, "publisher")
+		}
+	}
+
+	return nil
+}
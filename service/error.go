@@ -7,6 +7,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
 )
 
 func parseError(err error, data string) error {
@@ -15,6 +16,8 @@ func parseError(err error, data string) error {
 		return errors.Join(controller.ErrNotFound, fmt.Errorf(": %s", data))
 	case errors.Is(err, gorm.ErrDuplicatedKey):
 		return errors.Join(controller.ErrDuplicate, fmt.Errorf(": %s", data))
+	case errors.Is(err, model.ErrStaleVersion):
+		return errors.Join(controller.ErrPreconditionFailed, fmt.Errorf(": %s", data))
 	default:
 		return controller.ErrInternalServer
 	}
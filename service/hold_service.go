@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+type HoldService struct {
+	holdRepository model.HoldRepository
+	bookRepository model.BookRepository
+	accountService model.AccountService
+	pickupWindow   time.Duration
+}
+
+// NewHoldService keeps a copy set aside for a ready hold during
+// pickupWindow. Readers blocked by accountService cannot place holds.
+func NewHoldService(
+	holdRepository model.HoldRepository,
+	bookRepository model.BookRepository,
+	accountService model.AccountService,
+	pickupWindow time.Duration,
+) model.HoldService {
+	return &HoldService{
+		holdRepository: holdRepository,
+		bookRepository: bookRepository,
+		accountService: accountService,
+		pickupWindow:   pickupWindow,
+	}
+}
+
+// Place queues the user for a book. When a copy is free the hold is ready
+// at once; the repository allocates it in the same transaction.
+func (h HoldService) Place(ctx context.Context, bookID, userID int64) (*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID": bookID,
+			"userID": userID,
+		})
+
+	_, err := h.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	err = h.accountService.CheckStanding(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	_, err = h.holdRepository.FindActive(ctx, bookID, userID)
+	if err == nil {
+		return nil, errors.Join(controller.ErrDuplicate, errors.New(": hold"))
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error(err)
+		return nil, parseError(err, "hold")
+	}
+
+	now := time.Now()
+	hold := &model.Hold{
+		BookID:   bookID,
+		UserID:   userID,
+		Status:   model.HoldStatusWaiting,
+		PlacedAt: now,
+	}
+	hold, err = h.holdRepository.Create(ctx, hold, now, now.Add(h.pickupWindow))
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "hold")
+	}
+
+	return hold, nil
+}
+
+func (h HoldService) FindByID(ctx context.Context, holdID int64) (*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("holdID", holdID)
+
+	hold, err := h.holdRepository.FindByID(ctx, holdID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "hold")
+	}
+
+	return hold, nil
+}
+
+func (h HoldService) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	_, err := h.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	holds, err := h.holdRepository.FindAllByBookID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "hold")
+	}
+
+	return holds, nil
+}
+
+func (h HoldService) FindAllByUserID(ctx context.Context, userID int64, activeOnly bool) ([]*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	holds, err := h.holdRepository.FindAllByUserID(ctx, userID, activeOnly)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "hold")
+	}
+
+	return holds, nil
+}
+
+func (h HoldService) FindAvailability(ctx context.Context, bookID int64) (*model.Availability, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	_, err := h.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	availability, err := h.holdRepository.FindAvailability(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "hold")
+	}
+
+	return availability, nil
+}
+
+// Allocate sets aside free copies of a book for its waiting holds, first
+// come first served, until either runs out.
+func (h HoldService) Allocate(ctx context.Context, bookID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	for {
+		now := time.Now()
+		hold, err := h.holdRepository.AssignNext(ctx, bookID, now, now.Add(h.pickupWindow))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			logger.Error(err)
+			return parseError(err, "hold")
+		}
+
+		logger.WithField("holdID", hold.ID).Info("Hold is ready for pickup")
+	}
+}
+
+// Cancel closes a hold. A copy set aside for it goes to the next hold.
+func (h HoldService) Cancel(ctx context.Context, holdID int64) (*model.Hold, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("holdID", holdID)
+
+	hold, err := h.holdRepository.Cancel(ctx, holdID, time.Now())
+	if err != nil {
+		if errors.Is(err, model.ErrHoldClosed) {
+			return nil, errors.Join(controller.ErrConflict, errors.New(": hold is closed"))
+		}
+
+		logger.Error(err)
+		return nil, parseError(err, "hold")
+	}
+
+	if hold.CopyID != nil {
+		err = h.Allocate(ctx, hold.BookID)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+	}
+
+	return hold, nil
+}
+
+// ExpirePickups closes the ready holds whose copy was not picked up in
+// time and hands every free copy to the next waiting hold of its book. It
+// returns how many holds expired.
+func (h HoldService) ExpirePickups(ctx context.Context) (int, error) {
+	logger := logrus.WithContext(ctx)
+
+	holds, err := h.holdRepository.ExpireReady(ctx, time.Now())
+	if err != nil {
+		logger.Error(err)
+		return 0, parseError(err, "hold")
+	}
+
+	bookIDs, err := h.holdRepository.FindPendingBookIDs(ctx)
+	if err != nil {
+		logger.Error(err)
+		return 0, parseError(err, "hold")
+	}
+
+	for _, bookID := range bookIDs {
+		err = h.Allocate(ctx, bookID)
+		if err != nil {
+			logger.WithField("bookID", bookID).Error(err)
+			return 0, err
+		}
+	}
+
+	return len(holds), nil
+}
@@ -0,0 +1,561 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/dto"
+	"github.com/rhtyx/bayarind-service.git/isbn"
+	"github.com/rhtyx/bayarind-service.git/marc"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	importProgressInterval = 100
+	importMaxErrors        = 1000
+)
+
+type ImportService struct {
+	importJobRepository model.ImportJobRepository
+	authorRepository    model.AuthorRepository
+	publisherRepository model.PublisherRepository
+	bookService         model.BookService
+
+	ctx  context.Context
+	jobs *sync.WaitGroup
+}
+
+// NewImportService runs background jobs under ctx: cancelling it stops
+// them after their current row, and Wait returns once they have stopped.
+func NewImportService(
+	ctx context.Context,
+	importJobRepository model.ImportJobRepository,
+	authorRepository model.AuthorRepository,
+	publisherRepository model.PublisherRepository,
+	bookService model.BookService,
+) model.ImportService {
+	return &ImportService{
+		importJobRepository: importJobRepository,
+		authorRepository:    authorRepository,
+		publisherRepository: publisherRepository,
+		bookService:         bookService,
+		ctx:                 ctx,
+		jobs:                &sync.WaitGroup{},
+	}
+}
+
+type importRow struct {
+	number int
+	data   *dto.ImportRow
+	err    error
+}
+
+// Create stores a pending job and processes it in the background. The
+// returned job is a snapshot; poll FindByID for progress.
+func (i ImportService) Create(ctx context.Context, job *model.ImportJob, data []byte) (*model.ImportJob, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("job", utils.Dump(job))
+
+	switch job.Format {
+	case model.ImportFormatCSV, model.ImportFormatNDJSON, model.ImportFormatMARC, model.ImportFormatMARCXML:
+	default:
+		return nil, errors.Join(controller.ErrBadRequest, errors.New(": format must be csv, ndjson, marc or marcxml"))
+	}
+
+	job.Status = model.ImportStatusPending
+	job, err := i.importJobRepository.Create(ctx, job)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "import")
+	}
+
+	running := *job
+	i.jobs.Add(1)
+	go func() {
+		defer i.jobs.Done()
+		i.Run(i.ctx, &running, data)
+	}()
+
+	return job, nil
+}
+
+// FailUnfinished marks the jobs a previous process left pending or running
+// as failed; nothing resumes them.
+func (i ImportService) FailUnfinished(ctx context.Context) (int64, error) {
+	count, err := i.importJobRepository.FailUnfinished(ctx, time.Now())
+	if err != nil {
+		logrus.WithContext(ctx).Error(err)
+		return 0, parseError(err, "import")
+	}
+
+	return count, nil
+}
+
+// Wait blocks until every background job has returned.
+func (i ImportService) Wait() {
+	i.jobs.Wait()
+}
+
+func (i ImportService) FindByID(ctx context.Context, jobID, userID int64) (*model.ImportJob, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"jobID":  jobID,
+			"userID": userID,
+		})
+
+	job, err := i.importJobRepository.FindByID(ctx, jobID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "import")
+	}
+
+	if job.UserID != userID {
+		return nil, errors.Join(controller.ErrNotFound, errors.New(": import"))
+	}
+
+	return job, nil
+}
+
+func (i ImportService) FindAllByUserID(ctx context.Context, userID int64) ([]*model.ImportJob, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	jobs, err := i.importJobRepository.FindAllByUserID(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "import")
+	}
+
+	return jobs, nil
+}
+
+// Run processes every row of data and records progress on job. Rows are
+// independent: a failing row is reported and the import moves on. When ctx
+// is cancelled the job stops and is recorded as failed.
+func (i ImportService) Run(ctx context.Context, job *model.ImportJob, data []byte) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("jobID", job.ID)
+
+	rows, err := parseImportRows(job.Format, data)
+	if err != nil {
+		logger.Error(err)
+		job.Errors = []model.ImportRowError{{Message: err.Error()}}
+		i.finish(ctx, job, model.ImportStatusFailed)
+		return
+	}
+
+	job.Status = model.ImportStatusRunning
+	job.TotalRows = len(rows)
+	i.save(ctx, job)
+
+	seenISBN := map[string]int{}
+	authorIDs := map[string]int64{}
+	publisherIDs := map[string]int64{}
+	validate := validator.New()
+	for _, row := range rows {
+		if ctx.Err() != nil {
+			job.Errors = append(job.Errors, model.ImportRowError{Row: row.number, Message: model.ImportInterrupted})
+			i.finish(ctx, job, model.ImportStatusFailed)
+			return
+		}
+
+		rowErrors := i.importRow(ctx, job, row, validate, seenISBN, authorIDs, publisherIDs)
+
+		job.ProcessedRows++
+		if len(rowErrors) > 0 {
+			job.FailedRows++
+			for _, rowError := range rowErrors {
+				if len(job.Errors) < importMaxErrors {
+					job.Errors = append(job.Errors, rowError)
+				}
+			}
+		}
+
+		if job.ProcessedRows%importProgressInterval == 0 {
+			i.save(ctx, job)
+		}
+	}
+
+	i.finish(ctx, job, model.ImportStatusCompleted)
+}
+
+func (i ImportService) importRow(
+	ctx context.Context,
+	job *model.ImportJob,
+	row importRow,
+	validate *validator.Validate,
+	seenISBN map[string]int,
+	authorIDs, publisherIDs map[string]int64,
+) []model.ImportRowError {
+	if row.err != nil {
+		return []model.ImportRowError{{Row: row.number, Message: row.err.Error()}}
+	}
+
+	err := validate.Struct(row.data)
+	if err != nil {
+		rowErrors := []model.ImportRowError{}
+		for field, message := range utils.ParseValidationError(err) {
+			rowErrors = append(rowErrors, model.ImportRowError{Row: row.number, Field: field, Message: message})
+		}
+
+		return rowErrors
+	}
+
+	canonical, err := isbn.Normalize(row.data.ISBN)
+	if err != nil {
+		return []model.ImportRowError{{Row: row.number, Field: "ISBN", Message: err.Error()}}
+	}
+	row.data.ISBN = canonical
+
+	if first, ok := seenISBN[row.data.ISBN]; ok {
+		return []model.ImportRowError{{Row: row.number, Field: "ISBN", Message: fmt.Sprintf("duplicate of row %d", first)}}
+	}
+	seenISBN[row.data.ISBN] = row.number
+
+	_, err = i.bookService.FindByISBN(ctx, row.data.ISBN)
+	if err == nil {
+		return []model.ImportRowError{{Row: row.number, Field: "ISBN", Message: flattenError(errors.Join(controller.ErrDuplicate, errors.New(": isbn")))}}
+	}
+	if !errors.Is(err, controller.ErrNotFound) {
+		return []model.ImportRowError{{Row: row.number, Message: flattenError(err)}}
+	}
+
+	authorID, rowError := i.resolveAuthor(ctx, job, row, authorIDs)
+	if rowError != nil {
+		return []model.ImportRowError{*rowError}
+	}
+
+	if job.DryRun {
+		job.CreatedBooks++
+		return nil
+	}
+
+	edition, rowError := i.buildEdition(ctx, row, publisherIDs)
+	if rowError != nil {
+		return []model.ImportRowError{*rowError}
+	}
+
+	book := &model.Book{
+		ISBN:     row.data.ISBN,
+		Title:    row.data.Title,
+		Subtitle: row.data.Subtitle,
+		AuthorID: authorID,
+	}
+	_, err = i.bookService.CreateWithEdition(ctx, book, edition)
+	if err != nil {
+		return []model.ImportRowError{{Row: row.number, Message: flattenError(err)}}
+	}
+
+	job.CreatedBooks++
+	return nil
+}
+
+// resolveAuthor finds the row's author by id or by name, creating it when
+// a birth date is supplied. In dry-run mode nothing is written and the
+// would-be author is remembered by name for later rows.
+func (i ImportService) resolveAuthor(ctx context.Context, job *model.ImportJob, row importRow, authorIDs map[string]int64) (int64, *model.ImportRowError) {
+	if row.data.AuthorID != 0 {
+		_, err := i.authorRepository.FindByID(ctx, row.data.AuthorID)
+		if err != nil {
+			return 0, &model.ImportRowError{Row: row.number, Field: "AuthorID", Message: flattenError(parseError(err, "author"))}
+		}
+
+		return row.data.AuthorID, nil
+	}
+
+	key := strings.ToLower(strings.TrimSpace(row.data.AuthorName))
+	if authorID, ok := authorIDs[key]; ok {
+		return authorID, nil
+	}
+
+	author, err := i.authorRepository.FindByName(ctx, strings.TrimSpace(row.data.AuthorName))
+	if err == nil {
+		authorIDs[key] = author.ID
+		return author.ID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, &model.ImportRowError{Row: row.number, Field: "AuthorName", Message: flattenError(parseError(err, "author"))}
+	}
+
+	if row.data.AuthorBirthDate == "" {
+		return 0, &model.ImportRowError{Row: row.number, Field: "AuthorBirthDate", Message: "required to create a new author"}
+	}
+
+	birthDate, err := utils.ParseDate(row.data.AuthorBirthDate)
+	if err != nil {
+		return 0, &model.ImportRowError{Row: row.number, Field: "AuthorBirthDate", Message: err.Error()}
+	}
+
+	job.CreatedAuthors++
+	if job.DryRun {
+		authorIDs[key] = 0
+		return 0, nil
+	}
+
+	author, err = i.authorRepository.Create(ctx, &model.Author{
+		Name:      strings.TrimSpace(row.data.AuthorName),
+		BirthDate: *birthDate,
+	})
+	if err != nil {
+		job.CreatedAuthors--
+		return 0, &model.ImportRowError{Row: row.number, Field: "AuthorName", Message: flattenError(parseError(err, "author"))}
+	}
+
+	authorIDs[key] = author.ID
+	return author.ID, nil
+}
+
+// buildEdition describes the edition a row is published as, with its
+// publisher found or created by name. Rows without publisher, date or
+// language leave the book to a bare edition.
+func (i ImportService) buildEdition(ctx context.Context, row importRow, publisherIDs map[string]int64) (*model.Edition, *model.ImportRowError) {
+	if row.data.PublisherName == "" && row.data.PublicationDate == "" && row.data.Language == "" {
+		return nil, nil
+	}
+
+	edition := &model.Edition{
+		Language: row.data.Language,
+	}
+
+	if row.data.PublicationDate != "" {
+		publicationDate, err := utils.ParseDate(row.data.PublicationDate)
+		if err != nil {
+			return nil, &model.ImportRowError{Row: row.number, Field: "PublicationDate", Message: err.Error()}
+		}
+
+		edition.PublicationDate = publicationDate
+	}
+
+	if name := strings.TrimSpace(row.data.PublisherName); name != "" {
+		publisherID, rowError := i.resolvePublisher(ctx, row, name, publisherIDs)
+		if rowError != nil {
+			return nil, rowError
+		}
+
+		edition.PublisherID = &publisherID
+	}
+
+	return edition, nil
+}
+
+// resolvePublisher finds a publisher by name, creating it when there is
+// none, and remembers it for later rows.
+func (i ImportService) resolvePublisher(ctx context.Context, row importRow, name string, publisherIDs map[string]int64) (int64, *model.ImportRowError) {
+	key := strings.ToLower(name)
+	if publisherID, ok := publisherIDs[key]; ok {
+		return publisherID, nil
+	}
+
+	publisher, err := i.publisherRepository.FindByName(ctx, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		publisher, err = i.publisherRepository.Create(ctx, &model.Publisher{Name: name})
+	}
+	if err != nil {
+		return 0, &model.ImportRowError{Row: row.number, Field: "PublisherName", Message: flattenError(parseError(err, "publisher"))}
+	}
+
+	publisherIDs[key] = publisher.ID
+	return publisher.ID, nil
+}
+
+func (i ImportService) save(ctx context.Context, job *model.ImportJob) {
+	_, err := i.importJobRepository.Update(ctx, job)
+	if err != nil {
+		logrus.WithContext(ctx).WithField("jobID", job.ID).Error(err)
+	}
+}
+
+// finish records the outcome even when ctx has been cancelled.
+func (i ImportService) finish(ctx context.Context, job *model.ImportJob, status string) {
+	now := time.Now()
+	job.Status = status
+	job.FinishedAt = &now
+	i.save(context.WithoutCancel(ctx), job)
+}
+
+// parseImportRows numbers rows from 1, not counting the CSV header.
+func parseImportRows(format string, data []byte) ([]importRow, error) {
+	switch format {
+	case model.ImportFormatCSV:
+		return parseCSVRows(data)
+	case model.ImportFormatNDJSON:
+		return parseNDJSONRows(data)
+	case model.ImportFormatMARC:
+		return parseMARCRows(marc.NewReader(bytes.NewReader(data)), true)
+	case model.ImportFormatMARCXML:
+		return parseMARCRows(marc.NewXMLReader(bytes.NewReader(data)), false)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func parseCSVRows(data []byte) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for idx, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = idx
+	}
+
+	for _, required := range []string{"isbn", "title"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv header is missing column %q", required)
+		}
+	}
+
+	value := func(record []string, column string) string {
+		idx, ok := columns[column]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[idx])
+	}
+
+	rows := []importRow{}
+	for number := 1; ; number++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			rows = append(rows, importRow{number: number, err: err})
+			continue
+		}
+
+		row := &dto.ImportRow{
+			ISBN:            value(record, "isbn"),
+			Title:           value(record, "title"),
+			Subtitle:        value(record, "subtitle"),
+			AuthorName:      value(record, "author_name"),
+			AuthorBirthDate: value(record, "author_birth_date"),
+			PublisherName:   value(record, "publisher_name"),
+			PublicationDate: value(record, "publication_date"),
+			Language:        value(record, "language"),
+		}
+
+		var rowErr error
+		if authorID := value(record, "author_id"); authorID != "" {
+			row.AuthorID, rowErr = strconv.ParseInt(authorID, 10, 64)
+		}
+
+		rows = append(rows, importRow{number: number, data: row, err: rowErr})
+	}
+
+	return rows, nil
+}
+
+func parseNDJSONRows(data []byte) ([]importRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	rows := []importRow{}
+	for number := 1; scanner.Scan(); number++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			number--
+			continue
+		}
+
+		row := &dto.ImportRow{}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		err := decoder.Decode(row)
+		rows = append(rows, importRow{number: number, data: row, err: err})
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("read ndjson: %w", err)
+	}
+
+	return rows, nil
+}
+
+type marcReader interface {
+	Read() (*marc.Record, error)
+}
+
+// parseMARCRows maps each record through the MARC crosswalk. A bad binary
+// record only fails its own row, while a MARCXML syntax error ends the
+// document.
+func parseMARCRows(reader marcReader, resumes bool) ([]importRow, error) {
+	rows := []importRow{}
+	for number := 1; ; number++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			rows = append(rows, importRow{number: number, err: err})
+			if !resumes {
+				break
+			}
+
+			continue
+		}
+
+		entry, err := marc.ToEntry(record)
+		if err != nil {
+			rows = append(rows, importRow{number: number, err: err})
+			continue
+		}
+
+		row := &dto.ImportRow{
+			ISBN:     entry.Book.ISBN,
+			Title:    entry.Book.Title,
+			Subtitle: entry.Book.Subtitle,
+		}
+		if entry.Author != nil {
+			row.AuthorName = entry.Author.Name
+			if !entry.Author.BirthDate.IsZero() {
+				row.AuthorBirthDate = entry.Author.BirthDate.Format(time.DateOnly)
+			}
+		}
+
+		if entry.Publisher != nil {
+			row.PublisherName = entry.Publisher.Name
+		}
+
+		if entry.Edition.PublicationDate != nil {
+			row.PublicationDate = entry.Edition.PublicationDate.Format(time.DateOnly)
+		}
+		row.Language = entry.Edition.Language
+
+		rows = append(rows, importRow{number: number, data: row})
+	}
+
+	return rows, nil
+}
+
+func flattenError(err error) string {
+	return strings.ReplaceAll(err.Error(), "\n", "")
+}
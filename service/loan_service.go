@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+type LoanService struct {
+	loanRepository model.LoanRepository
+	copyRepository model.CopyRepository
+	userRepository model.UserRepository
+	holdService    model.HoldService
+	accountService model.AccountService
+	period         time.Duration
+	maxRenewals    int
+}
+
+// NewLoanService lends copies for period and lets each loan be renewed up
+// to maxRenewals times. Copies freed by a checkin or checkout go to the
+// holds of their book through holdService, and accountService fines late
+// returns and keeps readers who owe too much from borrowing.
+func NewLoanService(
+	loanRepository model.LoanRepository,
+	copyRepository model.CopyRepository,
+	userRepository model.UserRepository,
+	holdService model.HoldService,
+	accountService model.AccountService,
+	period time.Duration,
+	maxRenewals int,
+) model.LoanService {
+	return &LoanService{
+		loanRepository: loanRepository,
+		copyRepository: copyRepository,
+		userRepository: userRepository,
+		holdService:    holdService,
+		accountService: accountService,
+		period:         period,
+		maxRenewals:    maxRenewals,
+	}
+}
+
+func (l LoanService) Checkout(ctx context.Context, barcode string, userID int64) (*model.Loan, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"barcode": barcode,
+			"userID":  userID,
+		})
+
+	bookCopy, err := l.copyRepository.FindByBarcode(ctx, barcode)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "copy")
+	}
+
+	_, err = l.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(controller.ErrNotFound, errors.New(": user"))
+		}
+
+		logger.Error(err)
+		return nil, parseError(err, "user")
+	}
+
+	err = l.accountService.CheckStanding(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	now := time.Now()
+	loan := &model.Loan{
+		CopyID:       bookCopy.ID,
+		UserID:       userID,
+		CheckedOutAt: now,
+		DueAt:        now.Add(l.period),
+	}
+	loan, err = l.loanRepository.Checkout(ctx, loan)
+	if err != nil {
+		if errors.Is(err, model.ErrCopyOnLoan) {
+			return nil, errors.Join(controller.ErrConflict, errors.New(": copy is on loan"))
+		}
+
+		if errors.Is(err, model.ErrCopyOnHold) {
+			return nil, errors.Join(controller.ErrConflict, errors.New(": copy is on hold for another reader"))
+		}
+
+		logger.Error(err)
+		return nil, parseError(err, "copy")
+	}
+
+	// The borrower may have had another copy set aside, which is free now.
+	l.allocate(ctx, bookCopy.BookID)
+
+	return loan, nil
+}
+
+func (l LoanService) Checkin(ctx context.Context, barcode string) (*model.Loan, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("barcode", barcode)
+
+	bookCopy, err := l.copyRepository.FindByBarcode(ctx, barcode)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "copy")
+	}
+
+	loan, err := l.loanRepository.Checkin(ctx, bookCopy.ID, time.Now())
+	if err != nil {
+		if errors.Is(err, model.ErrCopyNotOnLoan) {
+			return nil, errors.Join(controller.ErrConflict, errors.New(": copy is not on loan"))
+		}
+
+		logger.Error(err)
+		return nil, parseError(err, "copy")
+	}
+
+	l.chargeOverdue(ctx, loan)
+	l.allocate(ctx, bookCopy.BookID)
+
+	return loan, nil
+}
+
+// Renew moves the due date of an open loan to one loan period from now.
+func (l LoanService) Renew(ctx context.Context, loanID int64) (*model.Loan, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("loanID", loanID)
+
+	loan, err := l.loanRepository.FindByID(ctx, loanID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "loan")
+	}
+
+	if loan.ReturnedAt != nil {
+		return nil, errors.Join(controller.ErrConflict, errors.New(": loan is returned"))
+	}
+
+	if loan.Renewals >= l.maxRenewals {
+		return nil, errors.Join(controller.ErrConflict, errors.New(": renewal limit reached"))
+	}
+
+	err = l.accountService.CheckStanding(ctx, loan.UserID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	// An overdue loan has a fine to settle at checkin, which a new due
+	// date would wipe out.
+	now := time.Now()
+	if now.After(loan.DueAt) {
+		return nil, errors.Join(controller.ErrConflict, errors.New(": loan is overdue"))
+	}
+
+	bookCopy, err := l.copyRepository.FindByID(ctx, loan.CopyID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "copy")
+	}
+
+	availability, err := l.holdService.FindAvailability(ctx, bookCopy.BookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if availability.WaitingHolds > 0 {
+		return nil, errors.Join(controller.ErrConflict, errors.New(": book has waiting holds"))
+	}
+
+	loan.Renewals++
+	loan.DueAt = now.Add(l.period)
+	loan, err = l.loanRepository.Renew(ctx, loan)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "loan")
+	}
+
+	return loan, nil
+}
+
+func (l LoanService) FindByID(ctx context.Context, loanID int64) (*model.Loan, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("loanID", loanID)
+
+	loan, err := l.loanRepository.FindByID(ctx, loanID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "loan")
+	}
+
+	return loan, nil
+}
+
+func (l LoanService) FindAllByUserID(ctx context.Context, userID int64, openOnly bool) ([]*model.Loan, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	loans, err := l.loanRepository.FindAllByUserID(ctx, userID, openOnly)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "loan")
+	}
+
+	return loans, nil
+}
+
+// allocate hands free copies of a book to its holds. The loan is already
+// committed, so a failure is only logged; the hold expiry job picks the
+// copy up on its next run.
+func (l LoanService) allocate(ctx context.Context, bookID int64) {
+	err := l.holdService.Allocate(ctx, bookID)
+	if err != nil {
+		logrus.
+			WithContext(ctx).
+			WithField("bookID", bookID).
+			Error("Failed to allocate copies to holds: ", err)
+	}
+}
+
+// chargeOverdue fines a late return. Like allocate it runs after the loan is
+// committed, so a failure is only logged for staff to charge by hand.
+func (l LoanService) chargeOverdue(ctx context.Context, loan *model.Loan) {
+	_, err := l.accountService.ChargeOverdue(ctx, loan)
+	if err != nil {
+		logrus.
+			WithContext(ctx).
+			WithField("loanID", loan.ID).
+			Error("Failed to charge overdue fine: ", err)
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/isbn"
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+type MetadataService struct {
+	metadataProvider model.MetadataProvider
+	authorRepository model.AuthorRepository
+}
+
+func NewMetadataService(metadataProvider model.MetadataProvider, authorRepository model.AuthorRepository) model.MetadataService {
+	return &MetadataService{
+		metadataProvider: metadataProvider,
+		authorRepository: authorRepository,
+	}
+}
+
+func (m MetadataService) Lookup(ctx context.Context, value string) (*model.BookMetadata, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("isbn", value)
+
+	canonical, err := isbn.Normalize(value)
+	if err != nil {
+		return nil, errors.Join(controller.ErrBadRequest, fmt.Errorf(": isbn %s", err))
+	}
+
+	metadata, err := m.metadataProvider.LookupISBN(ctx, canonical)
+	if err != nil {
+		if errors.Is(err, model.ErrMetadataNotFound) {
+			return nil, errors.Join(controller.ErrNotFound, errors.New(": metadata"))
+		}
+
+		logger.Error(err)
+		if errors.Is(err, model.ErrProviderUnavailable) {
+			return nil, errors.Join(controller.ErrBadGateway, errors.New(": metadata provider"))
+		}
+
+		return nil, controller.ErrInternalServer
+	}
+
+	for i, author := range metadata.Authors {
+		currAuthor, err := m.authorRepository.FindByName(ctx, author.Name)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+
+			logger.WithField("author", author.Name).Error(err)
+			return nil, parseError(err, "author")
+		}
+
+		metadata.Authors[i].AuthorID = currAuthor.ID
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+type PublisherService struct {
+	publisherRepository model.PublisherRepository
+	editionRepository   model.EditionRepository
+}
+
+func NewPublisherService(publisherRepository model.PublisherRepository, editionRepository model.EditionRepository) model.PublisherService {
+	return &PublisherService{
+		publisherRepository: publisherRepository,
+		editionRepository:   editionRepository,
+	}
+}
+
+func (p PublisherService) Create(ctx context.Context, publisher *model.Publisher) (*model.Publisher, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("publisher", utils.Dump(publisher))
+
+	publisher, err := p.publisherRepository.Create(ctx, publisher)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "publisher")
+	}
+
+	return publisher, nil
+}
+
+func (p PublisherService) FindByID(ctx context.Context, publisherID int64) (*model.Publisher, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("publisherID", publisherID)
+
+	publisher, err := p.publisherRepository.FindByID(ctx, publisherID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "publisher")
+	}
+
+	return publisher, nil
+}
+
+func (p PublisherService) FindAll(ctx context.Context) ([]*model.Publisher, error) {
+	logger := logrus.WithContext(ctx)
+
+	publishers, err := p.publisherRepository.FindAll(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "publisher")
+	}
+
+	return publishers, nil
+}
+
+func (p PublisherService) Update(ctx context.Context, publisher *model.Publisher) (*model.Publisher, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("publisher", utils.Dump(publisher))
+
+	publisher, err := p.publisherRepository.Update(ctx, publisher)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "publisher")
+	}
+
+	return publisher, nil
+}
+
+func (p PublisherService) Delete(ctx context.Context, publisherID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("publisherID", publisherID)
+
+	count, err := p.editionRepository.CountByPublisherID(ctx, publisherID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "edition")
+	}
+
+	if count > 0 {
+		return errors.Join(controller.ErrDependency, fmt.Errorf(": publisher has %d editions", count))
+	}
+
+	err = p.publisherRepository.Delete(ctx, publisherID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "publisher")
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+type RecommendationService struct {
+	recommendationRepository model.RecommendationRepository
+	bookRepository           model.BookRepository
+	perBook                  int
+}
+
+// NewRecommendationService keeps the perBook most similar books of every
+// book when refreshing.
+func NewRecommendationService(
+	recommendationRepository model.RecommendationRepository,
+	bookRepository model.BookRepository,
+	perBook int,
+) model.RecommendationService {
+	return &RecommendationService{
+		recommendationRepository: recommendationRepository,
+		bookRepository:           bookRepository,
+		perBook:                  perBook,
+	}
+}
+
+func (r RecommendationService) RecordView(ctx context.Context, userID, bookID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"userID": userID,
+			"bookID": bookID,
+		})
+
+	err := r.recommendationRepository.RecordView(ctx, userID, bookID, time.Now())
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "book")
+	}
+
+	return nil
+}
+
+// FindSimilar lists the books most similar to bookID that userID has not
+// read yet.
+func (r RecommendationService) FindSimilar(ctx context.Context, bookID, userID int64, pagination model.Pagination) ([]*model.Recommendation, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID": bookID,
+			"userID": userID,
+		})
+
+	_, err := r.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	recommendations, err := r.recommendationRepository.FindSimilar(ctx, bookID, userID, pagination)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	return recommendations, nil
+}
+
+func (r RecommendationService) FindForUser(ctx context.Context, userID int64, pagination model.Pagination) ([]*model.Recommendation, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	recommendations, err := r.recommendationRepository.FindForUser(ctx, userID, pagination)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	return recommendations, nil
+}
+
+// Refresh recomputes the similarity table and returns how many pairs it
+// holds.
+func (r RecommendationService) Refresh(ctx context.Context) (int64, error) {
+	logger := logrus.WithContext(ctx)
+
+	stored, err := r.recommendationRepository.Recompute(ctx, r.perBook, time.Now())
+	if err != nil {
+		logger.Error(err)
+		return 0, parseError(err, "book")
+	}
+
+	return stored, nil
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ReviewService struct {
+	reviewRepository model.ReviewRepository
+	bookRepository   model.BookRepository
+}
+
+func NewReviewService(reviewRepository model.ReviewRepository, bookRepository model.BookRepository) model.ReviewService {
+	return &ReviewService{
+		reviewRepository: reviewRepository,
+		bookRepository:   bookRepository,
+	}
+}
+
+// Create records the review of a user for a book. A user reviews a book
+// once and edits that review afterwards.
+func (r ReviewService) Create(ctx context.Context, review *model.Review) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("review", utils.Dump(review))
+
+	err := checkRating(review.Rating)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.bookRepository.FindByID(ctx, review.BookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	_, err = r.reviewRepository.FindByBookAndUser(ctx, review.BookID, review.UserID)
+	if err == nil {
+		return nil, errors.Join(controller.ErrDuplicate, errors.New(": review"))
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	review.Hidden = false
+	review.HiddenReason = ""
+	review, err = r.reviewRepository.Create(ctx, review)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	return review, nil
+}
+
+func (r ReviewService) FindByID(ctx context.Context, reviewID int64) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("reviewID", reviewID)
+
+	review, err := r.reviewRepository.FindByID(ctx, reviewID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	return review, nil
+}
+
+func (r ReviewService) FindAllByBookID(
+	ctx context.Context,
+	bookID int64,
+	sort string,
+	includeHidden bool,
+	pagination model.Pagination,
+) (*model.ReviewPage, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID": bookID,
+			"sort":   sort,
+		})
+
+	switch sort {
+	case "":
+		sort = model.ReviewSortHelpful
+	case model.ReviewSortHelpful, model.ReviewSortNewest, model.ReviewSortHighest, model.ReviewSortLowest:
+	default:
+		return nil, errors.Join(controller.ErrBadRequest, errors.New(": sort"))
+	}
+
+	_, err := r.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	reviews, total, err := r.reviewRepository.FindAllByBookID(ctx, bookID, sort, includeHidden, pagination)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	return &model.ReviewPage{
+		Items:   reviews,
+		Page:    pagination.Page,
+		PerPage: pagination.PerPage,
+		Total:   total,
+	}, nil
+}
+
+// Update changes the rating and text of a review. The book and author of
+// a review never change.
+func (r ReviewService) Update(ctx context.Context, review *model.Review) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("review", utils.Dump(review))
+
+	err := checkRating(review.Rating)
+	if err != nil {
+		return nil, err
+	}
+
+	currReview, err := r.reviewRepository.FindByID(ctx, review.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	if currReview.Version != review.Version {
+		return nil, errors.Join(controller.ErrPreconditionFailed, errors.New(": review"))
+	}
+
+	review, err = r.reviewRepository.Update(ctx, review)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	return review, nil
+}
+
+func (r ReviewService) Delete(ctx context.Context, reviewID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("reviewID", reviewID)
+
+	err := r.reviewRepository.Delete(ctx, reviewID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "review")
+	}
+
+	return nil
+}
+
+func (r ReviewService) Hide(ctx context.Context, reviewID int64, reason string) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("reviewID", reviewID)
+
+	review, err := r.reviewRepository.SetHidden(ctx, reviewID, true, reason)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	return review, nil
+}
+
+func (r ReviewService) Unhide(ctx context.Context, reviewID int64) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("reviewID", reviewID)
+
+	review, err := r.reviewRepository.SetHidden(ctx, reviewID, false, "")
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	return review, nil
+}
+
+// Vote marks a review as helpful. Readers cannot vote for their own
+// reviews or for hidden ones.
+func (r ReviewService) Vote(ctx context.Context, reviewID, userID int64) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"reviewID": reviewID,
+			"userID":   userID,
+		})
+
+	review, err := r.reviewRepository.FindByID(ctx, reviewID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	if review.Hidden {
+		return nil, errors.Join(controller.ErrNotFound, errors.New(": review"))
+	}
+
+	if review.UserID == userID {
+		return nil, errors.Join(controller.ErrForbidden, errors.New(": own review"))
+	}
+
+	err = r.reviewRepository.AddVote(ctx, reviewID, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	return r.FindByID(ctx, reviewID)
+}
+
+func (r ReviewService) Unvote(ctx context.Context, reviewID, userID int64) (*model.Review, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"reviewID": reviewID,
+			"userID":   userID,
+		})
+
+	err := r.reviewRepository.RemoveVote(ctx, reviewID, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "review")
+	}
+
+	return r.FindByID(ctx, reviewID)
+}
+
+func checkRating(rating int) error {
+	if rating < 1 || rating > 5 {
+		return errors.Join(controller.ErrBadRequest, errors.New(": rating must be between 1 and 5"))
+	}
+
+	return nil
+}
@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"gorm.io/gorm"
+
 	"github.com/rhtyx/bayarind-service.git/config"
 	"github.com/rhtyx/bayarind-service.git/controller"
 	"github.com/rhtyx/bayarind-service.git/model"
@@ -74,6 +77,30 @@ func (s SessionService) Create(ctx context.Context, username, password string) (
 	return session, nil
 }
 
+// Authenticate checks a username and password without opening a session,
+// for clients that send their credentials with every request.
+func (s SessionService) Authenticate(ctx context.Context, username, password string) (*model.User, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("username", username)
+
+	user, err := s.userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, controller.ErrCredentials
+		}
+
+		logger.Error(err)
+		return nil, parseError(err, "username")
+	}
+
+	if !utils.IsPasswordCorrect(password, user.Password) {
+		return nil, controller.ErrCredentials
+	}
+
+	return user, nil
+}
+
 func (s SessionService) FindByRefreshToken(ctx context.Context, refreshToken string) (*model.Session, error) {
 	logger := logrus.
 		WithContext(ctx).
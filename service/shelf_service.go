@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// shareTokenBytes is the entropy of shelf share tokens.
+const shareTokenBytes = 18
+
+type ShelfService struct {
+	shelfRepository model.ShelfRepository
+	bookRepository  model.BookRepository
+	userRepository  model.UserRepository
+}
+
+func NewShelfService(shelfRepository model.ShelfRepository, bookRepository model.BookRepository, userRepository model.UserRepository) model.ShelfService {
+	return &ShelfService{
+		shelfRepository: shelfRepository,
+		bookRepository:  bookRepository,
+		userRepository:  userRepository,
+	}
+}
+
+// Create makes a custom shelf for its user.
+func (s ShelfService) Create(ctx context.Context, shelf *model.Shelf) (*model.Shelf, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("shelf", utils.Dump(shelf))
+
+	shelf.Kind = model.ShelfKindCustom
+	err := s.prepare(ctx, shelf, nil)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	shelf, err = s.shelfRepository.Create(ctx, shelf)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	return shelf, nil
+}
+
+func (s ShelfService) FindByID(ctx context.Context, shelfID int64) (*model.Shelf, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("shelfID", shelfID)
+
+	shelf, err := s.shelfRepository.FindByID(ctx, shelfID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	return shelf, nil
+}
+
+// FindAllByUserID returns every shelf of a user, creating the built-in
+// shelves on first use.
+func (s ShelfService) FindAllByUserID(ctx context.Context, userID int64) ([]*model.Shelf, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	err := s.shelfRepository.EnsureBuiltins(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	shelves, err := s.shelfRepository.FindAllByUserID(ctx, userID, false)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	return shelves, nil
+}
+
+func (s ShelfService) FindPublicByUserID(ctx context.Context, userID int64) ([]*model.Shelf, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	shelves, err := s.shelfRepository.FindAllByUserID(ctx, userID, true)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	return shelves, nil
+}
+
+// Update renames a shelf and changes its privacy. Built-in shelves keep
+// their names.
+func (s ShelfService) Update(ctx context.Context, shelf *model.Shelf) (*model.Shelf, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("shelf", utils.Dump(shelf))
+
+	currShelf, err := s.shelfRepository.FindByID(ctx, shelf.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	if currShelf.Version != shelf.Version {
+		return nil, errors.Join(controller.ErrPreconditionFailed, errors.New(": shelf"))
+	}
+
+	if currShelf.Kind != model.ShelfKindCustom && strings.TrimSpace(shelf.Name) != currShelf.Name {
+		return nil, errors.Join(controller.ErrBadRequest, errors.New(": built-in shelves cannot be renamed"))
+	}
+
+	shelf.UserID = currShelf.UserID
+	shelf.Kind = currShelf.Kind
+	err = s.prepare(ctx, shelf, currShelf)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	shelf, err = s.shelfRepository.Update(ctx, shelf)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	return shelf, nil
+}
+
+func (s ShelfService) Delete(ctx context.Context, shelfID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("shelfID", shelfID)
+
+	shelf, err := s.shelfRepository.FindByID(ctx, shelfID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "shelf")
+	}
+
+	if shelf.Kind != model.ShelfKindCustom {
+		return errors.Join(controller.ErrBadRequest, errors.New(": built-in shelves cannot be deleted"))
+	}
+
+	err = s.shelfRepository.Delete(ctx, shelfID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "shelf")
+	}
+
+	return nil
+}
+
+func (s ShelfService) FindEntries(ctx context.Context, shelfID int64) ([]*model.ShelfEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("shelfID", shelfID)
+
+	entries, err := s.shelfRepository.FindEntries(ctx, shelfID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	return entries, nil
+}
+
+func (s ShelfService) AddEntry(ctx context.Context, entry *model.ShelfEntry) (*model.ShelfEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("entry", utils.Dump(entry))
+
+	_, err := s.bookRepository.FindByID(ctx, entry.BookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(controller.ErrNotFound, errors.New(": book"))
+		}
+
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	_, err = s.shelfRepository.FindEntry(ctx, entry.ShelfID, entry.BookID)
+	if err == nil {
+		return nil, errors.Join(controller.ErrDuplicate, errors.New(": book is on shelf"))
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	entry.AddedAt = time.Now()
+	entry, err = s.shelfRepository.AddEntry(ctx, entry)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	return entry, nil
+}
+
+func (s ShelfService) MoveEntry(ctx context.Context, entry *model.ShelfEntry) (*model.ShelfEntry, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("entry", utils.Dump(entry))
+
+	entry, err := s.shelfRepository.MoveEntry(ctx, entry)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	return entry, nil
+}
+
+func (s ShelfService) RemoveEntry(ctx context.Context, shelfID, bookID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"shelfID": shelfID,
+			"bookID":  bookID,
+		})
+
+	err := s.shelfRepository.RemoveEntry(ctx, shelfID, bookID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "book")
+	}
+
+	return nil
+}
+
+// FindShared returns the shelf behind a share token. Revoking the link,
+// by making the shelf private, makes the token unknown, and so does moving
+// its owner to the trash until they are restored.
+func (s ShelfService) FindShared(ctx context.Context, shareToken string) (*model.SharedShelf, error) {
+	logger := logrus.WithContext(ctx)
+
+	shelf, err := s.shelfRepository.FindByShareToken(ctx, shareToken)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	_, err = s.userRepository.FindByID(ctx, shelf.UserID)
+	if err != nil {
+		logger.WithField("userID", shelf.UserID).Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	entries, err := s.shelfRepository.FindEntries(ctx, shelf.ID)
+	if err != nil {
+		logger.WithField("shelfID", shelf.ID).Error(err)
+		return nil, parseError(err, "shelf")
+	}
+
+	return &model.SharedShelf{
+		Name:    shelf.Name,
+		Kind:    shelf.Kind,
+		Entries: entries,
+	}, nil
+}
+
+// prepare normalizes the name and privacy of shelf, checks the name is
+// free among the shelves of its user and hands out or drops its share
+// token. currShelf is nil for new shelves.
+func (s ShelfService) prepare(ctx context.Context, shelf *model.Shelf, currShelf *model.Shelf) error {
+	shelf.Name = strings.TrimSpace(shelf.Name)
+	if shelf.Name == "" {
+		return errors.Join(controller.ErrBadRequest, errors.New(": name is empty"))
+	}
+
+	if shelf.Privacy == "" {
+		shelf.Privacy = model.ShelfPrivacyPrivate
+	}
+
+	same, err := s.shelfRepository.FindByName(ctx, shelf.UserID, shelf.Name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return parseError(err, "shelf")
+	}
+
+	if err == nil && (currShelf == nil || same.ID != currShelf.ID) {
+		return errors.Join(controller.ErrDuplicate, errors.New(": shelf"))
+	}
+
+	switch {
+	case shelf.Privacy != model.ShelfPrivacyLink:
+		shelf.ShareToken = nil
+	case currShelf != nil && currShelf.ShareToken != nil:
+		shelf.ShareToken = currShelf.ShareToken
+	default:
+		shareToken, err := utils.RandomToken(shareTokenBytes)
+		if err != nil {
+			logrus.WithContext(ctx).Error(err)
+			return controller.ErrInternalServer
+		}
+
+		shelf.ShareToken = &shareToken
+	}
+
+	return nil
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SubjectService struct {
+	subjectRepository model.SubjectRepository
+	bookRepository    model.BookRepository
+}
+
+func NewSubjectService(subjectRepository model.SubjectRepository, bookRepository model.BookRepository) model.SubjectService {
+	return &SubjectService{
+		subjectRepository: subjectRepository,
+		bookRepository:    bookRepository,
+	}
+}
+
+func (s SubjectService) Create(ctx context.Context, subject *model.Subject) (*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subject", utils.Dump(subject))
+
+	subject.Translations = canonicalTranslations(subject.Translations)
+	if subject.ParentID != nil {
+		_, err := s.subjectRepository.FindByID(ctx, *subject.ParentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.Join(controller.ErrNotFound, errors.New(": parent_id"))
+			}
+
+			logger.Error(err)
+			return nil, parseError(err, "subject")
+		}
+	}
+
+	err := s.checkSibling(ctx, subject)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	subject, err = s.subjectRepository.Create(ctx, subject)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	return subject, nil
+}
+
+func (s SubjectService) FindByID(ctx context.Context, subjectID int64) (*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subjectID", subjectID)
+
+	subject, err := s.subjectRepository.FindByID(ctx, subjectID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	return subject, nil
+}
+
+func (s SubjectService) FindTree(ctx context.Context) ([]*model.SubjectNode, error) {
+	logger := logrus.WithContext(ctx)
+
+	roots, _, err := s.buildTree(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	return roots, nil
+}
+
+func (s SubjectService) FindSubtree(ctx context.Context, subjectID int64) (*model.SubjectNode, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subjectID", subjectID)
+
+	_, nodes, err := s.buildTree(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	node, ok := nodes[subjectID]
+	if !ok {
+		return nil, errors.Join(controller.ErrNotFound, errors.New(": subject"))
+	}
+
+	return node, nil
+}
+
+// FindBooks returns the books filed under the subject or any subject below
+// it.
+func (s SubjectService) FindBooks(ctx context.Context, subjectID int64) ([]*model.Book, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subjectID", subjectID)
+
+	subjectIDs, err := s.subjectRepository.FindDescendantIDs(ctx, subjectID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	books, err := s.bookRepository.FindAllBySubjectIDs(ctx, subjectIDs)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	return books, nil
+}
+
+func (s SubjectService) FindByBookID(ctx context.Context, bookID int64) ([]*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	_, err := s.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	subjects, err := s.subjectRepository.FindByBookID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	return subjects, nil
+}
+
+func (s SubjectService) SetBookSubjects(ctx context.Context, bookID int64, subjectIDs []int64) ([]*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID":     bookID,
+			"subjectIDs": subjectIDs,
+		})
+
+	_, err := s.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	subjectIDs = slices.Clone(subjectIDs)
+	slices.Sort(subjectIDs)
+	subjectIDs = slices.Compact(subjectIDs)
+	for _, subjectID := range subjectIDs {
+		_, err = s.subjectRepository.FindByID(ctx, subjectID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.Join(controller.ErrNotFound, fmt.Errorf(": subject %d", subjectID))
+			}
+
+			logger.Error(err)
+			return nil, parseError(err, "subject")
+		}
+	}
+
+	err = s.subjectRepository.ReplaceBookSubjects(ctx, bookID, subjectIDs)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	subjects, err := s.subjectRepository.FindByBookID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	return subjects, nil
+}
+
+func (s SubjectService) Update(ctx context.Context, subject *model.Subject) (*model.Subject, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subject", utils.Dump(subject))
+
+	subject.Translations = canonicalTranslations(subject.Translations)
+	currSubject, err := s.subjectRepository.FindByID(ctx, subject.ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	if currSubject.Version != subject.Version {
+		return nil, errors.Join(controller.ErrPreconditionFailed, errors.New(": subject"))
+	}
+
+	if subject.ParentID != nil {
+		descendantIDs, err := s.subjectRepository.FindDescendantIDs(ctx, subject.ID)
+		if err != nil {
+			logger.Error(err)
+			return nil, parseError(err, "subject")
+		}
+
+		if slices.Contains(descendantIDs, *subject.ParentID) {
+			return nil, errors.Join(controller.ErrBadRequest, errors.New(": parent_id would create a cycle"))
+		}
+
+		_, err = s.subjectRepository.FindByID(ctx, *subject.ParentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.Join(controller.ErrNotFound, errors.New(": parent_id"))
+			}
+
+			logger.Error(err)
+			return nil, parseError(err, "subject")
+		}
+	}
+
+	err = s.checkSibling(ctx, subject)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	subject, err = s.subjectRepository.Update(ctx, subject)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "subject")
+	}
+
+	return subject, nil
+}
+
+func (s SubjectService) Delete(ctx context.Context, subjectID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("subjectID", subjectID)
+
+	count, err := s.subjectRepository.CountChildren(ctx, subjectID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "subject")
+	}
+
+	if count > 0 {
+		return errors.Join(controller.ErrDependency, fmt.Errorf(": subject has %d children", count))
+	}
+
+	err = s.subjectRepository.Delete(ctx, subjectID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "subject")
+	}
+
+	return nil
+}
+
+// checkSibling rejects a subject whose name is already taken by another
+// subject under the same parent.
+func (s SubjectService) checkSibling(ctx context.Context, subject *model.Subject) error {
+	sibling, err := s.subjectRepository.FindByName(ctx, subject.ParentID, subject.Name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return parseError(err, "subject")
+	}
+
+	if sibling.ID != subject.ID {
+		return errors.Join(controller.ErrDuplicate, errors.New(": subject"))
+	}
+
+	return nil
+}
+
+// buildTree loads every subject with its book count and links them into a
+// forest. It returns the roots and every node by id.
+func (s SubjectService) buildTree(ctx context.Context) ([]*model.SubjectNode, map[int64]*model.SubjectNode, error) {
+	subjects, err := s.subjectRepository.FindAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counts, err := s.subjectRepository.CountBooks(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodes := make(map[int64]*model.SubjectNode, len(subjects))
+	for _, subject := range subjects {
+		nodes[subject.ID] = &model.SubjectNode{
+			Subject:   *subject,
+			BookCount: counts[subject.ID],
+			Children:  []*model.SubjectNode{},
+		}
+	}
+
+	roots := []*model.SubjectNode{}
+	for _, subject := range subjects {
+		node := nodes[subject.ID]
+		if subject.ParentID != nil {
+			parent, ok := nodes[*subject.ParentID]
+			if ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+
+		roots = append(roots, node)
+	}
+
+	return roots, nodes, nil
+}
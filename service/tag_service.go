@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxTagLength bounds tag names, counted in runes after normalization.
+const maxTagLength = 64
+
+type TagService struct {
+	tagRepository  model.TagRepository
+	bookRepository model.BookRepository
+}
+
+func NewTagService(tagRepository model.TagRepository, bookRepository model.BookRepository) model.TagService {
+	return &TagService{
+		tagRepository:  tagRepository,
+		bookRepository: bookRepository,
+	}
+}
+
+func (t TagService) FindAll(ctx context.Context) ([]*model.Tag, error) {
+	logger := logrus.WithContext(ctx)
+
+	tags, err := t.tagRepository.FindAll(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "tag")
+	}
+
+	return tags, nil
+}
+
+func (t TagService) FindBooks(ctx context.Context, name string) ([]*model.Book, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("tag", name)
+
+	name, err := normalizeTag(name)
+	if err != nil {
+		return nil, err
+	}
+
+	books, err := t.bookRepository.FindAllByTag(ctx, name)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	return books, nil
+}
+
+func (t TagService) FindByBookID(ctx context.Context, bookID int64) ([]*model.Tag, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("bookID", bookID)
+
+	_, err := t.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	tags, err := t.tagRepository.FindByBookID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "tag")
+	}
+
+	return tags, nil
+}
+
+func (t TagService) SetBookTags(ctx context.Context, bookID int64, names []string) ([]*model.Tag, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"bookID": bookID,
+			"names":  names,
+		})
+
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name, err := normalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+
+		normalized = append(normalized, name)
+	}
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+
+	_, err := t.bookRepository.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "book")
+	}
+
+	err = t.tagRepository.ReplaceBookTags(ctx, bookID, normalized)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "tag")
+	}
+
+	tags, err := t.tagRepository.FindByBookID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "tag")
+	}
+
+	return tags, nil
+}
+
+func (t TagService) Delete(ctx context.Context, tagID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("tagID", tagID)
+
+	err := t.tagRepository.Delete(ctx, tagID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "tag")
+	}
+
+	return nil
+}
+
+// normalizeTag lower-cases name and collapses its whitespace.
+func normalizeTag(name string) (string, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if name == "" {
+		return "", errors.Join(controller.ErrBadRequest, errors.New(": tag is empty"))
+	}
+
+	if len([]rune(name)) > maxTagLength {
+		return "", errors.Join(controller.ErrBadRequest, fmt.Errorf(": tag longer than %d characters", maxTagLength))
+	}
+
+	return name, nil
+}
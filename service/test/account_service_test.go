@@ -0,0 +1,193 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var finePolicy = model.FinePolicy{PerDay: 25, MaxOverdue: 1000, LostItem: 2500}
+
+const blockThreshold = 1000
+
+func TestFinePolicyOverdue(t *testing.T) {
+	dueAt := time.Now()
+
+	assert.Equal(t, int64(0), finePolicy.Overdue(dueAt, dueAt.Add(-time.Hour)))
+	assert.Equal(t, int64(25), finePolicy.Overdue(dueAt, dueAt.Add(time.Minute)))
+	assert.Equal(t, int64(75), finePolicy.Overdue(dueAt, dueAt.Add(3*24*time.Hour)))
+	assert.Equal(t, int64(1000), finePolicy.Overdue(dueAt, dueAt.Add(365*24*time.Hour)))
+}
+
+func TestAccountChargeOverdue(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		returnedAt := time.Now()
+		loan := &model.Loan{
+			ID:         utils.GenerateID(),
+			UserID:     utils.GenerateID(),
+			DueAt:      returnedAt.Add(-2 * 24 * time.Hour),
+			ReturnedAt: &returnedAt,
+		}
+
+		ledgerRepository := mock.NewMockLedgerRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+
+		ledgerRepository.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, entry *model.LedgerEntry) (*model.LedgerEntry, error) {
+				return entry, nil
+			})
+
+		accountService := service.NewAccountService(ledgerRepository, userRepository, loanRepository, finePolicy, "USD", blockThreshold)
+		entry, err := accountService.ChargeOverdue(ctx, loan)
+		assert.Nil(t, err)
+		assert.Equal(t, model.EntryKindCharge, entry.Kind)
+		assert.Equal(t, model.ChargeReasonOverdue, entry.Reason)
+		assert.Equal(t, int64(50), entry.Amount)
+		assert.Equal(t, loan.ID, *entry.LoanID)
+	})
+
+	t.Run("ok: returned in time", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		returnedAt := time.Now()
+		loan := &model.Loan{
+			ID:         utils.GenerateID(),
+			DueAt:      returnedAt.Add(time.Hour),
+			ReturnedAt: &returnedAt,
+		}
+
+		ledgerRepository := mock.NewMockLedgerRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+
+		accountService := service.NewAccountService(ledgerRepository, userRepository, loanRepository, finePolicy, "USD", blockThreshold)
+		entry, err := accountService.ChargeOverdue(ctx, loan)
+		assert.Nil(t, err)
+		assert.Nil(t, entry)
+	})
+}
+
+func TestAccountCharge(t *testing.T) {
+	t.Run("ok: lost item at policy fee", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		userID := utils.GenerateID()
+
+		ledgerRepository := mock.NewMockLedgerRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+
+		ledgerRepository.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, entry *model.LedgerEntry) (*model.LedgerEntry, error) {
+				return entry, nil
+			})
+
+		accountService := service.NewAccountService(ledgerRepository, userRepository, loanRepository, finePolicy, "USD", blockThreshold)
+		entry, err := accountService.Charge(ctx, &model.LedgerEntry{UserID: userID, Reason: model.ChargeReasonLost})
+		assert.Nil(t, err)
+		assert.Equal(t, model.EntryKindCharge, entry.Kind)
+		assert.Equal(t, finePolicy.LostItem, entry.Amount)
+	})
+
+	t.Run("error: loan belongs to another user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		loanID := utils.GenerateID()
+
+		ledgerRepository := mock.NewMockLedgerRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+
+		loanRepository.EXPECT().
+			FindByID(ctx, loanID).
+			Times(1).
+			Return(&model.Loan{ID: loanID, UserID: utils.GenerateID()}, nil)
+
+		accountService := service.NewAccountService(ledgerRepository, userRepository, loanRepository, finePolicy, "USD", blockThreshold)
+		entry, err := accountService.Charge(ctx, &model.LedgerEntry{
+			UserID: utils.GenerateID(),
+			Reason: model.ChargeReasonDamage,
+			Amount: 500,
+			LoanID: &loanID,
+		})
+		assert.Nil(t, entry)
+		assert.EqualError(t, err, "bad request\n: loan belongs to another user")
+	})
+}
+
+func TestAccountCredit(t *testing.T) {
+	t.Run("error: amount exceeds balance", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		ledgerRepository := mock.NewMockLedgerRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+
+		ledgerRepository.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			Return(nil, model.ErrExceedsBalance)
+
+		accountService := service.NewAccountService(ledgerRepository, userRepository, loanRepository, finePolicy, "USD", blockThreshold)
+		entry, err := accountService.Credit(ctx, &model.LedgerEntry{
+			UserID: utils.GenerateID(),
+			Kind:   model.EntryKindPayment,
+			Amount: 500,
+		})
+		assert.Nil(t, entry)
+		assert.EqualError(t, err, "conflict\n: amount exceeds balance")
+	})
+}
+
+func TestAccountStanding(t *testing.T) {
+	t.Run("ok: blocked above threshold", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		userID := utils.GenerateID()
+
+		ledgerRepository := mock.NewMockLedgerRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+
+		userRepository.EXPECT().
+			FindByID(ctx, userID).
+			Times(1).
+			Return(&model.User{ID: userID}, nil)
+
+		ledgerRepository.EXPECT().
+			Balance(ctx, userID).
+			Times(2).
+			Return(int64(blockThreshold+1), nil)
+
+		accountService := service.NewAccountService(ledgerRepository, userRepository, loanRepository, finePolicy, "USD", blockThreshold)
+		account, err := accountService.FindStanding(ctx, userID)
+		assert.Nil(t, err)
+		assert.True(t, account.Blocked)
+		assert.Equal(t, "USD", account.Currency)
+
+		err = accountService.CheckStanding(ctx, userID)
+		assert.EqualError(t, err, "forbidden\n: account balance exceeds limit")
+	})
+}
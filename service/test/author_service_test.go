@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
@@ -28,12 +29,14 @@ func TestAuthorCreate(t *testing.T) {
 		}
 
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
 		authorRepository.EXPECT().
 			Create(ctx, author).
 			Times(1).
 			Return(author, nil)
 
-		authorService := service.NewAuthorService(authorRepository)
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
 		resAuthor, err := authorService.Create(ctx, author)
 		assert.Nil(t, err)
 		assert.NotNil(t, resAuthor)
@@ -51,12 +54,14 @@ func TestAuthorCreate(t *testing.T) {
 		}
 
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
 		authorRepository.EXPECT().
 			Create(ctx, author).
 			Times(1).
 			Return(nil, gorm.ErrDuplicatedKey)
 
-		authorService := service.NewAuthorService(authorRepository)
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
 		resAuthor, err := authorService.Create(ctx, author)
 		assert.Nil(t, resAuthor)
 		assert.Error(t, err)
@@ -76,12 +81,14 @@ func TestAuthorFindByID(t *testing.T) {
 		}
 
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
 		authorRepository.EXPECT().
 			FindByID(ctx, author.ID).
 			Times(1).
 			Return(author, nil)
 
-		authorService := service.NewAuthorService(authorRepository)
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
 		resAuthor, err := authorService.FindByID(ctx, author.ID)
 		assert.Nil(t, err)
 		assert.NotNil(t, resAuthor)
@@ -99,12 +106,14 @@ func TestAuthorFindByID(t *testing.T) {
 		}
 
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
 		authorRepository.EXPECT().
 			FindByID(ctx, author.ID).
 			Times(1).
 			Return(nil, gorm.ErrRecordNotFound)
 
-		authorService := service.NewAuthorService(authorRepository)
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
 		resAuthor, err := authorService.FindByID(ctx, author.ID)
 		assert.Nil(t, resAuthor)
 		assert.Error(t, err)
@@ -131,12 +140,14 @@ func TestAuthorFindAll(t *testing.T) {
 		}
 
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
 		authorRepository.EXPECT().
 			FindAll(ctx).
 			Times(1).
 			Return(author, nil)
 
-		authorService := service.NewAuthorService(authorRepository)
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
 		resAuthor, err := authorService.FindAll(ctx)
 		assert.Nil(t, err)
 		assert.NotNil(t, resAuthor)
@@ -149,12 +160,14 @@ func TestAuthorFindAll(t *testing.T) {
 		ctx := context.TODO()
 
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
 		authorRepository.EXPECT().
 			FindAll(ctx).
 			Times(1).
 			Return(nil, gorm.ErrInvalidDB)
 
-		authorService := service.NewAuthorService(authorRepository)
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
 		resAuthor, err := authorService.FindAll(ctx)
 		assert.Nil(t, resAuthor)
 		assert.Error(t, err)
@@ -174,12 +187,14 @@ func TestAuthorUpdate(t *testing.T) {
 		}
 
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
 		authorRepository.EXPECT().
 			Update(ctx, author).
 			Times(1).
 			Return(author, nil)
 
-		authorService := service.NewAuthorService(authorRepository)
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
 		resAuthor, err := authorService.Update(ctx, author)
 		assert.Nil(t, err)
 		assert.NotNil(t, resAuthor)
@@ -197,17 +212,45 @@ func TestAuthorUpdate(t *testing.T) {
 		}
 
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
 		authorRepository.EXPECT().
 			Update(ctx, author).
 			Times(1).
 			Return(nil, gorm.ErrRecordNotFound)
 
-		authorService := service.NewAuthorService(authorRepository)
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
 		resAuthor, err := authorService.Update(ctx, author)
 		assert.Nil(t, resAuthor)
 		assert.Error(t, err)
 		assert.EqualError(t, err, "id not found\n: author")
 	})
+
+	t.Run("error: stale version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		author := &model.Author{
+			ID:        utils.GenerateID(),
+			Name:      gofakeit.Name(),
+			BirthDate: gofakeit.Date(),
+			Version:   1,
+		}
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			Update(ctx, author).
+			Times(1).
+			Return(nil, model.ErrStaleVersion)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		resAuthor, err := authorService.Update(ctx, author)
+		assert.Nil(t, resAuthor)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "precondition failed\n: author")
+	})
 }
 
 func TestAuthorDelete(t *testing.T) {
@@ -218,16 +261,64 @@ func TestAuthorDelete(t *testing.T) {
 		authorID := utils.GenerateID()
 
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			CountByAuthorID(ctx, authorID).
+			Times(1).
+			Return(int64(0), nil)
+
 		authorRepository.EXPECT().
 			Delete(ctx, authorID).
 			Times(1).
 			Return(nil)
 
-		authorService := service.NewAuthorService(authorRepository)
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
 		err := authorService.Delete(ctx, authorID)
 		assert.Nil(t, err)
 	})
 
+	t.Run("error: count books", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			CountByAuthorID(ctx, authorID).
+			Times(1).
+			Return(int64(0), gorm.ErrInvalidDB)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		err := authorService.Delete(ctx, authorID)
+		assert.Error(t, err)
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+	})
+
+	t.Run("error: author has books", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			CountByAuthorID(ctx, authorID).
+			Times(1).
+			Return(int64(3), nil)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		err := authorService.Delete(ctx, authorID)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, controller.ErrDependency)
+		assert.EqualError(t, err, "dependent entries exist\n: author has 3 books")
+	})
+
 	t.Run("error: id not found", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
@@ -235,14 +326,470 @@ func TestAuthorDelete(t *testing.T) {
 		authorID := utils.GenerateID()
 
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			CountByAuthorID(ctx, authorID).
+			Times(1).
+			Return(int64(0), nil)
+
 		authorRepository.EXPECT().
 			Delete(ctx, authorID).
 			Times(1).
 			Return(gorm.ErrRecordNotFound)
 
-		authorService := service.NewAuthorService(authorRepository)
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
 		err := authorService.Delete(ctx, authorID)
 		assert.Error(t, err)
 		assert.EqualError(t, err, "id not found\n: author")
 	})
 }
+
+func TestAuthorDeleteCascade(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			DeleteWithBooks(ctx, authorID).
+			Times(1).
+			Return(nil)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		err := authorService.DeleteCascade(ctx, authorID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("error: delete with books", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			DeleteWithBooks(ctx, authorID).
+			Times(1).
+			Return(gorm.ErrInvalidDB)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		err := authorService.DeleteCascade(ctx, authorID)
+		assert.Error(t, err)
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+	})
+
+	t.Run("error: author not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			DeleteWithBooks(ctx, authorID).
+			Times(1).
+			Return(gorm.ErrRecordNotFound)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		err := authorService.DeleteCascade(ctx, authorID)
+		assert.ErrorIs(t, err, controller.ErrNotFound)
+		assert.EqualError(t, err, "id not found\n: author")
+	})
+}
+
+func TestAuthorDeleteAndReassign(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+		target := &model.Author{
+			ID:        utils.GenerateID(),
+			Name:      gofakeit.Name(),
+			BirthDate: gofakeit.Date(),
+		}
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, target.ID).
+			Times(1).
+			Return(target, nil)
+
+		authorRepository.EXPECT().
+			DeleteAndReassignBooks(ctx, authorID, target.ID).
+			Times(1).
+			Return(nil)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		err := authorService.DeleteAndReassign(ctx, authorID, target.ID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("error: same author", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		err := authorService.DeleteAndReassign(ctx, authorID, authorID)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, controller.ErrBadRequest)
+	})
+
+	t.Run("error: target not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+		targetAuthorID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, targetAuthorID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		err := authorService.DeleteAndReassign(ctx, authorID, targetAuthorID)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "id not found\n: reassign_to")
+	})
+
+	t.Run("error: reassign books", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+		target := &model.Author{
+			ID:        utils.GenerateID(),
+			Name:      gofakeit.Name(),
+			BirthDate: gofakeit.Date(),
+		}
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, target.ID).
+			Times(1).
+			Return(target, nil)
+
+		authorRepository.EXPECT().
+			DeleteAndReassignBooks(ctx, authorID, target.ID).
+			Times(1).
+			Return(gorm.ErrInvalidDB)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		err := authorService.DeleteAndReassign(ctx, authorID, target.ID)
+		assert.Error(t, err)
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+	})
+
+	t.Run("error: author not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+		target := &model.Author{
+			ID:        utils.GenerateID(),
+			Name:      gofakeit.Name(),
+			BirthDate: gofakeit.Date(),
+		}
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, target.ID).
+			Times(1).
+			Return(target, nil)
+
+		authorRepository.EXPECT().
+			DeleteAndReassignBooks(ctx, authorID, target.ID).
+			Times(1).
+			Return(gorm.ErrRecordNotFound)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		err := authorService.DeleteAndReassign(ctx, authorID, target.ID)
+		assert.ErrorIs(t, err, controller.ErrNotFound)
+		assert.EqualError(t, err, "id not found\n: author")
+	})
+}
+
+func TestAuthorRestore(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		author := &model.Author{
+			ID:        utils.GenerateID(),
+			Name:      gofakeit.Name(),
+			BirthDate: gofakeit.Date(),
+		}
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			Restore(ctx, author.ID).
+			Times(1).
+			Return(nil)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, author.ID).
+			Times(1).
+			Return(author, nil)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		resAuthor, err := authorService.Restore(ctx, author.ID)
+		assert.Nil(t, err)
+		assert.NotNil(t, resAuthor)
+		assert.ObjectsAreEqualValues(author, resAuthor)
+	})
+
+	t.Run("error: not in trash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			Restore(ctx, authorID).
+			Times(1).
+			Return(gorm.ErrRecordNotFound)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		resAuthor, err := authorService.Restore(ctx, authorID)
+		assert.Nil(t, resAuthor)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "id not found\n: author")
+	})
+}
+
+func TestAuthorDiffVersions(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		author := &model.Author{
+			ID:        utils.GenerateID(),
+			Name:      "J.R.R. Tolkien",
+			BirthDate: time.Date(1892, 1, 3, 0, 0, 0, 0, time.UTC),
+			Version:   2,
+		}
+		past := &model.AuthorVersion{AuthorID: author.ID, Version: 1, Name: "Tolkien", BirthDate: author.BirthDate}
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, author.ID).
+			Times(2).
+			Return(author, nil)
+
+		authorRepository.EXPECT().
+			FindVersion(ctx, author.ID, int64(1)).
+			Times(1).
+			Return(past, nil)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		diff, err := authorService.DiffVersions(ctx, author.ID, 1, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []*model.FieldChange{{Field: "name", From: "Tolkien", To: "J.R.R. Tolkien"}}, diff.Changes)
+	})
+}
+
+func TestAuthorFindDuplicates(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		born := time.Date(1892, 1, 3, 0, 0, 0, 0, time.UTC)
+		tolkien := &model.Author{ID: utils.GenerateID(), Name: "J.R.R. Tolkien", BirthDate: born}
+		tolkienAgain := &model.Author{ID: utils.GenerateID(), Name: "Tolkien, J. R. R.", BirthDate: born}
+		other := &model.Author{ID: utils.GenerateID(), Name: "Someone Else", BirthDate: born}
+		sameNameLater := &model.Author{ID: utils.GenerateID(), Name: "J.R.R. Tolkien", BirthDate: born.AddDate(1, 0, 0)}
+		twin := &model.Author{ID: utils.GenerateID(), Name: "Unrelated", BirthDate: born.AddDate(1, 0, 0)}
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindAllSharingBirthDate(ctx).
+			Times(1).
+			Return([]*model.Author{tolkien, tolkienAgain, other, sameNameLater, twin}, nil)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		duplicates, err := authorService.FindDuplicates(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, duplicates, 1)
+		assert.Equal(t, tolkien, duplicates[0].Author)
+		assert.Equal(t, tolkienAgain, duplicates[0].Duplicate)
+		assert.Equal(t, 1.0, duplicates[0].Score)
+	})
+}
+
+func TestAuthorMerge(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		author := &model.Author{ID: utils.GenerateID(), Name: "J.R.R. Tolkien"}
+		mergedID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			Merge(ctx, author.ID, []int64{mergedID}, gomock.Any()).
+			Times(1).
+			Return(nil)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, author.ID).
+			Times(1).
+			Return(author, nil)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		resAuthor, err := authorService.Merge(ctx, author.ID, []int64{mergedID, mergedID})
+		assert.Nil(t, err)
+		assert.Equal(t, author, resAuthor)
+	})
+
+	t.Run("error: into itself", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		resAuthor, err := authorService.Merge(ctx, authorID, []int64{authorID})
+		assert.Nil(t, resAuthor)
+		assert.EqualError(t, err, "bad request\n: an author cannot be merged into itself")
+	})
+
+	t.Run("error: author not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+		mergedID := utils.GenerateID()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			Merge(ctx, authorID, []int64{mergedID}, gomock.Any()).
+			Times(1).
+			Return(gorm.ErrRecordNotFound)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		resAuthor, err := authorService.Merge(ctx, authorID, []int64{mergedID})
+		assert.Nil(t, resAuthor)
+		assert.EqualError(t, err, "id not found\n: author")
+	})
+}
+
+func TestAuthorAliases(t *testing.T) {
+	t.Run("ok: aliases are trimmed and deduplicated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		author := &model.Author{
+			Name:      "Pramoedya Ananta Toer",
+			BirthDate: gofakeit.Date(),
+			Aliases: []*model.AuthorAlias{
+				{Name: " Pram ", Kind: model.AuthorAliasPseudonym},
+				{Name: "pram"},
+				{Name: "pramoedya ananta toer"},
+				{Name: "  "},
+				{Name: "Pramoedya Ananta Tur"},
+			},
+		}
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			Create(ctx, author).
+			Times(1).
+			Return(author, nil)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		resAuthor, err := authorService.Create(ctx, author)
+		assert.Nil(t, err)
+		assert.Equal(t, []*model.AuthorAlias{
+			{Name: "Pram", Kind: model.AuthorAliasPseudonym},
+			{Name: "Pramoedya Ananta Tur", Kind: model.AuthorAliasAlternate},
+		}, resAuthor.Aliases)
+	})
+}
+
+func TestAuthorFindAllByName(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authors := []*model.Author{{
+			ID:        utils.GenerateID(),
+			Name:      "Pramoedya Ananta Toer",
+			BirthDate: gofakeit.Date(),
+			Aliases:   []*model.AuthorAlias{{Name: "Pram", Kind: model.AuthorAliasPseudonym}},
+		}}
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindAllByName(ctx, "pram").
+			Times(1).
+			Return(authors, nil)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		resAuthors, err := authorService.FindAllByName(ctx, "pram")
+		assert.Nil(t, err)
+		assert.Equal(t, authors, resAuthors)
+	})
+
+	t.Run("error: internal", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindAllByName(ctx, "pram").
+			Times(1).
+			Return(nil, gorm.ErrInvalidDB)
+
+		authorService := service.NewAuthorService(authorRepository, bookRepository)
+		resAuthors, err := authorService.FindAllByName(ctx, "pram")
+		assert.Nil(t, resAuthors)
+		assert.EqualError(t, err, "internal server error")
+	})
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
@@ -36,6 +37,7 @@ func TestBookCreate(t *testing.T) {
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
 			FindByISBN(ctx, book.ISBN).
@@ -47,18 +49,121 @@ func TestBookCreate(t *testing.T) {
 			Times(1).
 			Return(author, nil)
 
+		editionRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
 		bookRepository.EXPECT().
 			Create(ctx, book).
 			Times(1).
 			Return(book, nil)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
 		resBook, err := bookService.Create(ctx, book)
 		assert.Nil(t, err)
 		assert.NotNil(t, resBook)
 		assert.ObjectsAreEqualValues(book, resBook)
 	})
 
+	t.Run("ok: translations are keyed by canonical locale", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    "Bumi Manusia",
+			AuthorID: utils.GenerateID(),
+			Translations: model.Translations[model.BookTranslation]{
+				"en-us": {Title: "This Earth of Mankind"},
+				"EN":    {Title: "This Earth of Mankind"},
+				"id":    {},
+			},
+		}
+
+		author := &model.Author{
+			ID:        book.AuthorID,
+			Name:      gofakeit.Name(),
+			BirthDate: gofakeit.Date(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, book.AuthorID).
+			Times(1).
+			Return(author, nil)
+
+		editionRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		bookRepository.EXPECT().
+			Create(ctx, book).
+			Times(1).
+			Return(book, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Create(ctx, book)
+		assert.Nil(t, err)
+		assert.Equal(t, model.Translations[model.BookTranslation]{
+			"en-US": {Title: "This Earth of Mankind"},
+			"en":    {Title: "This Earth of Mankind"},
+		}, resBook.Translations)
+	})
+
+	t.Run("ok: hyphenated isbn is stored as isbn-13", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "978-0-306-40615-7",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, "9780306406157").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, book.AuthorID).
+			Times(1).
+			Return(&model.Author{ID: book.AuthorID}, nil)
+
+		editionRepository.EXPECT().
+			FindByISBN(ctx, "9780306406157").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		bookRepository.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, book *model.Book) (*model.Book, error) {
+				return book, nil
+			})
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Create(ctx, book)
+		assert.Nil(t, err)
+		assert.Equal(t, "9780306406157", resBook.ISBN)
+	})
+
 	t.Run("error: find isbn", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
@@ -72,13 +177,14 @@ func TestBookCreate(t *testing.T) {
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
 			FindByISBN(ctx, book.ISBN).
 			Times(1).
 			Return(nil, gorm.ErrInvalidDB)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
 		resBook, err := bookService.Create(ctx, book)
 		assert.Nil(t, resBook)
 		assert.Error(t, err)
@@ -105,13 +211,14 @@ func TestBookCreate(t *testing.T) {
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
 			FindByISBN(ctx, book.ISBN).
 			Times(1).
 			Return(bookDuplicate, nil)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
 		resBook, err := bookService.Create(ctx, book)
 		assert.Nil(t, resBook)
 		assert.Error(t, err)
@@ -131,6 +238,7 @@ func TestBookCreate(t *testing.T) {
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
 			FindByISBN(ctx, book.ISBN).
@@ -142,7 +250,7 @@ func TestBookCreate(t *testing.T) {
 			Times(1).
 			Return(nil, gorm.ErrInvalidDB)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
 		resBook, err := bookService.Create(ctx, book)
 		assert.Nil(t, resBook)
 		assert.Error(t, err)
@@ -162,6 +270,7 @@ func TestBookCreate(t *testing.T) {
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
 			FindByISBN(ctx, book.ISBN).
@@ -173,7 +282,7 @@ func TestBookCreate(t *testing.T) {
 			Times(1).
 			Return(nil, gorm.ErrRecordNotFound)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
 		resBook, err := bookService.Create(ctx, book)
 		assert.Nil(t, resBook)
 		assert.Error(t, err)
@@ -199,6 +308,7 @@ func TestBookCreate(t *testing.T) {
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
 			FindByISBN(ctx, book.ISBN).
@@ -210,444 +320,564 @@ func TestBookCreate(t *testing.T) {
 			Times(1).
 			Return(author, nil)
 
+		editionRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
 		bookRepository.EXPECT().
 			Create(ctx, book).
 			Times(1).
 			Return(nil, gorm.ErrInvalidDB)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
 		resBook, err := bookService.Create(ctx, book)
 		assert.Nil(t, resBook)
 		assert.Error(t, err)
 		assert.EqualError(t, err, controller.ErrInternalServer.Error())
 	})
-}
 
-func TestBookFindByID(t *testing.T) {
-	t.Run("ok", func(t *testing.T) {
+	t.Run("ok: isbn of an existing edition links it", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
 		book := &model.Book{
-			ID:       utils.GenerateID(),
 			ISBN:     "9789295055025",
 			Title:    gofakeit.BookTitle(),
 			AuthorID: utils.GenerateID(),
 		}
 
+		edition := &model.Edition{
+			ID:     utils.GenerateID(),
+			WorkID: utils.GenerateID(),
+			ISBN:   book.ISBN,
+		}
+
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			FindByID(ctx, book.ID).
+			FindByISBN(ctx, book.ISBN).
 			Times(1).
-			Return(book, nil)
+			Return(nil, gorm.ErrRecordNotFound)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBook, err := bookService.FindByID(ctx, book.ID)
+		authorRepository.EXPECT().
+			FindByID(ctx, book.AuthorID).
+			Times(1).
+			Return(&model.Author{ID: book.AuthorID}, nil)
+
+		editionRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(edition, nil)
+
+		bookRepository.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, book *model.Book) (*model.Book, error) {
+				return book, nil
+			})
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Create(ctx, book)
 		assert.Nil(t, err)
-		assert.NotNil(t, resBook)
-		assert.ObjectsAreEqualValues(book, resBook)
+		assert.Equal(t, &edition.ID, resBook.EditionID)
 	})
 
-	t.Run("error: id not found", func(t *testing.T) {
+	t.Run("ok: new isbn leaves the edition to the repository", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
+		editionID := utils.GenerateID()
 		book := &model.Book{
-			ID:       utils.GenerateID(),
-			ISBN:     "9789295055025",
-			Title:    gofakeit.BookTitle(),
-			AuthorID: utils.GenerateID(),
+			ISBN:      "9789295055025",
+			Title:     gofakeit.BookTitle(),
+			AuthorID:  utils.GenerateID(),
+			EditionID: &editionID,
 		}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			FindByID(ctx, book.ID).
+			FindByISBN(ctx, book.ISBN).
 			Times(1).
 			Return(nil, gorm.ErrRecordNotFound)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBook, err := bookService.FindByID(ctx, book.ID)
-		assert.Nil(t, resBook)
-		assert.Error(t, err)
-		assert.EqualError(t, err, "id not found\n: book")
+		authorRepository.EXPECT().
+			FindByID(ctx, book.AuthorID).
+			Times(1).
+			Return(&model.Author{ID: book.AuthorID}, nil)
+
+		editionRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		bookRepository.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, book *model.Book) (*model.Book, error) {
+				assert.Nil(t, book.EditionID)
+				return book, nil
+			})
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		_, err := bookService.Create(ctx, book)
+		assert.Nil(t, err)
 	})
-}
 
-func TestBookFindByISBN(t *testing.T) {
-	t.Run("ok", func(t *testing.T) {
+	t.Run("ok: new isbn is created with the given edition", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
 		book := &model.Book{
-			ID:       utils.GenerateID(),
 			ISBN:     "9789295055025",
 			Title:    gofakeit.BookTitle(),
 			AuthorID: utils.GenerateID(),
 		}
+		edition := &model.Edition{Language: "id"}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
 			FindByISBN(ctx, book.ISBN).
 			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, book.AuthorID).
+			Times(1).
+			Return(&model.Author{ID: book.AuthorID}, nil)
+
+		editionRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		bookRepository.EXPECT().
+			CreateWithEdition(ctx, book, edition).
+			Times(1).
 			Return(book, nil)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBook, err := bookService.FindByISBN(ctx, book.ISBN)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		_, err := bookService.CreateWithEdition(ctx, book, edition)
 		assert.Nil(t, err)
-		assert.NotNil(t, resBook)
-		assert.ObjectsAreEqualValues(book, resBook)
 	})
 
-	t.Run("error: isbn not found", func(t *testing.T) {
+	t.Run("ok: isbn of an existing edition ignores the given one", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
 		book := &model.Book{
-			ID:       utils.GenerateID(),
 			ISBN:     "9789295055025",
 			Title:    gofakeit.BookTitle(),
 			AuthorID: utils.GenerateID(),
 		}
+		existing := &model.Edition{ID: utils.GenerateID(), ISBN: book.ISBN}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
 			FindByISBN(ctx, book.ISBN).
 			Times(1).
 			Return(nil, gorm.ErrRecordNotFound)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBook, err := bookService.FindByISBN(ctx, book.ISBN)
-		assert.Nil(t, resBook)
-		assert.Error(t, err)
-		assert.EqualError(t, err, "id not found\n: book")
-	})
-}
-
-func TestBookFindAll(t *testing.T) {
-	t.Run("ok", func(t *testing.T) {
-		ctrl := gomock.NewController(t)
-
-		ctx := context.TODO()
-		books := []*model.Book{
-			{
-				ID:       utils.GenerateID(),
-				ISBN:     "9789295055025",
-				Title:    gofakeit.BookTitle(),
-				AuthorID: utils.GenerateID(),
-			},
-			{
-				ID:       utils.GenerateID(),
-				ISBN:     "9780323776714",
-				Title:    gofakeit.BookTitle(),
-				AuthorID: utils.GenerateID(),
-			},
-		}
+		authorRepository.EXPECT().
+			FindByID(ctx, book.AuthorID).
+			Times(1).
+			Return(&model.Author{ID: book.AuthorID}, nil)
 
-		bookRepository := mock.NewMockBookRepository(ctrl)
-		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(existing, nil)
 
 		bookRepository.EXPECT().
-			FindAll(ctx).
+			Create(ctx, book).
 			Times(1).
-			Return(books, nil)
+			Return(book, nil)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBooks, err := bookService.FindAll(ctx)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		_, err := bookService.CreateWithEdition(ctx, book, &model.Edition{Language: "id"})
 		assert.Nil(t, err)
-		assert.NotNil(t, resBooks)
-		assert.ObjectsAreEqualValues(books, resBooks)
+		assert.Equal(t, &existing.ID, book.EditionID)
 	})
 
-	t.Run("error: find all", func(t *testing.T) {
+	t.Run("error: find edition", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
+		book := &model.Book{
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			FindAll(ctx).
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, book.AuthorID).
+			Times(1).
+			Return(&model.Author{ID: book.AuthorID}, nil)
+
+		editionRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
 			Times(1).
 			Return(nil, gorm.ErrInvalidDB)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBooks, err := bookService.FindAll(ctx)
-		assert.Nil(t, resBooks)
-		assert.Error(t, err)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Create(ctx, book)
+		assert.Nil(t, resBook)
 		assert.EqualError(t, err, controller.ErrInternalServer.Error())
 	})
 }
 
-func TestBookUpdate(t *testing.T) {
-	t.Run("ok: change all", func(t *testing.T) {
+func TestBookFindByID(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
-		bookID := utils.GenerateID()
-		authorID := utils.GenerateID()
-		req := &model.Book{
-			ID:       bookID,
-			ISBN:     "9789353008956",
-			Title:    gofakeit.BookTitle(),
-			AuthorID: utils.GenerateID(),
-		}
-
 		book := &model.Book{
-			ID:       bookID,
+			ID:       utils.GenerateID(),
 			ISBN:     "9789295055025",
 			Title:    gofakeit.BookTitle(),
-			AuthorID: authorID,
-		}
-
-		author := &model.Author{
-			ID:        authorID,
-			Name:      gofakeit.Name(),
-			BirthDate: gofakeit.Date(),
+			AuthorID: utils.GenerateID(),
 		}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			FindByID(ctx, req.ID).
+			FindByID(ctx, book.ID).
 			Times(1).
 			Return(book, nil)
 
-		bookRepository.EXPECT().
-			FindByISBN(ctx, req.ISBN).
-			Times(1).
-			Return(nil, gorm.ErrRecordNotFound)
-
-		authorRepository.EXPECT().
-			FindByID(ctx, req.AuthorID).
-			Times(1).
-			Return(author, nil)
-
-		bookRepository.EXPECT().
-			Update(ctx, req).
-			Times(1).
-			Return(req, nil)
-
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBook, err := bookService.Update(ctx, req)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.FindByID(ctx, book.ID)
 		assert.Nil(t, err)
 		assert.NotNil(t, resBook)
-		assert.ObjectsAreEqualValues(req, resBook)
+		assert.ObjectsAreEqualValues(book, resBook)
 	})
 
-	t.Run("error: find id", func(t *testing.T) {
+	t.Run("error: id not found", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
-		req := &model.Book{
+		book := &model.Book{
 			ID:       utils.GenerateID(),
-			ISBN:     "9789353008956",
+			ISBN:     "9789295055025",
 			Title:    gofakeit.BookTitle(),
 			AuthorID: utils.GenerateID(),
 		}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			FindByID(ctx, req.ID).
+			FindByID(ctx, book.ID).
 			Times(1).
-			Return(nil, gorm.ErrInvalidDB)
+			Return(nil, gorm.ErrRecordNotFound)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBook, err := bookService.Update(ctx, req)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.FindByID(ctx, book.ID)
 		assert.Nil(t, resBook)
 		assert.Error(t, err)
-		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+		assert.EqualError(t, err, "id not found\n: book")
 	})
+}
 
-	t.Run("error: find isbn", func(t *testing.T) {
+func TestBookFindByISBN(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
-		bookID := utils.GenerateID()
-		authorID := utils.GenerateID()
-		req := &model.Book{
-			ID:       bookID,
-			ISBN:     "9789353008956",
-			Title:    gofakeit.BookTitle(),
-			AuthorID: utils.GenerateID(),
-		}
-
 		book := &model.Book{
-			ID:       bookID,
+			ID:       utils.GenerateID(),
 			ISBN:     "9789295055025",
 			Title:    gofakeit.BookTitle(),
-			AuthorID: authorID,
+			AuthorID: utils.GenerateID(),
 		}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			FindByID(ctx, req.ID).
+			FindByISBN(ctx, book.ISBN).
 			Times(1).
 			Return(book, nil)
 
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.FindByISBN(ctx, book.ISBN)
+		assert.Nil(t, err)
+		assert.NotNil(t, resBook)
+		assert.ObjectsAreEqualValues(book, resBook)
+	})
+
+	t.Run("error: isbn not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
 		bookRepository.EXPECT().
-			FindByISBN(ctx, req.ISBN).
+			FindByISBN(ctx, book.ISBN).
 			Times(1).
-			Return(nil, gorm.ErrInvalidDB)
+			Return(nil, gorm.ErrRecordNotFound)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBook, err := bookService.Update(ctx, req)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.FindByISBN(ctx, book.ISBN)
 		assert.Nil(t, resBook)
 		assert.Error(t, err)
-		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+		assert.EqualError(t, err, "id not found\n: book")
 	})
 
-	t.Run("error: isbn duplicated", func(t *testing.T) {
+	t.Run("ok: isbn-10 form", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
-		bookID := utils.GenerateID()
-		authorID := utils.GenerateID()
-		req := &model.Book{
-			ID:       bookID,
-			ISBN:     "9789353008956",
-			Title:    gofakeit.BookTitle(),
-			AuthorID: utils.GenerateID(),
-		}
-
 		book := &model.Book{
-			ID:       bookID,
-			ISBN:     "9789295055025",
-			Title:    gofakeit.BookTitle(),
-			AuthorID: authorID,
-		}
-
-		bookByISBN := &model.Book{
 			ID:       utils.GenerateID(),
-			ISBN:     "9789353008956",
+			ISBN:     "9780306406157",
 			Title:    gofakeit.BookTitle(),
 			AuthorID: utils.GenerateID(),
 		}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			FindByID(ctx, req.ID).
+			FindByISBN(ctx, book.ISBN).
 			Times(1).
 			Return(book, nil)
 
-		bookRepository.EXPECT().
-			FindByISBN(ctx, req.ISBN).
-			Times(1).
-			Return(bookByISBN, nil)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.FindByISBN(ctx, "0-306-40615-2")
+		assert.Nil(t, err)
+		assert.Equal(t, book, resBook)
+	})
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBook, err := bookService.Update(ctx, req)
+	t.Run("error: invalid isbn", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.FindByISBN(ctx, "9780306406158")
 		assert.Nil(t, resBook)
-		assert.Error(t, err)
-		assert.EqualError(t, err, "duplicate entry\n: isbn")
+		assert.ErrorIs(t, err, controller.ErrBadRequest)
 	})
+}
 
-	t.Run("error: find author", func(t *testing.T) {
+func TestBookFindAll(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
-		bookID := utils.GenerateID()
-		authorID := utils.GenerateID()
-		req := &model.Book{
-			ID:       bookID,
-			ISBN:     "9789353008956",
-			Title:    gofakeit.BookTitle(),
-			AuthorID: utils.GenerateID(),
-		}
-
-		book := &model.Book{
-			ID:       bookID,
-			ISBN:     "9789295055025",
-			Title:    gofakeit.BookTitle(),
-			AuthorID: authorID,
+		books := []*model.Book{
+			{
+				ID:       utils.GenerateID(),
+				ISBN:     "9789295055025",
+				Title:    gofakeit.BookTitle(),
+				AuthorID: utils.GenerateID(),
+			},
+			{
+				ID:       utils.GenerateID(),
+				ISBN:     "9780323776714",
+				Title:    gofakeit.BookTitle(),
+				AuthorID: utils.GenerateID(),
+			},
 		}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			FindByID(ctx, req.ID).
+			FindAll(ctx).
 			Times(1).
-			Return(book, nil)
+			Return(books, nil)
 
-		bookRepository.EXPECT().
-			FindByISBN(ctx, req.ISBN).
-			Times(1).
-			Return(nil, gorm.ErrRecordNotFound)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBooks, err := bookService.FindAll(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, resBooks)
+		assert.ObjectsAreEqualValues(books, resBooks)
+	})
 
-		authorRepository.EXPECT().
-			FindByID(ctx, req.AuthorID).
+	t.Run("error: find all", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindAll(ctx).
 			Times(1).
 			Return(nil, gorm.ErrInvalidDB)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBook, err := bookService.Update(ctx, req)
-		assert.Nil(t, resBook)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBooks, err := bookService.FindAll(ctx)
+		assert.Nil(t, resBooks)
 		assert.Error(t, err)
 		assert.EqualError(t, err, controller.ErrInternalServer.Error())
 	})
+}
 
-	t.Run("error: author not found", func(t *testing.T) {
+func TestBookStream(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
-		bookID := utils.GenerateID()
-		authorID := utils.GenerateID()
-		req := &model.Book{
-			ID:       bookID,
-			ISBN:     "9789353008956",
-			Title:    gofakeit.BookTitle(),
-			AuthorID: utils.GenerateID(),
+		filter := model.BookFilter{AuthorID: utils.GenerateID()}
+		books := []*model.Book{
+			{ID: utils.GenerateID(), ISBN: "9789295055025", Title: gofakeit.BookTitle(), AuthorID: filter.AuthorID},
+			{ID: utils.GenerateID(), ISBN: "9780306406157", Title: gofakeit.BookTitle(), AuthorID: filter.AuthorID},
 		}
 
-		book := &model.Book{
-			ID:       bookID,
-			ISBN:     "9789295055025",
-			Title:    gofakeit.BookTitle(),
-			AuthorID: authorID,
-		}
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			Stream(ctx, filter, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, _ model.BookFilter, fn func(*model.Book) error) error {
+				for _, book := range books {
+					err := fn(book)
+					if err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+
+		streamed := []*model.Book{}
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		err := bookService.Stream(ctx, filter, func(book *model.Book) error {
+			streamed = append(streamed, book)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, books, streamed)
+	})
+
+	t.Run("error: stream", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		filter := model.BookFilter{}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			FindByID(ctx, req.ID).
+			Stream(ctx, filter, gomock.Any()).
 			Times(1).
-			Return(book, nil)
+			Return(gorm.ErrInvalidTransaction)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		err := bookService.Stream(ctx, filter, func(*model.Book) error { return nil })
+		assert.Error(t, err)
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+	})
+}
+
+func TestBookStreamCitations(t *testing.T) {
+	t.Run("ok: isbn normalized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{ID: utils.GenerateID(), ISBN: "9780306406157", Title: gofakeit.BookTitle(), AuthorID: utils.GenerateID()}
+		citations := []*model.Citation{{Book: book, Author: &model.Author{ID: book.AuthorID}}}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			FindByISBN(ctx, req.ISBN).
+			StreamCitations(ctx, model.BookFilter{ISBN: "9780306406157"}, gomock.Any()).
 			Times(1).
-			Return(nil, gorm.ErrRecordNotFound)
+			DoAndReturn(func(_ context.Context, _ model.BookFilter, fn func(*model.Citation) error) error {
+				for _, citation := range citations {
+					err := fn(citation)
+					if err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+
+		streamed := []*model.Citation{}
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		err := bookService.StreamCitations(ctx, model.BookFilter{ISBN: "0-306-40615-2"}, func(citation *model.Citation) error {
+			streamed = append(streamed, citation)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, citations, streamed)
+	})
 
-		authorRepository.EXPECT().
-			FindByID(ctx, req.AuthorID).
+	t.Run("error: stream", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		filter := model.BookFilter{}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			StreamCitations(ctx, filter, gomock.Any()).
 			Times(1).
-			Return(nil, gorm.ErrRecordNotFound)
+			Return(gorm.ErrInvalidTransaction)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		resBook, err := bookService.Update(ctx, req)
-		assert.Nil(t, resBook)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		err := bookService.StreamCitations(ctx, filter, func(*model.Citation) error { return nil })
 		assert.Error(t, err)
-		assert.EqualError(t, err, "id not found\n: author")
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
 	})
+}
 
-	t.Run("error: create book", func(t *testing.T) {
+func TestBookUpdate(t *testing.T) {
+	t.Run("ok: change all", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
@@ -675,6 +905,7 @@ func TestBookUpdate(t *testing.T) {
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
 			FindByID(ctx, req.ID).
@@ -694,63 +925,876 @@ func TestBookUpdate(t *testing.T) {
 		bookRepository.EXPECT().
 			Update(ctx, req).
 			Times(1).
-			Return(nil, gorm.ErrInvalidDB)
+			Return(req, nil)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
 		resBook, err := bookService.Update(ctx, req)
-		assert.Nil(t, resBook)
-		assert.Error(t, err)
-		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+		assert.Nil(t, err)
+		assert.NotNil(t, resBook)
+		assert.ObjectsAreEqualValues(req, resBook)
 	})
-}
 
-func TestBookDelete(t *testing.T) {
-	t.Run("ok", func(t *testing.T) {
+	t.Run("error: find id", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
-		book := &model.Book{
+		req := &model.Book{
 			ID:       utils.GenerateID(),
-			ISBN:     "9789295055025",
+			ISBN:     "9789353008956",
 			Title:    gofakeit.BookTitle(),
 			AuthorID: utils.GenerateID(),
 		}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			Delete(ctx, book.ID).
+			FindByID(ctx, req.ID).
 			Times(1).
-			Return(nil)
+			Return(nil, gorm.ErrInvalidDB)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
-		err := bookService.Delete(ctx, book.ID)
-		assert.Nil(t, err)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Update(ctx, req)
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
 	})
 
-	t.Run("error: id not found", func(t *testing.T) {
+	t.Run("error: stale version", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		req := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789353008956",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+			Version:  1,
+		}
+
 		book := &model.Book{
-			ID:       utils.GenerateID(),
+			ID:       bookID,
 			ISBN:     "9789295055025",
 			Title:    gofakeit.BookTitle(),
 			AuthorID: utils.GenerateID(),
+			Version:  2,
 		}
 
 		bookRepository := mock.NewMockBookRepository(ctrl)
 		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
 
 		bookRepository.EXPECT().
-			Delete(ctx, book.ID).
+			FindByID(ctx, req.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Update(ctx, req)
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "precondition failed\n: book")
+	})
+
+	t.Run("error: find isbn", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		authorID := utils.GenerateID()
+		req := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789353008956",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		book := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: authorID,
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, req.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, req.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrInvalidDB)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Update(ctx, req)
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+	})
+
+	t.Run("error: isbn duplicated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		authorID := utils.GenerateID()
+		req := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789353008956",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		book := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: authorID,
+		}
+
+		bookByISBN := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789353008956",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, req.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, req.ISBN).
+			Times(1).
+			Return(bookByISBN, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Update(ctx, req)
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "duplicate entry\n: isbn")
+	})
+
+	t.Run("error: find author", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		authorID := utils.GenerateID()
+		req := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789353008956",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		book := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: authorID,
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, req.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, req.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, req.AuthorID).
+			Times(1).
+			Return(nil, gorm.ErrInvalidDB)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Update(ctx, req)
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+	})
+
+	t.Run("error: author not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		authorID := utils.GenerateID()
+		req := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789353008956",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		book := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: authorID,
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, req.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, req.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, req.AuthorID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Update(ctx, req)
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "id not found\n: author")
+	})
+
+	t.Run("error: create book", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		authorID := utils.GenerateID()
+		req := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789353008956",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		book := &model.Book{
+			ID:       bookID,
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: authorID,
+		}
+
+		author := &model.Author{
+			ID:        authorID,
+			Name:      gofakeit.Name(),
+			BirthDate: gofakeit.Date(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, req.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, req.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, req.AuthorID).
+			Times(1).
+			Return(author, nil)
+
+		bookRepository.EXPECT().
+			Update(ctx, req).
+			Times(1).
+			Return(nil, gorm.ErrInvalidDB)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Update(ctx, req)
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+	})
+}
+
+func TestBookPatch(t *testing.T) {
+	t.Run("ok: title only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+			Version:  1,
+		}
+
+		req := &model.Book{
+			ID:       book.ID,
+			ISBN:     book.ISBN,
+			Title:    gofakeit.BookTitle(),
+			AuthorID: book.AuthorID,
+			Version:  1,
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, req.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			UpdateColumns(ctx, req, []string{"title"}).
+			Times(1).
+			Return(req, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Patch(ctx, req, []string{"title"})
+		assert.Nil(t, err)
+		assert.NotNil(t, resBook)
+		assert.ObjectsAreEqualValues(req, resBook)
+	})
+
+	t.Run("error: isbn duplicated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		req := &model.Book{
+			ID:       book.ID,
+			ISBN:     "9789353008956",
+			Title:    book.Title,
+			AuthorID: book.AuthorID,
+		}
+
+		bookByISBN := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789353008956",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, req.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, req.ISBN).
+			Times(1).
+			Return(bookByISBN, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Patch(ctx, req, []string{"isbn"})
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "duplicate entry\n: isbn")
+	})
+
+	t.Run("error: author not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		req := &model.Book{
+			ID:       book.ID,
+			ISBN:     book.ISBN,
+			Title:    book.Title,
+			AuthorID: utils.GenerateID(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, req.ID).
+			Times(1).
+			Return(book, nil)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, req.AuthorID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Patch(ctx, req, []string{"author_id"})
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "id not found\n: author")
+	})
+
+	t.Run("error: stale version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+			Version:  3,
+		}
+
+		req := &model.Book{
+			ID:       book.ID,
+			ISBN:     book.ISBN,
+			Title:    gofakeit.BookTitle(),
+			AuthorID: book.AuthorID,
+			Version:  2,
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, req.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Patch(ctx, req, []string{"title"})
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "precondition failed\n: book")
+	})
+}
+
+func TestBookDelete(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			Delete(ctx, book.ID).
+			Times(1).
+			Return(nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		err := bookService.Delete(ctx, book.ID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("error: id not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			Delete(ctx, book.ID).
 			Times(1).
 			Return(gorm.ErrRecordNotFound)
 
-		bookService := service.NewBookService(bookRepository, authorRepository)
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
 		err := bookService.Delete(ctx, book.ID)
 		assert.Error(t, err)
 		assert.EqualError(t, err, "id not found\n: book")
 	})
 }
+
+func TestBookRestore(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		author := &model.Author{
+			ID:        book.AuthorID,
+			Name:      gofakeit.Name(),
+			BirthDate: gofakeit.Date(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindDeletedByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, book.AuthorID).
+			Times(1).
+			Return(author, nil)
+
+		bookRepository.EXPECT().
+			Restore(ctx, book.ID).
+			Times(1).
+			Return(nil)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Restore(ctx, book.ID)
+		assert.Nil(t, err)
+		assert.NotNil(t, resBook)
+		assert.ObjectsAreEqualValues(book, resBook)
+	})
+
+	t.Run("error: not in trash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindDeletedByID(ctx, bookID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Restore(ctx, bookID)
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "id not found\n: book")
+	})
+
+	t.Run("error: isbn taken by live book", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		liveBook := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindDeletedByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(liveBook, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Restore(ctx, book.ID)
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "duplicate entry\n: isbn")
+	})
+
+	t.Run("error: author not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindDeletedByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindByISBN(ctx, book.ISBN).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, book.AuthorID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		resBook, err := bookService.Restore(ctx, book.ID)
+		assert.Nil(t, resBook)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "id not found\n: author")
+	})
+}
+
+func TestBookPurge(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		deletedBefore := time.Now()
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			Purge(ctx, deletedBefore).
+			Times(1).
+			Return(int64(2), nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		count, err := bookService.Purge(ctx, deletedBefore)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("error: purge", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		deletedBefore := time.Now()
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			Purge(ctx, deletedBefore).
+			Times(1).
+			Return(int64(0), gorm.ErrInvalidDB)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		count, err := bookService.Purge(ctx, deletedBefore)
+		assert.Zero(t, count)
+		assert.Error(t, err)
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+	})
+}
+
+func TestBookFindVersions(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+			Version:  2,
+		}
+		validTo := time.Now()
+		past := &model.BookVersion{BookID: book.ID, Version: 1, ISBN: book.ISBN, Title: "Draft", AuthorID: book.AuthorID, ValidTo: &validTo}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindVersions(ctx, book.ID).
+			Times(1).
+			Return([]*model.BookVersion{past}, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		versions, err := bookService.FindVersions(ctx, book.ID)
+		assert.Nil(t, err)
+		assert.Len(t, versions, 2)
+		assert.Equal(t, int64(2), versions[0].Version)
+		assert.Nil(t, versions[0].ValidTo)
+		assert.Equal(t, past, versions[1])
+	})
+}
+
+func TestBookDiffVersions(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    "Final",
+			AuthorID: utils.GenerateID(),
+			CoverKey: "covers/1/0123456789abcdef",
+			Version:  3,
+		}
+		past := &model.BookVersion{BookID: book.ID, Version: 1, ISBN: book.ISBN, Title: "Draft", AuthorID: book.AuthorID}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, book.ID).
+			Times(2).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindVersion(ctx, book.ID, int64(1)).
+			Times(1).
+			Return(past, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		diff, err := bookService.DiffVersions(ctx, book.ID, 1, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, []*model.FieldChange{{Field: "title", From: "Draft", To: "Final"}}, diff.Changes)
+	})
+
+	t.Run("ok: translations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:       utils.GenerateID(),
+			ISBN:     "9789295055025",
+			Title:    "Bumi Manusia",
+			AuthorID: utils.GenerateID(),
+			Translations: model.Translations[model.BookTranslation]{
+				"en": {Title: "This Earth of Mankind"},
+			},
+			Version: 2,
+		}
+		past := &model.BookVersion{
+			BookID:       book.ID,
+			Version:      1,
+			ISBN:         book.ISBN,
+			Title:        book.Title,
+			AuthorID:     book.AuthorID,
+			Translations: model.Translations[model.BookTranslation]{},
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, book.ID).
+			Times(2).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindVersion(ctx, book.ID, int64(1)).
+			Times(1).
+			Return(past, nil)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		diff, err := bookService.DiffVersions(ctx, book.ID, 1, 2)
+		assert.Nil(t, err)
+		assert.Len(t, diff.Changes, 1)
+		assert.Equal(t, "translations", diff.Changes[0].Field)
+		assert.Nil(t, diff.Changes[0].From)
+		assert.Equal(t, book.Translations, diff.Changes[0].To)
+	})
+
+	t.Run("error: version not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{ID: utils.GenerateID(), Version: 3}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		bookRepository.EXPECT().
+			FindVersion(ctx, book.ID, int64(7)).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		bookService := service.NewBookService(bookRepository, authorRepository, editionRepository)
+		diff, err := bookService.DiffVersions(ctx, book.ID, 7, 3)
+		assert.Nil(t, diff)
+		assert.EqualError(t, err, "id not found\n: version")
+	})
+}
@@ -0,0 +1,166 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogFindBooks(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		pagination := model.Pagination{Page: 2, PerPage: 2}
+		filter := model.BookFilter{Query: "tolkien"}
+		author := &model.Author{ID: utils.GenerateID(), Name: "J. R. R. Tolkien"}
+		books := []*model.Book{
+			{ID: utils.GenerateID(), Title: "The Hobbit", AuthorID: author.ID},
+			{ID: utils.GenerateID(), Title: "The Silmarillion", AuthorID: author.ID},
+		}
+		subject := &model.Subject{ID: utils.GenerateID(), Name: "Fantasy"}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindPage(ctx, filter, pagination).
+			Times(1).
+			Return(books, int64(5), nil)
+
+		authorRepository.EXPECT().
+			FindAllByIDs(ctx, []int64{author.ID, author.ID}).
+			Times(1).
+			Return([]*model.Author{author}, nil)
+
+		subjectRepository.EXPECT().
+			FindByBookIDs(ctx, []int64{books[0].ID, books[1].ID}).
+			Times(1).
+			Return(map[int64][]*model.Subject{books[0].ID: {subject}}, nil)
+
+		catalogService := service.NewCatalogService(bookRepository, authorRepository, subjectRepository)
+		resPage, err := catalogService.FindBooks(ctx, filter, pagination)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(5), resPage.Total)
+		assert.Equal(t, 2, resPage.Page)
+		assert.Len(t, resPage.Items, 2)
+		assert.Equal(t, author, resPage.Items[0].Author)
+		assert.Equal(t, author, resPage.Items[1].Author)
+		assert.Equal(t, []*model.Subject{subject}, resPage.Items[0].Subjects)
+		assert.Empty(t, resPage.Items[1].Subjects)
+	})
+
+	t.Run("ok: empty page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		pagination := model.Pagination{Page: 3, PerPage: 20}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindPage(ctx, model.BookFilter{}, pagination).
+			Times(1).
+			Return([]*model.Book{}, int64(10), nil)
+
+		catalogService := service.NewCatalogService(bookRepository, authorRepository, subjectRepository)
+		resPage, err := catalogService.FindBooks(ctx, model.BookFilter{}, pagination)
+		assert.Nil(t, err)
+		assert.NotNil(t, resPage.Items)
+		assert.Empty(t, resPage.Items)
+		assert.Equal(t, int64(10), resPage.Total)
+	})
+}
+
+func TestCatalogFindBooksBySubject(t *testing.T) {
+	t.Run("ok: includes descendants", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		pagination := model.Pagination{Page: 1, PerPage: 20}
+		subject := &model.Subject{ID: utils.GenerateID(), Name: "Fiction"}
+		subjectIDs := []int64{subject.ID, utils.GenerateID()}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+
+		subjectRepository.EXPECT().
+			FindByID(ctx, subject.ID).
+			Times(1).
+			Return(subject, nil)
+
+		subjectRepository.EXPECT().
+			FindDescendantIDs(ctx, subject.ID).
+			Times(1).
+			Return(subjectIDs, nil)
+
+		bookRepository.EXPECT().
+			FindPage(ctx, model.BookFilter{SubjectIDs: subjectIDs}, pagination).
+			Times(1).
+			Return([]*model.Book{}, int64(0), nil)
+
+		catalogService := service.NewCatalogService(bookRepository, authorRepository, subjectRepository)
+		resSubject, resPage, err := catalogService.FindBooksBySubject(ctx, subject.ID, pagination)
+		assert.Nil(t, err)
+		assert.Equal(t, subject, resSubject)
+		assert.Empty(t, resPage.Items)
+	})
+
+	t.Run("error: subject not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		subjectID := utils.GenerateID()
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+
+		subjectRepository.EXPECT().
+			FindByID(ctx, subjectID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		catalogService := service.NewCatalogService(bookRepository, authorRepository, subjectRepository)
+		resSubject, resPage, err := catalogService.FindBooksBySubject(ctx, subjectID, model.Pagination{Page: 1, PerPage: 20})
+		assert.Nil(t, resSubject)
+		assert.Nil(t, resPage)
+		assert.EqualError(t, err, "id not found\n: subject")
+	})
+}
+
+func TestCatalogFindBooksByAuthor(t *testing.T) {
+	t.Run("error: author not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		authorID := utils.GenerateID()
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, authorID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		catalogService := service.NewCatalogService(bookRepository, authorRepository, subjectRepository)
+		resAuthor, resPage, err := catalogService.FindBooksByAuthor(ctx, authorID, model.Pagination{Page: 1, PerPage: 20})
+		assert.Nil(t, resAuthor)
+		assert.Nil(t, resPage)
+		assert.EqualError(t, err, "id not found\n: author")
+	})
+}
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCitationFindByBookIDs(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		published := time.Date(1980, time.August, 25, 0, 0, 0, 0, time.UTC)
+		publisher := &model.Publisher{ID: utils.GenerateID(), Name: "Hasta Mitra"}
+		author := &model.Author{ID: utils.GenerateID(), Name: "Pramoedya Ananta Toer"}
+		edition := &model.Edition{
+			ID:              utils.GenerateID(),
+			ISBN:            "9789799731234",
+			PublisherID:     &publisher.ID,
+			Language:        "id",
+			PublicationDate: &published,
+		}
+		books := []*model.Book{
+			{ID: utils.GenerateID(), Title: "Bumi Manusia", ISBN: edition.ISBN, AuthorID: author.ID, EditionID: &edition.ID},
+			{ID: utils.GenerateID(), Title: "Anak Semua Bangsa", ISBN: "9789799731235", AuthorID: author.ID},
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindAllByIDs(ctx, []int64{books[1].ID, books[0].ID}).
+			Times(1).
+			Return(books, nil)
+
+		authorRepository.EXPECT().
+			FindAllByIDs(ctx, []int64{author.ID, author.ID}).
+			Times(1).
+			Return([]*model.Author{author}, nil)
+
+		editionRepository.EXPECT().
+			FindAllByIDs(ctx, []int64{edition.ID}).
+			Times(1).
+			Return([]*model.Edition{edition}, nil)
+
+		publisherRepository.EXPECT().
+			FindAllByIDs(ctx, []int64{publisher.ID}).
+			Times(1).
+			Return([]*model.Publisher{publisher}, nil)
+
+		citationService := service.NewCitationService(bookRepository, authorRepository, editionRepository, publisherRepository)
+		resCitations, err := citationService.FindByBookIDs(ctx, []int64{books[1].ID, books[0].ID, books[1].ID})
+		assert.Nil(t, err)
+		assert.Len(t, resCitations, 2)
+		assert.Equal(t, books[1], resCitations[0].Book)
+		assert.Equal(t, author, resCitations[0].Author)
+		assert.Nil(t, resCitations[0].Edition)
+		assert.Nil(t, resCitations[0].Publisher)
+		assert.Equal(t, books[0], resCitations[1].Book)
+		assert.Equal(t, edition, resCitations[1].Edition)
+		assert.Equal(t, publisher, resCitations[1].Publisher)
+	})
+
+	t.Run("error: book not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{ID: utils.GenerateID(), Title: "Bumi Manusia"}
+		missingID := utils.GenerateID()
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindAllByIDs(ctx, []int64{book.ID, missingID}).
+			Times(1).
+			Return([]*model.Book{book}, nil)
+
+		citationService := service.NewCitationService(bookRepository, authorRepository, editionRepository, publisherRepository)
+		resCitations, err := citationService.FindByBookIDs(ctx, []int64{book.ID, missingID})
+		assert.Nil(t, resCitations)
+		assert.EqualError(t, err, fmt.Sprintf("id not found\n: book %d", missingID))
+	})
+
+	t.Run("error: book repository", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindAllByIDs(ctx, []int64{bookID}).
+			Times(1).
+			Return(nil, errors.New("connection refused"))
+
+		citationService := service.NewCitationService(bookRepository, authorRepository, editionRepository, publisherRepository)
+		resCitations, err := citationService.FindByBookIDs(ctx, []int64{bookID})
+		assert.Nil(t, resCitations)
+		assert.EqualError(t, err, "internal server error")
+	})
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyCreate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookCopy := &model.Copy{
+			BookID:  utils.GenerateID(),
+			Barcode: "31234000123456",
+			Branch:  "Main",
+		}
+
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, bookCopy.BookID).
+			Times(1).
+			Return(&model.Book{ID: bookCopy.BookID}, nil)
+
+		copyRepository.EXPECT().
+			FindByBarcode(ctx, bookCopy.Barcode).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		copyRepository.EXPECT().
+			Create(ctx, bookCopy).
+			Times(1).
+			Return(bookCopy, nil)
+
+		copyService := service.NewCopyService(copyRepository, bookRepository, loanRepository)
+		resCopy, err := copyService.Create(ctx, bookCopy)
+		assert.Nil(t, err)
+		assert.Equal(t, bookCopy, resCopy)
+	})
+
+	t.Run("error: barcode duplicate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookCopy := &model.Copy{
+			BookID:  utils.GenerateID(),
+			Barcode: "31234000123456",
+			Branch:  "Main",
+		}
+
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, bookCopy.BookID).
+			Times(1).
+			Return(&model.Book{ID: bookCopy.BookID}, nil)
+
+		copyRepository.EXPECT().
+			FindByBarcode(ctx, bookCopy.Barcode).
+			Times(1).
+			Return(&model.Copy{ID: utils.GenerateID(), Barcode: bookCopy.Barcode}, nil)
+
+		copyService := service.NewCopyService(copyRepository, bookRepository, loanRepository)
+		resCopy, err := copyService.Create(ctx, bookCopy)
+		assert.Nil(t, resCopy)
+		assert.EqualError(t, err, "duplicate entry\n: barcode")
+	})
+}
+
+func TestCopyDelete(t *testing.T) {
+	t.Run("error: copy has loans", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		copyID := utils.GenerateID()
+
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+
+		loanRepository.EXPECT().
+			CountByCopyID(ctx, copyID).
+			Times(1).
+			Return(int64(4), nil)
+
+		copyService := service.NewCopyService(copyRepository, bookRepository, loanRepository)
+		err := copyService.Delete(ctx, copyID)
+		assert.EqualError(t, err, "dependent entries exist\n: copy has 4 loans")
+	})
+}
@@ -0,0 +1,237 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func coverPNG(t *testing.T) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	assert.Nil(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 800, 1200))))
+	return buf.Bytes()
+}
+
+func TestCoverUpload(t *testing.T) {
+	t.Run("ok: replaces previous cover", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:        utils.GenerateID(),
+			ISBN:      "9789295055025",
+			Title:     gofakeit.BookTitle(),
+			AuthorID:  utils.GenerateID(),
+			Version:   3,
+			CoverKey:  "covers/1/old",
+			CoverType: "image/jpeg",
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		blobStore := mock.NewMockBlobStore(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		stored := []string{}
+		blobStore.EXPECT().
+			Put(ctx, gomock.Any(), gomock.Any(), "image/png").
+			Times(4).
+			DoAndReturn(func(_ context.Context, key string, _ []byte, _ string) error {
+				stored = append(stored, key)
+				return nil
+			})
+
+		bookRepository.EXPECT().
+			UpdateColumns(ctx, gomock.Any(), []string{"cover_key", "cover_type"}).
+			Times(1).
+			DoAndReturn(func(_ context.Context, book *model.Book, _ []string) (*model.Book, error) {
+				book.Version++
+				return book, nil
+			})
+
+		for _, name := range []string{"original", "large", "medium", "small"} {
+			blobStore.EXPECT().
+				Delete(ctx, "covers/1/old/"+name+".jpg").
+				Times(1).
+				Return(nil)
+		}
+
+		blobStore.EXPECT().
+			SignedURL(ctx, gomock.Any(), time.Hour).
+			Times(4).
+			DoAndReturn(func(_ context.Context, key string, _ time.Duration) (string, error) {
+				return "https://cdn.example.com/" + key + "?signature=x", nil
+			})
+
+		coverService := service.NewCoverService(bookRepository, blobStore, time.Hour)
+		res, err := coverService.Upload(ctx, book.ID, coverPNG(t))
+		assert.Nil(t, err)
+		assert.Equal(t, book.ID, res.BookID)
+		assert.Equal(t, int64(4), res.Version)
+		assert.Len(t, res.URLs, 4)
+		assert.Len(t, stored, 4)
+		for _, key := range stored {
+			assert.True(t, strings.HasPrefix(key, book.CoverKey+"/"), key)
+			assert.True(t, strings.HasSuffix(key, ".png"), key)
+		}
+	})
+
+	t.Run("error: not an image", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{ID: utils.GenerateID()}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		blobStore := mock.NewMockBlobStore(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		coverService := service.NewCoverService(bookRepository, blobStore, time.Hour)
+		res, err := coverService.Upload(ctx, book.ID, []byte("not an image"))
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, controller.ErrMediaType)
+	})
+
+	t.Run("error: blob store fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{ID: utils.GenerateID()}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		blobStore := mock.NewMockBlobStore(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		gomock.InOrder(
+			blobStore.EXPECT().
+				Put(ctx, gomock.Any(), gomock.Any(), "image/png").
+				Times(1).
+				Return(nil),
+			blobStore.EXPECT().
+				Put(ctx, gomock.Any(), gomock.Any(), "image/png").
+				Times(1).
+				Return(errors.New("disk full")),
+		)
+
+		blobStore.EXPECT().
+			Delete(ctx, gomock.Any()).
+			Times(1).
+			Return(nil)
+
+		coverService := service.NewCoverService(bookRepository, blobStore, time.Hour)
+		res, err := coverService.Upload(ctx, book.ID, coverPNG(t))
+		assert.Nil(t, res)
+		assert.EqualError(t, err, controller.ErrInternalServer.Error())
+	})
+
+	t.Run("error: book not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		blobStore := mock.NewMockBlobStore(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, bookID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		coverService := service.NewCoverService(bookRepository, blobStore, time.Hour)
+		res, err := coverService.Upload(ctx, bookID, coverPNG(t))
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "id not found\n: book")
+	})
+}
+
+func TestCoverURL(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{
+			ID:        utils.GenerateID(),
+			CoverKey:  "covers/1/abc",
+			CoverType: "image/png",
+		}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		blobStore := mock.NewMockBlobStore(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		blobStore.EXPECT().
+			SignedURL(ctx, "covers/1/abc/small.png", time.Hour).
+			Times(1).
+			Return("https://cdn.example.com/covers/1/abc/small.png?signature=x", nil)
+
+		coverService := service.NewCoverService(bookRepository, blobStore, time.Hour)
+		url, expiresAt, err := coverService.URL(ctx, book.ID, model.CoverSmall)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://cdn.example.com/covers/1/abc/small.png?signature=x", url)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+	})
+
+	t.Run("error: invalid size", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		blobStore := mock.NewMockBlobStore(ctrl)
+
+		coverService := service.NewCoverService(bookRepository, blobStore, time.Hour)
+		_, _, err := coverService.URL(context.TODO(), utils.GenerateID(), "huge")
+		assert.ErrorIs(t, err, controller.ErrBadRequest)
+	})
+
+	t.Run("error: no cover", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		book := &model.Book{ID: utils.GenerateID()}
+
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		blobStore := mock.NewMockBlobStore(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, book.ID).
+			Times(1).
+			Return(book, nil)
+
+		coverService := service.NewCoverService(bookRepository, blobStore, time.Hour)
+		_, _, err := coverService.URL(ctx, book.ID, model.CoverSmall)
+		assert.EqualError(t, err, "id not found\n: cover")
+	})
+}
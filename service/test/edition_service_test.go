@@ -0,0 +1,230 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditionCreate(t *testing.T) {
+	t.Run("ok: isbn normalized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		publisherID := utils.GenerateID()
+		edition := &model.Edition{
+			WorkID:      utils.GenerateID(),
+			PublisherID: &publisherID,
+			ISBN:        "0-306-40615-2",
+			Format:      model.EditionFormatPaperback,
+		}
+
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+
+		editionRepository.EXPECT().
+			FindByISBN(ctx, "9780306406157").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		workRepository.EXPECT().
+			FindByID(ctx, edition.WorkID).
+			Times(1).
+			Return(&model.Work{ID: edition.WorkID}, nil)
+
+		publisherRepository.EXPECT().
+			FindByID(ctx, publisherID).
+			Times(1).
+			Return(&model.Publisher{ID: publisherID}, nil)
+
+		editionRepository.EXPECT().
+			Create(ctx, edition).
+			Times(1).
+			Return(edition, nil)
+
+		editionService := service.NewEditionService(editionRepository, workRepository, publisherRepository)
+		resEdition, err := editionService.Create(ctx, edition)
+		assert.Nil(t, err)
+		assert.Equal(t, "9780306406157", resEdition.ISBN)
+	})
+
+	t.Run("ok: without isbn and publisher", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		edition := &model.Edition{
+			WorkID: utils.GenerateID(),
+		}
+
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+
+		workRepository.EXPECT().
+			FindByID(ctx, edition.WorkID).
+			Times(1).
+			Return(&model.Work{ID: edition.WorkID}, nil)
+
+		editionRepository.EXPECT().
+			Create(ctx, edition).
+			Times(1).
+			Return(edition, nil)
+
+		editionService := service.NewEditionService(editionRepository, workRepository, publisherRepository)
+		resEdition, err := editionService.Create(ctx, edition)
+		assert.Nil(t, err)
+		assert.Equal(t, edition, resEdition)
+	})
+
+	t.Run("error: invalid isbn", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		edition := &model.Edition{
+			WorkID: utils.GenerateID(),
+			ISBN:   "0306406153",
+		}
+
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+
+		editionService := service.NewEditionService(editionRepository, workRepository, publisherRepository)
+		resEdition, err := editionService.Create(ctx, edition)
+		assert.Nil(t, resEdition)
+		assert.ErrorIs(t, err, controller.ErrBadRequest)
+	})
+
+	t.Run("error: isbn duplicate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		edition := &model.Edition{
+			WorkID: utils.GenerateID(),
+			ISBN:   "9780306406157",
+		}
+
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+
+		editionRepository.EXPECT().
+			FindByISBN(ctx, edition.ISBN).
+			Times(1).
+			Return(&model.Edition{ID: utils.GenerateID(), ISBN: edition.ISBN}, nil)
+
+		editionService := service.NewEditionService(editionRepository, workRepository, publisherRepository)
+		resEdition, err := editionService.Create(ctx, edition)
+		assert.Nil(t, resEdition)
+		assert.EqualError(t, err, "duplicate entry\n: isbn")
+	})
+
+	t.Run("error: publisher not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		publisherID := utils.GenerateID()
+		edition := &model.Edition{
+			WorkID:      utils.GenerateID(),
+			PublisherID: &publisherID,
+		}
+
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+
+		workRepository.EXPECT().
+			FindByID(ctx, edition.WorkID).
+			Times(1).
+			Return(&model.Work{ID: edition.WorkID}, nil)
+
+		publisherRepository.EXPECT().
+			FindByID(ctx, publisherID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		editionService := service.NewEditionService(editionRepository, workRepository, publisherRepository)
+		resEdition, err := editionService.Create(ctx, edition)
+		assert.Nil(t, resEdition)
+		assert.EqualError(t, err, "id not found\n: publisher")
+	})
+}
+
+func TestEditionUpdate(t *testing.T) {
+	t.Run("ok: unchanged isbn is not a duplicate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		edition := &model.Edition{
+			ID:      utils.GenerateID(),
+			WorkID:  utils.GenerateID(),
+			ISBN:    "978-0-306-40615-7",
+			Version: 2,
+		}
+		currEdition := &model.Edition{
+			ID:      edition.ID,
+			WorkID:  edition.WorkID,
+			ISBN:    "9780306406157",
+			Version: 2,
+		}
+
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+
+		editionRepository.EXPECT().
+			FindByID(ctx, edition.ID).
+			Times(1).
+			Return(currEdition, nil)
+
+		workRepository.EXPECT().
+			FindByID(ctx, edition.WorkID).
+			Times(1).
+			Return(&model.Work{ID: edition.WorkID}, nil)
+
+		editionRepository.EXPECT().
+			Update(ctx, edition).
+			Times(1).
+			Return(edition, nil)
+
+		editionService := service.NewEditionService(editionRepository, workRepository, publisherRepository)
+		resEdition, err := editionService.Update(ctx, edition)
+		assert.Nil(t, err)
+		assert.Equal(t, "9780306406157", resEdition.ISBN)
+	})
+
+	t.Run("error: version mismatch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		edition := &model.Edition{
+			ID:      utils.GenerateID(),
+			WorkID:  utils.GenerateID(),
+			Version: 1,
+		}
+
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+
+		editionRepository.EXPECT().
+			FindByID(ctx, edition.ID).
+			Times(1).
+			Return(&model.Edition{ID: edition.ID, Version: 3}, nil)
+
+		editionService := service.NewEditionService(editionRepository, workRepository, publisherRepository)
+		resEdition, err := editionService.Update(ctx, edition)
+		assert.Nil(t, resEdition)
+		assert.EqualError(t, err, "precondition failed\n: edition")
+	})
+}
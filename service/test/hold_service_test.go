@@ -0,0 +1,180 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const pickupWindow = 7 * 24 * time.Hour
+
+func TestHoldPlace(t *testing.T) {
+	t.Run("ok: ready at once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		userID := utils.GenerateID()
+		copyID := utils.GenerateID()
+		holdID := utils.GenerateID()
+
+		holdRepository := mock.NewMockHoldRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, bookID).
+			Times(1).
+			Return(&model.Book{ID: bookID}, nil)
+
+		accountService.EXPECT().
+			CheckStanding(ctx, userID).
+			Times(1).
+			Return(nil)
+
+		holdRepository.EXPECT().
+			FindActive(ctx, bookID, userID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		holdRepository.EXPECT().
+			Create(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, hold *model.Hold, readyAt, expiresAt time.Time) (*model.Hold, error) {
+				assert.Equal(t, model.HoldStatusWaiting, hold.Status)
+				assert.Equal(t, pickupWindow, expiresAt.Sub(readyAt))
+				return &model.Hold{ID: holdID, BookID: bookID, UserID: userID, Status: model.HoldStatusReady, CopyID: &copyID}, nil
+			})
+
+		holdService := service.NewHoldService(holdRepository, bookRepository, accountService, pickupWindow)
+		hold, err := holdService.Place(ctx, bookID, userID)
+		assert.Nil(t, err)
+		assert.Equal(t, model.HoldStatusReady, hold.Status)
+		assert.Equal(t, copyID, *hold.CopyID)
+	})
+
+	t.Run("error: duplicate hold", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		userID := utils.GenerateID()
+
+		holdRepository := mock.NewMockHoldRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, bookID).
+			Times(1).
+			Return(&model.Book{ID: bookID}, nil)
+
+		accountService.EXPECT().
+			CheckStanding(ctx, userID).
+			Times(1).
+			Return(nil)
+
+		holdRepository.EXPECT().
+			FindActive(ctx, bookID, userID).
+			Times(1).
+			Return(&model.Hold{ID: utils.GenerateID()}, nil)
+
+		holdService := service.NewHoldService(holdRepository, bookRepository, accountService, pickupWindow)
+		hold, err := holdService.Place(ctx, bookID, userID)
+		assert.Nil(t, hold)
+		assert.EqualError(t, err, "duplicate entry\n: hold")
+	})
+}
+
+func TestHoldCancel(t *testing.T) {
+	t.Run("ok: copy goes to the next hold", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		copyID := utils.GenerateID()
+		holdID := utils.GenerateID()
+
+		holdRepository := mock.NewMockHoldRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		holdRepository.EXPECT().
+			Cancel(ctx, holdID, gomock.Any()).
+			Times(1).
+			Return(&model.Hold{ID: holdID, BookID: bookID, Status: model.HoldStatusCancelled, CopyID: &copyID}, nil)
+
+		holdRepository.EXPECT().
+			AssignNext(ctx, bookID, gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		holdService := service.NewHoldService(holdRepository, bookRepository, accountService, pickupWindow)
+		hold, err := holdService.Cancel(ctx, holdID)
+		assert.Nil(t, err)
+		assert.Equal(t, model.HoldStatusCancelled, hold.Status)
+	})
+
+	t.Run("error: hold is closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		holdID := utils.GenerateID()
+
+		holdRepository := mock.NewMockHoldRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		holdRepository.EXPECT().
+			Cancel(ctx, holdID, gomock.Any()).
+			Times(1).
+			Return(nil, model.ErrHoldClosed)
+
+		holdService := service.NewHoldService(holdRepository, bookRepository, accountService, pickupWindow)
+		hold, err := holdService.Cancel(ctx, holdID)
+		assert.Nil(t, hold)
+		assert.EqualError(t, err, "conflict\n: hold is closed")
+	})
+}
+
+func TestHoldExpirePickups(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+
+		holdRepository := mock.NewMockHoldRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		holdRepository.EXPECT().
+			ExpireReady(ctx, gomock.Any()).
+			Times(1).
+			Return([]*model.Hold{{ID: utils.GenerateID(), BookID: bookID}, {ID: utils.GenerateID(), BookID: bookID}}, nil)
+
+		holdRepository.EXPECT().
+			FindPendingBookIDs(ctx).
+			Times(1).
+			Return([]int64{bookID}, nil)
+
+		holdRepository.EXPECT().
+			AssignNext(ctx, bookID, gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		holdService := service.NewHoldService(holdRepository, bookRepository, accountService, pickupWindow)
+		count, err := holdService.ExpirePickups(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
@@ -0,0 +1,435 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/marc"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportCreate(t *testing.T) {
+	t.Run("error: unknown format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		job := &model.ImportJob{
+			UserID: utils.GenerateID(),
+			Format: "xlsx",
+		}
+
+		importJobRepository := mock.NewMockImportJobRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		bookService := mock.NewMockBookService(ctrl)
+
+		importService := service.NewImportService(ctx, importJobRepository, authorRepository, publisherRepository, bookService)
+		resJob, err := importService.Create(ctx, job, []byte("isbn,title\n"))
+		assert.Nil(t, resJob)
+		assert.ErrorIs(t, err, controller.ErrBadRequest)
+	})
+}
+
+func TestImportRun(t *testing.T) {
+	t.Run("ok: csv", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		job := &model.ImportJob{
+			ID:     utils.GenerateID(),
+			UserID: utils.GenerateID(),
+			Format: model.ImportFormatCSV,
+		}
+
+		author := &model.Author{
+			ID:        utils.GenerateID(),
+			Name:      "Pramoedya Ananta Toer",
+			BirthDate: gofakeit.Date(),
+		}
+
+		data := []byte("isbn,title,author_name,author_birth_date\n" +
+			"9789295055025,Bumi Manusia,Pramoedya Ananta Toer,\n" +
+			"9780323776714,Anak Semua Bangsa,pramoedya ananta toer,\n" +
+			"9780323776714,Jejak Langkah,Pramoedya Ananta Toer,\n" +
+			"not-an-isbn,Rumah Kaca,Pramoedya Ananta Toer,\n" +
+			"9789353008956,Laskar Pelangi,Andrea Hirata,1967-03-24\n")
+
+		importJobRepository := mock.NewMockImportJobRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		bookService := mock.NewMockBookService(ctrl)
+
+		importJobRepository.EXPECT().
+			Update(gomock.Any(), job).
+			AnyTimes().
+			Return(job, nil)
+
+		bookService.EXPECT().
+			FindByISBN(ctx, gomock.Any()).
+			Times(3).
+			Return(nil, controller.ErrNotFound)
+
+		authorRepository.EXPECT().
+			FindByName(ctx, "Pramoedya Ananta Toer").
+			Times(1).
+			Return(author, nil)
+
+		authorRepository.EXPECT().
+			FindByName(ctx, "Andrea Hirata").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, author *model.Author) (*model.Author, error) {
+				author.ID = utils.GenerateID()
+				return author, nil
+			})
+
+		bookService.EXPECT().
+			CreateWithEdition(ctx, gomock.Any(), nil).
+			Times(3).
+			DoAndReturn(func(_ context.Context, book *model.Book, _ *model.Edition) (*model.Book, error) {
+				return book, nil
+			})
+
+		importService := service.NewImportService(ctx, importJobRepository, authorRepository, publisherRepository, bookService)
+		importService.Run(ctx, job, data)
+		assert.Equal(t, model.ImportStatusCompleted, job.Status)
+		assert.Equal(t, 5, job.TotalRows)
+		assert.Equal(t, 5, job.ProcessedRows)
+		assert.Equal(t, 3, job.CreatedBooks)
+		assert.Equal(t, 1, job.CreatedAuthors)
+		assert.Equal(t, 2, job.FailedRows)
+		assert.NotNil(t, job.FinishedAt)
+		assert.Equal(t, 3, job.Errors[0].Row)
+		assert.Equal(t, "duplicate of row 2", job.Errors[0].Message)
+		assert.Equal(t, 4, job.Errors[1].Row)
+		assert.Equal(t, "ISBN", job.Errors[1].Field)
+	})
+
+	t.Run("ok: ndjson dry run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		job := &model.ImportJob{
+			ID:     utils.GenerateID(),
+			UserID: utils.GenerateID(),
+			Format: model.ImportFormatNDJSON,
+			DryRun: true,
+		}
+
+		existing := &model.Book{
+			ID:    utils.GenerateID(),
+			ISBN:  "9789295055025",
+			Title: gofakeit.BookTitle(),
+		}
+
+		data := []byte(`{"isbn":"9789295055025","title":"Taken","author_name":"Someone"}` + "\n" +
+			`{"isbn":"9780323776714","title":"New","author_name":"Ayu Utami","author_birth_date":"1968-11-21"}` + "\n" +
+			`{"isbn":"9789353008956","title":"Also New","author_name":"Ayu Utami"}` + "\n" +
+			`{"isbn":` + "\n")
+
+		importJobRepository := mock.NewMockImportJobRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		bookService := mock.NewMockBookService(ctrl)
+
+		importJobRepository.EXPECT().
+			Update(gomock.Any(), job).
+			AnyTimes().
+			Return(job, nil)
+
+		bookService.EXPECT().
+			FindByISBN(ctx, existing.ISBN).
+			Times(1).
+			Return(existing, nil)
+
+		bookService.EXPECT().
+			FindByISBN(ctx, gomock.Any()).
+			Times(2).
+			Return(nil, controller.ErrNotFound)
+
+		authorRepository.EXPECT().
+			FindByName(ctx, "Ayu Utami").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		importService := service.NewImportService(ctx, importJobRepository, authorRepository, publisherRepository, bookService)
+		importService.Run(ctx, job, data)
+		assert.Equal(t, model.ImportStatusCompleted, job.Status)
+		assert.Equal(t, 4, job.TotalRows)
+		assert.Equal(t, 2, job.CreatedBooks)
+		assert.Equal(t, 1, job.CreatedAuthors)
+		assert.Equal(t, 2, job.FailedRows)
+		assert.Equal(t, "duplicate entry: isbn", job.Errors[0].Message)
+		assert.Equal(t, 4, job.Errors[1].Row)
+	})
+
+	t.Run("ok: marc", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		job := &model.ImportJob{
+			ID:     utils.GenerateID(),
+			UserID: utils.GenerateID(),
+			Format: model.ImportFormatMARC,
+		}
+
+		record := &marc.Record{}
+		record.AddControl("008", "800101s1980    io            000 1 ind d")
+		record.AddField("020", ' ', ' ', marc.Subfield{Code: 'a', Value: "9789295055025 (pbk.)"})
+		record.AddField("100", '1', ' ',
+			marc.Subfield{Code: 'a', Value: "Toer, Pramoedya Ananta,"},
+			marc.Subfield{Code: 'd', Value: "1925-2006."},
+		)
+		record.AddField("245", '1', '0',
+			marc.Subfield{Code: 'a', Value: "Bumi manusia :"},
+			marc.Subfield{Code: 'b', Value: "sebuah roman /"},
+		)
+		record.AddField("264", ' ', '1',
+			marc.Subfield{Code: 'a', Value: "Jakarta :"},
+			marc.Subfield{Code: 'b', Value: "Hasta Mitra,"},
+			marc.Subfield{Code: 'c', Value: "1980."},
+		)
+		untitled := &marc.Record{}
+		untitled.AddField("020", ' ', ' ', marc.Subfield{Code: 'a', Value: "9780323776714"})
+
+		data := []byte{}
+		for _, r := range []*marc.Record{record, untitled} {
+			encoded, err := marc.Marshal(r)
+			assert.Nil(t, err)
+			data = append(data, encoded...)
+		}
+
+		importJobRepository := mock.NewMockImportJobRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		bookService := mock.NewMockBookService(ctrl)
+
+		importJobRepository.EXPECT().
+			Update(gomock.Any(), job).
+			AnyTimes().
+			Return(job, nil)
+
+		bookService.EXPECT().
+			FindByISBN(ctx, "9789295055025").
+			Times(1).
+			Return(nil, controller.ErrNotFound)
+
+		authorRepository.EXPECT().
+			FindByName(ctx, "Pramoedya Ananta Toer").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		authorRepository.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, author *model.Author) (*model.Author, error) {
+				assert.Equal(t, "1925-01-01", author.BirthDate.Format(time.DateOnly))
+				author.ID = utils.GenerateID()
+				return author, nil
+			})
+
+		publisherID := utils.GenerateID()
+		publisherRepository.EXPECT().
+			FindByName(ctx, "Hasta Mitra").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		publisherRepository.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, publisher *model.Publisher) (*model.Publisher, error) {
+				publisher.ID = publisherID
+				return publisher, nil
+			})
+
+		bookService.EXPECT().
+			CreateWithEdition(ctx, gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, book *model.Book, edition *model.Edition) (*model.Book, error) {
+				assert.Equal(t, "Bumi manusia", book.Title)
+				assert.Equal(t, "sebuah roman", book.Subtitle)
+				assert.Equal(t, &publisherID, edition.PublisherID)
+				assert.Equal(t, "id", edition.Language)
+				assert.Equal(t, "1980-01-01", edition.PublicationDate.Format(time.DateOnly))
+				return book, nil
+			})
+
+		importService := service.NewImportService(ctx, importJobRepository, authorRepository, publisherRepository, bookService)
+		importService.Run(ctx, job, data)
+		assert.Equal(t, model.ImportStatusCompleted, job.Status)
+		assert.Equal(t, 2, job.TotalRows)
+		assert.Equal(t, 1, job.CreatedBooks)
+		assert.Equal(t, 1, job.CreatedAuthors)
+		assert.Equal(t, 1, job.FailedRows)
+		assert.Equal(t, 2, job.Errors[0].Row)
+		assert.Equal(t, marc.ErrNoTitle.Error(), job.Errors[0].Message)
+	})
+
+	t.Run("ok: marcxml syntax error ends the document", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		job := &model.ImportJob{
+			ID:     utils.GenerateID(),
+			UserID: utils.GenerateID(),
+			Format: model.ImportFormatMARCXML,
+			DryRun: true,
+		}
+
+		data := []byte(`<collection xmlns="http://www.loc.gov/MARC21/slim">
+  <record>
+    <datafield tag="020" ind1=" " ind2=" "><subfield code="a">9789295055025</subfield></datafield>
+    <datafield tag="100" ind1="1" ind2=" "><subfield code="a">Toer, Pramoedya Ananta.</subfield></datafield>
+    <datafield tag="245" ind1="1" ind2="0"><subfield code="a">Bumi manusia.</subfield></datafield>
+  </record>
+  <record><datafield tag="245"`)
+
+		importJobRepository := mock.NewMockImportJobRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		bookService := mock.NewMockBookService(ctrl)
+
+		importJobRepository.EXPECT().
+			Update(gomock.Any(), job).
+			AnyTimes().
+			Return(job, nil)
+
+		bookService.EXPECT().
+			FindByISBN(ctx, "9789295055025").
+			Times(1).
+			Return(nil, controller.ErrNotFound)
+
+		authorRepository.EXPECT().
+			FindByName(ctx, "Pramoedya Ananta Toer").
+			Times(1).
+			Return(&model.Author{ID: utils.GenerateID()}, nil)
+
+		importService := service.NewImportService(ctx, importJobRepository, authorRepository, publisherRepository, bookService)
+		importService.Run(ctx, job, data)
+		assert.Equal(t, model.ImportStatusCompleted, job.Status)
+		assert.Equal(t, 2, job.TotalRows)
+		assert.Equal(t, 1, job.CreatedBooks)
+		assert.Equal(t, 1, job.FailedRows)
+		assert.Equal(t, 2, job.Errors[0].Row)
+	})
+
+	t.Run("error: missing csv column", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		job := &model.ImportJob{
+			ID:     utils.GenerateID(),
+			UserID: utils.GenerateID(),
+			Format: model.ImportFormatCSV,
+		}
+
+		importJobRepository := mock.NewMockImportJobRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		bookService := mock.NewMockBookService(ctrl)
+
+		importJobRepository.EXPECT().
+			Update(gomock.Any(), job).
+			Times(1).
+			Return(job, nil)
+
+		importService := service.NewImportService(ctx, importJobRepository, authorRepository, publisherRepository, bookService)
+		importService.Run(ctx, job, []byte("title,author_name\nFoo,Bar\n"))
+		assert.Equal(t, model.ImportStatusFailed, job.Status)
+		assert.Len(t, job.Errors, 1)
+	})
+
+	t.Run("ok: cancelled context fails the job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		job := &model.ImportJob{
+			ID:     utils.GenerateID(),
+			UserID: utils.GenerateID(),
+			Format: model.ImportFormatCSV,
+		}
+
+		importJobRepository := mock.NewMockImportJobRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		bookService := mock.NewMockBookService(ctrl)
+
+		importJobRepository.EXPECT().
+			Update(gomock.Any(), job).
+			Times(2).
+			Return(job, nil)
+
+		importService := service.NewImportService(ctx, importJobRepository, authorRepository, publisherRepository, bookService)
+		importService.Run(ctx, job, []byte("isbn,title\n9789295055025,Bumi Manusia\n"))
+		assert.Equal(t, model.ImportStatusFailed, job.Status)
+		assert.Equal(t, 0, job.ProcessedRows)
+		assert.Equal(t, model.ImportInterrupted, job.Errors[0].Message)
+		assert.NotNil(t, job.FinishedAt)
+	})
+}
+
+func TestImportFailUnfinished(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		importJobRepository := mock.NewMockImportJobRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		bookService := mock.NewMockBookService(ctrl)
+
+		importJobRepository.EXPECT().
+			FailUnfinished(ctx, gomock.Any()).
+			Times(1).
+			Return(int64(2), nil)
+
+		importService := service.NewImportService(ctx, importJobRepository, authorRepository, publisherRepository, bookService)
+		count, err := importService.FailUnfinished(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+}
+
+func TestImportFindByID(t *testing.T) {
+	t.Run("error: other user's job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		job := &model.ImportJob{
+			ID:     utils.GenerateID(),
+			UserID: utils.GenerateID(),
+			Format: model.ImportFormatCSV,
+		}
+
+		importJobRepository := mock.NewMockImportJobRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		bookService := mock.NewMockBookService(ctrl)
+
+		importJobRepository.EXPECT().
+			FindByID(ctx, job.ID).
+			Times(1).
+			Return(job, nil)
+
+		importService := service.NewImportService(ctx, importJobRepository, authorRepository, publisherRepository, bookService)
+		resJob, err := importService.FindByID(ctx, job.ID, utils.GenerateID())
+		assert.Nil(t, resJob)
+		assert.EqualError(t, err, "id not found\n: import")
+	})
+}
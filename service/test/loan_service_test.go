@@ -0,0 +1,378 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const loanPeriod = 21 * 24 * time.Hour
+
+func TestLoanCheckout(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookCopy := &model.Copy{ID: utils.GenerateID(), Barcode: "31234000123456"}
+		userID := utils.GenerateID()
+
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		holdService := mock.NewMockHoldService(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		copyRepository.EXPECT().
+			FindByBarcode(ctx, bookCopy.Barcode).
+			Times(1).
+			Return(bookCopy, nil)
+
+		userRepository.EXPECT().
+			FindByID(ctx, userID).
+			Times(1).
+			Return(&model.User{ID: userID}, nil)
+
+		accountService.EXPECT().
+			CheckStanding(ctx, userID).
+			Times(1).
+			Return(nil)
+
+		loanRepository.EXPECT().
+			Checkout(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, loan *model.Loan) (*model.Loan, error) {
+				return loan, nil
+			})
+
+		holdService.EXPECT().
+			Allocate(ctx, bookCopy.BookID).
+			Times(1).
+			Return(nil)
+
+		loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, loanPeriod, 2)
+		loan, err := loanService.Checkout(ctx, bookCopy.Barcode, userID)
+		assert.Nil(t, err)
+		assert.Equal(t, bookCopy.ID, loan.CopyID)
+		assert.Equal(t, userID, loan.UserID)
+		assert.Equal(t, loanPeriod, loan.DueAt.Sub(loan.CheckedOutAt))
+		assert.Nil(t, loan.ReturnedAt)
+	})
+
+	t.Run("error: copy is on loan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookCopy := &model.Copy{ID: utils.GenerateID(), Barcode: "31234000123456"}
+		userID := utils.GenerateID()
+
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		holdService := mock.NewMockHoldService(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		copyRepository.EXPECT().
+			FindByBarcode(ctx, bookCopy.Barcode).
+			Times(1).
+			Return(bookCopy, nil)
+
+		userRepository.EXPECT().
+			FindByID(ctx, userID).
+			Times(1).
+			Return(&model.User{ID: userID}, nil)
+
+		accountService.EXPECT().
+			CheckStanding(ctx, userID).
+			Times(1).
+			Return(nil)
+
+		loanRepository.EXPECT().
+			Checkout(ctx, gomock.Any()).
+			Times(1).
+			Return(nil, model.ErrCopyOnLoan)
+
+		loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, loanPeriod, 2)
+		loan, err := loanService.Checkout(ctx, bookCopy.Barcode, userID)
+		assert.Nil(t, loan)
+		assert.EqualError(t, err, "conflict\n: copy is on loan")
+	})
+
+	t.Run("error: unknown barcode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		holdService := mock.NewMockHoldService(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		copyRepository.EXPECT().
+			FindByBarcode(ctx, "missing").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, loanPeriod, 2)
+		loan, err := loanService.Checkout(ctx, "missing", utils.GenerateID())
+		assert.Nil(t, loan)
+		assert.EqualError(t, err, "id not found\n: copy")
+	})
+}
+
+func TestLoanCheckin(t *testing.T) {
+	t.Run("error: copy is not on loan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookCopy := &model.Copy{ID: utils.GenerateID(), Barcode: "31234000123456"}
+
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		holdService := mock.NewMockHoldService(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		copyRepository.EXPECT().
+			FindByBarcode(ctx, bookCopy.Barcode).
+			Times(1).
+			Return(bookCopy, nil)
+
+		loanRepository.EXPECT().
+			Checkin(ctx, bookCopy.ID, gomock.Any()).
+			Times(1).
+			Return(nil, model.ErrCopyNotOnLoan)
+
+		loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, loanPeriod, 2)
+		loan, err := loanService.Checkin(ctx, bookCopy.Barcode)
+		assert.Nil(t, loan)
+		assert.EqualError(t, err, "conflict\n: copy is not on loan")
+	})
+}
+
+func TestLoanRenew(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		checkedOutAt := time.Now().Add(-20 * 24 * time.Hour)
+		loan := &model.Loan{
+			ID:           utils.GenerateID(),
+			CopyID:       utils.GenerateID(),
+			UserID:       utils.GenerateID(),
+			CheckedOutAt: checkedOutAt,
+			DueAt:        checkedOutAt.Add(loanPeriod),
+			Renewals:     1,
+			Version:      2,
+		}
+		bookID := utils.GenerateID()
+
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		holdService := mock.NewMockHoldService(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		loanRepository.EXPECT().
+			FindByID(ctx, loan.ID).
+			Times(1).
+			Return(loan, nil)
+
+		accountService.EXPECT().
+			CheckStanding(ctx, loan.UserID).
+			Times(1).
+			Return(nil)
+
+		copyRepository.EXPECT().
+			FindByID(ctx, loan.CopyID).
+			Times(1).
+			Return(&model.Copy{ID: loan.CopyID, BookID: bookID}, nil)
+
+		holdService.EXPECT().
+			FindAvailability(ctx, bookID).
+			Times(1).
+			Return(&model.Availability{BookID: bookID, Copies: 1}, nil)
+
+		loanRepository.EXPECT().
+			Renew(ctx, loan).
+			Times(1).
+			Return(loan, nil)
+
+		loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, loanPeriod, 2)
+		resLoan, err := loanService.Renew(ctx, loan.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, resLoan.Renewals)
+		assert.WithinDuration(t, time.Now().Add(loanPeriod), resLoan.DueAt, time.Minute)
+	})
+
+	t.Run("error: account blocked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		loan := &model.Loan{
+			ID:     utils.GenerateID(),
+			UserID: utils.GenerateID(),
+		}
+
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		holdService := mock.NewMockHoldService(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		loanRepository.EXPECT().
+			FindByID(ctx, loan.ID).
+			Times(1).
+			Return(loan, nil)
+
+		accountService.EXPECT().
+			CheckStanding(ctx, loan.UserID).
+			Times(1).
+			Return(errors.Join(controller.ErrForbidden, errors.New(": account balance exceeds limit")))
+
+		loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, loanPeriod, 2)
+		resLoan, err := loanService.Renew(ctx, loan.ID)
+		assert.Nil(t, resLoan)
+		assert.EqualError(t, err, "forbidden\n: account balance exceeds limit")
+	})
+
+	t.Run("error: loan is overdue", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		loan := &model.Loan{
+			ID:     utils.GenerateID(),
+			UserID: utils.GenerateID(),
+			DueAt:  time.Now().Add(-24 * time.Hour),
+		}
+
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		holdService := mock.NewMockHoldService(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		loanRepository.EXPECT().
+			FindByID(ctx, loan.ID).
+			Times(1).
+			Return(loan, nil)
+
+		accountService.EXPECT().
+			CheckStanding(ctx, loan.UserID).
+			Times(1).
+			Return(nil)
+
+		loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, loanPeriod, 2)
+		resLoan, err := loanService.Renew(ctx, loan.ID)
+		assert.Nil(t, resLoan)
+		assert.EqualError(t, err, "conflict\n: loan is overdue")
+	})
+
+	t.Run("error: book has waiting holds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		loan := &model.Loan{
+			ID:     utils.GenerateID(),
+			CopyID: utils.GenerateID(),
+			UserID: utils.GenerateID(),
+			DueAt:  time.Now().Add(24 * time.Hour),
+		}
+		bookID := utils.GenerateID()
+
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		holdService := mock.NewMockHoldService(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		loanRepository.EXPECT().
+			FindByID(ctx, loan.ID).
+			Times(1).
+			Return(loan, nil)
+
+		accountService.EXPECT().
+			CheckStanding(ctx, loan.UserID).
+			Times(1).
+			Return(nil)
+
+		copyRepository.EXPECT().
+			FindByID(ctx, loan.CopyID).
+			Times(1).
+			Return(&model.Copy{ID: loan.CopyID, BookID: bookID}, nil)
+
+		holdService.EXPECT().
+			FindAvailability(ctx, bookID).
+			Times(1).
+			Return(&model.Availability{BookID: bookID, Copies: 1, WaitingHolds: 1}, nil)
+
+		loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, loanPeriod, 2)
+		resLoan, err := loanService.Renew(ctx, loan.ID)
+		assert.Nil(t, resLoan)
+		assert.EqualError(t, err, "conflict\n: book has waiting holds")
+	})
+
+	t.Run("error: renewal limit reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		loan := &model.Loan{
+			ID:       utils.GenerateID(),
+			Renewals: 2,
+		}
+
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		holdService := mock.NewMockHoldService(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		loanRepository.EXPECT().
+			FindByID(ctx, loan.ID).
+			Times(1).
+			Return(loan, nil)
+
+		loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, loanPeriod, 2)
+		resLoan, err := loanService.Renew(ctx, loan.ID)
+		assert.Nil(t, resLoan)
+		assert.EqualError(t, err, "conflict\n: renewal limit reached")
+	})
+
+	t.Run("error: loan is returned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		returnedAt := time.Now()
+		loan := &model.Loan{
+			ID:         utils.GenerateID(),
+			ReturnedAt: &returnedAt,
+		}
+
+		loanRepository := mock.NewMockLoanRepository(ctrl)
+		copyRepository := mock.NewMockCopyRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		holdService := mock.NewMockHoldService(ctrl)
+		accountService := mock.NewMockAccountService(ctrl)
+
+		loanRepository.EXPECT().
+			FindByID(ctx, loan.ID).
+			Times(1).
+			Return(loan, nil)
+
+		loanService := service.NewLoanService(loanRepository, copyRepository, userRepository, holdService, accountService, loanPeriod, 2)
+		resLoan, err := loanService.Renew(ctx, loan.ID)
+		assert.Nil(t, resLoan)
+		assert.EqualError(t, err, "conflict\n: loan is returned")
+	})
+}
@@ -0,0 +1,110 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataLookup(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		author := &model.Author{
+			ID:   utils.GenerateID(),
+			Name: "First Author",
+		}
+		metadata := &model.BookMetadata{
+			ISBN:    "9780306406157",
+			Title:   "Fixture Book",
+			Authors: []model.MetadataAuthor{{Name: "First Author"}, {Name: "Second Author"}},
+		}
+
+		metadataProvider := mock.NewMockMetadataProvider(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+
+		metadataProvider.EXPECT().
+			LookupISBN(ctx, "9780306406157").
+			Times(1).
+			Return(metadata, nil)
+
+		authorRepository.EXPECT().
+			FindByName(ctx, "First Author").
+			Times(1).
+			Return(author, nil)
+
+		authorRepository.EXPECT().
+			FindByName(ctx, "Second Author").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		metadataService := service.NewMetadataService(metadataProvider, authorRepository)
+		res, err := metadataService.Lookup(ctx, "0-306-40615-2")
+		assert.Nil(t, err)
+		assert.Equal(t, []model.MetadataAuthor{
+			{Name: "First Author", AuthorID: author.ID},
+			{Name: "Second Author"},
+		}, res.Authors)
+	})
+
+	t.Run("error: invalid isbn", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		metadataProvider := mock.NewMockMetadataProvider(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+
+		metadataService := service.NewMetadataService(metadataProvider, authorRepository)
+		res, err := metadataService.Lookup(context.TODO(), "9780306406158")
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, controller.ErrBadRequest)
+	})
+
+	t.Run("error: not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		metadataProvider := mock.NewMockMetadataProvider(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+
+		metadataProvider.EXPECT().
+			LookupISBN(ctx, "9780306406157").
+			Times(1).
+			Return(nil, model.ErrMetadataNotFound)
+
+		metadataService := service.NewMetadataService(metadataProvider, authorRepository)
+		res, err := metadataService.Lookup(ctx, "9780306406157")
+		assert.Nil(t, res)
+		assert.EqualError(t, err, "id not found\n: metadata")
+	})
+
+	t.Run("error: provider unavailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		metadataProvider := mock.NewMockMetadataProvider(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+
+		metadataProvider.EXPECT().
+			LookupISBN(ctx, "9780306406157").
+			Times(1).
+			Return(nil, errors.Join(model.ErrProviderUnavailable, errors.New("timeout")))
+
+		metadataService := service.NewMetadataService(metadataProvider, authorRepository)
+		res, err := metadataService.Lookup(ctx, "9780306406157")
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, controller.ErrBadGateway)
+	})
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublisherCreate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		publisher := &model.Publisher{
+			Name:    gofakeit.Company(),
+			Website: gofakeit.URL(),
+		}
+
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		publisherRepository.EXPECT().
+			Create(ctx, publisher).
+			Times(1).
+			Return(publisher, nil)
+
+		publisherService := service.NewPublisherService(publisherRepository, editionRepository)
+		resPublisher, err := publisherService.Create(ctx, publisher)
+		assert.Nil(t, err)
+		assert.Equal(t, publisher, resPublisher)
+	})
+}
+
+func TestPublisherUpdate(t *testing.T) {
+	t.Run("error: stale version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		publisher := &model.Publisher{
+			ID:      utils.GenerateID(),
+			Name:    gofakeit.Company(),
+			Version: 1,
+		}
+
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		publisherRepository.EXPECT().
+			Update(ctx, publisher).
+			Times(1).
+			Return(nil, model.ErrStaleVersion)
+
+		publisherService := service.NewPublisherService(publisherRepository, editionRepository)
+		resPublisher, err := publisherService.Update(ctx, publisher)
+		assert.Nil(t, resPublisher)
+		assert.EqualError(t, err, "precondition failed\n: publisher")
+	})
+}
+
+func TestPublisherDelete(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		publisherID := utils.GenerateID()
+
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		editionRepository.EXPECT().
+			CountByPublisherID(ctx, publisherID).
+			Times(1).
+			Return(int64(0), nil)
+
+		publisherRepository.EXPECT().
+			Delete(ctx, publisherID).
+			Times(1).
+			Return(nil)
+
+		publisherService := service.NewPublisherService(publisherRepository, editionRepository)
+		err := publisherService.Delete(ctx, publisherID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("error: publisher has editions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		publisherID := utils.GenerateID()
+
+		publisherRepository := mock.NewMockPublisherRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+
+		editionRepository.EXPECT().
+			CountByPublisherID(ctx, publisherID).
+			Times(1).
+			Return(int64(2), nil)
+
+		publisherService := service.NewPublisherService(publisherRepository, editionRepository)
+		err := publisherService.Delete(ctx, publisherID)
+		assert.EqualError(t, err, "dependent entries exist\n: publisher has 2 editions")
+	})
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecommendationFindSimilar(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		userID := utils.GenerateID()
+		pagination := model.Pagination{Page: 1, PerPage: 20}
+		similar := []*model.Recommendation{{Book: model.Book{ID: utils.GenerateID()}, Score: 1.5}}
+
+		recommendationRepository := mock.NewMockRecommendationRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, bookID).
+			Times(1).
+			Return(&model.Book{ID: bookID}, nil)
+
+		recommendationRepository.EXPECT().
+			FindSimilar(ctx, bookID, userID, pagination).
+			Times(1).
+			Return(similar, nil)
+
+		recommendationService := service.NewRecommendationService(recommendationRepository, bookRepository, 50)
+		resSimilar, err := recommendationService.FindSimilar(ctx, bookID, userID, pagination)
+		assert.Nil(t, err)
+		assert.Equal(t, similar, resSimilar)
+	})
+
+	t.Run("error: book not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+
+		recommendationRepository := mock.NewMockRecommendationRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, bookID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		recommendationService := service.NewRecommendationService(recommendationRepository, bookRepository, 50)
+		resSimilar, err := recommendationService.FindSimilar(ctx, bookID, utils.GenerateID(), model.Pagination{Page: 1, PerPage: 20})
+		assert.Nil(t, resSimilar)
+		assert.EqualError(t, err, "id not found\n: book")
+	})
+}
+
+func TestRecommendationRefresh(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		recommendationRepository := mock.NewMockRecommendationRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		recommendationRepository.EXPECT().
+			Recompute(ctx, 25, gomock.Any()).
+			Times(1).
+			Return(int64(120), nil)
+
+		recommendationService := service.NewRecommendationService(recommendationRepository, bookRepository, 25)
+		stored, err := recommendationService.Refresh(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(120), stored)
+	})
+}
@@ -0,0 +1,207 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewCreate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		review := &model.Review{BookID: utils.GenerateID(), UserID: utils.GenerateID(), Rating: 4, Body: "Worth it."}
+
+		reviewRepository := mock.NewMockReviewRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, review.BookID).
+			Times(1).
+			Return(&model.Book{ID: review.BookID}, nil)
+
+		reviewRepository.EXPECT().
+			FindByBookAndUser(ctx, review.BookID, review.UserID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		reviewRepository.EXPECT().
+			Create(ctx, review).
+			Times(1).
+			Return(review, nil)
+
+		reviewService := service.NewReviewService(reviewRepository, bookRepository)
+		resReview, err := reviewService.Create(ctx, review)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, resReview.Rating)
+	})
+
+	t.Run("error: rating out of range", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		reviewRepository := mock.NewMockReviewRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		reviewService := service.NewReviewService(reviewRepository, bookRepository)
+		review, err := reviewService.Create(ctx, &model.Review{BookID: utils.GenerateID(), Rating: 6})
+		assert.Nil(t, review)
+		assert.EqualError(t, err, "bad request\n: rating must be between 1 and 5")
+	})
+
+	t.Run("error: already reviewed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		review := &model.Review{BookID: utils.GenerateID(), UserID: utils.GenerateID(), Rating: 2}
+
+		reviewRepository := mock.NewMockReviewRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, review.BookID).
+			Times(1).
+			Return(&model.Book{ID: review.BookID}, nil)
+
+		reviewRepository.EXPECT().
+			FindByBookAndUser(ctx, review.BookID, review.UserID).
+			Times(1).
+			Return(&model.Review{ID: utils.GenerateID()}, nil)
+
+		reviewService := service.NewReviewService(reviewRepository, bookRepository)
+		resReview, err := reviewService.Create(ctx, review)
+		assert.Nil(t, resReview)
+		assert.EqualError(t, err, "duplicate entry\n: review")
+	})
+}
+
+func TestReviewFindAllByBookID(t *testing.T) {
+	t.Run("ok: sorted by helpfulness by default", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		pagination := model.Pagination{Page: 2, PerPage: 10}
+		reviews := []*model.Review{{ID: utils.GenerateID(), BookID: bookID, Rating: 5}}
+
+		reviewRepository := mock.NewMockReviewRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, bookID).
+			Times(1).
+			Return(&model.Book{ID: bookID}, nil)
+
+		reviewRepository.EXPECT().
+			FindAllByBookID(ctx, bookID, model.ReviewSortHelpful, false, pagination).
+			Times(1).
+			Return(reviews, int64(11), nil)
+
+		reviewService := service.NewReviewService(reviewRepository, bookRepository)
+		page, err := reviewService.FindAllByBookID(ctx, bookID, "", false, pagination)
+		assert.Nil(t, err)
+		assert.Equal(t, reviews, page.Items)
+		assert.Equal(t, 2, page.Page)
+		assert.Equal(t, 10, page.PerPage)
+		assert.Equal(t, int64(11), page.Total)
+	})
+
+	t.Run("error: unknown sort", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		reviewRepository := mock.NewMockReviewRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		reviewService := service.NewReviewService(reviewRepository, bookRepository)
+		page, err := reviewService.FindAllByBookID(ctx, utils.GenerateID(), "title", false, model.Pagination{Page: 1, PerPage: 20})
+		assert.Nil(t, page)
+		assert.EqualError(t, err, "bad request\n: sort")
+	})
+}
+
+func TestReviewUpdate(t *testing.T) {
+	t.Run("error: stale version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		review := &model.Review{ID: utils.GenerateID(), Rating: 3, Version: 1}
+
+		reviewRepository := mock.NewMockReviewRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		reviewRepository.EXPECT().
+			FindByID(ctx, review.ID).
+			Times(1).
+			Return(&model.Review{ID: review.ID, Rating: 5, Version: 2}, nil)
+
+		reviewService := service.NewReviewService(reviewRepository, bookRepository)
+		resReview, err := reviewService.Update(ctx, review)
+		assert.Nil(t, resReview)
+		assert.EqualError(t, err, "precondition failed\n: review")
+	})
+}
+
+func TestReviewVote(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		review := &model.Review{ID: utils.GenerateID(), UserID: utils.GenerateID()}
+		voterID := utils.GenerateID()
+
+		reviewRepository := mock.NewMockReviewRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		gomock.InOrder(
+			reviewRepository.EXPECT().
+				FindByID(ctx, review.ID).
+				Times(1).
+				Return(review, nil),
+			reviewRepository.EXPECT().
+				AddVote(ctx, review.ID, voterID).
+				Times(1).
+				Return(nil),
+			reviewRepository.EXPECT().
+				FindByID(ctx, review.ID).
+				Times(1).
+				Return(&model.Review{ID: review.ID, UserID: review.UserID, HelpfulCount: 1}, nil),
+		)
+
+		reviewService := service.NewReviewService(reviewRepository, bookRepository)
+		resReview, err := reviewService.Vote(ctx, review.ID, voterID)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), resReview.HelpfulCount)
+	})
+
+	t.Run("error: own review", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		review := &model.Review{ID: utils.GenerateID(), UserID: utils.GenerateID()}
+
+		reviewRepository := mock.NewMockReviewRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		reviewRepository.EXPECT().
+			FindByID(ctx, review.ID).
+			Times(1).
+			Return(review, nil)
+
+		reviewService := service.NewReviewService(reviewRepository, bookRepository)
+		resReview, err := reviewService.Vote(ctx, review.ID, review.UserID)
+		assert.Nil(t, resReview)
+		assert.EqualError(t, err, "forbidden\n: own review")
+	})
+}
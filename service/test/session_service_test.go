@@ -276,6 +276,82 @@ func TestSessionCreate(t *testing.T) {
 	})
 }
 
+func TestSessionAuthenticate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		password := gofakeit.Password(true, false, false, false, false, 2)
+		hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		user := &model.User{
+			ID:       utils.GenerateID(),
+			Username: gofakeit.Username(),
+			Password: string(hashedPassword),
+		}
+
+		sessionRepository := mock.NewMockSessionRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		jwtService := mock.NewMockJWTService(ctrl)
+
+		userRepository.EXPECT().
+			FindByUsername(ctx, user.Username).
+			Times(1).
+			Return(user, nil)
+
+		sessionService := service.NewSessionService(sessionRepository, userRepository, jwtService)
+		resUser, err := sessionService.Authenticate(ctx, user.Username, password)
+		assert.Nil(t, err)
+		assert.Equal(t, user, resUser)
+	})
+
+	t.Run("error: username not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		username := gofakeit.Username()
+
+		sessionRepository := mock.NewMockSessionRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		jwtService := mock.NewMockJWTService(ctrl)
+
+		userRepository.EXPECT().
+			FindByUsername(ctx, username).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		sessionService := service.NewSessionService(sessionRepository, userRepository, jwtService)
+		resUser, err := sessionService.Authenticate(ctx, username, gofakeit.Password(true, false, false, false, false, 2))
+		assert.Nil(t, resUser)
+		assert.EqualError(t, err, controller.ErrCredentials.Error())
+	})
+
+	t.Run("error: wrong password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.DefaultCost)
+		user := &model.User{
+			ID:       utils.GenerateID(),
+			Username: gofakeit.Username(),
+			Password: string(hashedPassword),
+		}
+
+		sessionRepository := mock.NewMockSessionRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+		jwtService := mock.NewMockJWTService(ctrl)
+
+		userRepository.EXPECT().
+			FindByUsername(ctx, user.Username).
+			Times(1).
+			Return(user, nil)
+
+		sessionService := service.NewSessionService(sessionRepository, userRepository, jwtService)
+		resUser, err := sessionService.Authenticate(ctx, user.Username, "wrong-password")
+		assert.Nil(t, resUser)
+		assert.EqualError(t, err, controller.ErrCredentials.Error())
+	})
+}
+
 func TestSessionFindByRefreshToken(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
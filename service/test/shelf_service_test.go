@@ -0,0 +1,296 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShelfCreate(t *testing.T) {
+	t.Run("ok: shared by link", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		shelf := &model.Shelf{UserID: utils.GenerateID(), Name: " Summer ", Privacy: model.ShelfPrivacyLink}
+
+		shelfRepository := mock.NewMockShelfRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+
+		shelfRepository.EXPECT().
+			FindByName(ctx, shelf.UserID, "Summer").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		shelfRepository.EXPECT().
+			Create(ctx, shelf).
+			Times(1).
+			Return(shelf, nil)
+
+		shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+		resShelf, err := shelfService.Create(ctx, shelf)
+		assert.Nil(t, err)
+		assert.Equal(t, "Summer", resShelf.Name)
+		assert.Equal(t, model.ShelfKindCustom, resShelf.Kind)
+		assert.NotNil(t, resShelf.ShareToken)
+	})
+
+	t.Run("error: duplicate name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		shelf := &model.Shelf{UserID: utils.GenerateID(), Name: "Summer"}
+
+		shelfRepository := mock.NewMockShelfRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+
+		shelfRepository.EXPECT().
+			FindByName(ctx, shelf.UserID, shelf.Name).
+			Times(1).
+			Return(&model.Shelf{ID: utils.GenerateID()}, nil)
+
+		shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+		resShelf, err := shelfService.Create(ctx, shelf)
+		assert.Nil(t, resShelf)
+		assert.EqualError(t, err, "duplicate entry\n: shelf")
+	})
+}
+
+func TestShelfFindAllByUserID(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		userID := utils.GenerateID()
+
+		shelfRepository := mock.NewMockShelfRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+
+		gomock.InOrder(
+			shelfRepository.EXPECT().
+				EnsureBuiltins(ctx, userID).
+				Times(1).
+				Return(nil),
+			shelfRepository.EXPECT().
+				FindAllByUserID(ctx, userID, false).
+				Times(1).
+				Return([]*model.Shelf{{UserID: userID, Kind: model.ShelfKindReading}}, nil),
+		)
+
+		shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+		shelves, err := shelfService.FindAllByUserID(ctx, userID)
+		assert.Nil(t, err)
+		assert.Len(t, shelves, 1)
+	})
+}
+
+func TestShelfUpdate(t *testing.T) {
+	t.Run("ok: link kept", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		shareToken := "token"
+		currShelf := &model.Shelf{ID: utils.GenerateID(), UserID: utils.GenerateID(), Name: "Summer", Kind: model.ShelfKindCustom, Privacy: model.ShelfPrivacyLink, ShareToken: &shareToken, Version: 2}
+		shelf := &model.Shelf{ID: currShelf.ID, Name: "Holiday", Privacy: model.ShelfPrivacyLink, Version: 2}
+
+		shelfRepository := mock.NewMockShelfRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+
+		shelfRepository.EXPECT().
+			FindByID(ctx, shelf.ID).
+			Times(1).
+			Return(currShelf, nil)
+
+		shelfRepository.EXPECT().
+			FindByName(ctx, currShelf.UserID, "Holiday").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		shelfRepository.EXPECT().
+			Update(ctx, shelf).
+			Times(1).
+			Return(shelf, nil)
+
+		shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+		resShelf, err := shelfService.Update(ctx, shelf)
+		assert.Nil(t, err)
+		assert.Equal(t, &shareToken, resShelf.ShareToken)
+	})
+
+	t.Run("error: built-in renamed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		currShelf := &model.Shelf{ID: utils.GenerateID(), Name: "Reading", Kind: model.ShelfKindReading, Version: 1}
+
+		shelfRepository := mock.NewMockShelfRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+
+		shelfRepository.EXPECT().
+			FindByID(ctx, currShelf.ID).
+			Times(1).
+			Return(currShelf, nil)
+
+		shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+		resShelf, err := shelfService.Update(ctx, &model.Shelf{ID: currShelf.ID, Name: "Now", Version: 1})
+		assert.Nil(t, resShelf)
+		assert.EqualError(t, err, "bad request\n: built-in shelves cannot be renamed")
+	})
+}
+
+func TestShelfDelete(t *testing.T) {
+	t.Run("error: built-in", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		shelfID := utils.GenerateID()
+
+		shelfRepository := mock.NewMockShelfRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+
+		shelfRepository.EXPECT().
+			FindByID(ctx, shelfID).
+			Times(1).
+			Return(&model.Shelf{ID: shelfID, Kind: model.ShelfKindFavorites}, nil)
+
+		shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+		err := shelfService.Delete(ctx, shelfID)
+		assert.EqualError(t, err, "bad request\n: built-in shelves cannot be deleted")
+	})
+}
+
+func TestShelfAddEntry(t *testing.T) {
+	t.Run("error: already on shelf", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		entry := &model.ShelfEntry{ShelfID: utils.GenerateID(), BookID: utils.GenerateID()}
+
+		shelfRepository := mock.NewMockShelfRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, entry.BookID).
+			Times(1).
+			Return(&model.Book{ID: entry.BookID}, nil)
+
+		shelfRepository.EXPECT().
+			FindEntry(ctx, entry.ShelfID, entry.BookID).
+			Times(1).
+			Return(&model.ShelfEntry{}, nil)
+
+		shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+		resEntry, err := shelfService.AddEntry(ctx, entry)
+		assert.Nil(t, resEntry)
+		assert.EqualError(t, err, "duplicate entry\n: book is on shelf")
+	})
+}
+
+func TestShelfFindShared(t *testing.T) {
+	t.Run("ok: share token resolves", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		shareToken := "c2hhcmVkLXNoZWxmLXRva2Vu"
+		shelf := &model.Shelf{
+			ID:         utils.GenerateID(),
+			UserID:     utils.GenerateID(),
+			Name:       "Summer",
+			Kind:       model.ShelfKindCustom,
+			Privacy:    model.ShelfPrivacyLink,
+			ShareToken: &shareToken,
+		}
+		entries := []*model.ShelfEntry{{ShelfID: shelf.ID, BookID: utils.GenerateID(), Position: 1}}
+
+		shelfRepository := mock.NewMockShelfRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+
+		shelfRepository.EXPECT().
+			FindByShareToken(ctx, shareToken).
+			Times(1).
+			Return(shelf, nil)
+
+		userRepository.EXPECT().
+			FindByID(ctx, shelf.UserID).
+			Times(1).
+			Return(&model.User{ID: shelf.UserID}, nil)
+
+		shelfRepository.EXPECT().
+			FindEntries(ctx, shelf.ID).
+			Times(1).
+			Return(entries, nil)
+
+		shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+		shared, err := shelfService.FindShared(ctx, shareToken)
+		assert.Nil(t, err)
+		assert.Equal(t, "Summer", shared.Name)
+		assert.Equal(t, entries, shared.Entries)
+	})
+
+	t.Run("error: unknown token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+
+		shelfRepository := mock.NewMockShelfRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+
+		shelfRepository.EXPECT().
+			FindByShareToken(ctx, "revoked").
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+		shared, err := shelfService.FindShared(ctx, "revoked")
+		assert.Nil(t, shared)
+		assert.EqualError(t, err, "id not found\n: shelf")
+	})
+
+	t.Run("error: owner in the trash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		shareToken := "c2hhcmVkLXNoZWxmLXRva2Vu"
+		shelf := &model.Shelf{
+			ID:         utils.GenerateID(),
+			UserID:     utils.GenerateID(),
+			Privacy:    model.ShelfPrivacyLink,
+			ShareToken: &shareToken,
+		}
+
+		shelfRepository := mock.NewMockShelfRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+		userRepository := mock.NewMockUserRepository(ctrl)
+
+		shelfRepository.EXPECT().
+			FindByShareToken(ctx, shareToken).
+			Times(1).
+			Return(shelf, nil)
+
+		userRepository.EXPECT().
+			FindByID(ctx, shelf.UserID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		shelfService := service.NewShelfService(shelfRepository, bookRepository, userRepository)
+		shared, err := shelfService.FindShared(ctx, shareToken)
+		assert.Nil(t, shared)
+		assert.EqualError(t, err, "id not found\n: shelf")
+	})
+}
@@ -0,0 +1,261 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubjectCreate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		parentID := utils.GenerateID()
+		subject := &model.Subject{
+			Name:     "Fantasy",
+			ParentID: &parentID,
+		}
+
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		subjectRepository.EXPECT().
+			FindByID(ctx, parentID).
+			Times(1).
+			Return(&model.Subject{ID: parentID, Name: "Fiction"}, nil)
+
+		subjectRepository.EXPECT().
+			FindByName(ctx, &parentID, subject.Name).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		subjectRepository.EXPECT().
+			Create(ctx, subject).
+			Times(1).
+			Return(subject, nil)
+
+		subjectService := service.NewSubjectService(subjectRepository, bookRepository)
+		resSubject, err := subjectService.Create(ctx, subject)
+		assert.Nil(t, err)
+		assert.Equal(t, subject, resSubject)
+	})
+
+	t.Run("error: sibling name taken", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		subject := &model.Subject{
+			Name: "Fiction",
+		}
+
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		subjectRepository.EXPECT().
+			FindByName(ctx, nil, subject.Name).
+			Times(1).
+			Return(&model.Subject{ID: utils.GenerateID(), Name: "fiction"}, nil)
+
+		subjectService := service.NewSubjectService(subjectRepository, bookRepository)
+		resSubject, err := subjectService.Create(ctx, subject)
+		assert.Nil(t, resSubject)
+		assert.EqualError(t, err, "duplicate entry\n: subject")
+	})
+}
+
+func TestSubjectFindTree(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		fiction := &model.Subject{ID: 1, Name: "Fiction"}
+		fantasy := &model.Subject{ID: 2, Name: "Fantasy", ParentID: &fiction.ID}
+		epic := &model.Subject{ID: 3, Name: "Epic", ParentID: &fantasy.ID}
+		history := &model.Subject{ID: 4, Name: "History"}
+
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		subjectRepository.EXPECT().
+			FindAll(ctx).
+			Times(1).
+			Return([]*model.Subject{epic, fantasy, fiction, history}, nil)
+
+		subjectRepository.EXPECT().
+			CountBooks(ctx).
+			Times(1).
+			Return(map[int64]int64{1: 5, 2: 3, 3: 1}, nil)
+
+		subjectService := service.NewSubjectService(subjectRepository, bookRepository)
+		roots, err := subjectService.FindTree(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, roots, 2)
+
+		assert.Equal(t, "Fiction", roots[0].Name)
+		assert.Equal(t, int64(5), roots[0].BookCount)
+		assert.Len(t, roots[0].Children, 1)
+		assert.Equal(t, "Fantasy", roots[0].Children[0].Name)
+		assert.Equal(t, int64(3), roots[0].Children[0].BookCount)
+		assert.Len(t, roots[0].Children[0].Children, 1)
+		assert.Equal(t, int64(1), roots[0].Children[0].Children[0].BookCount)
+
+		assert.Equal(t, "History", roots[1].Name)
+		assert.Equal(t, int64(0), roots[1].BookCount)
+		assert.Empty(t, roots[1].Children)
+	})
+}
+
+func TestSubjectFindBooks(t *testing.T) {
+	t.Run("ok: includes descendants", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		subjectID := utils.GenerateID()
+		subjectIDs := []int64{subjectID, utils.GenerateID(), utils.GenerateID()}
+		books := []*model.Book{{ID: utils.GenerateID()}, {ID: utils.GenerateID()}}
+
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		subjectRepository.EXPECT().
+			FindDescendantIDs(ctx, subjectID).
+			Times(1).
+			Return(subjectIDs, nil)
+
+		bookRepository.EXPECT().
+			FindAllBySubjectIDs(ctx, subjectIDs).
+			Times(1).
+			Return(books, nil)
+
+		subjectService := service.NewSubjectService(subjectRepository, bookRepository)
+		resBooks, err := subjectService.FindBooks(ctx, subjectID)
+		assert.Nil(t, err)
+		assert.Equal(t, books, resBooks)
+	})
+
+	t.Run("error: subject not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		subjectID := utils.GenerateID()
+
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		subjectRepository.EXPECT().
+			FindDescendantIDs(ctx, subjectID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		subjectService := service.NewSubjectService(subjectRepository, bookRepository)
+		resBooks, err := subjectService.FindBooks(ctx, subjectID)
+		assert.Nil(t, resBooks)
+		assert.EqualError(t, err, "id not found\n: subject")
+	})
+}
+
+func TestSubjectUpdate(t *testing.T) {
+	t.Run("error: parent is a descendant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		childID := utils.GenerateID()
+		subject := &model.Subject{
+			ID:       utils.GenerateID(),
+			Name:     "Fiction",
+			ParentID: &childID,
+			Version:  1,
+		}
+
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		subjectRepository.EXPECT().
+			FindByID(ctx, subject.ID).
+			Times(1).
+			Return(&model.Subject{ID: subject.ID, Name: "Fiction", Version: 1}, nil)
+
+		subjectRepository.EXPECT().
+			FindDescendantIDs(ctx, subject.ID).
+			Times(1).
+			Return([]int64{subject.ID, childID}, nil)
+
+		subjectService := service.NewSubjectService(subjectRepository, bookRepository)
+		resSubject, err := subjectService.Update(ctx, subject)
+		assert.Nil(t, resSubject)
+		assert.EqualError(t, err, "bad request\n: parent_id would create a cycle")
+	})
+}
+
+func TestSubjectSetBookSubjects(t *testing.T) {
+	t.Run("ok: duplicates dropped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		subjects := []*model.Subject{{ID: 1, Name: "Fantasy"}, {ID: 2, Name: "Fiction"}}
+
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, bookID).
+			Times(1).
+			Return(&model.Book{ID: bookID}, nil)
+
+		subjectRepository.EXPECT().
+			FindByID(ctx, int64(1)).
+			Times(1).
+			Return(subjects[0], nil)
+
+		subjectRepository.EXPECT().
+			FindByID(ctx, int64(2)).
+			Times(1).
+			Return(subjects[1], nil)
+
+		subjectRepository.EXPECT().
+			ReplaceBookSubjects(ctx, bookID, []int64{1, 2}).
+			Times(1).
+			Return(nil)
+
+		subjectRepository.EXPECT().
+			FindByBookID(ctx, bookID).
+			Times(1).
+			Return(subjects, nil)
+
+		subjectService := service.NewSubjectService(subjectRepository, bookRepository)
+		resSubjects, err := subjectService.SetBookSubjects(ctx, bookID, []int64{2, 1, 2})
+		assert.Nil(t, err)
+		assert.Equal(t, subjects, resSubjects)
+	})
+}
+
+func TestSubjectDelete(t *testing.T) {
+	t.Run("error: subject has children", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		subjectID := utils.GenerateID()
+
+		subjectRepository := mock.NewMockSubjectRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		subjectRepository.EXPECT().
+			CountChildren(ctx, subjectID).
+			Times(1).
+			Return(int64(2), nil)
+
+		subjectService := service.NewSubjectService(subjectRepository, bookRepository)
+		err := subjectService.Delete(ctx, subjectID)
+		assert.EqualError(t, err, "dependent entries exist\n: subject has 2 children")
+	})
+}
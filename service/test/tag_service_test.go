@@ -0,0 +1,101 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagSetBookTags(t *testing.T) {
+	t.Run("ok: names normalized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+		tags := []*model.Tag{{ID: 1, Name: "cozy"}, {ID: 2, Name: "sci-fi"}}
+
+		tagRepository := mock.NewMockTagRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindByID(ctx, bookID).
+			Times(1).
+			Return(&model.Book{ID: bookID}, nil)
+
+		tagRepository.EXPECT().
+			ReplaceBookTags(ctx, bookID, []string{"cozy", "sci-fi"}).
+			Times(1).
+			Return(nil)
+
+		tagRepository.EXPECT().
+			FindByBookID(ctx, bookID).
+			Times(1).
+			Return(tags, nil)
+
+		tagService := service.NewTagService(tagRepository, bookRepository)
+		resTags, err := tagService.SetBookTags(ctx, bookID, []string{" Sci-Fi", "cozy", "sci-fi "})
+		assert.Nil(t, err)
+		assert.Equal(t, tags, resTags)
+	})
+
+	t.Run("error: blank tag", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+
+		tagRepository := mock.NewMockTagRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		tagService := service.NewTagService(tagRepository, bookRepository)
+		resTags, err := tagService.SetBookTags(ctx, bookID, []string{"cozy", "   "})
+		assert.Nil(t, resTags)
+		assert.ErrorIs(t, err, controller.ErrBadRequest)
+	})
+
+	t.Run("error: tag too long", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		bookID := utils.GenerateID()
+
+		tagRepository := mock.NewMockTagRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		tagService := service.NewTagService(tagRepository, bookRepository)
+		resTags, err := tagService.SetBookTags(ctx, bookID, []string{strings.Repeat("a", 65)})
+		assert.Nil(t, resTags)
+		assert.ErrorIs(t, err, controller.ErrBadRequest)
+	})
+}
+
+func TestTagFindBooks(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		books := []*model.Book{{ID: utils.GenerateID()}}
+
+		tagRepository := mock.NewMockTagRepository(ctrl)
+		bookRepository := mock.NewMockBookRepository(ctrl)
+
+		bookRepository.EXPECT().
+			FindAllByTag(ctx, "space opera").
+			Times(1).
+			Return(books, nil)
+
+		tagService := service.NewTagService(tagRepository, bookRepository)
+		resBooks, err := tagService.FindBooks(ctx, "Space  Opera")
+		assert.Nil(t, err)
+		assert.Equal(t, books, resBooks)
+	})
+}
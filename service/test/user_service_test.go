@@ -369,6 +369,62 @@ func TestUserUpdate(t *testing.T) {
 	})
 }
 
+func TestUserPatch(t *testing.T) {
+	t.Run("ok: password only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		password := gofakeit.Password(true, false, false, false, false, 8)
+		req := &model.User{
+			ID:       utils.GenerateID(),
+			Username: gofakeit.Username(),
+			Password: password,
+		}
+
+		userRepository := mock.NewMockUserRepository(ctrl)
+		userRepository.EXPECT().
+			UpdateColumns(ctx, req, []string{"password"}).
+			Times(1).
+			DoAndReturn(func(_ context.Context, user *model.User, _ []string) (*model.User, error) {
+				assert.True(t, utils.IsPasswordCorrect(password, user.Password))
+				return user, nil
+			})
+
+		userService := service.NewUserService(userRepository)
+		resUser, err := userService.Patch(ctx, req, []string{"password"})
+		assert.Nil(t, err)
+		assert.NotNil(t, resUser)
+		assert.Empty(t, resUser.Password)
+	})
+
+	t.Run("error: duplicate username", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		req := &model.User{
+			ID:       utils.GenerateID(),
+			Username: gofakeit.Username(),
+		}
+
+		other := &model.User{
+			ID:       utils.GenerateID(),
+			Username: req.Username,
+		}
+
+		userRepository := mock.NewMockUserRepository(ctrl)
+		userRepository.EXPECT().
+			FindByUsername(ctx, req.Username).
+			Times(1).
+			Return(other, nil)
+
+		userService := service.NewUserService(userRepository)
+		resUser, err := userService.Patch(ctx, req, []string{"username"})
+		assert.Nil(t, resUser)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "duplicate entry\n: username")
+	})
+}
+
 func TestUserDelete(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -413,3 +469,77 @@ func TestUserDelete(t *testing.T) {
 		assert.EqualError(t, err, "id not found\n: user")
 	})
 }
+
+func TestUserRestore(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		user := &model.User{
+			ID:       utils.GenerateID(),
+			Username: gofakeit.Username(),
+			Password: gofakeit.Password(true, false, false, false, false, 2),
+		}
+
+		userRepository := mock.NewMockUserRepository(ctrl)
+		userRepository.EXPECT().
+			FindDeletedByID(ctx, user.ID).
+			Times(1).
+			Return(user, nil)
+
+		userRepository.EXPECT().
+			FindByUsername(ctx, user.Username).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		userRepository.EXPECT().
+			Restore(ctx, user.ID).
+			Times(1).
+			Return(nil)
+
+		userRepository.EXPECT().
+			FindByID(ctx, user.ID).
+			Times(1).
+			Return(user, nil)
+
+		userService := service.NewUserService(userRepository)
+		resUser, err := userService.Restore(ctx, user.ID)
+		assert.Nil(t, err)
+		assert.NotNil(t, resUser)
+		assert.Empty(t, resUser.Password)
+	})
+
+	t.Run("error: username taken by live user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		user := &model.User{
+			ID:       utils.GenerateID(),
+			Username: gofakeit.Username(),
+			Password: gofakeit.Password(true, false, false, false, false, 2),
+		}
+
+		liveUser := &model.User{
+			ID:       utils.GenerateID(),
+			Username: user.Username,
+			Password: gofakeit.Password(true, false, false, false, false, 2),
+		}
+
+		userRepository := mock.NewMockUserRepository(ctrl)
+		userRepository.EXPECT().
+			FindDeletedByID(ctx, user.ID).
+			Times(1).
+			Return(user, nil)
+
+		userRepository.EXPECT().
+			FindByUsername(ctx, user.Username).
+			Times(1).
+			Return(liveUser, nil)
+
+		userService := service.NewUserService(userRepository)
+		resUser, err := userService.Restore(ctx, user.ID)
+		assert.Nil(t, resUser)
+		assert.Error(t, err)
+		assert.EqualError(t, err, "duplicate entry\n: username")
+	})
+}
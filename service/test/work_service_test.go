@@ -0,0 +1,146 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/model/mock"
+	"github.com/rhtyx/bayarind-service.git/service"
+	"github.com/rhtyx/bayarind-service.git/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkCreate(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		work := &model.Work{
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, work.AuthorID).
+			Times(1).
+			Return(&model.Author{ID: work.AuthorID}, nil)
+
+		workRepository.EXPECT().
+			Create(ctx, work).
+			Times(1).
+			Return(work, nil)
+
+		workService := service.NewWorkService(workRepository, editionRepository, authorRepository)
+		resWork, err := workService.Create(ctx, work)
+		assert.Nil(t, err)
+		assert.Equal(t, work, resWork)
+	})
+
+	t.Run("error: author not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		work := &model.Work{
+			Title:    gofakeit.BookTitle(),
+			AuthorID: utils.GenerateID(),
+		}
+
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+
+		authorRepository.EXPECT().
+			FindByID(ctx, work.AuthorID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		workService := service.NewWorkService(workRepository, editionRepository, authorRepository)
+		resWork, err := workService.Create(ctx, work)
+		assert.Nil(t, resWork)
+		assert.EqualError(t, err, "id not found\n: author")
+	})
+}
+
+func TestWorkFindEditions(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		workID := utils.GenerateID()
+		editions := []*model.Edition{
+			{ID: utils.GenerateID(), WorkID: workID, Format: model.EditionFormatHardcover},
+			{ID: utils.GenerateID(), WorkID: workID, Format: model.EditionFormatEbook},
+		}
+
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+
+		workRepository.EXPECT().
+			FindByID(ctx, workID).
+			Times(1).
+			Return(&model.Work{ID: workID}, nil)
+
+		editionRepository.EXPECT().
+			FindAllByWorkID(ctx, workID).
+			Times(1).
+			Return(editions, nil)
+
+		workService := service.NewWorkService(workRepository, editionRepository, authorRepository)
+		resEditions, err := workService.FindEditions(ctx, workID)
+		assert.Nil(t, err)
+		assert.Equal(t, editions, resEditions)
+	})
+
+	t.Run("error: work not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		workID := utils.GenerateID()
+
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+
+		workRepository.EXPECT().
+			FindByID(ctx, workID).
+			Times(1).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		workService := service.NewWorkService(workRepository, editionRepository, authorRepository)
+		resEditions, err := workService.FindEditions(ctx, workID)
+		assert.Nil(t, resEditions)
+		assert.EqualError(t, err, "id not found\n: work")
+	})
+}
+
+func TestWorkDelete(t *testing.T) {
+	t.Run("error: work has editions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := context.TODO()
+		workID := utils.GenerateID()
+
+		workRepository := mock.NewMockWorkRepository(ctrl)
+		editionRepository := mock.NewMockEditionRepository(ctrl)
+		authorRepository := mock.NewMockAuthorRepository(ctrl)
+
+		editionRepository.EXPECT().
+			CountByWorkID(ctx, workID).
+			Times(1).
+			Return(int64(1), nil)
+
+		workService := service.NewWorkService(workRepository, editionRepository, authorRepository)
+		err := workService.Delete(ctx, workID)
+		assert.EqualError(t, err, "dependent entries exist\n: work has 1 editions")
+	})
+}
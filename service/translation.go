@@ -0,0 +1,23 @@
+package service
+
+import (
+	"github.com/rhtyx/bayarind-service.git/i18n"
+	"github.com/rhtyx/bayarind-service.git/model"
+)
+
+// canonicalTranslations keys translations by canonical locale, so that
+// "en-us" and "en-US" are one locale, and drops empty and unkeyable ones.
+func canonicalTranslations[T comparable](translations model.Translations[T]) model.Translations[T] {
+	var zero T
+	canonical := model.Translations[T]{}
+	for locale, translation := range translations {
+		locale = i18n.Canonical(locale)
+		if locale == "" || translation == zero {
+			continue
+		}
+
+		canonical[locale] = translation
+	}
+
+	return canonical
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -121,6 +123,46 @@ func (u UserService) Update(ctx context.Context, user *model.User) (*model.User,
 	return user, nil
 }
 
+func (u UserService) Patch(ctx context.Context, user *model.User, columns []string) (*model.User, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithFields(logrus.Fields{
+			"userID":  user.ID,
+			"columns": columns,
+		})
+
+	if slices.Contains(columns, "username") {
+		currUser, err := u.userRepository.FindByUsername(ctx, user.Username)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error(err)
+			return nil, parseError(err, "username")
+		}
+
+		if currUser != nil && currUser.ID != user.ID {
+			return nil, errors.Join(controller.ErrDuplicate, errors.New(": username"))
+		}
+	}
+
+	if slices.Contains(columns, "password") {
+		hashedPassword, err := utils.HashPassword(user.Password)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		user.Password = hashedPassword
+	}
+
+	user, err := u.userRepository.UpdateColumns(ctx, user, columns)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "user")
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
 func (u UserService) Delete(ctx context.Context, userID int64) error {
 	logger := logrus.
 		WithContext(ctx).
@@ -134,3 +176,70 @@ func (u UserService) Delete(ctx context.Context, userID int64) error {
 
 	return nil
 }
+
+func (u UserService) FindAllDeleted(ctx context.Context) ([]*model.User, error) {
+	logger := logrus.WithContext(ctx)
+
+	users, err := u.userRepository.FindAllDeleted(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "user")
+	}
+
+	for _, user := range users {
+		user.Password = ""
+	}
+
+	return users, nil
+}
+
+func (u UserService) Restore(ctx context.Context, userID int64) (*model.User, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("userID", userID)
+
+	user, err := u.userRepository.FindDeletedByID(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "user")
+	}
+
+	currUser, err := u.userRepository.FindByUsername(ctx, user.Username)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error(err)
+		return nil, parseError(err, "username")
+	}
+
+	if currUser != nil {
+		return nil, errors.Join(controller.ErrDuplicate, errors.New(": username"))
+	}
+
+	err = u.userRepository.Restore(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "user")
+	}
+
+	user, err = u.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "user")
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+func (u UserService) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("deletedBefore", deletedBefore)
+
+	count, err := u.userRepository.Purge(ctx, deletedBefore)
+	if err != nil {
+		logger.Error(err)
+		return 0, parseError(err, "user")
+	}
+
+	return count, nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/rhtyx/bayarind-service.git/model"
+)
+
+// bookVersionFields are the fields of a book version compared by a diff,
+// in the order changes are listed. The cover is kept in each version but
+// left out: replacing a cover deletes the old images, so a restore cannot
+// bring it back.
+var bookVersionFields = []string{"isbn", "title", "subtitle", "author_id", "translations"}
+
+var authorVersionFields = []string{"name", "birth_date", "death_date", "nationality", "biography", "website", "viaf", "isni", "wikidata_id", "translations"}
+
+func currentBookVersion(book *model.Book) *model.BookVersion {
+	validFrom := book.CreatedAt
+	if book.UpdatedAt != nil {
+		validFrom = *book.UpdatedAt
+	}
+
+	return &model.BookVersion{
+		BookID:       book.ID,
+		Version:      book.Version,
+		ISBN:         book.ISBN,
+		Title:        book.Title,
+		Subtitle:     book.Subtitle,
+		AuthorID:     book.AuthorID,
+		CoverKey:     book.CoverKey,
+		CoverType:    book.CoverType,
+		Translations: book.Translations,
+		ValidFrom:    validFrom,
+	}
+}
+
+func currentAuthorVersion(author *model.Author) *model.AuthorVersion {
+	validFrom := author.CreatedAt
+	if author.UpdatedAt != nil {
+		validFrom = *author.UpdatedAt
+	}
+
+	return &model.AuthorVersion{
+		AuthorID:     author.ID,
+		Version:      author.Version,
+		Name:         author.Name,
+		BirthDate:    author.BirthDate,
+		DeathDate:    author.DeathDate,
+		Nationality:  author.Nationality,
+		Biography:    author.Biography,
+		Website:      author.Website,
+		VIAF:         author.VIAF,
+		ISNI:         author.ISNI,
+		WikidataID:   author.WikidataID,
+		Translations: author.Translations,
+		ValidFrom:    validFrom,
+	}
+}
+
+func bookVersionValues(version *model.BookVersion) map[string]interface{} {
+	return map[string]interface{}{
+		"isbn":         version.ISBN,
+		"title":        version.Title,
+		"subtitle":     version.Subtitle,
+		"author_id":    version.AuthorID,
+		"translations": translationsValue(version.Translations),
+	}
+}
+
+func authorVersionValues(version *model.AuthorVersion) map[string]interface{} {
+	var deathDate interface{}
+	if version.DeathDate != nil {
+		deathDate = version.DeathDate.Format(time.DateOnly)
+	}
+
+	return map[string]interface{}{
+		"name":         version.Name,
+		"birth_date":   version.BirthDate.Format(time.DateOnly),
+		"death_date":   deathDate,
+		"nationality":  version.Nationality,
+		"biography":    version.Biography,
+		"website":      version.Website,
+		"viaf":         version.VIAF,
+		"isni":         version.ISNI,
+		"wikidata_id":  version.WikidataID,
+		"translations": translationsValue(version.Translations),
+	}
+}
+
+// translationsValue makes versions without translations compare equal
+// whether they hold an empty map or none.
+func translationsValue[T any](translations model.Translations[T]) interface{} {
+	if len(translations) == 0 {
+		return nil
+	}
+
+	return translations
+}
+
+// diffFields lists the fields whose values differ between from and to.
+func diffFields(fields []string, from, to map[string]interface{}) []*model.FieldChange {
+	changes := []*model.FieldChange{}
+	for _, field := range fields {
+		if reflect.DeepEqual(from[field], to[field]) {
+			continue
+		}
+
+		changes = append(changes, &model.FieldChange{
+			Field: field,
+			From:  from[field],
+			To:    to[field],
+		})
+	}
+
+	return changes
+}
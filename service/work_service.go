@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/rhtyx/bayarind-service.git/controller"
+	"github.com/rhtyx/bayarind-service.git/model"
+	"github.com/rhtyx/bayarind-service.git/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+type WorkService struct {
+	workRepository    model.WorkRepository
+	editionRepository model.EditionRepository
+	authorRepository  model.AuthorRepository
+}
+
+func NewWorkService(workRepository model.WorkRepository, editionRepository model.EditionRepository, authorRepository model.AuthorRepository) model.WorkService {
+	return &WorkService{
+		workRepository:    workRepository,
+		editionRepository: editionRepository,
+		authorRepository:  authorRepository,
+	}
+}
+
+func (w WorkService) Create(ctx context.Context, work *model.Work) (*model.Work, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("work", utils.Dump(work))
+
+	_, err := w.authorRepository.FindByID(ctx, work.AuthorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(controller.ErrNotFound, errors.New(": author"))
+		}
+
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	work, err = w.workRepository.Create(ctx, work)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "work")
+	}
+
+	return work, nil
+}
+
+func (w WorkService) FindByID(ctx context.Context, workID int64) (*model.Work, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("workID", workID)
+
+	work, err := w.workRepository.FindByID(ctx, workID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "work")
+	}
+
+	return work, nil
+}
+
+func (w WorkService) FindAll(ctx context.Context) ([]*model.Work, error) {
+	logger := logrus.WithContext(ctx)
+
+	works, err := w.workRepository.FindAll(ctx)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "work")
+	}
+
+	return works, nil
+}
+
+func (w WorkService) FindEditions(ctx context.Context, workID int64) ([]*model.Edition, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("workID", workID)
+
+	_, err := w.workRepository.FindByID(ctx, workID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "work")
+	}
+
+	editions, err := w.editionRepository.FindAllByWorkID(ctx, workID)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "edition")
+	}
+
+	return editions, nil
+}
+
+func (w WorkService) Update(ctx context.Context, work *model.Work) (*model.Work, error) {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("work", utils.Dump(work))
+
+	_, err := w.authorRepository.FindByID(ctx, work.AuthorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.Join(controller.ErrNotFound, errors.New(": author"))
+		}
+
+		logger.Error(err)
+		return nil, parseError(err, "author")
+	}
+
+	work, err = w.workRepository.Update(ctx, work)
+	if err != nil {
+		logger.Error(err)
+		return nil, parseError(err, "work")
+	}
+
+	return work, nil
+}
+
+func (w WorkService) Delete(ctx context.Context, workID int64) error {
+	logger := logrus.
+		WithContext(ctx).
+		WithField("workID", workID)
+
+	count, err := w.editionRepository.CountByWorkID(ctx, workID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "edition")
+	}
+
+	if count > 0 {
+		return errors.Join(controller.ErrDependency, fmt.Errorf(": work has %d editions", count))
+	}
+
+	err = w.workRepository.Delete(ctx, workID)
+	if err != nil {
+		logger.Error(err)
+		return parseError(err, "work")
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/rhtyx/bayarind-service.git/thumbnail"
+	"github.com/stretchr/testify/assert"
+)
+
+var sizes = []thumbnail.Size{
+	{Name: "small", MaxWidth: 100, MaxHeight: 150},
+	{Name: "large", MaxWidth: 600, MaxHeight: 900},
+}
+
+func encode(t *testing.T, width, height int, format string) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if format == "png" {
+		assert.Nil(t, png.Encode(buf, img))
+	} else {
+		assert.Nil(t, jpeg.Encode(buf, img, nil))
+	}
+
+	return buf.Bytes()
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("ok: jpeg is scaled down keeping aspect ratio", func(t *testing.T) {
+		res, err := thumbnail.Generate(encode(t, 400, 300, "jpeg"), sizes, thumbnail.DefaultMaxPixels)
+		assert.Nil(t, err)
+		assert.Len(t, res, 2)
+
+		assert.Equal(t, "small", res[0].Name)
+		assert.Equal(t, thumbnail.MIMEJPEG, res[0].ContentType)
+		assert.Equal(t, ".jpg", res[0].Extension)
+		assert.Equal(t, [2]int{100, 75}, [2]int{res[0].Width, res[0].Height})
+
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(res[0].Data))
+		assert.Nil(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, 100, cfg.Width)
+
+		// Larger boxes never upscale.
+		assert.Equal(t, [2]int{400, 300}, [2]int{res[1].Width, res[1].Height})
+	})
+
+	t.Run("ok: png stays png", func(t *testing.T) {
+		res, err := thumbnail.Generate(encode(t, 200, 600, "png"), sizes, thumbnail.DefaultMaxPixels)
+		assert.Nil(t, err)
+		assert.Equal(t, thumbnail.MIMEPNG, res[0].ContentType)
+		assert.Equal(t, [2]int{50, 150}, [2]int{res[0].Width, res[0].Height})
+	})
+
+	t.Run("error: unsupported format", func(t *testing.T) {
+		res, err := thumbnail.Generate([]byte("GIF89a not really"), sizes, thumbnail.DefaultMaxPixels)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, thumbnail.ErrUnsupportedFormat)
+	})
+
+	t.Run("error: too many pixels", func(t *testing.T) {
+		res, err := thumbnail.Generate(encode(t, 100, 100, "png"), sizes, 5000)
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, thumbnail.ErrTooManyPixels)
+	})
+}
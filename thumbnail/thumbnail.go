@@ -0,0 +1,111 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	MIMEJPEG = "image/jpeg"
+	MIMEPNG  = "image/png"
+
+	// DefaultMaxPixels bounds the decoded size of an upload so that a small
+	// but highly compressed file cannot exhaust memory.
+	DefaultMaxPixels = 40_000_000
+
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("image must be jpeg or png")
+	ErrTooManyPixels     = errors.New("image dimensions are too large")
+)
+
+// Size is a bounding box; renditions keep the aspect ratio of the source
+// and are never upscaled.
+type Size struct {
+	Name      string
+	MaxWidth  int
+	MaxHeight int
+}
+
+type Rendition struct {
+	Name        string
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// Generate decodes a JPEG or PNG image and renders it at every size. JPEG
+// sources produce JPEG renditions and PNG sources keep PNG so that
+// transparency survives.
+func Generate(data []byte, sizes []Size, maxPixels int) ([]Rendition, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Join(ErrUnsupportedFormat, err)
+	}
+
+	if format != "jpeg" && format != "png" {
+		return nil, ErrUnsupportedFormat
+	}
+
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooManyPixels, cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Join(ErrUnsupportedFormat, err)
+	}
+
+	renditions := make([]Rendition, 0, len(sizes))
+	for _, size := range sizes {
+		width, height := fit(src.Bounds().Dx(), src.Bounds().Dy(), size.MaxWidth, size.MaxHeight)
+
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Src, nil)
+
+		rendition := Rendition{
+			Name:   size.Name,
+			Width:  width,
+			Height: height,
+		}
+
+		buf := &bytes.Buffer{}
+		if format == "png" {
+			rendition.ContentType, rendition.Extension = MIMEPNG, ".png"
+			err = png.Encode(buf, dst)
+		} else {
+			rendition.ContentType, rendition.Extension = MIMEJPEG, ".jpg"
+			err = jpeg.Encode(buf, dst, &jpeg.Options{Quality: jpegQuality})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rendition.Data = buf.Bytes()
+		renditions = append(renditions, rendition)
+	}
+
+	return renditions, nil
+}
+
+func fit(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= maxWidth && height <= maxHeight {
+		return width, height
+	}
+
+	if width*maxHeight > height*maxWidth {
+		return maxWidth, max(1, height*maxWidth/width)
+	}
+
+	return max(1, width*maxHeight/height), maxHeight
+}